@@ -0,0 +1,69 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Command-line tool for connecting simulated driver stations to an arena, for running matches without robots. The
+// arena must be started with the -dev flag so that it accepts connections over localhost.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/Team254/cheesy-arena/dssim"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func main() {
+	arenaAddress := flag.String("arena", "127.0.0.1", "IP address of the arena to connect to")
+	teamList := flag.String(
+		"teams", "", "Comma-separated list of team numbers to simulate; should match the teams in the loaded match",
+	)
+	batteryVoltage := flag.Float64("battery", 12.5, "Battery voltage to report for each robot")
+	tripTimeMs := flag.Int("trip_time", 5, "Round-trip time in milliseconds to report for each robot")
+	statusPeriodSec := flag.Int("status_period", 5, "How often to print the status of each driver station, in seconds")
+	flag.Parse()
+
+	var driverStations []*dssim.DriverStation
+	for _, teamNumber := range strings.Split(*teamList, ",") {
+		teamId, err := strconv.Atoi(strings.TrimSpace(teamNumber))
+		if err != nil {
+			log.Fatalf("Invalid team number '%s'; use -teams to specify the teams to simulate.", teamNumber)
+		}
+		ds := dssim.NewDriverStation(teamId, *arenaAddress)
+		ds.BatteryVoltage = *batteryVoltage
+		ds.TripTimeMs = *tripTimeMs
+		driverStations = append(driverStations, ds)
+		go ds.Run()
+	}
+
+	for {
+		time.Sleep(time.Second * time.Duration(*statusPeriodSec))
+		for _, ds := range driverStations {
+			status := ds.Status()
+			if !status.Connected {
+				fmt.Printf("Team %5d: not connected\n", ds.TeamId)
+				continue
+			}
+			mode := "disabled"
+			if status.EStop {
+				mode = "E-stopped"
+			} else if status.Enabled && status.Auto {
+				mode = "auto enabled"
+			} else if status.Enabled {
+				mode = "teleop enabled"
+			}
+			fmt.Printf(
+				"Team %5d: %s, %s, %d control packets, %ds remaining, game data '%s'\n",
+				ds.TeamId,
+				status.AllianceStation,
+				mode,
+				status.ControlPacketCount,
+				status.MatchSecondsRemaining,
+				status.GameData,
+			)
+		}
+	}
+}
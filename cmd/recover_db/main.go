@@ -0,0 +1,63 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Command-line tool for rebuilding the event database from the last backup plus the write journal, for use after the
+// FMS machine crashes.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"log"
+	"os"
+	"time"
+)
+
+func main() {
+	dbPath := flag.String("db", "./event.db", "Path of the event database to rebuild")
+	backupPath := flag.String("backup", "", "Path of the backup to start from (defaults to the most recent backup)")
+	journalPath := flag.String("journal", "", "Path of the write journal (defaults to the database path + .journal)")
+	flag.Parse()
+
+	if *backupPath == "" {
+		var err error
+		if *backupPath, err = model.LatestBackupPath(); err != nil {
+			log.Fatalln("Error finding latest backup: ", err)
+		}
+	}
+	if *journalPath == "" {
+		*journalPath = model.JournalPath(*dbPath)
+	}
+
+	// Keep the existing database around in case it is needed for further forensics.
+	if _, err := os.Stat(*dbPath); err == nil {
+		movedPath := fmt.Sprintf("%s.pre_recovery_%s", *dbPath, time.Now().Format("20060102150405"))
+		if err = os.Rename(*dbPath, movedPath); err != nil {
+			log.Fatalln("Error moving existing database aside: ", err)
+		}
+		fmt.Printf("Moved existing database to %s\n", movedPath)
+	}
+
+	fmt.Printf("Recovering %s from backup %s and journal %s\n", *dbPath, *backupPath, *journalPath)
+	report, err := model.RecoverDatabase(*backupPath, *journalPath, *dbPath)
+	if err != nil {
+		log.Fatalln("Error recovering database: ", err)
+	}
+
+	fmt.Printf("Backup includes journal entries up to #%d\n", report.BackupSequence)
+	fmt.Printf("Applied %d journal entries:\n", len(report.Applied))
+	for _, entry := range report.Applied {
+		fmt.Printf("  %s\n", entry.String())
+	}
+	if len(report.Skipped) > 0 {
+		fmt.Printf("Skipped %d journal entries:\n", len(report.Skipped))
+		for _, skipped := range report.Skipped {
+			fmt.Printf("  %s: %s\n", skipped.Entry.String(), skipped.Error)
+		}
+	}
+	if report.TruncatedTail {
+		fmt.Println("The final journal entry was only partially written and has been ignored.")
+	}
+}
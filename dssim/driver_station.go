@@ -0,0 +1,381 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Simulated driver station that speaks the field side of the DS protocol, for testing the arena without robots.
+
+package dssim
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	ArenaTcpPort            = 1750
+	ArenaUdpPort            = 1160
+	statusPacketPeriodMs    = 20
+	keepalivePacketPeriodMs = 500
+	connectRetryPeriodMs    = 1000
+	tcpReadTimeoutSec       = 5
+)
+
+// Station assignment statuses sent by the arena when a driver station connects.
+const (
+	StationCorrect    = 0
+	StationWrong      = 1
+	StationNotInMatch = 2
+	StationInvalid    = 3
+)
+
+// Alliance stations in the order in which they are numbered in the DS protocol.
+var allianceStations = []string{"R1", "R2", "R3", "B1", "B2", "B3"}
+
+// State of a simulated driver station as last commanded by the arena.
+type Status struct {
+	Connected             bool
+	AllianceStation       string
+	StationStatus         int
+	EventName             string
+	Auto                  bool
+	Enabled               bool
+	EStop                 bool
+	AStop                 bool
+	MatchType             int
+	MatchNumber           int
+	MatchSecondsRemaining int
+	GameData              string
+	ControlPacketCount    int
+	LastControlPacketTime time.Time
+}
+
+// DriverStation simulates a single team's driver station and robot. The exported fields control what it reports to
+// the arena and should be set before calling Run().
+type DriverStation struct {
+	TeamId            int
+	ArenaAddress      string
+	ArenaTcpPort      int
+	ArenaUdpPort      int
+	BatteryVoltage    float64
+	TripTimeMs        int
+	MissedPacketCount int
+	RadioLinked       bool
+	RioLinked         bool
+	RobotLinked       bool
+
+	mutex               sync.Mutex
+	status              Status
+	tcpConn             net.Conn
+	udpConn             *net.UDPConn
+	statusPacketCount   int
+	reportedStatusByte  byte
+	lastKeepaliveTime   time.Time
+	closed              bool
+	connectionErrorText string
+}
+
+// Creates a driver station for the given team that will connect to the arena at the given IP address, reporting a
+// healthy robot.
+func NewDriverStation(teamId int, arenaAddress string) *DriverStation {
+	return &DriverStation{
+		TeamId:         teamId,
+		ArenaAddress:   arenaAddress,
+		ArenaTcpPort:   ArenaTcpPort,
+		ArenaUdpPort:   ArenaUdpPort,
+		BatteryVoltage: 12.5,
+		TripTimeMs:     5,
+		RadioLinked:    true,
+		RioLinked:      true,
+		RobotLinked:    true,
+	}
+}
+
+// Returns a copy of the current state of the driver station.
+func (ds *DriverStation) Status() Status {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	return ds.status
+}
+
+// Loops indefinitely to stay connected to the arena and exchange packets with it, until Close() is called.
+func (ds *DriverStation) Run() {
+	for !ds.isClosed() {
+		if err := ds.Connect(); err != nil {
+			if err.Error() != ds.connectionErrorText {
+				log.Printf("Simulated DS for Team %d failed to connect: %v", ds.TeamId, err)
+				ds.connectionErrorText = err.Error()
+			}
+			time.Sleep(time.Millisecond * connectRetryPeriodMs)
+			continue
+		}
+		ds.connectionErrorText = ""
+		log.Printf("Simulated DS for Team %d connected in station %s.", ds.TeamId, ds.Status().AllianceStation)
+
+		for ds.Status().Connected {
+			startTime := time.Now()
+			if err := ds.sendPackets(); err != nil {
+				log.Printf("Simulated DS for Team %d lost its connection: %v", ds.TeamId, err)
+				ds.disconnect()
+				break
+			}
+			time.Sleep(time.Until(startTime.Add(time.Millisecond * statusPacketPeriodMs)))
+		}
+	}
+}
+
+// Connects to the arena and waits for the station assignment. Returns an error if the connection fails or the team
+// isn't in the current match.
+func (ds *DriverStation) Connect() error {
+	udpConn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return err
+	}
+	tcpConn, err := net.Dial("tcp", net.JoinHostPort(ds.ArenaAddress, strconv.Itoa(ds.ArenaTcpPort)))
+	if err != nil {
+		udpConn.Close()
+		return err
+	}
+
+	// Identify as a new-style DS, which tells the arena which port to send control packets to.
+	teamNumber := strconv.Itoa(ds.TeamId)
+	udpPort := udpConn.LocalAddr().(*net.UDPAddr).Port
+	packet := []byte{0, byte(5 + len(teamNumber)), 30, byte(udpPort >> 8), byte(udpPort & 0xff), 0}
+	packet = append(packet, byte(len(teamNumber)))
+	packet = append(packet, teamNumber...)
+	if _, err = tcpConn.Write(packet); err == nil {
+		if err = tcpConn.SetReadDeadline(time.Now().Add(time.Second * tcpReadTimeoutSec)); err == nil {
+			packet, err = readTcpPacket(tcpConn)
+		}
+	}
+	if err == nil && (len(packet) < 8 || packet[2] != 31) {
+		err = fmt.Errorf("invalid station assignment packet %v", packet)
+	}
+	if err == nil && packet[4] >= StationNotInMatch {
+		err = fmt.Errorf("arena rejected the connection with station status %d", packet[4])
+	}
+	if err == nil {
+		// The arena only sends TCP packets occasionally, so the connection shouldn't time out while idle.
+		err = tcpConn.SetReadDeadline(time.Time{})
+	}
+	if err != nil {
+		tcpConn.Close()
+		udpConn.Close()
+		return err
+	}
+
+	ds.mutex.Lock()
+	ds.tcpConn = tcpConn
+	ds.udpConn = udpConn
+	ds.status = Status{Connected: true, StationStatus: int(packet[4])}
+	if int(packet[3]) < len(allianceStations) {
+		ds.status.AllianceStation = allianceStations[packet[3]]
+	}
+	ds.mutex.Unlock()
+
+	go ds.handleTcpPackets(tcpConn)
+	go ds.handleUdpPackets(udpConn)
+	return nil
+}
+
+// Disconnects from the arena and stops the Run() loop.
+func (ds *DriverStation) Close() {
+	ds.mutex.Lock()
+	ds.closed = true
+	ds.mutex.Unlock()
+	ds.disconnect()
+}
+
+func (ds *DriverStation) isClosed() bool {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	return ds.closed
+}
+
+func (ds *DriverStation) disconnect() {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	if ds.tcpConn != nil {
+		ds.tcpConn.Close()
+		ds.tcpConn = nil
+	}
+	if ds.udpConn != nil {
+		ds.udpConn.Close()
+		ds.udpConn = nil
+	}
+	ds.status.Connected = false
+}
+
+// Sends the periodic robot status to the arena over UDP, and a keepalive and mode report over TCP when due.
+func (ds *DriverStation) sendPackets() error {
+	ds.mutex.Lock()
+	tcpConn, udpConn := ds.tcpConn, ds.udpConn
+	status := ds.status
+	ds.mutex.Unlock()
+	if tcpConn == nil || udpConn == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	arenaUdpAddress, err := net.ResolveUDPAddr(
+		"udp4", net.JoinHostPort(ds.ArenaAddress, strconv.Itoa(ds.ArenaUdpPort)),
+	)
+	if err != nil {
+		return err
+	}
+	if _, err = udpConn.WriteToUDP(ds.encodeStatusPacket(), arenaUdpAddress); err != nil {
+		return err
+	}
+
+	// Report the mode the robot is in back to the arena whenever it changes, as the real DS does in its log packets.
+	statusByte := encodeReportedStatusByte(status)
+	keepaliveDue := time.Since(ds.lastKeepaliveTime).Milliseconds() >= keepalivePacketPeriodMs
+	if statusByte != ds.reportedStatusByte || keepaliveDue {
+		if _, err = tcpConn.Write([]byte{0, 6, 22, 0, 0, 0, 0, statusByte}); err != nil {
+			return err
+		}
+		if _, err = tcpConn.Write([]byte{0, 1, 29}); err != nil {
+			return err
+		}
+		ds.reportedStatusByte = statusByte
+		ds.lastKeepaliveTime = time.Now()
+	}
+	return nil
+}
+
+// Serializes the robot status into a UDP packet for the arena.
+func (ds *DriverStation) encodeStatusPacket() []byte {
+	packet := make([]byte, 15)
+
+	// Packet number, stored big-endian in two bytes.
+	packet[0] = byte(ds.statusPacketCount >> 8 & 0xff)
+	packet[1] = byte(ds.statusPacketCount & 0xff)
+	ds.statusPacketCount++
+
+	// Protocol version.
+	packet[2] = 0
+
+	// Link status byte.
+	if ds.RioLinked {
+		packet[3] |= 0x08
+	}
+	if ds.RadioLinked {
+		packet[3] |= 0x10
+	}
+	if ds.RobotLinked {
+		packet[3] |= 0x20
+	}
+
+	// Team number.
+	packet[4] = byte(ds.TeamId >> 8)
+	packet[5] = byte(ds.TeamId & 0xff)
+
+	// Battery voltage, stored as volts * 256.
+	packet[6] = byte(int(ds.BatteryVoltage))
+	packet[7] = byte(int((ds.BatteryVoltage - float64(int(ds.BatteryVoltage))) * 256))
+
+	// Tag 1 carries the lost packet count and round-trip time.
+	packet[8] = 6
+	packet[9] = 1
+	packet[10] = byte(ds.MissedPacketCount >> 8 & 0xff)
+	packet[11] = byte(ds.MissedPacketCount & 0xff)
+	packet[14] = byte(min(ds.TripTimeMs, 255))
+
+	return packet
+}
+
+// Returns the status byte the DS reports in its log packets for the given commanded state.
+func encodeReportedStatusByte(status Status) byte {
+	if !status.Enabled || status.EStop {
+		return 0x08
+	}
+	if status.Auto {
+		return 0x10
+	}
+	return 0x20
+}
+
+// Loops to read control packets from the arena until the connection is closed.
+func (ds *DriverStation) handleUdpPackets(udpConn *net.UDPConn) {
+	buffer := make([]byte, 1500)
+	for {
+		count, err := udpConn.Read(buffer)
+		if err != nil {
+			return
+		}
+		ds.decodeControlPacket(buffer[:count])
+	}
+}
+
+// Updates the driver station's state from the given control packet sent by the arena.
+func (ds *DriverStation) decodeControlPacket(packet []byte) {
+	if len(packet) < 22 {
+		log.Printf("Simulated DS for Team %d received short control packet: %v", ds.TeamId, packet)
+		return
+	}
+
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.status.Auto = packet[3]&0x02 != 0
+	ds.status.Enabled = packet[3]&0x04 != 0
+	ds.status.AStop = packet[3]&0x40 != 0
+	ds.status.EStop = packet[3]&0x80 != 0
+	if int(packet[5]) < len(allianceStations) {
+		ds.status.AllianceStation = allianceStations[packet[5]]
+	}
+	ds.status.MatchType = int(packet[6])
+	ds.status.MatchNumber = int(packet[7])<<8 + int(packet[8])
+	ds.status.MatchSecondsRemaining = int(packet[20])<<8 + int(packet[21])
+	if len(packet) >= 24 && packet[23] == 32 {
+		gameDataEnd := min(22+1+int(packet[22]), len(packet))
+		ds.status.GameData = string(packet[24:gameDataEnd])
+	}
+	ds.status.ControlPacketCount++
+	ds.status.LastControlPacketTime = time.Now()
+}
+
+// Loops to read TCP packets from the arena until the connection is closed.
+func (ds *DriverStation) handleTcpPackets(tcpConn net.Conn) {
+	for {
+		packet, err := readTcpPacket(tcpConn)
+		if err != nil {
+			if !ds.isClosed() && err != io.EOF {
+				log.Printf("Simulated DS for Team %d failed to read TCP packet: %v", ds.TeamId, err)
+			}
+			ds.disconnect()
+			return
+		}
+
+		ds.mutex.Lock()
+		switch packet[2] {
+		case 20:
+			if len(packet) >= 4 {
+				ds.status.EventName = string(packet[4:min(4+int(packet[3]), len(packet))])
+			}
+		case 28:
+			if len(packet) >= 4 {
+				ds.status.GameData = string(packet[4:min(4+int(packet[3]), len(packet))])
+			}
+		}
+		ds.mutex.Unlock()
+	}
+}
+
+// Reads a single length-prefixed packet from the given TCP connection, returning it including the length bytes.
+func readTcpPacket(tcpConn net.Conn) ([]byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(tcpConn, header); err != nil {
+		return nil, err
+	}
+	packet := make([]byte, 2+int(header[0])<<8+int(header[1]))
+	copy(packet, header)
+	if _, err := io.ReadFull(tcpConn, packet[2:]); err != nil {
+		return nil, err
+	}
+	if len(packet) < 3 {
+		return nil, fmt.Errorf("empty TCP packet")
+	}
+	return packet, nil
+}
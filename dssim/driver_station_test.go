@@ -0,0 +1,150 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package dssim
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDriverStationConnect(t *testing.T) {
+	ds, tcpConn := connectToFakeArena(t, 254, []byte{0, 6, 31, 4, 1, 0, 0, 254})
+	defer ds.Close()
+	defer tcpConn.Close()
+
+	status := ds.Status()
+	assert.True(t, status.Connected)
+	assert.Equal(t, "B2", status.AllianceStation)
+	assert.Equal(t, StationWrong, status.StationStatus)
+
+	// Check that the event name and game data are picked up from TCP packets.
+	tcpConn.Write([]byte{0, 6, 20, 4, 'c', 'h', 'c', 'c'})
+	tcpConn.Write([]byte{0, 3, 28, 1, 'R'})
+	assert.Eventually(
+		t, func() bool { return ds.Status().GameData == "R" }, time.Second, 10*time.Millisecond,
+	)
+	assert.Equal(t, "chcc", ds.Status().EventName)
+}
+
+func TestDriverStationConnectRejected(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	go func() {
+		tcpConn, err := listener.Accept()
+		if err == nil {
+			readTcpPacket(tcpConn)
+			tcpConn.Write([]byte{0, 6, 31, 0, 2, 0, 0, 0})
+		}
+	}()
+
+	ds := NewDriverStation(254, "127.0.0.1")
+	ds.ArenaTcpPort = listener.Addr().(*net.TCPAddr).Port
+	assert.EqualError(t, ds.Connect(), "arena rejected the connection with station status 2")
+	assert.False(t, ds.Status().Connected)
+
+	// Check that a connection failure is reported.
+	listener.Close()
+	assert.NotNil(t, ds.Connect())
+}
+
+func TestDriverStationSendPackets(t *testing.T) {
+	arenaUdpConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	defer arenaUdpConn.Close()
+	ds, tcpConn := connectToFakeArena(t, 1114, []byte{0, 6, 31, 0, 0, 0, 0, 0})
+	defer ds.Close()
+	defer tcpConn.Close()
+	ds.ArenaUdpPort = arenaUdpConn.LocalAddr().(*net.UDPAddr).Port
+	ds.BatteryVoltage = 12.25
+	ds.TripTimeMs = 7
+	ds.MissedPacketCount = 258
+	ds.RadioLinked = false
+
+	assert.Nil(t, ds.sendPackets())
+	buffer := make([]byte, 100)
+	arenaUdpConn.SetReadDeadline(time.Now().Add(time.Second))
+	count, err := arenaUdpConn.Read(buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0x28, 4, 90, 12, 64, 6, 1, 1, 2, 0, 0, 7}, buffer[:count])
+
+	// Check that the disabled mode is reported over TCP along with a keepalive.
+	tcpConn.SetReadDeadline(time.Now().Add(time.Second))
+	packet, err := readTcpPacket(tcpConn)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 6, 22, 0, 0, 0, 0, 0x08}, packet)
+	packet, _ = readTcpPacket(tcpConn)
+	assert.Equal(t, []byte{0, 1, 29}, packet)
+
+	// Check that a change in the commanded mode is reported straight away.
+	ds.status.Enabled = true
+	ds.status.Auto = true
+	assert.Nil(t, ds.sendPackets())
+	packet, _ = readTcpPacket(tcpConn)
+	assert.Equal(t, []byte{0, 6, 22, 0, 0, 0, 0, 0x10}, packet)
+}
+
+func TestDriverStationDecodeControlPacket(t *testing.T) {
+	ds := NewDriverStation(254, "127.0.0.1")
+	packet := make([]byte, 28)
+	packet[3] = 0x02 | 0x04 | 0x40
+	packet[5] = 5
+	packet[6] = 2
+	packet[7] = 1
+	packet[8] = 2
+	packet[21] = 15
+	packet[22] = 5
+	packet[23] = 32
+	copy(packet[24:], "RBRB")
+	ds.decodeControlPacket(packet)
+
+	status := ds.Status()
+	assert.True(t, status.Auto)
+	assert.True(t, status.Enabled)
+	assert.True(t, status.AStop)
+	assert.False(t, status.EStop)
+	assert.Equal(t, "B3", status.AllianceStation)
+	assert.Equal(t, 2, status.MatchType)
+	assert.Equal(t, 258, status.MatchNumber)
+	assert.Equal(t, 15, status.MatchSecondsRemaining)
+	assert.Equal(t, "RBRB", status.GameData)
+	assert.Equal(t, 1, status.ControlPacketCount)
+
+	// Short packets should be ignored.
+	ds.decodeControlPacket(packet[:10])
+	assert.Equal(t, 1, ds.Status().ControlPacketCount)
+}
+
+// Connects a driver station to a fake arena that responds with the given assignment packet, and returns the arena's
+// end of the TCP connection.
+func connectToFakeArena(t *testing.T, teamId int, assignmentPacket []byte) (*DriverStation, net.Conn) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	ds := NewDriverStation(teamId, "127.0.0.1")
+	ds.ArenaTcpPort = listener.Addr().(*net.TCPAddr).Port
+
+	connected := make(chan net.Conn)
+	var initialPacket []byte
+	go func() {
+		tcpConn, err := listener.Accept()
+		assert.Nil(t, err)
+		tcpConn.SetReadDeadline(time.Now().Add(time.Second))
+		initialPacket, err = readTcpPacket(tcpConn)
+		assert.Nil(t, err)
+		tcpConn.Write(assignmentPacket)
+		connected <- tcpConn
+	}()
+	assert.Nil(t, ds.Connect())
+	tcpConn := <-connected
+
+	udpPort := ds.udpConn.LocalAddr().(*net.UDPAddr).Port
+	expectedPacket := []byte{0, byte(5 + len(strconv.Itoa(teamId))), 30, byte(udpPort >> 8), byte(udpPort), 0}
+	expectedPacket = append(append(expectedPacket, byte(len(strconv.Itoa(teamId)))), strconv.Itoa(teamId)...)
+	assert.Equal(t, expectedPacket, initialPacket)
+	return ds, tcpConn
+}
@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -54,18 +55,42 @@ const (
 	PostTimeout
 )
 
+// Stable names for the match states, for use in external integrations.
+var MatchStateNames = map[MatchState]string{
+	PreMatch:      "pre_match",
+	StartMatch:    "start_match",
+	AutoPeriod:    "auto",
+	PausePeriod:   "pause",
+	TeleopPeriod:  "teleop",
+	PostMatch:     "post_match",
+	TimeoutActive: "timeout",
+	PostTimeout:   "post_timeout",
+}
+
 type Arena struct {
 	Database         *model.Database
 	EventSettings    *model.EventSettings
+	FieldNumber      int
+	fieldArenas      []*Arena
+	fieldArenasMutex sync.RWMutex
+	primaryArena     *Arena
+	fieldsMutex      sync.Mutex
+	tbaEventCode     string
+	dsListenAddress  string
 	accessPoint      network.AccessPoint
 	networkSwitch    *network.Switch
 	redSCC           *network.SCCSwitch
 	blueSCC          *network.SCCSwitch
 	Plc              plc.Plc
+	hardwarePlc      plc.Plc
+	SimulatedPlc     *plc.SimulatedPlc
+	plcStop          chan struct{}
 	TbaClient        *partner.TbaClient
 	NexusClient      *partner.NexusClient
 	BlackmagicClient *partner.BlackmagicClient
 	CompanionClient  *partner.CompanionClient
+	WebhookClient    *partner.WebhookClient
+	MqttClient       *partner.MqttClient
 	AllianceStations map[string]*AllianceStation
 	Displays         map[string]*Display
 	TeamSigns        *TeamSigns
@@ -105,8 +130,15 @@ type Arena struct {
 	breakNextMatchName                string
 	preloadedTeams                    *[6]*model.Team
 	NextFoulId                        int
+	scoreEvents                       []*model.ScoreEvent
+	scoreEventsMutex                  sync.Mutex
 	DriverStationUdpSocket            *net.UDPConn
+	driverStationMutex                sync.Mutex
 	redWonAuto                        bool
+	stackLights                       partner.MqttStackLights
+	passive                           atomic.Bool
+	Standby                           StandbyStatus
+	standbyMutex                      sync.Mutex
 }
 
 type AllianceStation struct {
@@ -122,11 +154,126 @@ type AllianceStation struct {
 	GameData     string
 }
 
-// Creates the arena and sets it to its initial state.
+// Creates the arena for the event's first field and sets it to its initial state.
 func NewArena(dbPath string) (*Arena, error) {
+	database, err := model.OpenDatabase(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return newArena(database, 1, nil, nil)
+}
+
+// Creates the arena for the event's first field in passive mode, in which it mirrors the data of the primary server
+// at the given URL and leaves the field hardware alone until it is promoted.
+func NewStandbyArena(dbPath, primaryUrl, password string) (*Arena, error) {
+	database, err := model.OpenDatabase(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return newArena(database, 1, nil, &StandbyStatus{PrimaryUrl: primaryUrl, password: password})
+}
+
+// Creates an arena for each additional field configured for the event, each of which has its own field hardware and
+// runs its own match queue. Normally all fields play a single event, sharing this arena's database and playoff
+// tournament. If divisions are enabled, each additional field instead plays a division with its own teams, schedule,
+// rankings and playoff tournament, and this arena's field hosts the finals between the division winners.
+func (arena *Arena) CreateFieldArenas() error {
+	var fieldArenas []*Arena
+	for fieldNumber := 2; fieldNumber <= arena.EventSettings.NumFields; fieldNumber++ {
+		database := arena.Database
+		if arena.EventSettings.DivisionsEnabled {
+			var err error
+			if database, err = arena.Database.DivisionDatabase(fieldNumber); err != nil {
+				return err
+			}
+		}
+		fieldArena, err := newArena(database, fieldNumber, arena, nil)
+		if err != nil {
+			return err
+		}
+		fieldArenas = append(fieldArenas, fieldArena)
+	}
+	arena.fieldArenasMutex.Lock()
+	arena.fieldArenas = fieldArenas
+	arena.fieldArenasMutex.Unlock()
+
+	if arena.HasDivisions() {
+		// Replace the playoff tournament with the finals now that the number of divisions is known.
+		if err := arena.CreatePlayoffTournament(); err != nil {
+			return err
+		}
+		return arena.UpdatePlayoffTournament()
+	}
+	return nil
+}
+
+// Returns the arenas for the additional fields beyond this one, if any are running.
+func (arena *Arena) FieldArenas() []*Arena {
+	arena.fieldArenasMutex.RLock()
+	defer arena.fieldArenasMutex.RUnlock()
+	return arena.fieldArenas
+}
+
+// Returns true if this arena's field is playing its own division rather than being part of the event as a whole.
+func (arena *Arena) IsDivision() bool {
+	return arena.Database.Division > 0
+}
+
+// Returns true if the additional fields are playing separate divisions, in which case this arena's field hosts the
+// finals between the division winners.
+func (arena *Arena) HasDivisions() bool {
+	fieldArenas := arena.FieldArenas()
+	return len(fieldArenas) > 0 && fieldArenas[0].IsDivision()
+}
+
+// Returns the arena owning the playoff tournament played on this arena's field, which is the first field's unless this
+// field is playing its own division.
+func (arena *Arena) playoffArena() *Arena {
+	if arena.IsDivision() {
+		return arena
+	}
+	return arena.firstFieldArena()
+}
+
+// Returns the arena for the first field, which owns the state shared between all of them.
+func (arena *Arena) firstFieldArena() *Arena {
+	if arena.primaryArena != nil {
+		return arena.primaryArena
+	}
+	return arena
+}
+
+// Returns the arena for the given additional field, or nil if that field isn't running.
+func (arena *Arena) GetFieldArena(fieldNumber int) *Arena {
+	for _, fieldArena := range arena.FieldArenas() {
+		if fieldArena.FieldNumber == fieldNumber {
+			return fieldArena
+		}
+	}
+	return nil
+}
+
+// Returns true if this is a standby server that is mirroring the primary server's data rather than running the field.
+func (arena *Arena) IsPassive() bool {
+	return arena.passive.Load()
+}
+
+func newArena(
+	database *model.Database, fieldNumber int, primaryArena *Arena, standby *StandbyStatus,
+) (*Arena, error) {
 	arena := new(Arena)
+	arena.Database = database
+	arena.FieldNumber = fieldNumber
+	arena.primaryArena = primaryArena
+	if standby != nil {
+		arena.passive.Store(true)
+		arena.Standby = *standby
+	}
 	arena.configureNotifiers()
-	arena.Plc = new(plc.ModbusPlc)
+	arena.hardwarePlc = new(plc.ModbusPlc)
+	arena.SimulatedPlc = plc.NewSimulatedPlc()
+	arena.Plc = arena.hardwarePlc
+	arena.plcStop = make(chan struct{})
 
 	arena.AllianceStations = make(map[string]*AllianceStation)
 	arena.AllianceStations["R1"] = new(AllianceStation)
@@ -140,14 +287,10 @@ func NewArena(dbPath string) (*Arena, error) {
 
 	arena.TeamSigns = NewTeamSigns()
 	arena.Leds = led.NewController()
+	arena.WebhookClient = partner.NewWebhookClient(database)
+	arena.MqttClient = partner.NewMqttClient()
 
-	var err error
-	arena.Database, err = model.OpenDatabase(dbPath)
-	if err != nil {
-		return nil, err
-	}
-	err = arena.LoadSettings()
-	if err != nil {
+	if err := arena.LoadSettings(); err != nil {
 		return nil, err
 	}
 
@@ -155,7 +298,7 @@ func NewArena(dbPath string) (*Arena, error) {
 
 	// Load empty match as current.
 	arena.MatchState = PreMatch
-	arena.LoadTestMatch()
+	arena.loadMatch(newTestMatch())
 	arena.LastMatchTimeSec = 0
 	arena.lastMatchState = -1
 
@@ -175,16 +318,20 @@ func (arena *Arena) LoadSettings() error {
 		return err
 	}
 	arena.EventSettings = settings
+	fieldSettings, err := arena.getFieldSettings()
+	if err != nil {
+		return err
+	}
 
 	// Initialize the components that depend on settings.
-	arena.TeamSigns.Red1.SetId(settings.TeamSignRed1Id)
-	arena.TeamSigns.Red2.SetId(settings.TeamSignRed2Id)
-	arena.TeamSigns.Red3.SetId(settings.TeamSignRed3Id)
-	arena.TeamSigns.RedTimer.SetId(settings.TeamSignRedTimerId)
-	arena.TeamSigns.Blue1.SetId(settings.TeamSignBlue1Id)
-	arena.TeamSigns.Blue2.SetId(settings.TeamSignBlue2Id)
-	arena.TeamSigns.Blue3.SetId(settings.TeamSignBlue3Id)
-	arena.TeamSigns.BlueTimer.SetId(settings.TeamSignBlueTimerId)
+	arena.TeamSigns.Red1.SetId(fieldSettings.TeamSignRed1Id)
+	arena.TeamSigns.Red2.SetId(fieldSettings.TeamSignRed2Id)
+	arena.TeamSigns.Red3.SetId(fieldSettings.TeamSignRed3Id)
+	arena.TeamSigns.RedTimer.SetId(fieldSettings.TeamSignRedTimerId)
+	arena.TeamSigns.Blue1.SetId(fieldSettings.TeamSignBlue1Id)
+	arena.TeamSigns.Blue2.SetId(fieldSettings.TeamSignBlue2Id)
+	arena.TeamSigns.Blue3.SetId(fieldSettings.TeamSignBlue3Id)
+	arena.TeamSigns.BlueTimer.SetId(fieldSettings.TeamSignBlueTimerId)
 	accessPointWifiStatuses := [6]*network.TeamWifiStatus{
 		&arena.AllianceStations["R1"].WifiStatus,
 		&arena.AllianceStations["R2"].WifiStatus,
@@ -194,38 +341,54 @@ func (arena *Arena) LoadSettings() error {
 		&arena.AllianceStations["B3"].WifiStatus,
 	}
 	arena.accessPoint.SetSettings(
-		settings.ApAddress,
-		settings.ApPassword,
-		settings.ApChannel,
+		fieldSettings.ApAddress,
+		fieldSettings.ApPassword,
+		fieldSettings.ApChannel,
 		settings.NetworkSecurityEnabled,
 		accessPointWifiStatuses,
 	)
-	arena.networkSwitch = network.NewSwitch(settings.SwitchAddress, settings.SwitchPassword)
+	arena.networkSwitch = network.NewSwitch(fieldSettings.SwitchAddress, fieldSettings.SwitchPassword)
 	sccUpCommands := strings.Split(settings.SCCUpCommands, "\n")
 	sccDownCommands := strings.Split(settings.SCCDownCommands, "\n")
 	arena.redSCC = network.NewSCCSwitch(
-		settings.RedSCCAddress,
+		fieldSettings.RedSCCAddress,
 		settings.SCCUsername,
 		settings.SCCPassword,
 		sccUpCommands,
 		sccDownCommands,
 	)
 	arena.blueSCC = network.NewSCCSwitch(
-		settings.BlueSCCAddress,
+		fieldSettings.BlueSCCAddress,
 		settings.SCCUsername,
 		settings.SCCPassword,
 		sccUpCommands,
 		sccDownCommands,
 	)
-	arena.Plc.SetAddress(settings.PlcAddress)
-	if err = arena.Leds.SetAddress(settings.LedControllerAddress); err != nil {
-		return err
+	if settings.PlcSimulated {
+		// Make sure the real PLC doesn't also drive the field while the simulated one is in use.
+		arena.hardwarePlc.SetAddress("")
+		arena.setActivePlc(arena.SimulatedPlc)
+	} else if arena.Plc == arena.SimulatedPlc {
+		arena.setActivePlc(arena.hardwarePlc)
+	}
+	arena.dsListenAddress = fieldSettings.DriverStationAddress
+	if !arena.IsPassive() {
+		// A standby leaves the field hardware alone until it is promoted, at which point the settings are reloaded.
+		arena.Plc.SetAddress(fieldSettings.PlcAddress)
+		if err = arena.Leds.SetAddress(fieldSettings.LedControllerAddress); err != nil {
+			return err
+		}
 	}
-	arena.TbaClient = partner.NewTbaClient(settings.TbaEventCode, settings.TbaSecretId, settings.TbaSecret)
-	arena.NexusClient = partner.NewNexusClient(settings.TbaEventCode, settings.NexusAutoQueueKey)
-	arena.BlackmagicClient = partner.NewBlackmagicClient(settings.BlackmagicAddresses)
+	arena.tbaEventCode = settings.TbaEventCode
+	if arena.IsDivision() {
+		// Each division is its own event on The Blue Alliance and Nexus.
+		arena.tbaEventCode = fieldSettings.TbaEventCode
+	}
+	arena.TbaClient = partner.NewTbaClient(arena.tbaEventCode, settings.TbaSecretId, settings.TbaSecret)
+	arena.NexusClient = partner.NewNexusClient(arena.tbaEventCode, settings.NexusAutoQueueKey)
+	arena.BlackmagicClient = partner.NewBlackmagicClient(fieldSettings.BlackmagicAddresses)
 
-	// Initialize Companion client with event configurations
+	// Initialize Companion client with event configurations; each field has its own Companion but the same buttons.
 	companionEventConfigs := map[partner.CompanionEvent]partner.CompanionEventConfig{
 		partner.EventMatchPreview: {
 			Page:   settings.CompanionMatchPreviewPage,
@@ -274,11 +437,22 @@ func (arena *Arena) LoadSettings() error {
 		},
 	}
 	arena.CompanionClient = partner.NewCompanionClient(
-		settings.CompanionAddress,
-		settings.CompanionPort,
+		fieldSettings.CompanionAddress,
+		fieldSettings.CompanionPort,
 		companionEventConfigs,
 	)
+	mqttTopicPrefix := settings.MqttTopicPrefix
+	if arena.FieldNumber > 1 {
+		// Keep each field's topics separate when running multiple fields against the same broker.
+		mqttTopicPrefix += fmt.Sprintf("/field%d", arena.FieldNumber)
+	}
+	arena.MqttClient.SetSettings(
+		settings.MqttAddress, settings.MqttPort, settings.MqttUsername, settings.MqttPassword, mqttTopicPrefix,
+	)
 
+	if err = game.SetCurrentGame(settings.GameKey); err != nil {
+		return err
+	}
 	game.MatchTiming.AutoDurationSec = settings.AutoDurationSec
 	game.MatchTiming.PauseDurationSec = settings.PauseDurationSec
 	game.MatchTiming.TransitionShiftDurationSec = settings.TransitionShiftDurationSec
@@ -290,6 +464,15 @@ func (arena *Arena) LoadSettings() error {
 	game.EnergizedBonusThreshold = settings.EnergizedBonusThreshold
 	game.SuperchargedBonusThreshold = settings.SuperchargedBonusThreshold
 	game.TraversalBonusThreshold = settings.TraversalBonusThreshold
+	game.CurrentRankingRules = settings.RankingRules()
+
+	if playoffArena := arena.playoffArena(); playoffArena != arena {
+		// Additional fields share the first field's playoff tournament rather than reconstructing their own.
+		playoffArena.fieldsMutex.Lock()
+		arena.PlayoffTournament = playoffArena.PlayoffTournament
+		playoffArena.fieldsMutex.Unlock()
+		return nil
+	}
 
 	// Reconstruct the playoff tournament in memory.
 	if err = arena.CreatePlayoffTournament(); err != nil {
@@ -299,20 +482,80 @@ func (arena *Arena) LoadSettings() error {
 		return err
 	}
 
+	// Propagate the new settings to the arenas for any additional fields.
+	for _, fieldArena := range arena.FieldArenas() {
+		if err = fieldArena.LoadSettings(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// Constructs an empty playoff tournament in memory, based only on the number of alliances.
+// Returns the hardware settings for this arena's field. Those for the first field are part of the event settings.
+func (arena *Arena) getFieldSettings() (*model.FieldSettings, error) {
+	if arena.primaryArena == nil {
+		return arena.EventSettings.PrimaryFieldSettings(), nil
+	}
+	fieldSettings, err := arena.Database.GetFieldSettingsById(arena.FieldNumber)
+	if err != nil {
+		return nil, err
+	}
+	if fieldSettings == nil {
+		fieldSettings = &model.FieldSettings{Id: arena.FieldNumber}
+	}
+	return fieldSettings, nil
+}
+
+// Constructs an empty playoff tournament in memory, based only on the number of alliances (or the bracket definition,
+// for a custom playoff format).
 func (arena *Arena) CreatePlayoffTournament() error {
+	if playoffArena := arena.playoffArena(); playoffArena != arena {
+		// The playoff tournament is shared between all fields and owned by the first one.
+		return playoffArena.CreatePlayoffTournament()
+	}
+
+	var playoffTournament *playoff.PlayoffTournament
 	var err error
-	arena.PlayoffTournament, err = playoff.NewPlayoffTournament(
-		arena.EventSettings.PlayoffType, arena.EventSettings.NumPlayoffAlliances,
-	)
-	return err
+	if numDivisions := len(arena.FieldArenas()); arena.HasDivisions() {
+		// The finals between the division winners are a best-of-three between two divisions, or a round robin
+		// followed by a best-of-three between more.
+		if numDivisions == 2 {
+			playoffTournament, err = playoff.NewPlayoffTournament(model.SingleEliminationPlayoff, numDivisions)
+		} else {
+			playoffTournament, err = playoff.NewPlayoffTournament(model.RoundRobinPlayoff, numDivisions)
+		}
+	} else if arena.EventSettings.PlayoffType == model.CustomPlayoff {
+		var definition *playoff.BracketDefinition
+		definition, err = playoff.ParseBracketDefinition([]byte(arena.EventSettings.CustomBracketDefinition))
+		if err != nil {
+			return err
+		}
+		playoffTournament, err = playoff.NewCustomPlayoffTournament(definition)
+	} else {
+		playoffTournament, err = playoff.NewPlayoffTournament(
+			arena.EventSettings.PlayoffType, arena.EventSettings.NumPlayoffAlliances,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	arena.fieldsMutex.Lock()
+	defer arena.fieldsMutex.Unlock()
+	arena.PlayoffTournament = playoffTournament
+	for _, fieldArena := range arena.FieldArenas() {
+		if !fieldArena.IsDivision() {
+			fieldArena.PlayoffTournament = playoffTournament
+		}
+	}
+	return nil
 }
 
 // Performs the one-time creation of all matches for the playoff tournament.
 func (arena *Arena) CreatePlayoffMatches(startTime time.Time) error {
+	fieldsMutex := &arena.playoffArena().fieldsMutex
+	fieldsMutex.Lock()
+	defer fieldsMutex.Unlock()
 	return arena.PlayoffTournament.CreateMatchesAndBreaks(arena.Database, startTime)
 }
 
@@ -323,6 +566,10 @@ func (arena *Arena) UpdatePlayoffTournament() error {
 		return err
 	}
 	if len(alliances) > 0 {
+		// The tournament may be shared between several fields, any of which may be committing a playoff match.
+		fieldsMutex := &arena.playoffArena().fieldsMutex
+		fieldsMutex.Lock()
+		defer fieldsMutex.Unlock()
 		return arena.PlayoffTournament.UpdateMatches(arena.Database)
 	}
 	return nil
@@ -330,11 +577,26 @@ func (arena *Arena) UpdatePlayoffTournament() error {
 
 // Sets up the arena for the given match.
 func (arena *Arena) LoadMatch(match *model.Match) error {
+	if arena.IsPassive() {
+		return fmt.Errorf("cannot load a match while this server is a standby for the primary server")
+	}
+	return arena.loadMatch(match)
+}
+
+func (arena *Arena) loadMatch(match *model.Match) error {
 	if arena.MatchState != PreMatch && arena.MatchState != TimeoutActive {
 		return fmt.Errorf("cannot load match while there is a match still in progress or with results pending")
 	}
 
+	// Check that no other field has the match loaded and claim it in one go, so that two fields can't both load it.
+	fieldsMutex := &arena.firstFieldArena().fieldsMutex
+	fieldsMutex.Lock()
+	if match.Type != model.Test && arena.isMatchLoadedOnOtherField(match) {
+		fieldsMutex.Unlock()
+		return fmt.Errorf("cannot load match %s since it is already loaded on another field", match.ShortName)
+	}
 	arena.CurrentMatch = match
+	fieldsMutex.Unlock()
 
 	loadedByNexus := false
 	if match.ShouldAllowNexusSubstitution() && arena.EventSettings.NexusEnabled {
@@ -401,6 +663,9 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 	arena.ScoringPanelRegistry.resetScoreCommitted()
 	arena.Plc.ResetMatch()
 	arena.NextFoulId = 1
+	arena.scoreEventsMutex.Lock()
+	arena.scoreEvents = nil
+	arena.scoreEventsMutex.Unlock()
 	arena.redWonAuto = false
 	arena.Leds.SetMode(led.OffMode, led.OffMode)
 
@@ -410,13 +675,18 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 	arena.AllianceStationDisplayMode = "match"
 	arena.AllianceStationDisplayModeNotifier.Notify()
 	arena.ScoringStatusNotifier.Notify()
+	arena.sendMatchWebhookEvent(partner.WebhookMatchLoaded, partner.NewWebhookMatch(match))
 
 	return nil
 }
 
 // Sets a new test match containing no teams as the current match.
 func (arena *Arena) LoadTestMatch() error {
-	return arena.LoadMatch(&model.Match{Type: model.Test, ShortName: "T", LongName: "Test Match"})
+	return arena.LoadMatch(newTestMatch())
+}
+
+func newTestMatch() *model.Match {
+	return &model.Match{Type: model.Test, ShortName: "T", LongName: "Test Match"}
 }
 
 // Loads the first unplayed match of the current match type.
@@ -505,6 +775,55 @@ func (arena *Arena) SubstituteTeams(red1, red2, red3, blue1, blue2, blue3 int) e
 	return nil
 }
 
+// Calls up the given backup team to replace the given member of the given playoff alliance. The backup takes the
+// replaced team's place in any upcoming playoff matches, including the currently loaded one if it hasn't started yet.
+func (arena *Arena) CallUpBackup(allianceId, backupTeamId, replacedTeamId int) error {
+	alliance, err := arena.Database.GetAllianceById(allianceId)
+	if err != nil {
+		return err
+	}
+	if alliance == nil {
+		return fmt.Errorf("Alliance %d does not exist.", allianceId)
+	}
+	if team, err := arena.Database.GetTeamById(backupTeamId); err != nil {
+		return err
+	} else if team == nil {
+		return fmt.Errorf("Team %d is not present at the event.", backupTeamId)
+	} else if team.Withdrawn {
+		return fmt.Errorf("Team %d has withdrawn from the event.", backupTeamId)
+	}
+	if err = alliance.CallUpBackup(backupTeamId, replacedTeamId, time.Now()); err != nil {
+		return err
+	}
+	if err = arena.Database.UpdateAlliance(alliance); err != nil {
+		return err
+	}
+	if err = arena.UpdatePlayoffTournament(); err != nil {
+		return err
+	}
+
+	match := arena.CurrentMatch
+	if arena.MatchState == PreMatch && match.Type == model.Playoff &&
+		(match.PlayoffRedAlliance == allianceId || match.PlayoffBlueAlliance == allianceId) {
+		teamIds := []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3}
+		for i, teamId := range teamIds {
+			if teamId == replacedTeamId {
+				teamIds[i] = backupTeamId
+			}
+		}
+		err = arena.SubstituteTeams(teamIds[0], teamIds[1], teamIds[2], teamIds[3], teamIds[4], teamIds[5])
+		if err != nil {
+			return err
+		}
+	} else {
+		arena.MatchLoadNotifier.Notify()
+	}
+
+	// Signal displays of the bracket to update themselves.
+	arena.ScorePostedNotifier.Notify()
+	return nil
+}
+
 // Starts the match if all conditions are met.
 func (arena *Arena) StartMatch() error {
 	err := arena.checkCanStartMatch()
@@ -565,6 +884,10 @@ func (arena *Arena) AbortMatch() error {
 	arena.SetAudienceDisplayMode("blank")
 	go arena.BlackmagicClient.StopRecording()
 	go arena.CompanionClient.SendEvent(partner.EventMatchAbort)
+	arena.sendMatchWebhookEvent(
+		partner.WebhookMatchEnded,
+		partner.WebhookMatchEnd{Match: partner.NewWebhookMatch(arena.CurrentMatch), Aborted: true},
+	)
 	return nil
 }
 
@@ -679,6 +1002,10 @@ func (arena *Arena) MatchTimeSec() float64 {
 // Performs a single iteration of checking inputs and timers and setting outputs accordingly to control the
 // flow of a match.
 func (arena *Arena) Update() {
+	// Hold off the driver station listeners, which run in their own goroutines, while the loop acts on their state.
+	arena.driverStationMutex.Lock()
+	defer arena.driverStationMutex.Unlock()
+
 	// Decide what state the robots need to be in, depending on where we are in the match.
 	auto := false
 	enabled := false
@@ -701,6 +1028,7 @@ func (arena *Arena) Update() {
 		arena.SetAllianceStationDisplayMode("match")
 		go arena.BlackmagicClient.StartRecording()
 		go arena.CompanionClient.SendEvent(partner.EventMatchStart)
+		arena.sendMatchWebhookEvent(partner.WebhookMatchStarted, partner.NewWebhookMatch(arena.CurrentMatch))
 		arena.MatchState = AutoPeriod
 		enabled = true
 		sendDsPacket = true
@@ -737,6 +1065,9 @@ func (arena *Arena) Update() {
 			sendDsPacket = true
 			go arena.BlackmagicClient.StopRecording()
 			go arena.CompanionClient.SendEvent(partner.EventMatchEnd)
+			arena.sendMatchWebhookEvent(
+				partner.WebhookMatchEnded, partner.WebhookMatchEnd{Match: partner.NewWebhookMatch(arena.CurrentMatch)},
+			)
 			go func() {
 				// Leave the scores on the screen briefly at the end of the match.
 				time.Sleep(time.Second * matchEndScoreDwellSec)
@@ -824,6 +1155,8 @@ func (arena *Arena) Update() {
 	// Handle the team number / timer displays.
 	arena.TeamSigns.Update(arena)
 
+	arena.handleMqtt(matchTimeSec)
+
 	arena.LastMatchTimeSec = matchTimeSec
 	arena.lastMatchState = arena.MatchState
 }
@@ -870,14 +1203,33 @@ func (arena *Arena) logTeamSnapshots() {
 
 // Loops indefinitely to track and update the arena components.
 func (arena *Arena) Run() {
-	// Bind the shared driver station UDP socket before any loop sends control packets from it.
-	arena.initializeUdpListener()
+	// Leave the field hardware alone and just mirror the primary server's data until this arena is promoted.
+	for arena.IsPassive() {
+		arena.runStandbySync()
+		time.Sleep(time.Millisecond * standbySyncPeriodMs)
+	}
 
-	// Start other loops in goroutines.
-	go arena.listenForDriverStations()
-	go arena.listenForDsUdpPackets()
+	// Run any additional fields alongside this one.
+	for _, fieldArena := range arena.FieldArenas() {
+		log.Printf("Running field %d.", fieldArena.FieldNumber)
+		go fieldArena.Run()
+	}
+
+	if arena.primaryArena != nil && arena.dsListenAddress == "" {
+		log.Printf(
+			"No driver station address is configured for field %d; driver stations can't connect.", arena.FieldNumber,
+		)
+	} else {
+		// Bind the shared driver station UDP socket before any loop sends control packets from it.
+		arena.initializeUdpListener()
+
+		// Start other loops in goroutines.
+		go arena.listenForDriverStations()
+		go arena.listenForDsUdpPackets()
+	}
 	go arena.accessPoint.Run()
-	go arena.Plc.Run()
+	go arena.runPlc()
+	go arena.MqttClient.Run()
 
 	for {
 		loopStartTime := time.Now()
@@ -894,6 +1246,29 @@ func (arena *Arena) Run() {
 	}
 }
 
+// Switches the arena over to the given PLC, stopping the loop of the one it replaces. The swap happens under the driver
+// station mutex so that it can't take effect partway through an iteration of the arena loop.
+func (arena *Arena) setActivePlc(activePlc plc.Plc) {
+	arena.driverStationMutex.Lock()
+	defer arena.driverStationMutex.Unlock()
+	if arena.Plc == activePlc {
+		return
+	}
+	arena.Plc = activePlc
+	close(arena.plcStop)
+	arena.plcStop = make(chan struct{})
+}
+
+// Loops indefinitely to run whichever PLC is active, restarting with the other one whenever the settings switch it.
+func (arena *Arena) runPlc() {
+	for {
+		arena.driverStationMutex.Lock()
+		activePlc, stop := arena.Plc, arena.plcStop
+		arena.driverStationMutex.Unlock()
+		activePlc.Run(stop)
+	}
+}
+
 // Calculates the red alliance score summary for the given realtime snapshot.
 func (arena *Arena) RedScoreSummary() *game.ScoreSummary {
 	return arena.RedRealtimeScore.CurrentScore.Summarize(&arena.BlueRealtimeScore.CurrentScore)
@@ -1007,7 +1382,10 @@ func (arena *Arena) getNextMatch(excludeCurrent bool) (*model.Match, error) {
 		return nil, err
 	}
 	for _, match := range matches {
-		if !match.IsComplete() && !(excludeCurrent && match.Id == arena.CurrentMatch.Id) {
+		if match.IsComplete() || excludeCurrent && match.Id == arena.CurrentMatch.Id {
+			continue
+		}
+		if arena.IsMatchOnField(&match) && !arena.isMatchLoadedOnOtherFieldLocking(&match) {
 			return &match, nil
 		}
 	}
@@ -1016,6 +1394,36 @@ func (arena *Arena) getNextMatch(excludeCurrent bool) (*model.Match, error) {
 	return nil, nil
 }
 
+// Returns true if the given match is to be played on this arena's field, either because it has been assigned to it or
+// because it is unassigned and can be played on any field.
+func (arena *Arena) IsMatchOnField(match *model.Match) bool {
+	return arena.EventSettings.NumFields <= 1 || arena.IsDivision() || match.FieldNumber == 0 ||
+		match.FieldNumber == arena.FieldNumber
+}
+
+// Returns true if the given match is currently loaded on a field other than this arena's. Acquires the first field's
+// fieldsMutex; loadMatch makes the definitive check when the match is loaded.
+func (arena *Arena) isMatchLoadedOnOtherFieldLocking(match *model.Match) bool {
+	fieldsMutex := &arena.firstFieldArena().fieldsMutex
+	fieldsMutex.Lock()
+	defer fieldsMutex.Unlock()
+	return arena.isMatchLoadedOnOtherField(match)
+}
+
+// Returns true if the given match is currently loaded on a field other than this arena's. Must be called with the first
+// field's fieldsMutex held.
+func (arena *Arena) isMatchLoadedOnOtherField(match *model.Match) bool {
+	firstFieldArena := arena.firstFieldArena()
+	for _, otherArena := range append([]*Arena{firstFieldArena}, firstFieldArena.FieldArenas()...) {
+		// Fields playing separate divisions have separate matches, whose IDs may coincide.
+		if otherArena != arena && otherArena.Database == arena.Database && otherArena.CurrentMatch != nil &&
+			otherArena.CurrentMatch.Id == match.Id {
+			return true
+		}
+	}
+	return false
+}
+
 // Configures the field network for the next match in advance of the current match being scored and committed.
 func (arena *Arena) preLoadNextMatch() {
 	if arena.MatchState != PostMatch {
@@ -1087,7 +1495,7 @@ func (arena *Arena) setupNetwork(teams [6]*model.Team, isPreload bool) {
 		}
 	}
 
-	if arena.EventSettings.NetworkSecurityEnabled {
+	if arena.EventSettings.NetworkSecurityEnabled && !arena.IsPassive() {
 		if err := arena.accessPoint.ConfigureTeamWifi(teams); err != nil {
 			log.Printf("Failed to configure team WiFi: %s", err.Error())
 		}
@@ -1304,7 +1712,7 @@ func (arena *Arena) handlePlcInputOutput() {
 		// Set the stack light state -- solid alliance color(s) if robots are not connected, solid orange if scores are
 		// not input, or blinking green if ready.
 		greenStackLight := redAllianceReady && blueAllianceReady && arena.Plc.GetCycleState(2, 0, 2)
-		arena.Plc.SetStackLights(!redAllianceReady, !blueAllianceReady, false, greenStackLight)
+		arena.setStackLights(!redAllianceReady, !blueAllianceReady, false, greenStackLight)
 		arena.Plc.SetStackBuzzer(redAllianceReady && blueAllianceReady)
 
 		// Turn off lights if all teams become ready.
@@ -1323,10 +1731,10 @@ func (arena *Arena) handlePlcInputOutput() {
 		}
 		scoreReady := arena.RedRealtimeScore.FoulsCommitted && arena.BlueRealtimeScore.FoulsCommitted &&
 			arena.positionPostMatchScoreReady("red") && arena.positionPostMatchScoreReady("blue")
-		arena.Plc.SetStackLights(false, false, !scoreReady, false)
+		arena.setStackLights(false, false, !scoreReady, false)
 	case AutoPeriod, PausePeriod, TeleopPeriod:
 		arena.Plc.SetStackBuzzer(false)
-		arena.Plc.SetStackLights(!redAllianceReady, !blueAllianceReady, false, true)
+		arena.setStackLights(!redAllianceReady, !blueAllianceReady, false, true)
 	}
 
 	matchStartTime := arena.MatchStartTime
@@ -1450,6 +1858,61 @@ func (arena *Arena) SignalReset() {
 	arena.Leds.SetMode(led.GreenMode, led.GreenMode)
 }
 
+// Sets the field stack lights, keeping track of their state for reporting to external integrations.
+func (arena *Arena) setStackLights(red, blue, orange, green bool) {
+	arena.stackLights = partner.MqttStackLights{Red: red, Blue: blue, Orange: orange, Green: green}
+	arena.Plc.SetStackLights(red, blue, orange, green)
+}
+
+// Publishes the current field state to the MQTT broker, if configured, and carries out any commands received from it.
+func (arena *Arena) handleMqtt(matchTimeSec float64) {
+	if !arena.MqttClient.IsEnabled() || arena.IsPassive() {
+		return
+	}
+
+	state := arena.getMqttFieldState(matchTimeSec)
+	arena.MqttClient.SetState(&state)
+	for {
+		select {
+		case command := <-arena.MqttClient.Commands():
+			arena.handleMqttCommand(command)
+		default:
+			return
+		}
+	}
+}
+
+// Returns a snapshot of the field state that is published to the MQTT broker.
+func (arena *Arena) getMqttFieldState(matchTimeSec float64) partner.MqttFieldState {
+	redLedMode, blueLedMode := arena.Leds.GetModes()
+	state := partner.MqttFieldState{
+		MatchState:   MatchStateNames[arena.MatchState],
+		MatchTimeSec: int(matchTimeSec),
+		RedScore:     arena.RedScoreSummary().Score,
+		BlueScore:    arena.BlueScoreSummary().Score,
+		StackLights:  arena.stackLights,
+		RedLedMode:   led.ModeNames[redLedMode],
+		BlueLedMode:  led.ModeNames[blueLedMode],
+		FieldEStop:   arena.Plc.GetFieldEStop(),
+		TeamEStops:   make(map[string]bool),
+		TeamAStops:   make(map[string]bool),
+	}
+	for station, allianceStation := range arena.AllianceStations {
+		state.TeamEStops[station] = allianceStation.EStop
+		state.TeamAStops[station] = allianceStation.AStop
+	}
+	return state
+}
+
+func (arena *Arena) handleMqttCommand(command partner.MqttCommand) {
+	switch command {
+	case partner.MqttCommandSignalVolunteers:
+		arena.SignalVolunteers()
+	case partner.MqttCommandSignalReset:
+		arena.SignalReset()
+	}
+}
+
 func (arena *Arena) handleSounds(matchTimeSec float64) {
 	if arena.MatchState == PreMatch || arena.MatchState == TimeoutActive || arena.MatchState == PostTimeout {
 		// Only apply this logic during a match.
@@ -1476,6 +1939,28 @@ func (arena *Arena) PlaySound(name string) {
 	}
 }
 
+// Notifies the webhooks subscribed to the given event, unless this is a standby server mirroring the primary.
+func (arena *Arena) SendWebhookEvent(event partner.WebhookEvent, data any) {
+	if !arena.IsPassive() {
+		arena.WebhookClient.SendEvent(event, data)
+	}
+}
+
+// Notifies the webhooks of an event in the current match's lifecycle, unless it is a test match such as the placeholder
+// loaded at startup, which isn't part of the event schedule.
+func (arena *Arena) sendMatchWebhookEvent(event partner.WebhookEvent, data any) {
+	if arena.CurrentMatch.Type != model.Test {
+		arena.SendWebhookEvent(event, data)
+	}
+}
+
+// Returns true if results should be published to The Blue Alliance, which a standby server leaves to the primary and
+// which a division only does if it has its own event code.
+func (arena *Arena) ShouldPublishToTba() bool {
+	return arena.EventSettings.TbaPublishingEnabled && !arena.IsPassive() &&
+		(!arena.IsDivision() || arena.tbaEventCode != "")
+}
+
 func (arena *Arena) positionPostMatchScoreReady(position string) bool {
 	numPanels := arena.ScoringPanelRegistry.GetNumPanels(position)
 	return numPanels > 0 && arena.ScoringPanelRegistry.GetNumScoreCommitted(position) >= numPanels
@@ -1515,6 +2000,19 @@ func (arena *Arena) runPeriodicTasks() {
 	arena.updateEarlyLateMessage()
 	arena.purgeDisconnectedDisplays()
 	arena.checkForUpdatedNexusLineup()
+	arena.purgeExpiredUserSessions()
+}
+
+// Deletes any login sessions that have outlived the configured session lifetime.
+func (arena *Arena) purgeExpiredUserSessions() {
+	count, err := arena.Database.DeleteExpiredUserSessions(arena.EventSettings.SessionLifetimeHours)
+	if err != nil {
+		log.Printf("Failed to purge expired user sessions: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("Purged %d expired user sessions.", count)
+	}
 }
 
 // Handles audience display automation from after score post to next match intro.
@@ -166,6 +166,7 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 	var matchup *playoff.Matchup
 	redOffFieldTeams := []*model.Team{}
 	blueOffFieldTeams := []*model.Team{}
+	backupTeams := map[string]bool{}
 	if arena.CurrentMatch.Type == model.Playoff {
 		matchGroup := arena.PlayoffTournament.MatchGroups()[arena.CurrentMatch.PlayoffMatchGroupId]
 		matchup, _ = matchGroup.(*playoff.Matchup)
@@ -190,6 +191,7 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 			blueOffFieldTeams = append(blueOffFieldTeams, team)
 			allTeamIds = append(allTeamIds, teamId)
 		}
+		backupTeams = arena.getBackupTeams(arena.CurrentMatch)
 	}
 
 	rankings := make(map[string]int)
@@ -216,6 +218,7 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 		Matchup            *playoff.Matchup
 		RedOffFieldTeams   []*model.Team
 		BlueOffFieldTeams  []*model.Team
+		BackupTeams        map[string]bool
 		BreakDescription   string
 		BreakNextMatchName string
 	}{
@@ -227,6 +230,7 @@ func (arena *Arena) GenerateMatchLoadMessage() any {
 		matchup,
 		redOffFieldTeams,
 		blueOffFieldTeams,
+		backupTeams,
 		arena.breakDescription,
 		arena.breakNextMatchName,
 	}
@@ -280,6 +284,7 @@ func (arena *Arena) GenerateScorePostedMessage() any {
 	var redDestination, blueDestination string
 	redOffFieldTeamIds := []int{}
 	blueOffFieldTeamIds := []int{}
+	backupTeams := map[string]bool{}
 	if arena.SavedMatch.Type == model.Playoff {
 		matchGroup := arena.PlayoffTournament.MatchGroups()[arena.SavedMatch.PlayoffMatchGroupId]
 		if matchup, ok := matchGroup.(*playoff.Matchup); ok {
@@ -294,6 +299,7 @@ func (arena *Arena) GenerateScorePostedMessage() any {
 			log.Printf("Failed to get off-field teams for match %d while generating score posted message: %v",
 				arena.SavedMatch.Id, err)
 		}
+		backupTeams = arena.getBackupTeams(arena.SavedMatch)
 	}
 
 	redRankings := map[int]*game.Ranking{
@@ -326,6 +332,7 @@ func (arena *Arena) GenerateScorePostedMessage() any {
 		BlueRankings        map[int]*game.Ranking
 		RedOffFieldTeamIds  []int
 		BlueOffFieldTeamIds []int
+		BackupTeams         map[string]bool
 		RedWon              bool
 		BlueWon             bool
 		TiebreakReason      string
@@ -348,6 +355,7 @@ func (arena *Arena) GenerateScorePostedMessage() any {
 		blueRankings,
 		redOffFieldTeamIds,
 		blueOffFieldTeamIds,
+		backupTeams,
 		arena.SavedMatch.Status == game.RedWonMatch,
 		arena.SavedMatch.Status == game.BlueWonMatch,
 		tiebreakReason,
@@ -408,3 +416,21 @@ func getRulesViolated(redFouls, blueFouls []game.Foul) map[int]*game.Rule {
 	}
 	return rules
 }
+
+// Returns the set of teams, keyed by team ID, that have been called up as backups for either alliance in the given
+// playoff match.
+func (arena *Arena) getBackupTeams(match *model.Match) map[string]bool {
+	backupTeams := make(map[string]bool)
+	for _, allianceId := range []int{match.PlayoffRedAlliance, match.PlayoffBlueAlliance} {
+		alliance, err := arena.Database.GetAllianceById(allianceId)
+		if err != nil {
+			log.Printf(
+				"Failed to get alliance %d for match %d while finding backup teams: %v", allianceId, match.Id, err,
+			)
+		}
+		if alliance != nil && alliance.BackupTeamId != 0 {
+			backupTeams[strconv.Itoa(alliance.BackupTeamId)] = true
+		}
+	}
+	return backupTeams
+}
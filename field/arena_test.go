@@ -4,6 +4,7 @@
 package field
 
 import (
+	"encoding/json"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/led"
 	"github.com/Team254/cheesy-arena/model"
@@ -15,6 +16,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -451,6 +453,109 @@ func TestLoadNextMatch(t *testing.T) {
 	assert.Equal(t, qualificationMatch2.Id, arena.CurrentMatch.Id)
 }
 
+func TestArenaFieldArenas(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.Nil(t, arena.CreateFieldArenas())
+	assert.Empty(t, arena.FieldArenas())
+
+	arena.EventSettings.NumFields = 3
+	arena.EventSettings.TeamSignRed1Id = 51
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	arena.Database.CreateFieldSettings(&model.FieldSettings{Id: 2, PlcAddress: "10.0.200.40", TeamSignRed1Id: 61})
+	assert.Nil(t, arena.LoadSettings())
+	assert.Nil(t, arena.CreateFieldArenas())
+	if !assert.Equal(t, 2, len(arena.FieldArenas())) {
+		return
+	}
+	fieldArena := arena.FieldArenas()[0]
+	assert.Equal(t, 1, arena.FieldNumber)
+	assert.Equal(t, 2, fieldArena.FieldNumber)
+	assert.Equal(t, 3, arena.FieldArenas()[1].FieldNumber)
+	assert.Same(t, arena.Database, fieldArena.Database)
+
+	// Each field has its own hardware settings.
+	fieldSettings, err := fieldArena.getFieldSettings()
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.200.40", fieldSettings.PlcAddress)
+	fieldSettings, err = arena.FieldArenas()[1].getFieldSettings()
+	assert.Nil(t, err)
+	assert.Equal(t, model.FieldSettings{Id: 3}, *fieldSettings)
+	assert.Equal(t, byte(51), arena.TeamSigns.Red1.address)
+	assert.Equal(t, byte(61), fieldArena.TeamSigns.Red1.address)
+	assert.Equal(t, byte(0), arena.FieldArenas()[1].TeamSigns.Red1.address)
+
+	// The playoff tournament is shared, even when it is recreated from any of the fields.
+	assert.Same(t, arena.PlayoffTournament, fieldArena.PlayoffTournament)
+	assert.Nil(t, fieldArena.CreatePlayoffTournament())
+	assert.Same(t, arena.PlayoffTournament, fieldArena.PlayoffTournament)
+	arena.EventSettings.PlayoffType = model.SingleEliminationPlayoff
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Equal(t, model.SingleEliminationPlayoff, fieldArena.EventSettings.PlayoffType)
+	assert.Same(t, arena.PlayoffTournament, arena.FieldArenas()[1].PlayoffTournament)
+
+	// Each field works through the matches assigned to it, and unassigned matches go to whichever field is free.
+	var matches []model.Match
+	for i := 1; i <= 4; i++ {
+		match := model.Match{Type: model.Qualification, TypeOrder: i, FieldNumber: (i-1)%2 + 1}
+		arena.Database.CreateMatch(&match)
+		matches = append(matches, match)
+	}
+	assert.True(t, arena.IsMatchOnField(&matches[0]))
+	assert.False(t, arena.IsMatchOnField(&matches[1]))
+	assert.True(t, fieldArena.IsMatchOnField(&matches[1]))
+	assert.Nil(t, arena.LoadMatch(&matches[0]))
+	assert.Nil(t, fieldArena.LoadMatch(&matches[1]))
+	nextMatch, err := arena.getNextMatch(true)
+	assert.Nil(t, err)
+	assert.Equal(t, matches[2].Id, nextMatch.Id)
+	nextMatch, err = fieldArena.getNextMatch(true)
+	assert.Nil(t, err)
+	assert.Equal(t, matches[3].Id, nextMatch.Id)
+
+	practiceMatch1 := model.Match{Type: model.Practice, TypeOrder: 1}
+	arena.Database.CreateMatch(&practiceMatch1)
+	practiceMatch2 := model.Match{Type: model.Practice, TypeOrder: 2}
+	arena.Database.CreateMatch(&practiceMatch2)
+	assert.Nil(t, arena.LoadMatch(&practiceMatch1))
+	assert.Nil(t, fieldArena.LoadMatch(&practiceMatch2))
+	nextMatch, err = fieldArena.getNextMatch(false)
+	assert.Nil(t, err)
+	assert.Equal(t, practiceMatch2.Id, nextMatch.Id)
+	nextMatch, err = arena.getNextMatch(false)
+	assert.Nil(t, err)
+	assert.Equal(t, practiceMatch1.Id, nextMatch.Id)
+
+	// Check that a match can't be loaded by hand on a second field, though test matches can be loaded on all of them.
+	err = arena.LoadMatch(&practiceMatch2)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "already loaded on another field")
+	}
+	assert.Equal(t, practiceMatch1.Id, arena.CurrentMatch.Id)
+	assert.Nil(t, arena.LoadTestMatch())
+	assert.Nil(t, fieldArena.LoadTestMatch())
+	assert.Nil(t, arena.LoadMatch(&practiceMatch1))
+	assert.Nil(t, fieldArena.LoadMatch(&practiceMatch2))
+
+	// Check that the fields can update the shared state concurrently.
+	tournament.CreateTestAlliances(arena.Database, 8)
+	assert.Nil(t, arena.CreatePlayoffMatches(time.Unix(0, 0)))
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(2)
+	go func() {
+		defer waitGroup.Done()
+		assert.Nil(t, arena.UpdatePlayoffTournament())
+		assert.Nil(t, arena.LoadMatch(&practiceMatch1))
+	}()
+	go func() {
+		defer waitGroup.Done()
+		assert.Nil(t, fieldArena.UpdatePlayoffTournament())
+		_, err := fieldArena.getNextMatch(false)
+		assert.Nil(t, err)
+	}()
+	waitGroup.Wait()
+}
+
 func TestSubstituteTeam(t *testing.T) {
 	arena := setupTestArena(t)
 	tournament.CreateTestAlliances(arena.Database, 2)
@@ -507,6 +612,57 @@ func TestSubstituteTeam(t *testing.T) {
 	}
 }
 
+func TestArenaCallUpBackup(t *testing.T) {
+	arena := setupTestArena(t)
+	tournament.CreateTestAlliances(arena.Database, 8)
+	for _, teamId := range []int{101, 102, 103, 104, 801, 802, 803, 804, 901} {
+		arena.Database.CreateTeam(&model.Team{Id: teamId})
+	}
+	arena.Database.CreateTeam(&model.Team{Id: 903, Withdrawn: true})
+	assert.Nil(t, arena.CreatePlayoffTournament())
+	assert.Nil(t, arena.CreatePlayoffMatches(time.Unix(0, 0)))
+	assert.Nil(t, arena.UpdatePlayoffTournament())
+	matches, _ := arena.Database.GetMatchesByType(model.Playoff, false)
+	match := matches[0]
+	assert.Nil(t, arena.LoadMatch(&match))
+	assert.Equal(t, 1, arena.CurrentMatch.PlayoffRedAlliance)
+	assert.Equal(t, 102, arena.CurrentMatch.Red1)
+
+	err := arena.CallUpBackup(9, 901, 102)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Alliance 9 does not exist.", err.Error())
+	}
+	err = arena.CallUpBackup(1, 902, 102)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Team 902 is not present at the event.", err.Error())
+	}
+	err = arena.CallUpBackup(1, 903, 102)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Team 903 has withdrawn from the event.", err.Error())
+	}
+	err = arena.CallUpBackup(1, 901, 802)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Team 802 is not a member of alliance 1.", err.Error())
+	}
+
+	// The backup is swapped into the loaded match as well as the alliance's lineup.
+	assert.Nil(t, arena.CallUpBackup(1, 901, 102))
+	alliance, _ := arena.Database.GetAllianceById(1)
+	assert.Equal(t, 901, alliance.BackupTeamId)
+	assert.Equal(t, 102, alliance.ReplacedTeamId)
+	assert.Equal(t, [3]int{901, 101, 103}, alliance.Lineup)
+	assert.Equal(t, 901, arena.CurrentMatch.Red1)
+	assert.Equal(t, 901, arena.AllianceStations["R1"].Team.Id)
+	match2, _ := arena.Database.GetMatchById(match.Id)
+	assert.Equal(t, 901, match2.Red1)
+	assert.Equal(t, map[string]bool{"901": true}, arena.getBackupTeams(arena.CurrentMatch))
+
+	err = arena.CallUpBackup(1, 804, 101)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Alliance 1 has already called up backup team 901.", err.Error())
+	}
+}
+
 func TestLoadTeamsFromNexus(t *testing.T) {
 	arena := setupTestArena(t)
 
@@ -662,6 +818,74 @@ func TestArenaTimeout(t *testing.T) {
 	assert.Equal(t, match, *arena.CurrentMatch)
 }
 
+func TestArenaWebhooks(t *testing.T) {
+	arena := setupTestArena(t)
+
+	type webhookRequest struct {
+		Event string
+		Data  json.RawMessage
+	}
+	requests := make(chan webhookRequest, 10)
+	webhookServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				var request webhookRequest
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+				requests <- request
+			},
+		),
+	)
+	defer webhookServer.Close()
+	assert.Nil(t, arena.Database.CreateWebhook(&model.Webhook{Url: webhookServer.URL, Enabled: true}))
+	receiveRequest := func() webhookRequest {
+		select {
+		case request := <-requests:
+			return request
+		case <-time.After(time.Second):
+			assert.Fail(t, "Timed out waiting for webhook request")
+			return webhookRequest{}
+		}
+	}
+
+	// Check that loading a test match doesn't send an event, so that the first one received is for the real match.
+	assert.Nil(t, arena.LoadTestMatch())
+	match := model.Match{Id: 12, Type: model.Qualification, ShortName: "Q12", Red1: 254}
+	assert.Nil(t, arena.LoadMatch(&match))
+	request := receiveRequest()
+	assert.Equal(t, "match_loaded", request.Event)
+	var webhookMatch partner.WebhookMatch
+	assert.Nil(t, json.Unmarshal(request.Data, &webhookMatch))
+	assert.Equal(t, "Q12", webhookMatch.ShortName)
+
+	arena.AllianceStations["R1"].Bypass = true
+	arena.AllianceStations["R2"].Bypass = true
+	arena.AllianceStations["R3"].Bypass = true
+	arena.AllianceStations["B1"].Bypass = true
+	arena.AllianceStations["B2"].Bypass = true
+	arena.AllianceStations["B3"].Bypass = true
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	assert.Equal(t, "match_started", receiveRequest().Event)
+
+	assert.Nil(t, arena.AbortMatch())
+	request = receiveRequest()
+	assert.Equal(t, "match_ended", request.Event)
+	var matchEnd partner.WebhookMatchEnd
+	assert.Nil(t, json.Unmarshal(request.Data, &matchEnd))
+	assert.True(t, matchEnd.Aborted)
+	assert.Equal(t, 12, matchEnd.Match.Id)
+
+	// Check that a standby server doesn't send any events.
+	assert.Nil(t, arena.ResetMatch())
+	arena.passive.Store(true)
+	arena.SendWebhookEvent(partner.WebhookMatchLoaded, partner.NewWebhookMatch(arena.CurrentMatch))
+	select {
+	case request = <-requests:
+		assert.Fail(t, "Unexpected webhook request", request.Event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
 func TestSaveTeamHasConnected(t *testing.T) {
 	arena := setupTestArena(t)
 
@@ -1225,6 +1449,43 @@ func TestPlcMatchCycleGameSpecific(t *testing.T) {
 	assertHubLights(false, false)
 }
 
+func TestArenaSwitchPlc(t *testing.T) {
+	arena := setupTestArena(t)
+	var hardwarePlc FakePlc
+	arena.hardwarePlc = &hardwarePlc
+	arena.Plc = &hardwarePlc
+	go arena.runPlc()
+	isStopped := func(stop chan struct{}) bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// Check that switching to the simulated PLC stops the hardware PLC's loop.
+	hardwareStop := arena.plcStop
+	arena.EventSettings.PlcSimulated = true
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Equal(t, arena.SimulatedPlc, arena.Plc)
+	assert.True(t, isStopped(hardwareStop))
+	simulatedStop := arena.plcStop
+	assert.False(t, isStopped(simulatedStop))
+
+	// Check that reloading unchanged settings leaves the running loop alone.
+	assert.Nil(t, arena.LoadSettings())
+	assert.False(t, isStopped(simulatedStop))
+
+	arena.EventSettings.PlcSimulated = false
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Equal(t, &hardwarePlc, arena.Plc)
+	assert.True(t, isStopped(simulatedStop))
+	assert.False(t, isStopped(arena.plcStop))
+}
+
 func TestSignalVolunteers(t *testing.T) {
 	arena := setupTestArena(t)
 	assertHubLedModes := func(red, blue led.Mode) {
@@ -1310,3 +1571,63 @@ func TestSignalReset(t *testing.T) {
 	assert.Equal(t, "fieldReset", arena.AllianceStationDisplayMode)
 	assertHubLedModes(led.GreenMode, led.GreenMode)
 }
+
+func TestArenaMqtt(t *testing.T) {
+	arena := setupTestArena(t)
+	var plc FakePlc
+	plc.isEnabled = true
+	arena.Plc = &plc
+
+	arena.MatchState = PostMatch
+	arena.RedRealtimeScore.CurrentScore.AutoTowerStatuses[0] = game.TowerLevel1
+	arena.setStackLights(false, false, true, false)
+	arena.Leds.SetMode(led.RedMode, led.OffMode)
+	plc.fieldEStop = true
+	arena.AllianceStations["R2"].EStop = true
+	arena.AllianceStations["B1"].AStop = true
+	state := arena.getMqttFieldState(42.9)
+	assert.Equal(t, "post_match", state.MatchState)
+	assert.Equal(t, 42, state.MatchTimeSec)
+	assert.NotZero(t, state.RedScore)
+	assert.Equal(t, arena.RedScoreSummary().Score, state.RedScore)
+	assert.Equal(t, 0, state.BlueScore)
+	assert.Equal(t, partner.MqttStackLights{Orange: true}, state.StackLights)
+	assert.Equal(t, [4]bool{false, false, true, false}, plc.stackLights)
+	assert.Equal(t, led.ModeNames[led.RedMode], state.RedLedMode)
+	assert.Equal(t, led.ModeNames[led.OffMode], state.BlueLedMode)
+	assert.True(t, state.FieldEStop)
+	assert.Equal(t, 6, len(state.TeamEStops))
+	assert.True(t, state.TeamEStops["R2"])
+	assert.False(t, state.TeamEStops["B1"])
+	assert.True(t, state.TeamAStops["B1"])
+	assert.False(t, state.TeamAStops["R2"])
+
+	arena.handleMqttCommand(partner.MqttCommandSignalVolunteers)
+	assert.True(t, arena.FieldVolunteers)
+	arena.handleMqttCommand(partner.MqttCommandSignalReset)
+	assert.False(t, arena.FieldVolunteers)
+	assert.True(t, arena.FieldReset)
+
+	// Check that commands are ignored during a match, the same as from the web interface.
+	arena.FieldReset = false
+	arena.MatchState = TeleopPeriod
+	arena.handleMqttCommand(partner.MqttCommandSignalReset)
+	assert.False(t, arena.FieldReset)
+}
+
+func TestPurgeExpiredUserSessions(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.SessionLifetimeHours = 12
+
+	arena.Database.CreateUserSession(
+		&model.UserSession{Token: "token1", Username: "admin", CreatedAt: time.Now().Add(-13 * time.Hour)},
+	)
+	arena.Database.CreateUserSession(
+		&model.UserSession{Token: "token2", Username: "admin", CreatedAt: time.Now().Add(-11 * time.Hour)},
+	)
+	arena.purgeExpiredUserSessions()
+	sessions, _ := arena.Database.GetAllUserSessions()
+	if assert.Equal(t, 1, len(sessions)) {
+		assert.Equal(t, "token2", sessions[0].Token)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for running a finals between the winners of divisions played on the additional fields.
+
+package field
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"slices"
+)
+
+// Returns the winning alliance of each division, in field order, as the alliances for the finals hosted on this arena's
+// field. The winning teams' records are copied into the event database so that they can be shown in the finals.
+func (arena *Arena) ImportDivisionWinners() ([]model.Alliance, error) {
+	if !arena.HasDivisions() {
+		return nil, fmt.Errorf("the additional fields aren't playing separate divisions")
+	}
+
+	var alliances []model.Alliance
+	for i, divisionArena := range arena.FieldArenas() {
+		if !divisionArena.PlayoffTournament.IsComplete() {
+			return nil, fmt.Errorf(
+				"the playoffs for the division on field %d haven't finished yet", divisionArena.FieldNumber,
+			)
+		}
+		winningAlliance, err := divisionArena.Database.GetAllianceById(
+			divisionArena.PlayoffTournament.WinningAllianceId(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if winningAlliance == nil {
+			return nil, fmt.Errorf(
+				"the winning alliance for the division on field %d doesn't exist", divisionArena.FieldNumber,
+			)
+		}
+
+		for _, teamId := range winningAlliance.TeamIds {
+			if teamId == 0 {
+				continue
+			}
+			team, err := arena.Database.GetTeamById(teamId)
+			if err != nil {
+				return nil, err
+			}
+			if team != nil {
+				continue
+			}
+			if team, err = divisionArena.Database.GetTeamById(teamId); err != nil {
+				return nil, err
+			}
+			if team == nil {
+				team = &model.Team{Id: teamId}
+			}
+			if err = arena.Database.CreateTeam(team); err != nil {
+				return nil, err
+			}
+		}
+		alliances = append(alliances, model.Alliance{Id: i + 1, TeamIds: slices.Clone(winningAlliance.TeamIds)})
+	}
+	return alliances, nil
+}
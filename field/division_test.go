@@ -0,0 +1,95 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestArenaDivisions(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.EventSettings.NumFields = 3
+	arena.EventSettings.DivisionsEnabled = true
+	arena.EventSettings.PlayoffType = model.SingleEliminationPlayoff
+	arena.EventSettings.NumPlayoffAlliances = 2
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Nil(t, arena.CreateFieldArenas())
+	assert.True(t, arena.HasDivisions())
+	assert.False(t, arena.IsDivision())
+	division2 := arena.GetFieldArena(2)
+	division3 := arena.GetFieldArena(3)
+	if !assert.NotNil(t, division2) || !assert.NotNil(t, division3) {
+		return
+	}
+	assert.True(t, division2.IsDivision())
+	assert.False(t, division2.HasDivisions())
+	assert.Equal(t, 3, division3.Database.Division)
+
+	// Each division has its own playoff tournament, and the first field hosts the finals between the two winners.
+	assert.NotSame(t, arena.PlayoffTournament, division2.PlayoffTournament)
+	assert.NotSame(t, division2.PlayoffTournament, division3.PlayoffTournament)
+	assert.Contains(t, arena.PlayoffTournament.MatchGroups(), "F")
+	assert.Equal(t, 1, len(arena.PlayoffTournament.MatchGroups()))
+
+	// Each division plays all of its own matches, even where their IDs coincide with another division's.
+	for _, divisionArena := range []*Arena{division2, division3} {
+		match := model.Match{Type: model.Qualification, TypeOrder: 1, FieldNumber: 1}
+		assert.Nil(t, divisionArena.Database.CreateMatch(&match))
+		assert.Equal(t, 1, match.Id)
+		assert.True(t, divisionArena.IsMatchOnField(&match))
+		assert.Nil(t, divisionArena.LoadMatch(&match))
+	}
+	assert.Nil(t, division2.LoadTestMatch())
+	assert.Nil(t, division3.LoadTestMatch())
+
+	// Check that the finals can't start until every division has a winner.
+	_, err := arena.ImportDivisionWinners()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "division on field 2 haven't finished")
+	}
+	_, err = division2.ImportDivisionWinners()
+	assert.NotNil(t, err)
+
+	for i, divisionArena := range []*Arena{division2, division3} {
+		tournament.CreateTestAlliances(divisionArena.Database, 2)
+		assert.Nil(t, divisionArena.Database.CreateTeam(&model.Team{Id: 101 + 100*i, Nickname: "Captain"}))
+		assert.Nil(t, divisionArena.CreatePlayoffMatches(time.Unix(0, 0)))
+		matches, err := divisionArena.Database.GetMatchesByType(model.Playoff, false)
+		assert.Nil(t, err)
+		for _, match := range matches[:2] {
+			match.Status = game.RedWonMatch
+			if i == 1 {
+				match.Status = game.BlueWonMatch
+			}
+			assert.Nil(t, divisionArena.Database.UpdateMatch(&match))
+		}
+		assert.Nil(t, divisionArena.UpdatePlayoffTournament())
+	}
+	assert.Equal(t, 1, division2.PlayoffTournament.WinningAllianceId())
+	assert.Equal(t, 2, division3.PlayoffTournament.WinningAllianceId())
+	assert.False(t, arena.PlayoffTournament.IsComplete())
+
+	alliances, err := arena.ImportDivisionWinners()
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]model.Alliance{{Id: 1, TeamIds: []int{101, 102, 103, 104}}, {Id: 2, TeamIds: []int{201, 202, 203, 204}}},
+		alliances,
+	)
+	team, _ := arena.Database.GetTeamById(101)
+	if assert.NotNil(t, team) {
+		assert.Equal(t, "Captain", team.Nickname)
+	}
+	team, _ = arena.Database.GetTeamById(204)
+	assert.NotNil(t, team)
+	alliances, err = arena.ImportDivisionWinners()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(alliances))
+}
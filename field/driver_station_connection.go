@@ -117,7 +117,7 @@ func newDriverStationConnection(
 }
 
 func (arena *Arena) initializeUdpListener() {
-	bindAddress := listenAddress(driverStationUdpReceivePort)
+	bindAddress := arena.driverStationListenAddress(driverStationUdpReceivePort)
 	udpAddress, err := net.ResolveUDPAddr("udp4", bindAddress)
 	if err != nil {
 		log.Fatalf(
@@ -157,6 +157,7 @@ func (arena *Arena) listenForDsUdpPackets() {
 
 		teamId := int(data[4])<<8 + int(data[5])
 
+		arena.driverStationMutex.Lock()
 		var dsConn *DriverStationConnection
 		for _, allianceStation := range arena.AllianceStations {
 			if allianceStation.Team != nil && allianceStation.Team.Id == teamId {
@@ -203,6 +204,7 @@ func (arena *Arena) listenForDsUdpPackets() {
 		} else {
 			log.Printf("Failed to find DS for UDP packet with teamid %d", teamId)
 		}
+		arena.driverStationMutex.Unlock()
 	}
 }
 
@@ -360,9 +362,18 @@ func listenAddress(port int) string {
 	return fmt.Sprintf("%s:%d", network.ServerIpAddress, port)
 }
 
+// Returns the local address on which to listen for driver stations on this arena's field. Additional fields each need
+// their own configured address since the first field's listeners already occupy the standard one.
+func (arena *Arena) driverStationListenAddress(port int) string {
+	if arena.primaryArena != nil {
+		return fmt.Sprintf("%s:%d", arena.dsListenAddress, port)
+	}
+	return listenAddress(port)
+}
+
 // Listens for TCP connection requests to Cheesy Arena from driver stations.
 func (arena *Arena) listenForDriverStations() {
-	bindAddress := listenAddress(driverStationTcpListenPort)
+	bindAddress := arena.driverStationListenAddress(driverStationTcpListenPort)
 	l, err := net.Listen("tcp", bindAddress)
 	if err != nil {
 		log.Fatalf(
@@ -402,166 +413,188 @@ func (arena *Arena) serveDriverStations(listener net.Listener) {
 			tcpConn.Close()
 			continue
 		}
-		packet := fullPacket[:count]
+		arena.registerDriverStation(tcpConn, fullPacket[:count])
+	}
+}
+
+// Checks the initial packet received from a newly connected driver station and, if it belongs to a team in the current
+// match, assigns it to the team's alliance station and starts handling further communication with it. The arena state
+// is only accessed under the driver station mutex, which is released while writing to the driver station so that a
+// slow connection can't hold up the arena loop.
+func (arena *Arena) registerDriverStation(tcpConn net.Conn, packet []byte) {
+	if len(packet) < 5 {
+		log.Println("Invalid initial packet received: ", packet)
+		tcpConn.Close()
+		return
+	}
+
+	arena.driverStationMutex.Lock()
+	eventSettings := arena.EventSettings
+	arena.driverStationMutex.Unlock()
+
+	udpSendPort := driverStationRoboRioUdpPort
+	if eventSettings.UseLiteUdpPort {
+		udpSendPort = driverStationRoboRioUdpPortLite
+	}
+
+	isNewDs := false
+
+	teamId := 0
+	var err error
 
-		if len(packet) < 5 {
-			log.Println("Invalid initial packet received: ", packet)
+	if packet[0] == 0 && packet[1] == 3 && packet[2] == 24 {
+		log.Printf("Received NI DS Connection")
+		teamId = int(packet[3])<<8 + int(packet[4])
+	} else if packet[0] == 0 && packet[1] >= 5 && packet[2] == 30 {
+		if len(packet) < 7 {
+			log.Printf("Invalid initial packet of length %d received: %v", len(packet), packet)
 			tcpConn.Close()
-			continue
+			return
 		}
-
-		udpSendPort := driverStationRoboRioUdpPort
-		if arena.EventSettings.UseLiteUdpPort {
-			udpSendPort = driverStationRoboRioUdpPortLite
+		log.Printf("Received New DS Connection")
+		isNewDs = true
+		packenLen := int(packet[0])<<8 + int(packet[1])
+		udpSendPort = int(packet[3])<<8 + int(packet[4])
+		// Skip 5, its flags currently
+		// Try to parse the team number in ASCII
+		teamNumberLen := int(packet[6])
+		if packenLen < 5+teamNumberLen || len(packet) < 7+teamNumberLen {
+			log.Printf("Invalid initial packet of length %d received with team number length %d: %v", packenLen, teamNumberLen, packet)
+			tcpConn.Close()
+			return
 		}
+		teamIdStr := string(packet[7 : 7+teamNumberLen])
+		teamId, err = strconv.Atoi(teamIdStr)
+		if err != nil {
+			log.Printf("Error parsing team number from new DS connection: %v", err)
+			go handleInvalidTcpConnection(tcpConn, 3, 0, isNewDs)
+			return
+		} else if teamId < 0 || teamId > 65535 {
+			log.Printf("Team number from new DS connection out of range: %d", teamId)
+			go handleInvalidTcpConnection(tcpConn, 3, 0, isNewDs)
+			return
+		}
+	} else {
+		log.Printf("Invalid initial packet received: %v", packet)
+		closeTcpConn(tcpConn, "invalid initial packet")
+		return
+	}
 
-		isNewDs := false
-
-		teamId := 0
+	// Check to see if the team is supposed to be on the field, and notify the DS accordingly.
+	stationTeamId, ipAddress, hasStationTeamId := driverStationTeamIdFromRemoteAddr(tcpConn.RemoteAddr())
+	isWrongStation := eventSettings.NetworkSecurityEnabled && hasStationTeamId && stationTeamId != teamId
+	arena.driverStationMutex.Lock()
+	assignedStation := arena.getAssignedAllianceStation(teamId)
+	wrongAssignedStation := ""
+	if isWrongStation {
+		wrongAssignedStation = arena.getAssignedAllianceStation(stationTeamId)
+	}
+	arena.driverStationMutex.Unlock()
+	if assignedStation == "" {
+		log.Printf("Rejecting connection from Team %d, who is not in the current match, soon.", teamId)
+		go handleInvalidTcpConnection(tcpConn, 2, 0, isNewDs)
+		return
+	}
 
-		if packet[0] == 0 && packet[1] == 3 && packet[2] == 24 {
-			log.Printf("Received NI DS Connection")
-			teamId = int(packet[3])<<8 + int(packet[4])
-		} else if packet[0] == 0 && packet[1] >= 5 && packet[2] == 30 {
-			if len(packet) < 7 {
-				log.Printf("Invalid initial packet of length %d received: %v", len(packet), packet)
-				tcpConn.Close()
-				continue
-			}
-			log.Printf("Received New DS Connection")
-			isNewDs = true
-			packenLen := int(packet[0])<<8 + int(packet[1])
-			udpSendPort = int(packet[3])<<8 + int(packet[4])
-			// Skip 5, its flags currently
-			// Try to parse the team number in ASCII
-			teamNumberLen := int(packet[6])
-			if packenLen < 5+teamNumberLen || len(packet) < 7+teamNumberLen {
-				log.Printf("Invalid initial packet of length %d received with team number length %d: %v", packenLen, teamNumberLen, packet)
-				tcpConn.Close()
-				continue
-			}
-			teamIdStr := string(packet[7 : 7+teamNumberLen])
-			teamId, err = strconv.Atoi(teamIdStr)
-			if err != nil {
-				log.Printf("Error parsing team number from new DS connection: %v", err)
-				go handleInvalidTcpConnection(tcpConn, 3, 0, isNewDs)
-				continue
-			} else if teamId < 0 || teamId > 65535 {
-				log.Printf("Team number from new DS connection out of range: %d", teamId)
-				go handleInvalidTcpConnection(tcpConn, 3, 0, isNewDs)
-				continue
-			}
+	// Use the team number from the IP address to check for a station mismatch.
+	stationStatus := byte(0)
+	if isWrongStation {
+		// The team is supposed to be in this match, but is plugged into the wrong station.
+		if wrongAssignedStation != "" {
+			log.Printf("Team %d is in incorrect station %s.", teamId, wrongAssignedStation)
 		} else {
-			log.Printf("Invalid initial packet received: %v", packet)
-			closeTcpConn(tcpConn, "invalid initial packet")
-			continue
+			log.Printf("Team %d is in unknown station with IP address %s.", teamId, ipAddress)
 		}
+		stationStatus = 1
+	}
 
-		// Check to see if the team is supposed to be on the field, and notify the DS accordingly.
-		assignedStation := arena.getAssignedAllianceStation(teamId)
-		if assignedStation == "" {
-			log.Printf("Rejecting connection from Team %d, who is not in the current match, soon.", teamId)
-			go handleInvalidTcpConnection(tcpConn, 2, 0, isNewDs)
-			continue
-		}
+	flags := 0
+	if eventSettings.UseLiteUdpPort {
+		flags |= 0x01
+	}
 
-		// Read the team number from the IP address to check for a station mismatch.
-		stationStatus := byte(0)
-		wrongAssignedStation := ""
-		if arena.EventSettings.NetworkSecurityEnabled {
-			stationTeamId, ipAddress, ok := driverStationTeamIdFromRemoteAddr(tcpConn.RemoteAddr())
-			if ok && stationTeamId != teamId {
-				wrongAssignedStation = arena.getAssignedAllianceStation(stationTeamId)
-				// The team is supposed to be in this match, but is plugged into the wrong station.
-				if wrongAssignedStation != "" {
-					log.Printf("Team %d is in incorrect station %s.", teamId, wrongAssignedStation)
-					stationStatus = 1
-				} else {
-					log.Printf("Team %d is in unknown station with IP address %s.", teamId, ipAddress)
-					stationStatus = 1
-				}
-			}
-		}
+	sendLength := 8
 
-		flags := 0
-		if arena.EventSettings.UseLiteUdpPort {
-			flags |= 0x01
-		}
+	var assignmentPacket [8]byte
+	assignmentPacket[0] = 0  // Packet size
+	assignmentPacket[1] = 6  // Packet size
+	assignmentPacket[2] = 31 // Packet type
+	log.Printf("Accepting connection from Team %d in station %s with port %d", teamId, assignedStation, udpSendPort)
+	assignmentPacket[3] = allianceStationPositionMap[assignedStation]
+	assignmentPacket[4] = stationStatus
+	assignmentPacket[5] = byte(flags)
+	assignmentPacket[6] = byte(teamId >> 8)
+	assignmentPacket[7] = byte(teamId & 0xFF)
 
-		sendLength := 8
-
-		var assignmentPacket [8]byte
-		assignmentPacket[0] = 0  // Packet size
-		assignmentPacket[1] = 6  // Packet size
-		assignmentPacket[2] = 31 // Packet type
-		log.Printf("Accepting connection from Team %d in station %s with port %d", teamId, assignedStation, udpSendPort)
-		assignmentPacket[3] = allianceStationPositionMap[assignedStation]
-		assignmentPacket[4] = stationStatus
-		assignmentPacket[5] = byte(flags)
-		assignmentPacket[6] = byte(teamId >> 8)
-		assignmentPacket[7] = byte(teamId & 0xFF)
-
-		if !isNewDs {
-			assignmentPacket[2] = 25 // Packet type
-			assignmentPacket[1] = 3  // Packet size
-			sendLength = 5
-		}
+	if !isNewDs {
+		assignmentPacket[2] = 25 // Packet type
+		assignmentPacket[1] = 3  // Packet size
+		sendLength = 5
+	}
 
-		_, err = tcpConn.Write(assignmentPacket[:sendLength])
-		if err != nil {
-			log.Printf("Error sending driver station assignment packet: %v", err)
-			closeTcpConn(tcpConn, "driver station assignment packet error")
-			continue
-		}
+	_, err = tcpConn.Write(assignmentPacket[:sendLength])
+	if err != nil {
+		log.Printf("Error sending driver station assignment packet: %v", err)
+		closeTcpConn(tcpConn, "driver station assignment packet error")
+		return
+	}
 
-		// Write event code here. We need to strip any numbers off the front if it has it.
-		// We also need to limit to 62 characters.
-		eventName := arena.EventSettings.TbaEventCode
+	// Write event code here. We need to strip any numbers off the front if it has it.
+	// We also need to limit to 62 characters.
+	eventName := eventSettings.TbaEventCode
+	if len(eventName) > 0 {
+		trimIndex := 0
+		for trimIndex < len(eventName) && eventName[trimIndex] >= '0' && eventName[trimIndex] <= '9' {
+			trimIndex++
+		}
+		eventName = eventName[trimIndex:]
+		if len(eventName) > 62 {
+			eventName = eventName[:62]
+		}
 		if len(eventName) > 0 {
-			trimIndex := 0
-			for trimIndex < len(eventName) && eventName[trimIndex] >= '0' && eventName[trimIndex] <= '9' {
-				trimIndex++
-			}
-			eventName = eventName[trimIndex:]
-			if len(eventName) > 62 {
-				eventName = eventName[:62]
-			}
-			if len(eventName) > 0 {
-				eventNamePacket := make([]byte, 4+len(eventName))
-				eventNamePacket[0] = 0
-				eventNamePacket[1] = byte(len(eventName) + 2)
-				eventNamePacket[2] = 20 // Packet type for event name
-				eventNamePacket[3] = byte(len(eventName))
-				copy(eventNamePacket[4:], []byte(eventName))
-				_, err = tcpConn.Write(eventNamePacket)
-				if err != nil {
-					log.Printf("Error sending event name packet: %v", err)
-					closeTcpConn(tcpConn, "event name packet error")
-					continue
-				}
+			eventNamePacket := make([]byte, 4+len(eventName))
+			eventNamePacket[0] = 0
+			eventNamePacket[1] = byte(len(eventName) + 2)
+			eventNamePacket[2] = 20 // Packet type for event name
+			eventNamePacket[3] = byte(len(eventName))
+			copy(eventNamePacket[4:], []byte(eventName))
+			_, err = tcpConn.Write(eventNamePacket)
+			if err != nil {
+				log.Printf("Error sending event name packet: %v", err)
+				closeTcpConn(tcpConn, "event name packet error")
+				return
 			}
 		}
+	}
 
-		dsConn, err := newDriverStationConnection(teamId, assignedStation, tcpConn, udpSendPort, isNewDs)
-		if err != nil {
-			log.Printf("Error registering driver station connection: %v", err)
-			closeTcpConn(tcpConn, "driver station registration error")
-			continue
-		}
-		allianceStation := arena.AllianceStations[assignedStation]
-		if previousDsConn := allianceStation.DsConn; previousDsConn != nil {
-			dsConn.copyDsReportedStatus(previousDsConn)
-			previousDsConn.close()
-		}
-		allianceStation.DsConn = dsConn
+	dsConn, err := newDriverStationConnection(teamId, assignedStation, tcpConn, udpSendPort, isNewDs)
+	if err != nil {
+		log.Printf("Error registering driver station connection: %v", err)
+		closeTcpConn(tcpConn, "driver station registration error")
+		return
+	}
 
-		if wrongAssignedStation != "" {
-			dsConn.WrongStation = wrongAssignedStation
-		}
+	arena.driverStationMutex.Lock()
+	defer arena.driverStationMutex.Unlock()
+	if arena.getAssignedAllianceStation(teamId) != assignedStation {
+		// A different match was loaded while the assignment was being sent; the DS will reconnect and be reassigned.
+		log.Printf("Dropping connection from Team %d, whose station changed during registration.", teamId)
+		dsConn.close()
+		return
+	}
+	allianceStation := arena.AllianceStations[assignedStation]
+	if previousDsConn := allianceStation.DsConn; previousDsConn != nil {
+		dsConn.copyDsReportedStatus(previousDsConn)
+		previousDsConn.close()
+	}
+	allianceStation.DsConn = dsConn
 
-		// Spin up a goroutine to handle further TCP communication with this driver station.
-		go dsConn.handleTcpConnection(arena)
+	if wrongAssignedStation != "" {
+		dsConn.WrongStation = wrongAssignedStation
 	}
+
+	// Spin up a goroutine to handle further TCP communication with this driver station.
+	go dsConn.handleTcpConnection(arena)
 }
 
 func readTaggedTcpPacket(tcpConn net.Conn, buffer []byte) (int, error) {
@@ -597,10 +630,12 @@ func (dsConn *DriverStationConnection) handleTcpConnection(arena *Arena) {
 		count, err := readTaggedTcpPacket(dsConn.tcpConn, buffer)
 		if err != nil {
 			log.Printf("Error reading from connection for Team %d: %v", dsConn.TeamId, err)
+			arena.driverStationMutex.Lock()
 			dsConn.close()
 			if arena.AllianceStations[dsConn.AllianceStation].DsConn == dsConn {
 				arena.AllianceStations[dsConn.AllianceStation].DsConn = nil
 			}
+			arena.driverStationMutex.Unlock()
 			break
 		}
 
@@ -610,7 +645,9 @@ func (dsConn *DriverStationConnection) handleTcpConnection(arena *Arena) {
 			// DS keepalive packet; do nothing.
 			continue
 		case 22:
+			arena.driverStationMutex.Lock()
 			dsConn.parseDsLogPacket(buffer[:count])
+			arena.driverStationMutex.Unlock()
 		default:
 			log.Printf("Received unknown packet type %d from Team %d", packetType, dsConn.TeamId)
 		}
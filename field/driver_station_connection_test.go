@@ -5,10 +5,12 @@ package field
 
 import (
 	"fmt"
+	"github.com/Team254/cheesy-arena/dssim"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/network"
 	"github.com/stretchr/testify/assert"
 	"net"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -26,6 +28,15 @@ func TestDriverStationListenAddress(t *testing.T) {
 
 	network.DevMode = true
 	assert.Equal(t, ":1750", listenAddress(1750))
+
+	// Additional fields listen on their own configured address regardless of the mode.
+	arena := setupTestArena(t)
+	assert.Equal(t, ":1750", arena.driverStationListenAddress(1750))
+	arena.EventSettings.NumFields = 2
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.Database.CreateFieldSettings(&model.FieldSettings{Id: 2, DriverStationAddress: "10.0.200.5"}))
+	assert.Nil(t, arena.CreateFieldArenas())
+	assert.Equal(t, "10.0.200.5:1750", arena.FieldArenas()[0].driverStationListenAddress(1750))
 }
 
 func TestEncodeControlPacket(t *testing.T) {
@@ -290,6 +301,123 @@ func TestListenForDriverStations_NetworkSecurityIgnoresNonFieldIp(t *testing.T)
 	}
 }
 
+func TestRegisterDriverStationReleasesLockWhileWriting(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.assignTeam(254, "R1")
+
+	// Writes to a pipe block until the other end reads them, like those to a driver station that has stalled.
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+	registered := make(chan struct{})
+	go func() {
+		arena.registerDriverStation(serverConn, []byte{0, 3, 24, 0, 254})
+		close(registered)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if assert.True(t, arena.driverStationMutex.TryLock(), "arena was blocked by a stalled driver station") {
+		arena.driverStationMutex.Unlock()
+	}
+
+	var dataReceived [5]byte
+	_, err := readTaggedTcpPacket(clientConn, dataReceived[:])
+	assert.Nil(t, err)
+	assert.Equal(t, [5]byte{0, 3, 25, 0, 0}, dataReceived)
+	<-registered
+}
+
+func TestMatchWithSimulatedDriverStations(t *testing.T) {
+	arena := setupTestArena(t)
+	serverAddress := startTestDriverStationServer(t, arena)
+	udpSocket, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	arena.DriverStationUdpSocket = udpSocket
+	go arena.listenForDsUdpPackets()
+
+	// Anything touching the driver station state outside of arena.Update() holds the same lock as the listeners do.
+	withDriverStationLock := func(check func() bool) bool {
+		arena.driverStationMutex.Lock()
+		defer arena.driverStationMutex.Unlock()
+		return check()
+	}
+
+	stations := []string{"R1", "R2", "R3", "B1", "B2", "B3"}
+	simulatedDriverStations := make(map[string]*dssim.DriverStation)
+	for i, station := range stations {
+		teamId := 100 + i
+		arena.Database.CreateTeam(&model.Team{Id: teamId})
+		withDriverStationLock(func() bool { return assert.Nil(t, arena.assignTeam(teamId, station)) })
+		ds := dssim.NewDriverStation(teamId, "127.0.0.1")
+		ds.ArenaTcpPort = listenerPort(serverAddress)
+		ds.ArenaUdpPort = udpSocket.LocalAddr().(*net.UDPAddr).Port
+		ds.BatteryVoltage = 12 + float64(i)/4
+		go ds.Run()
+		t.Cleanup(ds.Close)
+		simulatedDriverStations[station] = ds
+	}
+
+	// Check that the arena sees all the robots as linked and reporting status.
+	assert.Eventually(
+		t,
+		func() bool {
+			arena.Update()
+			return withDriverStationLock(
+				func() bool {
+					for _, station := range stations {
+						dsConn := arena.AllianceStations[station].DsConn
+						if dsConn == nil || !dsConn.RobotLinked {
+							return false
+						}
+					}
+					return true
+				},
+			)
+		},
+		3*time.Second,
+		10*time.Millisecond,
+	)
+	withDriverStationLock(
+		func() bool {
+			assert.Equal(t, 12.25, arena.AllianceStations["R2"].DsConn.BatteryVoltage)
+			return assert.Equal(t, 5, arena.AllianceStations["B3"].DsConn.DsRobotTripTimeMs)
+		},
+	)
+	assert.Equal(t, "B1", simulatedDriverStations["B1"].Status().AllianceStation)
+
+	// Start the match and check that the driver stations get enabled and report back that they are.
+	withDriverStationLock(func() bool { return assert.Nil(t, arena.StartMatch()) })
+	assert.Eventually(
+		t,
+		func() bool {
+			arena.Update()
+			return withDriverStationLock(
+				func() bool {
+					for _, station := range stations {
+						status := simulatedDriverStations[station].Status()
+						if !status.Auto || !status.Enabled || !arena.AllianceStations[station].DsConn.DsReportedAuto {
+							return false
+						}
+					}
+					return true
+				},
+			)
+		},
+		3*time.Second,
+		10*time.Millisecond,
+	)
+	assert.Equal(t, AutoPeriod, arena.MatchState)
+
+	withDriverStationLock(func() bool { return assert.Nil(t, arena.AbortMatch()) })
+	assert.Eventually(
+		t,
+		func() bool {
+			arena.Update()
+			return !simulatedDriverStations["R1"].Status().Enabled
+		},
+		3*time.Second,
+		10*time.Millisecond,
+	)
+}
+
 func TestNewDriverStationConnection_UdpPortSelection(t *testing.T) {
 	tcpConn := setupFakeTcpConnection(t)
 	defer tcpConn.Close()
@@ -348,6 +476,12 @@ func (conn fakeRemoteAddrConn) RemoteAddr() net.Addr {
 	return conn.remoteAddr
 }
 
+func listenerPort(address string) int {
+	_, port, _ := net.SplitHostPort(address)
+	portNumber, _ := strconv.Atoi(port)
+	return portNumber
+}
+
 func startTestDriverStationServer(t *testing.T, arena *Arena) string {
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	assert.Nil(t, err)
@@ -368,6 +502,8 @@ func waitForDriverStationConnection(t *testing.T, arena *Arena, station string)
 	if !assert.Eventually(
 		t,
 		func() bool {
+			arena.driverStationMutex.Lock()
+			defer arena.driverStationMutex.Unlock()
 			dsConn = arena.AllianceStations[station].DsConn
 			return dsConn != nil
 		},
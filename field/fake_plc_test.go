@@ -47,7 +47,8 @@ func (plc *FakePlc) IoChangeNotifier() *websocket.Notifier {
 	return nil
 }
 
-func (plc *FakePlc) Run() {
+func (plc *FakePlc) Run(stop <-chan struct{}) {
+	<-stop
 }
 
 func (plc *FakePlc) GetArmorBlockStatuses() map[string]bool {
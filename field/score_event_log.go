@@ -0,0 +1,127 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for recording scoring commands to the per-match audit log and reverting a scorer's most recent one.
+
+package field
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"time"
+)
+
+// Stamps the given event with the current match and time and appends it to the persisted audit log.
+func (arena *Arena) LogScoreEvent(scoreEvent *model.ScoreEvent) error {
+	arena.scoreEventsMutex.Lock()
+	defer arena.scoreEventsMutex.Unlock()
+	return arena.logScoreEvent(scoreEvent)
+}
+
+// Must be called with the score events mutex held.
+func (arena *Arena) logScoreEvent(scoreEvent *model.ScoreEvent) error {
+	scoreEvent.MatchId = arena.CurrentMatch.Id
+	scoreEvent.Time = time.Now()
+	scoreEvent.MatchTimeSec = arena.MatchTimeSec()
+	if err := arena.Database.CreateScoreEvent(scoreEvent); err != nil {
+		return err
+	}
+	arena.scoreEvents = append(arena.scoreEvents, scoreEvent)
+	return nil
+}
+
+// Scoring commands that can be reverted by an undo.
+var undoableScoreCommands = map[string]bool{"autoTower": true, "endgame": true, "addFoul": true}
+
+// Reverts the most recent undoable event logged during the current match by the given user from the given panel
+// position, records the reversion in the audit log, and returns the reverted event. Only allowed while the match is
+// running or over but not yet committed by the position's scorers, and only if nobody else has changed the same value
+// since.
+func (arena *Arena) UndoLastScoreEvent(position, username string) (*model.ScoreEvent, error) {
+	isUndoAllowed := false
+	switch arena.MatchState {
+	case StartMatch, AutoPeriod, PausePeriod, TeleopPeriod:
+		isUndoAllowed = true
+	case PostMatch:
+		isUndoAllowed = arena.ScoringPanelRegistry.GetNumScoreCommitted(position) == 0
+	}
+	if !isUndoAllowed {
+		return nil, fmt.Errorf("Cannot undo outside of a match or once its score has been committed.")
+	}
+
+	arena.scoreEventsMutex.Lock()
+	defer arena.scoreEventsMutex.Unlock()
+	var scoreEvent *model.ScoreEvent
+	for i := len(arena.scoreEvents) - 1; i >= 0; i-- {
+		event := arena.scoreEvents[i]
+		if event.Position == position && event.Username == username && undoableScoreCommands[event.Command] &&
+			!event.IsUndone {
+			scoreEvent = event
+			break
+		}
+	}
+	if scoreEvent == nil {
+		return nil, fmt.Errorf("There is no action to undo.")
+	}
+
+	var score *game.Score
+	if scoreEvent.Alliance == "red" {
+		score = &arena.RedRealtimeScore.CurrentScore
+	} else {
+		score = &arena.BlueRealtimeScore.CurrentScore
+	}
+	switch scoreEvent.Command {
+	case "autoTower":
+		if err := revertTowerStatus(&score.AutoTowerStatuses, scoreEvent); err != nil {
+			return nil, err
+		}
+	case "endgame":
+		if err := revertTowerStatus(&score.EndgameTowerStatuses, scoreEvent); err != nil {
+			return nil, err
+		}
+	case "addFoul":
+		foulIndex := -1
+		for i, foul := range score.Fouls {
+			if foul.FoulId == scoreEvent.FoulId {
+				foulIndex = i
+				break
+			}
+		}
+		if foulIndex == -1 {
+			return nil, fmt.Errorf("Cannot undo adding the foul because it has since been deleted.")
+		}
+		score.Fouls = append(score.Fouls[:foulIndex], score.Fouls[foulIndex+1:]...)
+	}
+
+	scoreEvent.IsUndone = true
+	if err := arena.Database.UpdateScoreEvent(scoreEvent); err != nil {
+		return nil, err
+	}
+	undoEvent := model.ScoreEvent{
+		Position:    position,
+		Username:    username,
+		Command:     "undo",
+		Alliance:    scoreEvent.Alliance,
+		Description: fmt.Sprintf("Undid: %s", scoreEvent.Description),
+		UndoOfId:    scoreEvent.Id,
+	}
+	if err := arena.logScoreEvent(&undoEvent); err != nil {
+		return nil, err
+	}
+	arena.RealtimeScoreNotifier.Notify()
+	return scoreEvent, nil
+}
+
+// Restores the tower status changed by the given event, unless it has since been changed again by someone else.
+func revertTowerStatus(statuses *[3]game.TowerStatus, scoreEvent *model.ScoreEvent) error {
+	if scoreEvent.TeamPosition < 1 || scoreEvent.TeamPosition > 3 {
+		return fmt.Errorf("Cannot undo '%s' action for invalid position %d.", scoreEvent.Command, scoreEvent.TeamPosition)
+	}
+	status := &statuses[scoreEvent.TeamPosition-1]
+	if *status != game.TowerStatus(scoreEvent.NewValue) {
+		return fmt.Errorf("Cannot undo the change because the value has since been changed again.")
+	}
+	*status = game.TowerStatus(scoreEvent.PreviousValue)
+	return nil
+}
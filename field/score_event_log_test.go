@@ -0,0 +1,153 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLogScoreEvent(t *testing.T) {
+	arena := setupTestArena(t)
+	match := model.Match{Type: model.Qualification, ShortName: "Q1"}
+	arena.Database.CreateMatch(&match)
+	assert.Nil(t, arena.LoadMatch(&match))
+
+	scoreEvent := model.ScoreEvent{Position: "red", Username: "scorer1", Command: "endgame", Alliance: "red"}
+	assert.Nil(t, arena.LogScoreEvent(&scoreEvent))
+	assert.Equal(t, match.Id, scoreEvent.MatchId)
+	assert.False(t, scoreEvent.Time.IsZero())
+
+	scoreEvents, _ := arena.Database.GetScoreEventsForMatch(match.Id)
+	if assert.Equal(t, 1, len(scoreEvents)) {
+		assert.Equal(t, "scorer1", scoreEvents[0].Username)
+	}
+}
+
+func TestUndoLastScoreEvent(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.MatchState = TeleopPeriod
+	score := &arena.RedRealtimeScore.CurrentScore
+
+	_, err := arena.UndoLastScoreEvent("red", "scorer1")
+	assert.EqualError(t, err, "There is no action to undo.")
+
+	score.AutoTowerStatuses[1] = game.TowerLevel1
+	arena.LogScoreEvent(
+		&model.ScoreEvent{
+			Position: "red", Username: "scorer1", Command: "autoTower", Alliance: "red", TeamPosition: 2,
+			PreviousValue: int(game.TowerNone), NewValue: int(game.TowerLevel1),
+		},
+	)
+	score.Fouls = append(score.Fouls, game.Foul{FoulId: 1})
+	arena.LogScoreEvent(
+		&model.ScoreEvent{Position: "red", Username: "scorer1", Command: "addFoul", Alliance: "red", FoulId: 1},
+	)
+	arena.LogScoreEvent(&model.ScoreEvent{Position: "red", Username: "scorer1", Command: "card", Alliance: "red"})
+	score.EndgameTowerStatuses[0] = game.TowerLevel3
+	arena.LogScoreEvent(
+		&model.ScoreEvent{
+			Position: "red", Username: "scorer2", Command: "endgame", Alliance: "red", TeamPosition: 1,
+			PreviousValue: int(game.TowerLevel2), NewValue: int(game.TowerLevel3),
+		},
+	)
+
+	// Check that a user can only undo their own actions, most recent first, skipping any that can't be undone.
+	scoreEvent, err := arena.UndoLastScoreEvent("red", "scorer1")
+	assert.Nil(t, err)
+	assert.Equal(t, "addFoul", scoreEvent.Command)
+	assert.Empty(t, score.Fouls)
+	assert.Equal(t, game.TowerLevel3, score.EndgameTowerStatuses[0])
+
+	scoreEvent, err = arena.UndoLastScoreEvent("red", "scorer1")
+	assert.Nil(t, err)
+	assert.Equal(t, "autoTower", scoreEvent.Command)
+	assert.Equal(t, game.TowerNone, score.AutoTowerStatuses[1])
+
+	_, err = arena.UndoLastScoreEvent("red", "scorer1")
+	assert.EqualError(t, err, "There is no action to undo.")
+
+	scoreEvent, err = arena.UndoLastScoreEvent("red", "scorer2")
+	assert.Nil(t, err)
+	assert.Equal(t, game.TowerLevel2, score.EndgameTowerStatuses[0])
+
+	// Check that the undos were recorded in the log.
+	scoreEvents, _ := arena.Database.GetScoreEventsForMatch(arena.CurrentMatch.Id)
+	if assert.Equal(t, 7, len(scoreEvents)) {
+		assert.True(t, scoreEvents[0].IsUndone)
+		assert.True(t, scoreEvents[1].IsUndone)
+		assert.False(t, scoreEvents[2].IsUndone)
+		assert.Equal(t, "undo", scoreEvents[4].Command)
+		assert.Equal(t, scoreEvents[1].Id, scoreEvents[4].UndoOfId)
+	}
+}
+
+func TestUndoLastScoreEventDeletedFoul(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.MatchState = PostMatch
+
+	arena.BlueRealtimeScore.CurrentScore.Fouls = []game.Foul{{FoulId: 1}}
+	arena.LogScoreEvent(
+		&model.ScoreEvent{Position: "blue", Username: "scorer1", Command: "addFoul", Alliance: "blue", FoulId: 1},
+	)
+	arena.BlueRealtimeScore.CurrentScore.Fouls = nil
+	_, err := arena.UndoLastScoreEvent("blue", "scorer1")
+	assert.EqualError(t, err, "Cannot undo adding the foul because it has since been deleted.")
+}
+
+func TestScoreEventsResetOnMatchLoad(t *testing.T) {
+	arena := setupTestArena(t)
+
+	arena.LogScoreEvent(&model.ScoreEvent{Position: "red", Command: "autoTower", Alliance: "red", TeamPosition: 1})
+	assert.Nil(t, arena.ResetMatch())
+	assert.Nil(t, arena.LoadTestMatch())
+	arena.MatchState = PostMatch
+	_, err := arena.UndoLastScoreEvent("red", "")
+	assert.EqualError(t, err, "There is no action to undo.")
+}
+
+func TestUndoLastScoreEventOverwrittenValue(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.MatchState = AutoPeriod
+	score := &arena.BlueRealtimeScore.CurrentScore
+
+	score.AutoTowerStatuses[2] = game.TowerLevel1
+	arena.LogScoreEvent(
+		&model.ScoreEvent{
+			Position: "blue", Username: "scorer1", Command: "autoTower", Alliance: "blue", TeamPosition: 3,
+			PreviousValue: int(game.TowerNone), NewValue: int(game.TowerLevel1),
+		},
+	)
+
+	// Check that the undo is refused if another scorer has changed the same value since.
+	score.AutoTowerStatuses[2] = game.TowerLevel2
+	_, err := arena.UndoLastScoreEvent("blue", "scorer1")
+	assert.EqualError(t, err, "Cannot undo the change because the value has since been changed again.")
+	assert.Equal(t, game.TowerLevel2, score.AutoTowerStatuses[2])
+
+	// Check that the undo is refused once the match score has been committed.
+	score.AutoTowerStatuses[2] = game.TowerLevel1
+	for _, matchState := range []MatchState{PreMatch, TimeoutActive, PostTimeout} {
+		arena.MatchState = matchState
+		_, err = arena.UndoLastScoreEvent("blue", "scorer1")
+		assert.EqualError(t, err, "Cannot undo outside of a match or once its score has been committed.")
+	}
+	assert.Equal(t, game.TowerLevel1, score.AutoTowerStatuses[2])
+
+	arena.MatchState = PostMatch
+	ws := new(websocket.Websocket)
+	arena.ScoringPanelRegistry.RegisterPanel("blue", ws)
+	arena.ScoringPanelRegistry.SetScoreCommitted("blue", ws)
+	_, err = arena.UndoLastScoreEvent("blue", "scorer1")
+	assert.EqualError(t, err, "Cannot undo outside of a match or once its score has been committed.")
+	assert.Equal(t, game.TowerLevel1, score.AutoTowerStatuses[2])
+
+	arena.ScoringPanelRegistry.resetScoreCommitted()
+	_, err = arena.UndoLastScoreEvent("blue", "scorer1")
+	assert.Nil(t, err)
+	assert.Equal(t, game.TowerNone, score.AutoTowerStatuses[2])
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for running the arena as a passive hot standby that mirrors the data of a primary server until promoted.
+
+package field
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	standbySyncPeriodMs = 1000
+	standbyTimeoutSec   = 5
+)
+
+// Tracks the state of mirroring from the primary server while the arena is in standby.
+type StandbyStatus struct {
+	PrimaryUrl       string
+	password         string
+	LastSyncTime     time.Time
+	MirroredSequence int
+	LastError        string
+	PromotedAt       time.Time
+}
+
+// Response returned by the primary server to a standby requesting the journal entries it hasn't yet mirrored.
+type StandbyJournalResponse struct {
+	Entries          []model.JournalEntry
+	SnapshotRequired bool
+}
+
+// Takes over as the primary server, enabling control of the field hardware and creating the arenas for any additional
+// fields, which Run() then starts.
+func (arena *Arena) Promote() error {
+	arena.standbyMutex.Lock()
+	defer arena.standbyMutex.Unlock()
+	if !arena.IsPassive() {
+		return fmt.Errorf("Arena is not in standby mode.")
+	}
+
+	// Take a backup to serve as the starting point for recovery from this server's own journal.
+	if err := arena.Database.Backup(arena.EventSettings.Name, "promotion"); err != nil {
+		log.Println(err)
+	}
+
+	// Reload the settings once out of passive mode so that the field hardware gets configured this time.
+	arena.passive.Store(false)
+	if err := arena.LoadSettings(); err != nil {
+		return err
+	}
+	if err := arena.CreateFieldArenas(); err != nil {
+		return err
+	}
+	arena.Standby.PromotedAt = time.Now()
+	log.Printf("Promoted standby arena to primary; stopped mirroring %s.", arena.Standby.PrimaryUrl)
+	return nil
+}
+
+// Performs a single round of mirroring from the primary server, recording any error in the standby status.
+func (arena *Arena) runStandbySync() {
+	arena.standbyMutex.Lock()
+	defer arena.standbyMutex.Unlock()
+	if !arena.IsPassive() {
+		return
+	}
+	if err := arena.syncFromPrimary(); err != nil {
+		if arena.Standby.LastError != err.Error() {
+			log.Printf("Failed to sync from primary server: %v", err)
+		}
+		arena.Standby.LastError = err.Error()
+		return
+	}
+	arena.Standby.LastError = ""
+	arena.Standby.LastSyncTime = time.Now()
+}
+
+// Fetches and applies all changes made on the primary server since the last sync.
+func (arena *Arena) syncFromPrimary() error {
+	mirroredSequence, err := arena.Database.GetMirroredJournalSequence()
+	if err != nil {
+		return err
+	}
+
+	resp, err := arena.standbyRequest(fmt.Sprintf("/api/standby/journal?after=%d", mirroredSequence))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var journalResponse StandbyJournalResponse
+	if err = json.NewDecoder(resp.Body).Decode(&journalResponse); err != nil {
+		return err
+	}
+
+	if journalResponse.SnapshotRequired {
+		if err = arena.copyDatabaseFromPrimary(); err != nil {
+			return err
+		}
+	} else if len(journalResponse.Entries) > 0 {
+		if err = arena.Database.MirrorJournalEntries(journalResponse.Entries); err != nil {
+			return err
+		}
+	} else {
+		arena.Standby.MirroredSequence = mirroredSequence
+		return nil
+	}
+
+	if arena.Standby.MirroredSequence, err = arena.Database.GetMirroredJournalSequence(); err != nil {
+		return err
+	}
+	eventSettings, err := arena.Database.GetEventSettings()
+	if err != nil {
+		return err
+	}
+
+	// Swap in the mirrored settings under the same lock that the arena loop and driver station listeners hold while
+	// acting on them.
+	arena.driverStationMutex.Lock()
+	arena.EventSettings = eventSettings
+	arena.driverStationMutex.Unlock()
+	return nil
+}
+
+// Replaces the local database with a full copy of the primary server's, for when the journal alone can't bring the
+// standby up to date.
+func (arena *Arena) copyDatabaseFromPrimary() error {
+	resp, err := arena.standbyRequest("/api/standby/snapshot")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	tempFile, err := os.CreateTemp(filepath.Dir(arena.Database.Path), "standby-db-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	_, err = io.Copy(tempFile, resp.Body)
+	closeErr := tempFile.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	if err = arena.Database.ReplaceWithSnapshot(tempFile.Name()); err != nil {
+		return err
+	}
+	sequence, err := arena.Database.GetJournalSequence()
+	if err != nil {
+		return err
+	}
+	log.Printf("Copied full database from primary server at journal entry #%d.", sequence)
+	return arena.Database.SetMirroredJournalSequence(sequence)
+}
+
+// Sends an authenticated GET request for the given path to the primary server.
+func (arena *Arena) standbyRequest(path string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", arena.Standby.PrimaryUrl+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth("admin", arena.Standby.password)
+	client := &http.Client{Timeout: standbyTimeoutSec * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("primary server returned status %d for %s", resp.StatusCode, path)
+	}
+	return resp, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestStandbyMirrorsPrimary(t *testing.T) {
+	primary := setupTestArena(t)
+	server := httptest.NewServer(newFakePrimaryHandler(t, primary, "secret"))
+	defer server.Close()
+
+	standby := SetupTestStandbyArena(t, server.URL, "secret")
+	assert.True(t, standby.IsPassive())
+
+	primary.EventSettings.Name = "Chezy Champs"
+	assert.Nil(t, primary.Database.UpdateEventSettings(primary.EventSettings))
+	assert.Nil(t, primary.Database.CreateTeam(&model.Team{Id: 254}))
+	standby.runStandbySync()
+	assert.Equal(t, "", standby.Standby.LastError)
+	assert.False(t, standby.Standby.LastSyncTime.IsZero())
+	assert.Equal(t, "Chezy Champs", standby.EventSettings.Name)
+	team, _ := standby.Database.GetTeamById(254)
+	assert.NotNil(t, team)
+	primarySequence, _ := primary.Database.GetJournalSequence()
+	assert.Equal(t, primarySequence, standby.Standby.MirroredSequence)
+
+	// Check that subsequent syncs pick up only the new changes.
+	assert.Nil(t, primary.Database.DeleteTeam(254))
+	assert.Nil(t, primary.Database.CreateTeam(&model.Team{Id: 1114}))
+	standby.runStandbySync()
+	teams, _ := standby.Database.GetAllTeams()
+	if assert.Equal(t, 1, len(teams)) {
+		assert.Equal(t, 1114, teams[0].Id)
+	}
+
+	// Check that errors are recorded.
+	standby.Standby.password = "wrong"
+	standby.runStandbySync()
+	assert.Contains(t, standby.Standby.LastError, "status 401")
+}
+
+func TestStandbyCopiesDatabaseWhenTooFarBehind(t *testing.T) {
+	primary := setupTestArena(t)
+	server := httptest.NewServer(newFakePrimaryHandler(t, primary, ""))
+	defer server.Close()
+	assert.Nil(t, primary.Database.CreateTeam(&model.Team{Id: 254}))
+	assert.Nil(t, primary.Database.CreateTeam(&model.Team{Id: 1114}))
+
+	// Make it look like the standby has mirrored entries from some other primary that are ahead of this one.
+	standby := SetupTestStandbyArena(t, server.URL, "")
+	assert.Nil(t, standby.Database.CreateUserSession(&model.UserSession{Token: "local", Username: "admin"}))
+	assert.Nil(t, standby.Database.CreateTeam(&model.Team{Id: 9999}))
+	assert.Nil(t, standby.Database.SetMirroredJournalSequence(100))
+
+	standby.runStandbySync()
+	assert.Equal(t, "", standby.Standby.LastError)
+	teams, _ := standby.Database.GetAllTeams()
+	if assert.Equal(t, 2, len(teams)) {
+		assert.Equal(t, 254, teams[0].Id)
+		assert.Equal(t, 1114, teams[1].Id)
+	}
+	primarySequence, _ := primary.Database.GetJournalSequence()
+	assert.Equal(t, primarySequence, standby.Standby.MirroredSequence)
+
+	// Check that incremental mirroring resumes after the copy.
+	assert.Nil(t, primary.Database.CreateTeam(&model.Team{Id: 2056}))
+	standby.runStandbySync()
+	teams, _ = standby.Database.GetAllTeams()
+	assert.Equal(t, 3, len(teams))
+
+	// Check that the local login sessions survive the copy.
+	session, _ := standby.Database.GetUserSessionByToken("local")
+	assert.NotNil(t, session)
+}
+
+func TestStandbyPromote(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.EqualError(t, arena.Promote(), "Arena is not in standby mode.")
+
+	arena = SetupTestStandbyArena(t, "http://localhost:1", "")
+	arena.EventSettings.NumFields = 2
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Empty(t, arena.FieldArenas())
+	assert.Nil(t, arena.Promote())
+	assert.False(t, arena.IsPassive())
+	assert.False(t, arena.Standby.PromotedAt.IsZero())
+	assert.Nil(t, arena.LoadTestMatch())
+
+	// Check that the additional fields are started once promoted.
+	if assert.Equal(t, 1, len(arena.FieldArenas())) {
+		assert.Equal(t, 2, arena.FieldArenas()[0].FieldNumber)
+		assert.False(t, arena.FieldArenas()[0].IsPassive())
+	}
+
+	// Syncing should do nothing once promoted.
+	arena.runStandbySync()
+	assert.Equal(t, "", arena.Standby.LastError)
+}
+
+func TestStandbyLeavesFieldAlone(t *testing.T) {
+	arena := SetupTestStandbyArena(t, "http://localhost:1", "")
+	assert.True(t, arena.IsPassive())
+	assert.Equal(t, "T", arena.CurrentMatch.ShortName)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", Red1: 254}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	err := arena.LoadMatch(&match)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "standby")
+	}
+	assert.Equal(t, "T", arena.CurrentMatch.ShortName)
+
+	arena.EventSettings.TbaPublishingEnabled = true
+	assert.False(t, arena.ShouldPublishToTba())
+	arena.passive.Store(false)
+	assert.True(t, arena.ShouldPublishToTba())
+}
+
+// Returns a handler that serves the standby API from the given arena's database, like the web package does.
+func newFakePrimaryHandler(t *testing.T, primary *Arena, password string) http.Handler {
+	mux := http.NewServeMux()
+	checkAuth := func(w http.ResponseWriter, r *http.Request) bool {
+		if _, requestPassword, _ := r.BasicAuth(); requestPassword != password {
+			http.Error(w, "Unauthorized", 401)
+			return false
+		}
+		return true
+	}
+	mux.HandleFunc(
+		"GET /api/standby/journal", func(w http.ResponseWriter, r *http.Request) {
+			if !checkAuth(w, r) {
+				return
+			}
+			afterSequence, _ := strconv.Atoi(r.URL.Query().Get("after"))
+			entries, complete, err := primary.Database.GetJournalEntriesAfter(afterSequence)
+			assert.Nil(t, err)
+			json.NewEncoder(w).Encode(StandbyJournalResponse{Entries: entries, SnapshotRequired: !complete})
+		},
+	)
+	mux.HandleFunc(
+		"GET /api/standby/snapshot", func(w http.ResponseWriter, r *http.Request) {
+			if !checkAuth(w, r) {
+				return
+			}
+			assert.Nil(t, primary.Database.WriteBackup(w))
+		},
+	)
+	return mux
+}
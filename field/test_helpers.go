@@ -29,6 +29,21 @@ func SetupTestArena(t *testing.T) *Arena {
 	return arena
 }
 
+func SetupTestStandbyArena(t *testing.T, primaryUrl, password string) *Arena {
+	rand.Seed(0)
+	model.BaseDir = ".."
+	dbDir := t.TempDir()
+	dbPath := filepath.Join(dbDir, "test.db")
+	arena, err := NewStandbyArena(dbPath, primaryUrl, password)
+	assert.Nil(t, err)
+	t.Cleanup(
+		func() {
+			arena.Database.Close()
+		},
+	)
+	return arena
+}
+
 func setupTestArena(t *testing.T) *Arena {
 	game.MatchTiming.PauseDurationSec = 2
 	return SetupTestArena(t)
@@ -0,0 +1,120 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Interface and registry for the season-specific rules of an FRC game, allowing the active game to be selected per
+// event.
+
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// A single alliance's score in whatever form the game defines. It is opaque to the Game interface so that each season
+// can bring its own scoring state; the 2026 game uses *Score. Note that only the rules are pluggable so far: realtime
+// scoring and stored match results are still built around *Score, and ScoreSummary and RankingFields still carry the
+// 2026 game's fields, so a new season's game also needs those reworked before it can be played.
+type AllianceScore any
+
+// Encapsulates all logic that changes from one season's game to the next.
+type Game interface {
+	// Returns the unique identifier under which the game is registered and persisted in the event settings.
+	Key() string
+
+	// Returns the human-readable name of the game.
+	Name() string
+
+	// Returns the default period durations for the game.
+	DefaultMatchTiming() MatchTimingSettings
+
+	// Returns the total duration of the teleoperated period given the configured match timing.
+	TeleopDurationSec(timing *MatchTimingSettings) int
+
+	// Calculates the summary fields used for ranking and display from the given alliance's and opponent's scores.
+	// Fields of the summary that have no equivalent in the game are left at zero. Returns an error if the scores aren't
+	// of the type that the game uses.
+	Summarize(score, opponentScore AllianceScore) (*ScoreSummary, error)
+
+	// Determines the winner of the match, applying the game-specific tiebreakers in playoffs.
+	DetermineMatchStatus(
+		redScoreSummary, blueScoreSummary *ScoreSummary, applyPlayoffTiebreakers bool,
+	) (MatchStatus, string)
+
+	// Accumulates the given match outcome into the team's qualification ranking fields.
+	AddScoreSummary(fields *RankingFields, ownScore, opponentScore *ScoreSummary, disqualified bool)
+
+	// Returns the default ranking point values and tiebreaker order for the qualification rankings.
+	DefaultRankingRules() RankingRules
+
+	// Returns the most bonus ranking points that an alliance can earn in a single match.
+	MaxBonusRankingPoints() int
+
+	// Returns the number of points that the given foul adds to the opposing alliance's score.
+	FoulPointValue(foul *Foul) int
+
+	// Returns all rules that carry point penalties, ordered by ID.
+	Rules() []*Rule
+
+	// Builds the score breakdown for one alliance in the format expected by The Blue Alliance.
+	TbaScoreBreakdown(
+		score AllianceScore, scoreSummary, opponentScoreSummary *ScoreSummary, rankingPoints int,
+	) (map[string]any, error)
+}
+
+const DefaultGameKey = "2026"
+
+var games = map[string]Game{}
+
+// The game whose rules are currently in effect. Mutable via SetCurrentGame.
+var CurrentGame Game
+
+func init() {
+	RegisterGame(Rebuilt2026{})
+	CurrentGame = games[DefaultGameKey]
+	CurrentRankingRules = CurrentGame.DefaultRankingRules()
+	MatchTiming = CurrentGame.DefaultMatchTiming()
+}
+
+// Makes the given game available for selection.
+func RegisterGame(game Game) {
+	games[game.Key()] = game
+}
+
+// Returns the registered game having the given key, or an error if it doesn't exist.
+func GetGame(key string) (Game, error) {
+	if game, ok := games[key]; ok {
+		return game, nil
+	}
+	return nil, fmt.Errorf("Invalid game '%s'.", key)
+}
+
+// Returns all registered games, ordered by key.
+func GetAllGames() []Game {
+	var allGames []Game
+	for _, game := range games {
+		allGames = append(allGames, game)
+	}
+	sort.Slice(
+		allGames,
+		func(i, j int) bool {
+			return allGames[i].Key() < allGames[j].Key()
+		},
+	)
+	return allGames
+}
+
+// Sets the active game to the one having the given key, falling back to the default game if the key is blank. Also
+// resets the ranking rules to the game's defaults.
+func SetCurrentGame(key string) error {
+	if key == "" {
+		key = DefaultGameKey
+	}
+	game, err := GetGame(key)
+	if err != nil {
+		return err
+	}
+	CurrentGame = game
+	CurrentRankingRules = game.DefaultRankingRules()
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package game
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Variant of the 2026 game with different foul values and no rules, used to verify delegation to the current game.
+type testGame struct {
+	Rebuilt2026
+}
+
+func (testGame) Key() string {
+	return "test"
+}
+
+func (testGame) Name() string {
+	return "Test Game"
+}
+
+func (testGame) FoulPointValue(foul *Foul) int {
+	return 100
+}
+
+func (testGame) Rules() []*Rule {
+	return []*Rule{{1, "T101", true, false, "Don't test in production."}}
+}
+
+func TestGetGame(t *testing.T) {
+	currentGame, err := GetGame(DefaultGameKey)
+	assert.Nil(t, err)
+	assert.Equal(t, "2026 REBUILT", currentGame.Name())
+	assert.Equal(t, currentGame, CurrentGame)
+
+	_, err = GetGame("1992")
+	assert.EqualError(t, err, "Invalid game '1992'.")
+}
+
+func TestSetCurrentGame(t *testing.T) {
+	RegisterGame(testGame{})
+	defer func() {
+		delete(games, "test")
+		CurrentGame = Rebuilt2026{}
+	}()
+
+	allGames := GetAllGames()
+	if assert.Equal(t, 2, len(allGames)) {
+		assert.Equal(t, "2026", allGames[0].Key())
+		assert.Equal(t, "test", allGames[1].Key())
+	}
+
+	foul := Foul{IsMajor: true, RuleId: 1}
+	assert.Equal(t, 15, foul.PointValue())
+	assert.Equal(t, "G206", GetRuleById(1).RuleNumber)
+
+	assert.Nil(t, SetCurrentGame("test"))
+	assert.Equal(t, "test", CurrentGame.Key())
+	assert.Equal(t, 100, foul.PointValue())
+	assert.Equal(t, "T101", GetRuleById(1).RuleNumber)
+	assert.Nil(t, GetRuleById(2))
+
+	assert.NotNil(t, SetCurrentGame("1992"))
+	assert.Equal(t, "test", CurrentGame.Key())
+	assert.Nil(t, SetCurrentGame(""))
+	assert.Equal(t, DefaultGameKey, CurrentGame.Key())
+	assert.Equal(t, "G206", GetRuleById(1).RuleNumber)
+}
+
+func TestRebuilt2026DefaultMatchTiming(t *testing.T) {
+	timing := Rebuilt2026{}.DefaultMatchTiming()
+	assert.Equal(t, 20, timing.AutoDurationSec)
+	assert.Equal(t, 140, Rebuilt2026{}.TeleopDurationSec(&timing))
+}
+
+// Game from another season with its own score type, used to verify that the interface doesn't depend on *Score.
+type otherSeasonGame struct {
+	Rebuilt2026
+}
+
+type otherSeasonScore struct {
+	Points    int
+	Penalties int
+}
+
+func (otherSeasonGame) Key() string {
+	return "other"
+}
+
+func (otherSeasonGame) Summarize(score, opponentScore AllianceScore) (*ScoreSummary, error) {
+	ownScore, ok := score.(*otherSeasonScore)
+	if !ok {
+		return nil, fmt.Errorf("wrong score type %T", score)
+	}
+	summary := ScoreSummary{MatchPoints: ownScore.Points, FoulPoints: opponentScore.(*otherSeasonScore).Penalties}
+	summary.Score = summary.MatchPoints + summary.FoulPoints
+	return &summary, nil
+}
+
+func (otherSeasonGame) TbaScoreBreakdown(
+	score AllianceScore, scoreSummary, _ *ScoreSummary, rankingPoints int,
+) (map[string]any, error) {
+	return map[string]any{"points": score.(*otherSeasonScore).Points, "rp": rankingPoints}, nil
+}
+
+func TestGameWithOwnScoreType(t *testing.T) {
+	RegisterGame(otherSeasonGame{})
+	defer func() {
+		delete(games, "other")
+		CurrentGame = Rebuilt2026{}
+	}()
+	assert.Nil(t, SetCurrentGame("other"))
+
+	redScore := &otherSeasonScore{Points: 50, Penalties: 4}
+	blueScore := &otherSeasonScore{Points: 30, Penalties: 10}
+	redSummary, err := CurrentGame.Summarize(redScore, blueScore)
+	assert.Nil(t, err)
+	blueSummary, err := CurrentGame.Summarize(blueScore, redScore)
+	assert.Nil(t, err)
+	assert.Equal(t, 60, redSummary.Score)
+	assert.Equal(t, 34, blueSummary.Score)
+	status, _ := DetermineMatchStatus(redSummary, blueSummary, false)
+	assert.Equal(t, RedWonMatch, status)
+	breakdown, err := CurrentGame.TbaScoreBreakdown(redScore, redSummary, blueSummary, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"points": 50, "rp": 3}, breakdown)
+
+	// Check that the 2026 score format, which realtime scoring still uses, is refused rather than misread.
+	_, err = CurrentGame.Summarize(&Score{}, &Score{})
+	assert.NotNil(t, err)
+	assert.Equal(t, ScoreSummary{}, *(&Score{}).Summarize(&Score{}))
+}
+
+func TestRebuilt2026WrongScoreType(t *testing.T) {
+	_, err := Rebuilt2026{}.Summarize(&otherSeasonScore{}, &Score{})
+	assert.EqualError(t, err, "2026 REBUILT can't summarize a score of type *game.otherSeasonScore")
+	_, err = Rebuilt2026{}.Summarize(&Score{}, &otherSeasonScore{})
+	assert.NotNil(t, err)
+	_, err = Rebuilt2026{}.TbaScoreBreakdown(&otherSeasonScore{}, &ScoreSummary{}, &ScoreSummary{}, 0)
+	assert.EqualError(t, err, "2026 REBUILT can't build a TBA breakdown from a score of type *game.otherSeasonScore")
+}
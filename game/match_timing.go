@@ -12,17 +12,21 @@ const (
 	MotorsOnExtraPeriodSec = 2
 )
 
-var MatchTiming = struct {
+type MatchTimingSettings struct {
 	AutoDurationSec            int
 	PauseDurationSec           int
 	TransitionShiftDurationSec int
 	ShiftDurationSec           int
 	EndgameDurationSec         int
 	TimeoutDurationSec         int
-}{20, 3, 10, 25, 30, 0}
+}
+
+// The period durations currently in effect, which start out as the default game's and are then set from the event
+// settings.
+var MatchTiming MatchTimingSettings
 
 func GetTeleopDurationSec() int {
-	return MatchTiming.TransitionShiftDurationSec + 4*MatchTiming.ShiftDurationSec + MatchTiming.EndgameDurationSec
+	return CurrentGame.TeleopDurationSec(&MatchTiming)
 }
 
 func GetDurationToAutoEnd() time.Duration {
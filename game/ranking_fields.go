@@ -31,34 +31,10 @@ type Rankings []Ranking
 
 var RankingRandomFloat64 = rand.Float64
 
+// Accumulates the given match outcome into the ranking fields, using the rules of the current game and the current
+// ranking point values.
 func (fields *RankingFields) AddScoreSummary(ownScore *ScoreSummary, opponentScore *ScoreSummary, disqualified bool) {
-	fields.Played += 1
-
-	// Store a random value to be used as the last tiebreaker if necessary.
-	fields.Random = RankingRandomFloat64()
-
-	if disqualified {
-		// Don't award any points.
-		fields.Disqualifications += 1
-		return
-	}
-
-	// Assign ranking points and wins/losses/ties.
-	if ownScore.Score > opponentScore.Score {
-		fields.RankingPoints += 3
-		fields.Wins += 1
-	} else if ownScore.Score == opponentScore.Score {
-		fields.RankingPoints += 1
-		fields.Ties += 1
-	} else {
-		fields.Losses += 1
-	}
-	fields.RankingPoints += ownScore.BonusRankingPoints
-
-	// Assign tiebreaker points.
-	fields.MatchPoints += ownScore.MatchPoints
-	fields.AutoFuelPoints += ownScore.AutoFuelPoints
-	fields.TowerPoints += ownScore.AutoTowerPoints + ownScore.TeleopTowerPoints
+	CurrentGame.AddScoreSummary(fields, ownScore, opponentScore, disqualified)
 }
 
 // Helper function to implement the required interface for Sort.
@@ -68,23 +44,7 @@ func (rankings Rankings) Len() int {
 
 // Helper function to implement the required interface for Sort.
 func (rankings Rankings) Less(i, j int) bool {
-	a := rankings[i]
-	b := rankings[j]
-
-	// Use cross-multiplication to keep it in integer math.
-	if a.RankingPoints*b.Played == b.RankingPoints*a.Played {
-		if a.MatchPoints*b.Played == b.MatchPoints*a.Played {
-			if a.AutoFuelPoints*b.Played == b.AutoFuelPoints*a.Played {
-				if a.TowerPoints*b.Played == b.TowerPoints*a.Played {
-					return a.Random > b.Random
-				}
-				return a.TowerPoints*b.Played > b.TowerPoints*a.Played
-			}
-			return a.AutoFuelPoints*b.Played > b.AutoFuelPoints*a.Played
-		}
-		return a.MatchPoints*b.Played > b.MatchPoints*a.Played
-	}
-	return a.RankingPoints*b.Played > b.RankingPoints*a.Played
+	return CurrentRankingRules.Less(&rankings[i].RankingFields, &rankings[j].RankingFields)
 }
 
 // Helper function to implement the required interface for Sort.
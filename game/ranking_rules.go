@@ -0,0 +1,257 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Configurable rules for awarding qualification ranking points and ordering teams in the rankings.
+
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A statistic by which teams can be ordered in the qualification rankings, averaged over the matches played.
+type RankingCriterion string
+
+const (
+	RankingPointsCriterion  RankingCriterion = "RankingPoints"
+	MatchPointsCriterion    RankingCriterion = "MatchPoints"
+	AutoFuelPointsCriterion RankingCriterion = "AutoFuelPoints"
+	TowerPointsCriterion    RankingCriterion = "TowerPoints"
+	WinsCriterion           RankingCriterion = "Wins"
+)
+
+var rankingCriterionNames = map[RankingCriterion]string{
+	RankingPointsCriterion:  "Ranking Points",
+	MatchPointsCriterion:    "Match Points",
+	AutoFuelPointsCriterion: "Auto Fuel Points",
+	TowerPointsCriterion:    "Tower Points",
+	WinsCriterion:           "Wins",
+}
+
+type RankingRules struct {
+	WinPoints                 int
+	TiePoints                 int
+	LossPoints                int
+	BonusRankingPointsEnabled bool
+
+	// Number of each team's worst matches to leave out of its record once it has played more than that many.
+	DropLowestMatches int
+
+	// Criteria to rank by, in order of precedence. Teams that are equal on all of them are ordered randomly.
+	Criteria []RankingCriterion
+}
+
+// The rules currently in effect for the qualification rankings. Mutable via SetCurrentGame or from the event settings.
+var CurrentRankingRules RankingRules
+
+// Returns all criteria by which the rankings can be ordered.
+func AllRankingCriteria() []RankingCriterion {
+	return []RankingCriterion{
+		RankingPointsCriterion, MatchPointsCriterion, AutoFuelPointsCriterion, TowerPointsCriterion, WinsCriterion,
+	}
+}
+
+// Returns the human-readable name of the criterion.
+func (criterion RankingCriterion) Name() string {
+	return rankingCriterionNames[criterion]
+}
+
+// Parses the given comma-separated list of criterion keys, ignoring blank entries.
+func ParseRankingCriteria(value string) ([]RankingCriterion, error) {
+	var criteria []RankingCriterion
+	for _, key := range strings.Split(value, ",") {
+		criterion := RankingCriterion(strings.TrimSpace(key))
+		if criterion == "" {
+			continue
+		}
+		if _, ok := rankingCriterionNames[criterion]; !ok {
+			return nil, fmt.Errorf("invalid ranking criterion '%s'", criterion)
+		}
+		criteria = append(criteria, criterion)
+	}
+	return criteria, nil
+}
+
+// Returns the criteria as a comma-separated list of keys, suitable for storage.
+func (rules RankingRules) CriteriaString() string {
+	keys := make([]string, len(rules.Criteria))
+	for i, criterion := range rules.Criteria {
+		keys[i] = string(criterion)
+	}
+	return strings.Join(keys, ",")
+}
+
+// Returns an error if the rules are incomplete or inconsistent.
+func (rules RankingRules) Validate() error {
+	if rules.WinPoints < 0 || rules.TiePoints < 0 || rules.LossPoints < 0 {
+		return fmt.Errorf("ranking point values must not be negative")
+	}
+	if rules.DropLowestMatches < 0 {
+		return fmt.Errorf("number of matches to drop must not be negative")
+	}
+	if len(rules.Criteria) == 0 {
+		return fmt.Errorf("at least one ranking criterion is required")
+	}
+	for i, criterion := range rules.Criteria {
+		if _, ok := rankingCriterionNames[criterion]; !ok {
+			return fmt.Errorf("invalid ranking criterion '%s'", criterion)
+		}
+		for _, otherCriterion := range rules.Criteria[:i] {
+			if criterion == otherCriterion {
+				return fmt.Errorf("ranking criterion '%s' is listed more than once", criterion.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// Returns a human-readable summary of the rules, for showing alongside the rankings.
+func (rules RankingRules) Description() string {
+	names := make([]string, len(rules.Criteria))
+	for i, criterion := range rules.Criteria {
+		names[i] = criterion.Name()
+	}
+	description := fmt.Sprintf(
+		"Ranked by %s. Win/Tie/Loss = %d/%d/%d RP", strings.Join(names, ", "), rules.WinPoints, rules.TiePoints,
+		rules.LossPoints,
+	)
+	if rules.BonusRankingPointsEnabled {
+		description += " plus bonus RP"
+	}
+	if rules.DropLowestMatches == 1 {
+		description += "; lowest match dropped"
+	} else if rules.DropLowestMatches > 1 {
+		description += fmt.Sprintf("; lowest %d matches dropped", rules.DropLowestMatches)
+	}
+	return description + "."
+}
+
+// Returns the ranking points earned for a match having the given outcome and number of bonus ranking points.
+func (rules RankingRules) MatchRankingPoints(ownScore, opponentScore, bonusRankingPoints int) int {
+	rankingPoints := rules.LossPoints
+	if ownScore > opponentScore {
+		rankingPoints = rules.WinPoints
+	} else if ownScore == opponentScore {
+		rankingPoints = rules.TiePoints
+	}
+	if rules.BonusRankingPointsEnabled {
+		rankingPoints += bonusRankingPoints
+	}
+	return rankingPoints
+}
+
+// Returns the running total of the first criterion by which the rankings are ordered.
+func (rules RankingRules) PrimaryCriterionValue(fields *RankingFields) int {
+	if len(rules.Criteria) == 0 {
+		return 0
+	}
+	return fields.criterionValue(rules.Criteria[0])
+}
+
+// Returns the most that a team can add to the first ranking criterion in a single match, given which outcomes of the
+// match are still possible for it, or false if the criterion has no upper limit. The least is always zero, since the
+// team might be disqualified.
+func (rules RankingRules) MaxPrimaryCriterionPerMatch(canWin, canTie, canLose bool) (int, bool) {
+	if len(rules.Criteria) == 0 {
+		return 0, true
+	}
+	switch rules.Criteria[0] {
+	case RankingPointsCriterion:
+		maxRankingPoints := 0
+		if canWin {
+			maxRankingPoints = max(maxRankingPoints, rules.WinPoints)
+		}
+		if canTie {
+			maxRankingPoints = max(maxRankingPoints, rules.TiePoints)
+		}
+		if canLose {
+			maxRankingPoints = max(maxRankingPoints, rules.LossPoints)
+		}
+		if rules.BonusRankingPointsEnabled {
+			maxRankingPoints += CurrentGame.MaxBonusRankingPoints()
+		}
+		return maxRankingPoints, true
+	case WinsCriterion:
+		if canWin {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// Returns true if the first set of ranking fields should be ranked ahead of the second.
+func (rules RankingRules) Less(a, b *RankingFields) bool {
+	if comparison := rules.compare(a, b); comparison != 0 {
+		return comparison > 0
+	}
+	return a.Random > b.Random
+}
+
+// Combines the given per-match ranking fields for a team into its overall record, leaving out its worst matches if so
+// configured. The random tiebreaker is taken from the last match.
+func (rules RankingRules) CombineMatches(matchFields []RankingFields) RankingFields {
+	var combined RankingFields
+	if len(matchFields) == 0 {
+		return combined
+	}
+	combined.Random = matchFields[len(matchFields)-1].Random
+
+	keptFields := matchFields
+	if rules.DropLowestMatches > 0 && len(matchFields) > rules.DropLowestMatches {
+		keptFields = make([]RankingFields, len(matchFields))
+		copy(keptFields, matchFields)
+		sort.SliceStable(keptFields, func(i, j int) bool {
+			return rules.compare(&keptFields[i], &keptFields[j]) > 0
+		})
+		keptFields = keptFields[:len(keptFields)-rules.DropLowestMatches]
+	}
+	for _, fields := range keptFields {
+		combined.RankingPoints += fields.RankingPoints
+		combined.MatchPoints += fields.MatchPoints
+		combined.AutoFuelPoints += fields.AutoFuelPoints
+		combined.TowerPoints += fields.TowerPoints
+		combined.Wins += fields.Wins
+		combined.Losses += fields.Losses
+		combined.Ties += fields.Ties
+		combined.Disqualifications += fields.Disqualifications
+		combined.Played += fields.Played
+	}
+	return combined
+}
+
+// Compares the per-match averages of the two sets of ranking fields according to the criteria in order, returning a
+// positive value if the first is better, a negative value if the second is better, and zero if they are equal.
+func (rules RankingRules) compare(a, b *RankingFields) int {
+	for _, criterion := range rules.Criteria {
+		// Use cross-multiplication to keep it in integer math.
+		aValue := a.criterionValue(criterion) * b.Played
+		bValue := b.criterionValue(criterion) * a.Played
+		if aValue != bValue {
+			if aValue > bValue {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// Returns the running total of the given criterion.
+func (fields *RankingFields) criterionValue(criterion RankingCriterion) int {
+	switch criterion {
+	case RankingPointsCriterion:
+		return fields.RankingPoints
+	case MatchPointsCriterion:
+		return fields.MatchPoints
+	case AutoFuelPointsCriterion:
+		return fields.AutoFuelPoints
+	case TowerPointsCriterion:
+		return fields.TowerPoints
+	case WinsCriterion:
+		return fields.Wins
+	}
+	return 0
+}
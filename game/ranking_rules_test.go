@@ -0,0 +1,152 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package game
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+)
+
+func TestParseRankingCriteria(t *testing.T) {
+	criteria, err := ParseRankingCriteria("MatchPoints, Wins,,RankingPoints")
+	assert.Nil(t, err)
+	assert.Equal(t, []RankingCriterion{MatchPointsCriterion, WinsCriterion, RankingPointsCriterion}, criteria)
+	assert.Equal(t, "MatchPoints,Wins,RankingPoints", RankingRules{Criteria: criteria}.CriteriaString())
+
+	criteria, err = ParseRankingCriteria("")
+	assert.Nil(t, err)
+	assert.Empty(t, criteria)
+
+	_, err = ParseRankingCriteria("MatchPoints,Coopertition")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid ranking criterion 'Coopertition'", err.Error())
+	}
+}
+
+func TestRankingRulesValidate(t *testing.T) {
+	rules := Rebuilt2026{}.DefaultRankingRules()
+	assert.Nil(t, rules.Validate())
+
+	rules.TiePoints = -1
+	assert.EqualError(t, rules.Validate(), "ranking point values must not be negative")
+	rules.TiePoints = 1
+	rules.DropLowestMatches = -1
+	assert.EqualError(t, rules.Validate(), "number of matches to drop must not be negative")
+	rules.DropLowestMatches = 0
+	rules.Criteria = nil
+	assert.EqualError(t, rules.Validate(), "at least one ranking criterion is required")
+	rules.Criteria = []RankingCriterion{WinsCriterion, MatchPointsCriterion, WinsCriterion}
+	assert.EqualError(t, rules.Validate(), "ranking criterion 'Wins' is listed more than once")
+	rules.Criteria = []RankingCriterion{"Bogus"}
+	assert.EqualError(t, rules.Validate(), "invalid ranking criterion 'Bogus'")
+}
+
+func TestRankingRulesDescription(t *testing.T) {
+	rules := Rebuilt2026{}.DefaultRankingRules()
+	assert.Equal(
+		t,
+		"Ranked by Ranking Points, Match Points, Auto Fuel Points, Tower Points. Win/Tie/Loss = 3/1/0 RP plus "+
+			"bonus RP.",
+		rules.Description(),
+	)
+
+	rules = RankingRules{WinPoints: 2, DropLowestMatches: 2, Criteria: []RankingCriterion{MatchPointsCriterion}}
+	assert.Equal(t, "Ranked by Match Points. Win/Tie/Loss = 2/0/0 RP; lowest 2 matches dropped.", rules.Description())
+}
+
+func TestRankingRulesMatchRankingPoints(t *testing.T) {
+	rules := RankingRules{WinPoints: 2, TiePoints: 1, LossPoints: 0}
+	assert.Equal(t, 2, rules.MatchRankingPoints(100, 50, 3))
+	assert.Equal(t, 1, rules.MatchRankingPoints(50, 50, 3))
+	assert.Equal(t, 0, rules.MatchRankingPoints(50, 100, 3))
+
+	rules.BonusRankingPointsEnabled = true
+	assert.Equal(t, 5, rules.MatchRankingPoints(100, 50, 3))
+	assert.Equal(t, 3, rules.MatchRankingPoints(50, 100, 3))
+}
+
+func TestRankingRulesCustomOrder(t *testing.T) {
+	defer func() {
+		CurrentRankingRules = CurrentGame.DefaultRankingRules()
+	}()
+	CurrentRankingRules = RankingRules{Criteria: []RankingCriterion{MatchPointsCriterion, WinsCriterion}}
+
+	// Ranking points are ignored since they aren't one of the criteria.
+	rankings := Rankings{
+		{TeamId: 1, RankingFields: RankingFields{RankingPoints: 30, MatchPoints: 400, Wins: 8, Played: 10}},
+		{TeamId: 2, RankingFields: RankingFields{RankingPoints: 10, MatchPoints: 500, Wins: 2, Played: 10}},
+		{TeamId: 3, RankingFields: RankingFields{RankingPoints: 20, MatchPoints: 400, Wins: 9, Played: 10}},
+		{TeamId: 4, RankingFields: RankingFields{RankingPoints: 20, MatchPoints: 360, Wins: 7, Played: 9}},
+	}
+	sort.Sort(rankings)
+	assert.Equal(t, 2, rankings[0].TeamId)
+	assert.Equal(t, 3, rankings[1].TeamId)
+	assert.Equal(t, 1, rankings[2].TeamId)
+	assert.Equal(t, 4, rankings[3].TeamId)
+}
+
+func TestRankingRulesCombineMatches(t *testing.T) {
+	matchFields := []RankingFields{
+		{RankingPoints: 3, MatchPoints: 100, Random: 0.1, Wins: 1, Played: 1},
+		{RankingPoints: 0, MatchPoints: 80, Random: 0.2, Losses: 1, Played: 1},
+		{RankingPoints: 1, MatchPoints: 90, Random: 0.3, Ties: 1, Played: 1},
+		{RankingPoints: 0, MatchPoints: 0, Random: 0.4, Disqualifications: 1, Played: 1},
+	}
+	rules := Rebuilt2026{}.DefaultRankingRules()
+	assert.Equal(
+		t,
+		RankingFields{
+			RankingPoints:     4,
+			MatchPoints:       270,
+			Random:            0.4,
+			Wins:              1,
+			Losses:            1,
+			Ties:              1,
+			Disqualifications: 1,
+			Played:            4,
+		},
+		rules.CombineMatches(matchFields),
+	)
+
+	// The worst matches according to the ranking criteria are left out of the record.
+	rules.DropLowestMatches = 2
+	assert.Equal(
+		t,
+		RankingFields{RankingPoints: 4, MatchPoints: 190, Random: 0.4, Wins: 1, Ties: 1, Played: 2},
+		rules.CombineMatches(matchFields),
+	)
+
+	// Nothing is dropped until the team has played more matches than the number to drop.
+	assert.Equal(t, 2, rules.CombineMatches(matchFields[:2]).Played)
+	assert.Equal(t, RankingFields{}, rules.CombineMatches(nil))
+}
+
+func TestRankingRulesPrimaryCriterionBounds(t *testing.T) {
+	fields := RankingFields{RankingPoints: 7, MatchPoints: 300, Wins: 2, Played: 3}
+	rules := Rebuilt2026{}.DefaultRankingRules()
+	assert.Equal(t, 7, rules.PrimaryCriterionValue(&fields))
+	maxValue, ok := rules.MaxPrimaryCriterionPerMatch(true, true, true)
+	assert.True(t, ok)
+	assert.Equal(t, 6, maxValue)
+	maxValue, _ = rules.MaxPrimaryCriterionPerMatch(false, false, true)
+	assert.Equal(t, 3, maxValue)
+	rules.BonusRankingPointsEnabled = false
+	maxValue, _ = rules.MaxPrimaryCriterionPerMatch(false, true, false)
+	assert.Equal(t, 1, maxValue)
+
+	rules.Criteria = []RankingCriterion{WinsCriterion, MatchPointsCriterion}
+	assert.Equal(t, 2, rules.PrimaryCriterionValue(&fields))
+	maxValue, ok = rules.MaxPrimaryCriterionPerMatch(true, false, false)
+	assert.True(t, ok)
+	assert.Equal(t, 1, maxValue)
+	maxValue, _ = rules.MaxPrimaryCriterionPerMatch(false, true, true)
+	assert.Equal(t, 0, maxValue)
+
+	// Scoring criteria have no upper limit.
+	rules.Criteria = []RankingCriterion{MatchPointsCriterion}
+	assert.Equal(t, 300, rules.PrimaryCriterionValue(&fields))
+	_, ok = rules.MaxPrimaryCriterionPerMatch(true, true, true)
+	assert.False(t, ok)
+}
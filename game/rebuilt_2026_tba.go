@@ -0,0 +1,136 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// The Blue Alliance score breakdown format for the 2026 game.
+
+package game
+
+import (
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+)
+
+type rebuilt2026TbaBreakdown struct {
+	AutoTowerRobot1      string `mapstructure:"autoTowerRobot1"`
+	AutoTowerRobot2      string `mapstructure:"autoTowerRobot2"`
+	AutoTowerRobot3      string `mapstructure:"autoTowerRobot3"`
+	AutoTowerPoints      int    `mapstructure:"autoTowerPoints"`
+	HubScore             tbaHub `mapstructure:"hubScore"`
+	EndGameTowerRobot1   string `mapstructure:"endGameTowerRobot1"`
+	EndGameTowerRobot2   string `mapstructure:"endGameTowerRobot2"`
+	EndGameTowerRobot3   string `mapstructure:"endGameTowerRobot3"`
+	EndGameTowerPoints   int    `mapstructure:"endGameTowerPoints"`
+	MinorFoulCount       int    `mapstructure:"minorFoulCount"`
+	MajorFoulCount       int    `mapstructure:"majorFoulCount"`
+	FoulPoints           int    `mapstructure:"foulPoints"`
+	TotalTowerPoints     int    `mapstructure:"totalTowerPoints"`
+	TotalAutoPoints      int    `mapstructure:"totalAutoPoints"`
+	TotalTeleopPoints    int    `mapstructure:"totalTeleopPoints"`
+	TotalPoints          int    `mapstructure:"totalPoints"`
+	EnergizedAchieved    bool   `mapstructure:"energizedAchieved"`
+	SuperchargedAchieved bool   `mapstructure:"superchargedAchieved"`
+	TraversalAchieved    bool   `mapstructure:"traversalAchieved"`
+	G206Penalty          bool   `mapstructure:"g206Penalty"`
+	RP                   int    `mapstructure:"rp"`
+}
+
+type tbaHub struct {
+	AutoCount        int `mapstructure:"autoCount"`
+	AutoPoints       int `mapstructure:"autoPoints"`
+	TransitionCount  int `mapstructure:"transitionCount"`
+	TransitionPoints int `mapstructure:"transitionPoints"`
+	Shift1Count      int `mapstructure:"shift1Count"`
+	Shift1Points     int `mapstructure:"shift1Points"`
+	Shift2Count      int `mapstructure:"shift2Count"`
+	Shift2Points     int `mapstructure:"shift2Points"`
+	Shift3Count      int `mapstructure:"shift3Count"`
+	Shift3Points     int `mapstructure:"shift3Points"`
+	Shift4Count      int `mapstructure:"shift4Count"`
+	Shift4Points     int `mapstructure:"shift4Points"`
+	EndgameCount     int `mapstructure:"endgameCount"`
+	EndgamePoints    int `mapstructure:"endgamePoints"`
+	TeleopCount      int `mapstructure:"teleopCount"`
+	TeleopPoints     int `mapstructure:"teleopPoints"`
+	TotalCount       int `mapstructure:"totalCount"`
+	TotalPoints      int `mapstructure:"totalPoints"`
+}
+
+var towerStatusMapping = map[TowerStatus]string{
+	TowerNone:   "None",
+	TowerLevel1: "Level1",
+	TowerLevel2: "Level2",
+	TowerLevel3: "Level3",
+}
+
+func (rebuilt Rebuilt2026) TbaScoreBreakdown(
+	allianceScore AllianceScore, scoreSummary, _ *ScoreSummary, rankingPoints int,
+) (map[string]any, error) {
+	score, ok := allianceScore.(*Score)
+	if !ok {
+		return nil, fmt.Errorf("%s can't build a TBA breakdown from a score of type %T", rebuilt.Name(), allianceScore)
+	}
+	var breakdown rebuilt2026TbaBreakdown
+	breakdown.AutoTowerRobot1 = towerStatusMapping[score.AutoTowerStatuses[0]]
+	breakdown.AutoTowerRobot2 = towerStatusMapping[score.AutoTowerStatuses[1]]
+	breakdown.AutoTowerRobot3 = towerStatusMapping[score.AutoTowerStatuses[2]]
+	breakdown.AutoTowerPoints = scoreSummary.AutoTowerPoints
+	breakdown.HubScore.AutoCount = score.Hub.GetShiftCount(ShiftAuto, true)
+	breakdown.HubScore.AutoPoints = score.Hub.GetShiftCount(ShiftAuto, true)
+	breakdown.HubScore.TransitionCount = score.Hub.GetShiftCount(ShiftTransition, true)
+	breakdown.HubScore.TransitionPoints = score.Hub.GetShiftCount(ShiftTransition, true)
+	breakdown.HubScore.Shift1Count = score.Hub.GetShiftCount(Shift1, true)
+	breakdown.HubScore.Shift1Points = score.Hub.GetShiftCount(Shift1, true)
+	breakdown.HubScore.Shift2Count = score.Hub.GetShiftCount(Shift2, true)
+	breakdown.HubScore.Shift2Points = score.Hub.GetShiftCount(Shift2, true)
+	breakdown.HubScore.Shift3Count = score.Hub.GetShiftCount(Shift3, true)
+	breakdown.HubScore.Shift3Points = score.Hub.GetShiftCount(Shift3, true)
+	breakdown.HubScore.Shift4Count = score.Hub.GetShiftCount(Shift4, true)
+	breakdown.HubScore.Shift4Points = score.Hub.GetShiftCount(Shift4, true)
+	breakdown.HubScore.EndgameCount = score.Hub.GetShiftCount(ShiftEndgame, true) +
+		score.Hub.GetShiftCount(ShiftPostMatch, true)
+	breakdown.HubScore.EndgamePoints = score.Hub.GetShiftCount(ShiftEndgame, true) +
+		score.Hub.GetShiftCount(ShiftPostMatch, true)
+	breakdown.HubScore.TeleopCount = breakdown.HubScore.TransitionCount + breakdown.HubScore.Shift1Count +
+		breakdown.HubScore.Shift2Count + breakdown.HubScore.Shift3Count + breakdown.HubScore.Shift4Count +
+		breakdown.HubScore.EndgameCount
+	breakdown.HubScore.TeleopPoints = score.Hub.GetTeleopActiveFuelCount()
+	breakdown.HubScore.TotalCount = breakdown.HubScore.AutoCount + breakdown.HubScore.TeleopCount
+	breakdown.HubScore.TotalPoints = breakdown.HubScore.AutoPoints + breakdown.HubScore.TeleopPoints
+	breakdown.EndGameTowerRobot1 = towerStatusMapping[score.EndgameTowerStatuses[0]]
+	breakdown.EndGameTowerRobot2 = towerStatusMapping[score.EndgameTowerStatuses[1]]
+	breakdown.EndGameTowerRobot3 = towerStatusMapping[score.EndgameTowerStatuses[2]]
+	breakdown.EndGameTowerPoints = scoreSummary.TeleopTowerPoints
+	breakdown.TotalTowerPoints = scoreSummary.AutoTowerPoints + scoreSummary.TeleopTowerPoints
+	breakdown.TotalAutoPoints = scoreSummary.AutoFuelPoints + scoreSummary.AutoTowerPoints
+	breakdown.TotalTeleopPoints = scoreSummary.TeleopFuelPoints + scoreSummary.TeleopTowerPoints
+	breakdown.EnergizedAchieved = scoreSummary.EnergizedBonusRankingPoint
+	breakdown.SuperchargedAchieved = scoreSummary.SuperchargedBonusRankingPoint
+	breakdown.TraversalAchieved = scoreSummary.TraversalBonusRankingPoint
+
+	for _, foul := range score.Fouls {
+		if foul.IsMajor {
+			breakdown.MajorFoulCount++
+		} else if rebuilt.FoulPointValue(&foul) > 0 {
+			breakdown.MinorFoulCount++
+		}
+		if foul.Rule() != nil && foul.Rule().IsRankingPoint {
+			switch foul.Rule().RuleNumber {
+			case "G206":
+				breakdown.G206Penalty = true
+			}
+		}
+	}
+	breakdown.FoulPoints = scoreSummary.FoulPoints
+	breakdown.TotalPoints = scoreSummary.Score
+
+	breakdown.RP = rankingPoints
+
+	// Turn the breakdown struct into a map in order to be able to remove any fields that are disabled based on the
+	// event settings (none in 2026).
+	breakdownMap := make(map[string]any)
+	if err := mapstructure.Decode(breakdown, &breakdownMap); err != nil {
+		return nil, err
+	}
+
+	return breakdownMap, nil
+}
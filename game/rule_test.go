@@ -10,15 +10,15 @@ import (
 
 func TestGetRuleById(t *testing.T) {
 	assert.Nil(t, GetRuleById(0))
-	assert.Equal(t, rules[0], GetRuleById(1))
-	assert.Equal(t, rules[20], GetRuleById(21))
+	assert.Equal(t, rebuilt2026Rules[0], GetRuleById(1))
+	assert.Equal(t, rebuilt2026Rules[20], GetRuleById(21))
 	assert.Nil(t, GetRuleById(1000))
 }
 
 func TestGetAllRules(t *testing.T) {
 	allRules := GetAllRules()
-	assert.Equal(t, len(rules), len(allRules))
-	for _, rule := range rules {
+	assert.Equal(t, len(rebuilt2026Rules), len(allRules))
+	for _, rule := range rebuilt2026Rules {
 		assert.Equal(t, rule, allRules[rule.Id])
 	}
 }
@@ -28,93 +28,13 @@ const (
 	TowerLevel3
 )
 
-// Summarize calculates and returns the summary fields used for ranking and display.
+// Summarize calculates and returns the summary fields used for ranking and display, using the rules of the current
+// game. Returns a blank summary if the current game doesn't use this score format, which the event settings prevent.
 func (score *Score) Summarize(opponentScore *Score) *ScoreSummary {
-	summary := new(ScoreSummary)
-	summary.PlayoffDq = score.PlayoffDq
-
-	// Leave the score at zero if the alliance was disqualified.
-	if score.PlayoffDq {
-		return summary
-	}
-
-	// Calculate autonomous period points.
-	summary.AutoFuelPoints = score.Hub.GetShiftCount(ShiftAuto, true)
-	summary.NumFuel += summary.AutoFuelPoints
-	numAutoTowerRobots := 0
-	for _, status := range score.AutoTowerStatuses {
-		if status == TowerLevel1 || status == TowerLevel2 || status == TowerLevel3 {
-			summary.AutoTowerPoints += 15
-			numAutoTowerRobots++
-			if numAutoTowerRobots == 2 {
-				break
-			}
-		}
-	}
-
-	// Calculate teleoperated period points.
-	summary.TeleopFuelPoints = score.Hub.GetTeleopActiveFuelCount()
-	summary.NumFuelPostMatch = score.Hub.GetShiftCount(ShiftPostMatch, true)
-	summary.NumFuel += summary.TeleopFuelPoints
-	for _, status := range score.EndgameTowerStatuses {
-		switch status {
-		case TowerLevel1:
-			summary.TeleopTowerPoints += 10
-		case TowerLevel2:
-			summary.TeleopTowerPoints += 20
-		case TowerLevel3:
-			summary.TeleopTowerPoints += 30
-		default:
-		}
-	}
-
-	summary.MatchPoints = summary.AutoFuelPoints + summary.AutoTowerPoints +
-		summary.TeleopFuelPoints + summary.TeleopTowerPoints
-	summary.PostMatchPoints = summary.TeleopTowerPoints + summary.NumFuelPostMatch
-
-	// Calculate penalty points.
-	for _, foul := range opponentScore.Fouls {
-		summary.FoulPoints += foul.PointValue()
-		// Store the number of major fouls since it is used to break ties in playoffs.
-		if foul.IsMajor {
-			summary.NumOpponentMajorFouls++
-		}
+	summary, err := CurrentGame.Summarize(score, opponentScore)
+	if err != nil {
+		return new(ScoreSummary)
 	}
-
-	summary.Score = summary.MatchPoints + summary.FoulPoints
-
-	// Fuel bonus ranking points.
-	summary.NumFuelGoal = EnergizedBonusThreshold
-	if summary.NumFuel >= EnergizedBonusThreshold {
-		summary.EnergizedBonusRankingPoint = true
-		summary.NumFuelGoal = SuperchargedBonusThreshold
-	}
-	summary.SuperchargedBonusRankingPoint = summary.NumFuel >= SuperchargedBonusThreshold
-
-	// Tower bonus ranking point.
-	summary.TraversalBonusRankingPoint = summary.AutoTowerPoints+summary.TeleopTowerPoints >= TraversalBonusThreshold
-
-	// Check for G206 violation.
-	for _, foul := range score.Fouls {
-		if foul.Rule() != nil && foul.Rule().RuleNumber == "G206" {
-			summary.EnergizedBonusRankingPoint = false
-			summary.SuperchargedBonusRankingPoint = false
-			summary.TraversalBonusRankingPoint = false
-			break
-		}
-	}
-
-	// Add up the bonus ranking points.
-	if summary.EnergizedBonusRankingPoint {
-		summary.BonusRankingPoints++
-	}
-	if summary.SuperchargedBonusRankingPoint {
-		summary.BonusRankingPoints++
-	}
-	if summary.TraversalBonusRankingPoint {
-		summary.BonusRankingPoints++
-	}
-
 	return summary
 }
 
@@ -45,38 +45,7 @@ func DetermineMatchStatus(
 	redScoreSummary, blueScoreSummary *ScoreSummary,
 	applyPlayoffTiebreakers bool,
 ) (MatchStatus, string) {
-	if redScoreSummary.PlayoffDq != blueScoreSummary.PlayoffDq {
-		if redScoreSummary.PlayoffDq {
-			return BlueWonMatch, ""
-		}
-		return RedWonMatch, ""
-	}
-
-	if status := comparePoints(redScoreSummary.Score, blueScoreSummary.Score); status != TieMatch {
-		return status, ""
-	}
-
-	if applyPlayoffTiebreakers {
-		// Check scoring breakdowns to resolve playoff ties.
-		if status := comparePoints(
-			redScoreSummary.NumOpponentMajorFouls, blueScoreSummary.NumOpponentMajorFouls,
-		); status != TieMatch {
-			return status, "TIEBREAK: MAJOR FOULS"
-		}
-		status := comparePoints(redScoreSummary.AutoFuelPoints, blueScoreSummary.AutoFuelPoints)
-		if status != TieMatch {
-			return status, "TIEBREAK: AUTO FUEL"
-		}
-		if status = comparePoints(
-			redScoreSummary.AutoTowerPoints+redScoreSummary.TeleopTowerPoints,
-			blueScoreSummary.AutoTowerPoints+blueScoreSummary.TeleopTowerPoints,
-		); status != TieMatch {
-			return status, "TIEBREAK: TOWER POINTS"
-		}
-		return TieMatch, "TRUE TIE"
-	}
-
-	return TieMatch, ""
+	return CurrentGame.DetermineMatchStatus(redScoreSummary, blueScoreSummary, applyPlayoffTiebreakers)
 }
 
 // Helper method to compare the red and blue alliance point totals and return the appropriate MatchStatus.
@@ -9,6 +9,7 @@ import (
 	"github.com/Team254/cheesy-arena/network"
 	"github.com/Team254/cheesy-arena/web"
 	"log"
+	"strings"
 )
 
 const eventDbPath = "./event.db"
@@ -17,11 +18,33 @@ const httpPort = 8080
 // Main entry point for the application.
 func main() {
 	flag.BoolVar(&network.DevMode, "dev", false, "Bind driver station listeners to all IP addresses for development")
+	standbyPrimaryUrl := flag.String(
+		"standby_primary_url", "", "Run as a hot standby mirroring the primary server at this URL until promoted",
+	)
+	standbyPassword := flag.String("standby_password", "", "Admin password of the primary server")
 	flag.Parse()
 
-	arena, err := field.NewArena(eventDbPath)
-	if err != nil {
-		log.Fatalln("Error during startup: ", err)
+	var arena *field.Arena
+	var err error
+	if *standbyPrimaryUrl != "" {
+		// A standby creates the arenas for any additional fields only once it is promoted.
+		arena, err = field.NewStandbyArena(
+			eventDbPath, strings.TrimSuffix(*standbyPrimaryUrl, "/"), *standbyPassword,
+		)
+		if err != nil {
+			log.Fatalln("Error during startup: ", err)
+		}
+		log.Printf("Running as a hot standby for %s.", *standbyPrimaryUrl)
+	} else {
+		arena, err = field.NewArena(eventDbPath)
+		if err != nil {
+			log.Fatalln("Error during startup: ", err)
+		}
+
+		// Any additional fields are run alongside the first one.
+		if err = arena.CreateFieldArenas(); err != nil {
+			log.Fatalln("Error during startup: ", err)
+		}
 	}
 
 	// Start the web server in a separate goroutine.
@@ -5,12 +5,19 @@
 
 package model
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+	"time"
+)
 
 type Alliance struct {
-	Id      int `db:"id,manual"`
-	TeamIds []int
-	Lineup  [3]int
+	Id             int `db:"id,manual"`
+	TeamIds        []int
+	Lineup         [3]int
+	BackupTeamId   int
+	ReplacedTeamId int
+	BackupCalledAt time.Time
 }
 
 type AllianceSelectionRankedTeam struct {
@@ -53,6 +60,57 @@ func (database *Database) GetAllAlliances() ([]Alliance, error) {
 	return alliances, nil
 }
 
+// Records that the given backup team has been called up to replace the given member of the alliance, swapping it into
+// the alliance's lineup if the replaced team was in it. An alliance may only call up a single backup.
+func (alliance *Alliance) CallUpBackup(backupTeamId, replacedTeamId int, calledAt time.Time) error {
+	if alliance.BackupTeamId != 0 {
+		return fmt.Errorf("Alliance %d has already called up backup team %d.", alliance.Id, alliance.BackupTeamId)
+	}
+	if !alliance.hasTeam(replacedTeamId) {
+		return fmt.Errorf("Team %d is not a member of alliance %d.", replacedTeamId, alliance.Id)
+	}
+	if alliance.hasTeam(backupTeamId) {
+		return fmt.Errorf("Team %d is already a member of alliance %d.", backupTeamId, alliance.Id)
+	}
+
+	alliance.BackupTeamId = backupTeamId
+	alliance.ReplacedTeamId = replacedTeamId
+	alliance.BackupCalledAt = calledAt
+	for i, teamId := range alliance.Lineup {
+		if teamId == replacedTeamId {
+			alliance.Lineup[i] = backupTeamId
+		}
+	}
+	return nil
+}
+
+// Returns the IDs of the teams that are still eligible to play for the alliance, with any called-up backup team taking
+// the place of the team it replaced.
+func (alliance *Alliance) ActiveTeamIds() []int {
+	teamIds := make([]int, 0, len(alliance.TeamIds))
+	for _, teamId := range alliance.TeamIds {
+		if alliance.BackupTeamId != 0 && teamId == alliance.ReplacedTeamId {
+			teamId = alliance.BackupTeamId
+		}
+		teamIds = append(teamIds, teamId)
+	}
+	return teamIds
+}
+
+// Returns true if the given team has been called up as the alliance's backup.
+func (alliance *Alliance) IsBackup(teamId int) bool {
+	return teamId != 0 && teamId == alliance.BackupTeamId
+}
+
+func (alliance *Alliance) hasTeam(teamId int) bool {
+	for _, allianceTeamId := range alliance.TeamIds {
+		if teamId == allianceTeamId {
+			return true
+		}
+	}
+	return false
+}
+
 // Updates the alliance, if necessary, to include whoever played in the match, in case there was a substitute.
 func (database *Database) UpdateAllianceFromMatch(allianceId int, matchTeamIds [3]int) error {
 	alliance, err := database.GetAllianceById(allianceId)
@@ -67,7 +125,7 @@ func (database *Database) UpdateAllianceFromMatch(allianceId int, matchTeamIds [
 	}
 
 	for _, teamId := range matchTeamIds {
-		found := false
+		found := alliance.IsBackup(teamId)
 		for _, allianceTeamId := range alliance.TeamIds {
 			if teamId == allianceTeamId {
 				found = true
@@ -117,7 +175,7 @@ func (database *Database) getOffFieldTeamIdsForAlliance(allianceId int, teamId1,
 		return nil, err
 	}
 	offFieldTeamIds := []int{}
-	for _, allianceTeamId := range alliance.TeamIds {
+	for _, allianceTeamId := range alliance.ActiveTeamIds() {
 		if allianceTeamId != teamId1 && allianceTeamId != teamId2 && allianceTeamId != teamId3 {
 			offFieldTeamIds = append(offFieldTeamIds, allianceTeamId)
 		}
@@ -6,6 +6,7 @@ package model
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestGetNonexistentAlliance(t *testing.T) {
@@ -52,6 +53,57 @@ func TestUpdateAllianceFromMatch(t *testing.T) {
 	assert.Equal(t, [3]int{1503, 188, 296}, alliance2.Lineup)
 }
 
+func TestUpdateAllianceFromMatchWithBackup(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	alliance := Alliance{Id: 3, TeamIds: []int{254, 1114, 296}, Lineup: [3]int{1114, 254, 296}}
+	assert.Nil(t, alliance.CallUpBackup(188, 254, time.Unix(1000, 0)))
+	assert.Nil(t, db.CreateAlliance(&alliance))
+	assert.Nil(t, db.UpdateAllianceFromMatch(3, [3]int{296, 188, 1114}))
+	alliance2, err := db.GetAllianceById(3)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{254, 1114, 296}, alliance2.TeamIds)
+	assert.Equal(t, [3]int{296, 188, 1114}, alliance2.Lineup)
+	assert.Equal(t, 188, alliance2.BackupTeamId)
+	assert.Equal(t, 254, alliance2.ReplacedTeamId)
+}
+
+func TestAllianceCallUpBackup(t *testing.T) {
+	alliance := Alliance{Id: 2, TeamIds: []int{254, 1114, 296, 1503}, Lineup: [3]int{1114, 254, 296}}
+	assert.False(t, alliance.IsBackup(188))
+
+	err := alliance.CallUpBackup(188, 148, time.Unix(1000, 0))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Team 148 is not a member of alliance 2.", err.Error())
+	}
+	err = alliance.CallUpBackup(1503, 254, time.Unix(1000, 0))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Team 1503 is already a member of alliance 2.", err.Error())
+	}
+	assert.Equal(t, 0, alliance.BackupTeamId)
+
+	assert.Nil(t, alliance.CallUpBackup(188, 254, time.Unix(1000, 0)))
+	assert.Equal(t, 188, alliance.BackupTeamId)
+	assert.Equal(t, 254, alliance.ReplacedTeamId)
+	assert.Equal(t, time.Unix(1000, 0), alliance.BackupCalledAt)
+	assert.Equal(t, [3]int{1114, 188, 296}, alliance.Lineup)
+	assert.Equal(t, []int{254, 1114, 296, 1503}, alliance.TeamIds)
+	assert.Equal(t, []int{188, 1114, 296, 1503}, alliance.ActiveTeamIds())
+	assert.True(t, alliance.IsBackup(188))
+	assert.False(t, alliance.IsBackup(254))
+
+	err = alliance.CallUpBackup(148, 1114, time.Unix(2000, 0))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Alliance 2 has already called up backup team 188.", err.Error())
+	}
+
+	// Calling up a backup for a team that isn't in the current lineup leaves the lineup alone.
+	alliance = Alliance{Id: 2, TeamIds: []int{254, 1114, 296, 1503}, Lineup: [3]int{1114, 254, 296}}
+	assert.Nil(t, alliance.CallUpBackup(188, 1503, time.Unix(1000, 0)))
+	assert.Equal(t, [3]int{1114, 254, 296}, alliance.Lineup)
+}
+
 func TestTruncateAllianceTeams(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
@@ -132,4 +184,12 @@ func TestGetOffFieldTeamIds(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, []int{}, redOffFieldTeams)
 	assert.Equal(t, []int{254, 469}, blueOffFieldTeams)
+
+	// A called-up backup takes the place of the team it replaced.
+	alliance, _ := db.GetAllianceById(1)
+	assert.Nil(t, alliance.CallUpBackup(188, 254, time.Unix(1000, 0)))
+	assert.Nil(t, db.UpdateAlliance(alliance))
+	_, blueOffFieldTeams, err = db.GetOffFieldTeamIds(match)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{188, 469}, blueOffFieldTeams)
 }
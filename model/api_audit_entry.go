@@ -0,0 +1,36 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the record of a request made to the match control API.
+
+package model
+
+import "time"
+
+// Number of the most recent audit entries to keep, so that a flood of requests can't grow the database without bound.
+const maxApiAuditEntries = 1000
+
+type ApiAuditEntry struct {
+	Id         int `db:"id"`
+	Time       time.Time
+	TokenId    int
+	TokenName  string
+	IpAddress  string
+	Command    string
+	Arguments  string
+	StatusCode int
+	Error      string
+}
+
+func (database *Database) CreateApiAuditEntry(entry *ApiAuditEntry) error {
+	return database.apiAuditEntryTable.create(entry)
+}
+
+// Returns up to the given number of audit entries, with the most recent first.
+func (database *Database) GetRecentApiAuditEntries(limit int) ([]ApiAuditEntry, error) {
+	return database.apiAuditEntryTable.getRecent(limit)
+}
+
+func (database *Database) TruncateApiAuditEntries() error {
+	return database.apiAuditEntryTable.truncate()
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetRecentApiAuditEntries(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	entries, err := db.GetRecentApiAuditEntries(10)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+
+	for i := 1; i <= 5; i++ {
+		entry := ApiAuditEntry{
+			Time:       time.Unix(int64(i), 0),
+			TokenId:    1,
+			TokenName:  "Stream Deck",
+			Command:    fmt.Sprintf("command%d", i),
+			StatusCode: 200,
+		}
+		assert.Nil(t, db.CreateApiAuditEntry(&entry))
+	}
+	entries, err = db.GetRecentApiAuditEntries(3)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(entries)) {
+		assert.Equal(t, "command5", entries[0].Command)
+		assert.Equal(t, "command3", entries[2].Command)
+	}
+
+	// Check that only the most recent entries are kept.
+	for i := 6; i <= maxApiAuditEntries+2; i++ {
+		assert.Nil(t, db.CreateApiAuditEntry(&ApiAuditEntry{Command: fmt.Sprintf("command%d", i)}))
+	}
+	entries, err = db.GetRecentApiAuditEntries(2 * maxApiAuditEntries)
+	assert.Nil(t, err)
+	if assert.Equal(t, maxApiAuditEntries, len(entries)) {
+		assert.Equal(t, fmt.Sprintf("command%d", maxApiAuditEntries+2), entries[0].Command)
+		assert.Equal(t, "command3", entries[maxApiAuditEntries-1].Command)
+	}
+
+	assert.Nil(t, db.TruncateApiAuditEntries())
+	entries, err = db.GetRecentApiAuditEntries(3)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
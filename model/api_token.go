@@ -0,0 +1,75 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for a token granting an external tool access to the match control API.
+
+package model
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"sort"
+	"time"
+)
+
+type ApiToken struct {
+	Id         int `db:"id"`
+	Name       string
+	TokenHash  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// Returns the hash under which the given token is stored; the token itself is only shown once, upon creation.
+func HashApiToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func (database *Database) CreateApiToken(apiToken *ApiToken) error {
+	return database.apiTokenTable.create(apiToken)
+}
+
+func (database *Database) GetApiTokenById(id int) (*ApiToken, error) {
+	return database.apiTokenTable.getById(id)
+}
+
+// Returns the API token matching the given plaintext token, or nil if there is none.
+func (database *Database) GetApiTokenByToken(token string) (*ApiToken, error) {
+	apiTokens, err := database.apiTokenTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenHash := []byte(HashApiToken(token))
+	for _, apiToken := range apiTokens {
+		if subtle.ConstantTimeCompare([]byte(apiToken.TokenHash), tokenHash) == 1 {
+			return &apiToken, nil
+		}
+	}
+	return nil, nil
+}
+
+func (database *Database) UpdateApiToken(apiToken *ApiToken) error {
+	return database.apiTokenTable.update(apiToken)
+}
+
+func (database *Database) DeleteApiToken(id int) error {
+	return database.apiTokenTable.delete(id)
+}
+
+// Returns all API tokens, with the most recently created first.
+func (database *Database) GetAllApiTokens() ([]ApiToken, error) {
+	apiTokens, err := database.apiTokenTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		apiTokens,
+		func(i, j int) bool {
+			return apiTokens[i].CreatedAt.After(apiTokens[j].CreatedAt)
+		},
+	)
+	return apiTokens, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetNonexistentApiToken(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	apiToken, err := db.GetApiTokenById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, apiToken)
+	apiToken, err = db.GetApiTokenByToken("blorpy")
+	assert.Nil(t, err)
+	assert.Nil(t, apiToken)
+}
+
+func TestApiTokenCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	apiToken := ApiToken{Name: "Stream Deck", TokenHash: HashApiToken("secret1"), CreatedAt: time.Unix(1000, 0)}
+	assert.Nil(t, db.CreateApiToken(&apiToken))
+	assert.NotEqual(t, "secret1", apiToken.TokenHash)
+	apiToken2, err := db.GetApiTokenByToken("secret1")
+	assert.Nil(t, err)
+	if assert.NotNil(t, apiToken2) {
+		assert.Equal(t, apiToken.Id, apiToken2.Id)
+		assert.Equal(t, "Stream Deck", apiToken2.Name)
+	}
+	apiToken2, err = db.GetApiTokenByToken(apiToken.TokenHash)
+	assert.Nil(t, err)
+	assert.Nil(t, apiToken2)
+
+	apiToken.LastUsedAt = time.Unix(2000, 0)
+	assert.Nil(t, db.UpdateApiToken(&apiToken))
+	apiToken2, _ = db.GetApiTokenById(apiToken.Id)
+	assert.True(t, apiToken2.LastUsedAt.Equal(time.Unix(2000, 0)))
+
+	assert.Nil(t, db.DeleteApiToken(apiToken.Id))
+	apiToken2, err = db.GetApiTokenByToken("secret1")
+	assert.Nil(t, err)
+	assert.Nil(t, apiToken2)
+}
+
+func TestGetAllApiTokens(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	apiTokens, err := db.GetAllApiTokens()
+	assert.Nil(t, err)
+	assert.Empty(t, apiTokens)
+
+	db.CreateApiToken(&ApiToken{Name: "Older", TokenHash: HashApiToken("a"), CreatedAt: time.Unix(1000, 0)})
+	db.CreateApiToken(&ApiToken{Name: "Newer", TokenHash: HashApiToken("b"), CreatedAt: time.Unix(2000, 0)})
+	apiTokens, err = db.GetAllApiTokens()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(apiTokens)) {
+		assert.Equal(t, "Newer", apiTokens[0].Name)
+		assert.Equal(t, "Older", apiTokens[1].Name)
+	}
+}
@@ -13,6 +13,9 @@ type Award struct {
 	AwardName  string
 	TeamId     int
 	PersonName string
+
+	// Whether the award has been announced to the audience or published to The Blue Alliance, and so is public.
+	Revealed bool
 }
 
 type AwardType int
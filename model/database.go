@@ -21,21 +21,30 @@ const backupsDir = "db/backups"
 var BaseDir = "." // Mutable for testing
 
 type Database struct {
-	Path                string
-	bolt                *bbolt.DB
-	allianceTable       *table[Alliance]
-	awardTable          *table[Award]
-	eventSettingsTable  *table[EventSettings]
-	judgingSlotTable    *table[JudgingSlot]
-	lowerThirdTable     *table[LowerThird]
-	matchTable          *table[Match]
-	matchResultTable    *table[MatchResult]
-	rankingTable        *table[game.Ranking]
-	scheduleBlockTable  *table[ScheduleBlock]
-	scheduledBreakTable *table[ScheduledBreak]
-	sponsorSlideTable   *table[SponsorSlide]
-	teamTable           *table[Team]
-	userSessionTable    *table[UserSession]
+	Path                 string
+	Division             int // Field number of the division this is a view of, or zero for the event as a whole.
+	bolt                 *bbolt.DB
+	journal              *journal
+	allianceTable        *table[Alliance]
+	apiAuditEntryTable   *table[ApiAuditEntry]
+	apiTokenTable        *table[ApiToken]
+	awardTable           *table[Award]
+	eventSettingsTable   *table[EventSettings]
+	fieldSettingsTable   *table[FieldSettings]
+	judgingSlotTable     *table[JudgingSlot]
+	lowerThirdTable      *table[LowerThird]
+	matchTable           *table[Match]
+	matchResultTable     *table[MatchResult]
+	rankingTable         *table[game.Ranking]
+	scheduleBlockTable   *table[ScheduleBlock]
+	scheduledBreakTable  *table[ScheduledBreak]
+	scoreEventTable      *table[ScoreEvent]
+	sponsorSlideTable    *table[SponsorSlide]
+	teamTable            *table[Team]
+	userTable            *table[User]
+	userSessionTable     *table[UserSession]
+	webhookTable         *table[Webhook]
+	webhookDeliveryTable *table[WebhookDelivery]
 }
 
 // Opens the Bolt database at the given path, creating it if it doesn't exist.
@@ -47,16 +56,32 @@ func OpenDatabase(filename string) (*Database, error) {
 		return nil, err
 	}
 
+	// Writes are journaled and flushed individually since Bolt itself isn't syncing them to disk.
+	if database.journal, err = openJournal(JournalPath(database.Path)); err != nil {
+		database.bolt.Close()
+		return nil, err
+	}
+
 	// Register tables.
 	if database.allianceTable, err = newTable[Alliance](&database); err != nil {
 		return nil, err
 	}
+	if database.apiAuditEntryTable, err = newTable[ApiAuditEntry](&database); err != nil {
+		return nil, err
+	}
+	database.apiAuditEntryTable.maxRecords = maxApiAuditEntries
+	if database.apiTokenTable, err = newTable[ApiToken](&database); err != nil {
+		return nil, err
+	}
 	if database.awardTable, err = newTable[Award](&database); err != nil {
 		return nil, err
 	}
 	if database.eventSettingsTable, err = newTable[EventSettings](&database); err != nil {
 		return nil, err
 	}
+	if database.fieldSettingsTable, err = newTable[FieldSettings](&database); err != nil {
+		return nil, err
+	}
 	if database.judgingSlotTable, err = newTable[JudgingSlot](&database); err != nil {
 		return nil, err
 	}
@@ -78,20 +103,80 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.scheduledBreakTable, err = newTable[ScheduledBreak](&database); err != nil {
 		return nil, err
 	}
+	if database.scoreEventTable, err = newTable[ScoreEvent](&database); err != nil {
+		return nil, err
+	}
 	if database.sponsorSlideTable, err = newTable[SponsorSlide](&database); err != nil {
 		return nil, err
 	}
 	if database.teamTable, err = newTable[Team](&database); err != nil {
 		return nil, err
 	}
+	if database.userTable, err = newTable[User](&database); err != nil {
+		return nil, err
+	}
 	if database.userSessionTable, err = newTable[UserSession](&database); err != nil {
 		return nil, err
 	}
+	if database.webhookTable, err = newTable[Webhook](&database); err != nil {
+		return nil, err
+	}
+	if database.webhookDeliveryTable, err = newTable[WebhookDelivery](&database); err != nil {
+		return nil, err
+	}
+	database.webhookDeliveryTable.maxRecords = maxWebhookDeliveries
 
 	return &database, nil
 }
 
+// Returns a view of the database for the division played on the given field, which has its own teams, schedule,
+// results, rankings, alliances, awards and judging schedule but shares the event settings, user accounts and other
+// event-wide data. The view is stored in the same file as the rest of the event so that it is backed up, journaled and mirrored
+// along with it, and it must not be closed separately.
+func (database *Database) DivisionDatabase(fieldNumber int) (*Database, error) {
+	divisionDatabase := *database
+	divisionDatabase.Division = fieldNumber
+	var err error
+	if divisionDatabase.allianceTable, err = newTable[Alliance](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.awardTable, err = newTable[Award](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.judgingSlotTable, err = newTable[JudgingSlot](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.lowerThirdTable, err = newTable[LowerThird](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.matchTable, err = newTable[Match](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.matchResultTable, err = newTable[MatchResult](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.rankingTable, err = newTable[game.Ranking](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.scheduleBlockTable, err = newTable[ScheduleBlock](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.scheduledBreakTable, err = newTable[ScheduledBreak](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.scoreEventTable, err = newTable[ScoreEvent](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	if divisionDatabase.teamTable, err = newTable[Team](&divisionDatabase); err != nil {
+		return nil, err
+	}
+	return &divisionDatabase, nil
+}
+
 func (database *Database) Close() error {
+	if err := database.journal.close(); err != nil {
+		return err
+	}
 	return database.bolt.Close()
 }
 
@@ -115,20 +200,65 @@ func (database *Database) Backup(eventName, reason string) error {
 		return err
 	}
 
-	err = database.WriteBackup(dest)
+	backupSequence, err := database.writeSnapshot(dest)
 	closeErr := dest.Close()
 	if err != nil {
 		return err
 	}
-	return closeErr
+	if closeErr != nil {
+		return closeErr
+	}
+
+	// Start a new journal now that everything written so far is covered by the backup.
+	return database.journal.rotate(backupSequence)
+}
+
+// Returns the path of the most recently modified database file in the backups directory.
+func LatestBackupPath() (string, error) {
+	backupsPath := filepath.Join(BaseDir, backupsDir)
+	dirEntries, err := os.ReadDir(backupsPath)
+	if err != nil {
+		return "", err
+	}
+
+	var latestPath string
+	var latestModTime time.Time
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || filepath.Ext(dirEntry.Name()) != ".db" {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			return "", err
+		}
+		if latestPath == "" || info.ModTime().After(latestModTime) {
+			latestPath = filepath.Join(backupsPath, dirEntry.Name())
+			latestModTime = info.ModTime()
+		}
+	}
+	if latestPath == "" {
+		return "", fmt.Errorf("no backups found in %s", backupsPath)
+	}
+	return latestPath, nil
 }
 
 // Takes a snapshot of Bolt database and writes it to the given writer.
 func (database *Database) WriteBackup(writer io.Writer) error {
-	return database.bolt.View(
+	_, err := database.writeSnapshot(writer)
+	return err
+}
+
+// Writes a snapshot of the Bolt database to the given writer and returns the journal sequence number it includes.
+func (database *Database) writeSnapshot(writer io.Writer) (int, error) {
+	sequence := 0
+	err := database.bolt.View(
 		func(tx *bbolt.Tx) error {
+			if bucket := tx.Bucket([]byte(journalBucketName)); bucket != nil {
+				sequence = getJournalSequence(bucket)
+			}
 			_, err := tx.WriteTo(writer)
 			return err
 		},
 	)
+	return sequence, err
 }
@@ -5,7 +5,10 @@ package model
 
 import (
 	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestOpenUnreachableDatabase(t *testing.T) {
@@ -13,6 +16,84 @@ func TestOpenUnreachableDatabase(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestLatestBackupPath(t *testing.T) {
+	BaseDir = t.TempDir()
+	defer func() {
+		BaseDir = ".."
+	}()
+
+	_, err := LatestBackupPath()
+	assert.NotNil(t, err)
+
+	backupsPath := filepath.Join(BaseDir, backupsDir)
+	assert.Nil(t, os.MkdirAll(backupsPath, 0755))
+	_, err = LatestBackupPath()
+	assert.EqualError(t, err, "no backups found in "+backupsPath)
+
+	for i, name := range []string{"event_2.db", "event_3.db", "event_1.db", "notes.txt"} {
+		path := filepath.Join(backupsPath, name)
+		assert.Nil(t, os.WriteFile(path, []byte{}, 0644))
+		modTime := time.Now().Add(time.Duration(i) * time.Minute)
+		if name == "event_1.db" {
+			modTime = time.Now().Add(-time.Hour)
+		}
+		assert.Nil(t, os.Chtimes(path, modTime, modTime))
+	}
+	latestPath, err := LatestBackupPath()
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(backupsPath, "event_3.db"), latestPath)
+}
+
+func TestDivisionDatabase(t *testing.T) {
+	db := setupTestDb(t)
+	division2Db, err := db.DivisionDatabase(2)
+	assert.Nil(t, err)
+	division3Db, err := db.DivisionDatabase(3)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, division2Db.Division)
+
+	// Each division has its own teams and matches.
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254}))
+	assert.Nil(t, division2Db.CreateTeam(&Team{Id: 1114}))
+	assert.Nil(t, division3Db.CreateTeam(&Team{Id: 254, Nickname: "Division 3"}))
+	teams, _ := division2Db.GetAllTeams()
+	if assert.Equal(t, 1, len(teams)) {
+		assert.Equal(t, 1114, teams[0].Id)
+	}
+	team, _ := division3Db.GetTeamById(254)
+	assert.Equal(t, "Division 3", team.Nickname)
+	team, _ = db.GetTeamById(254)
+	assert.Equal(t, "", team.Nickname)
+	match := Match{Type: Qualification, ShortName: "Q1"}
+	assert.Nil(t, division2Db.CreateMatch(&match))
+	assert.Equal(t, 1, match.Id)
+	matches, _ := db.GetMatchesByType(Qualification, true)
+	assert.Empty(t, matches)
+
+	// The event settings and user accounts are shared.
+	settings, _ := division2Db.GetEventSettings()
+	settings.Name = "Championship"
+	assert.Nil(t, division2Db.UpdateEventSettings(settings))
+	settings, _ = db.GetEventSettings()
+	assert.Equal(t, "Championship", settings.Name)
+	assert.Nil(t, db.CreateUser(&User{Username: "scorer"}))
+	users, _ := division3Db.GetAllUsers()
+	assert.Equal(t, 1, len(users))
+
+	// The divisions are journaled, and reopening the database finds their records again.
+	entries, _, err := ReadJournal(JournalPath(db.Path))
+	assert.Nil(t, err)
+	assert.Contains(t, entries[1].Table, "Division2.Team")
+	assert.Nil(t, db.Close())
+	db, err = OpenDatabase(db.Path)
+	assert.Nil(t, err)
+	division2Db, err = db.DivisionDatabase(2)
+	assert.Nil(t, err)
+	teams, _ = division2Db.GetAllTeams()
+	assert.Equal(t, 1, len(teams))
+	assert.Nil(t, db.Close())
+}
+
 func setupTestDb(t *testing.T) *Database {
 	return SetupTestDb(t)
 }
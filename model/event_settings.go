@@ -16,8 +16,13 @@ type PlayoffType int
 const (
 	DoubleEliminationPlayoff PlayoffType = iota
 	SingleEliminationPlayoff
+	RoundRobinPlayoff
+	CustomPlayoff
 )
 
+// Login session lifetime used for new events, and for events created before the lifetime was configurable.
+const DefaultSessionLifetimeHours = 72
+
 // Configured here to avoid circular import dependencies.
 var (
 	sccDefaultUpCommands = []string{
@@ -41,8 +46,12 @@ var (
 type EventSettings struct {
 	Id                               int `db:"id"`
 	Name                             string
+	GameKey                          string
 	PlayoffType                      PlayoffType
 	NumPlayoffAlliances              int
+	CustomBracketDefinition          string
+	NumFields                        int
+	DivisionsEnabled                 bool
 	SelectionRound2Order             string
 	SelectionRound3Order             string
 	SelectionShowUnpickedTeams       bool
@@ -69,8 +78,10 @@ type EventSettings struct {
 	SCCUpCommands                    string
 	SCCDownCommands                  string
 	PlcAddress                       string
+	PlcSimulated                     bool
 	LedControllerAddress             string
 	AdminPassword                    string
+	SessionLifetimeHours             int
 	TeamSignRed1Id                   int
 	TeamSignRed2Id                   int
 	TeamSignRed3Id                   int
@@ -110,6 +121,11 @@ type EventSettings struct {
 	CompanionMatchAbortPage          int
 	CompanionMatchAbortRow           int
 	CompanionMatchAbortColumn        int
+	MqttAddress                      string
+	MqttPort                         int
+	MqttUsername                     string
+	MqttPassword                     string
+	MqttTopicPrefix                  string
 	AutoDurationSec                  int
 	PauseDurationSec                 int
 	TransitionShiftDurationSec       int
@@ -118,6 +134,12 @@ type EventSettings struct {
 	EnergizedBonusThreshold          int
 	SuperchargedBonusThreshold       int
 	TraversalBonusThreshold          int
+	RankingWinPoints                 int
+	RankingTiePoints                 int
+	RankingLossPoints                int
+	RankingBonusPointsEnabled        bool
+	RankingDropLowestMatches         int
+	RankingCriteria                  string
 }
 
 func (database *Database) GetEventSettings() (*EventSettings, error) {
@@ -127,14 +149,21 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 	}
 	if len(allEventSettings) == 1 {
 		eventSettings := allEventSettings[0]
+		if eventSettings.SessionLifetimeHours <= 0 {
+			// Settings saved before the lifetime existed would otherwise leave sessions valid forever.
+			eventSettings.SessionLifetimeHours = DefaultSessionLifetimeHours
+		}
 		return &eventSettings, nil
 	}
 
 	// Database record doesn't exist yet; create it now.
+	rankingRules := game.CurrentGame.DefaultRankingRules()
 	eventSettings := EventSettings{
 		Name:                       "Untitled Event",
+		GameKey:                    game.DefaultGameKey,
 		PlayoffType:                DoubleEliminationPlayoff,
 		NumPlayoffAlliances:        8,
+		NumFields:                  1,
 		SelectionRound2Order:       "L",
 		SelectionRound3Order:       "",
 		SelectionShowUnpickedTeams: true,
@@ -143,6 +172,8 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		SCCUpCommands:              strings.Join(sccDefaultUpCommands, "\n"),
 		SCCDownCommands:            strings.Join(sccDefaultDownCommands, "\n"),
 		CompanionAddress:           "",
+		MqttTopicPrefix:            "cheesy-arena",
+		SessionLifetimeHours:       DefaultSessionLifetimeHours,
 		AutoDurationSec:            game.MatchTiming.AutoDurationSec,
 		PauseDurationSec:           game.MatchTiming.PauseDurationSec,
 		TransitionShiftDurationSec: game.MatchTiming.TransitionShiftDurationSec,
@@ -151,6 +182,12 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		EnergizedBonusThreshold:    game.EnergizedBonusThreshold,
 		SuperchargedBonusThreshold: game.SuperchargedBonusThreshold,
 		TraversalBonusThreshold:    game.TraversalBonusThreshold,
+		RankingWinPoints:           rankingRules.WinPoints,
+		RankingTiePoints:           rankingRules.TiePoints,
+		RankingLossPoints:          rankingRules.LossPoints,
+		RankingBonusPointsEnabled:  rankingRules.BonusRankingPointsEnabled,
+		RankingDropLowestMatches:   rankingRules.DropLowestMatches,
+		RankingCriteria:            rankingRules.CriteriaString(),
 	}
 
 	if err := database.eventSettingsTable.create(&eventSettings); err != nil {
@@ -162,3 +199,47 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 func (database *Database) UpdateEventSettings(eventSettings *EventSettings) error {
 	return database.eventSettingsTable.update(eventSettings)
 }
+
+// Returns the configured qualification ranking rules, or the current game's defaults if they haven't been configured
+// (e.g. for a database created before they were introduced).
+func (eventSettings *EventSettings) RankingRules() game.RankingRules {
+	criteria, err := game.ParseRankingCriteria(eventSettings.RankingCriteria)
+	if err != nil || len(criteria) == 0 {
+		return game.CurrentGame.DefaultRankingRules()
+	}
+	return game.RankingRules{
+		WinPoints:                 eventSettings.RankingWinPoints,
+		TiePoints:                 eventSettings.RankingTiePoints,
+		LossPoints:                eventSettings.RankingLossPoints,
+		BonusRankingPointsEnabled: eventSettings.RankingBonusPointsEnabled,
+		DropLowestMatches:         eventSettings.RankingDropLowestMatches,
+		Criteria:                  criteria,
+	}
+}
+
+// Returns the hardware settings for the first field, which are stored as part of the event settings.
+func (eventSettings *EventSettings) PrimaryFieldSettings() *FieldSettings {
+	return &FieldSettings{
+		Id:                   1,
+		ApAddress:            eventSettings.ApAddress,
+		ApPassword:           eventSettings.ApPassword,
+		ApChannel:            eventSettings.ApChannel,
+		SwitchAddress:        eventSettings.SwitchAddress,
+		SwitchPassword:       eventSettings.SwitchPassword,
+		RedSCCAddress:        eventSettings.RedSCCAddress,
+		BlueSCCAddress:       eventSettings.BlueSCCAddress,
+		PlcAddress:           eventSettings.PlcAddress,
+		LedControllerAddress: eventSettings.LedControllerAddress,
+		TeamSignRed1Id:       eventSettings.TeamSignRed1Id,
+		TeamSignRed2Id:       eventSettings.TeamSignRed2Id,
+		TeamSignRed3Id:       eventSettings.TeamSignRed3Id,
+		TeamSignRedTimerId:   eventSettings.TeamSignRedTimerId,
+		TeamSignBlue1Id:      eventSettings.TeamSignBlue1Id,
+		TeamSignBlue2Id:      eventSettings.TeamSignBlue2Id,
+		TeamSignBlue3Id:      eventSettings.TeamSignBlue3Id,
+		TeamSignBlueTimerId:  eventSettings.TeamSignBlueTimerId,
+		BlackmagicAddresses:  eventSettings.BlackmagicAddresses,
+		CompanionAddress:     eventSettings.CompanionAddress,
+		CompanionPort:        eventSettings.CompanionPort,
+	}
+}
@@ -9,6 +9,19 @@ import (
 	"testing"
 )
 
+func TestEventSettingsDefaultSessionLifetime(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	// Settings saved before the session lifetime existed read back with the default rather than never expiring.
+	eventSettings, _ := db.GetEventSettings()
+	eventSettings.SessionLifetimeHours = 0
+	assert.Nil(t, db.UpdateEventSettings(eventSettings))
+	eventSettings, err := db.GetEventSettings()
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultSessionLifetimeHours, eventSettings.SessionLifetimeHours)
+}
+
 func TestEventSettingsReadWrite(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
@@ -20,8 +33,10 @@ func TestEventSettingsReadWrite(t *testing.T) {
 		EventSettings{
 			Id:                         1,
 			Name:                       "Untitled Event",
+			GameKey:                    "2026",
 			PlayoffType:                DoubleEliminationPlayoff,
 			NumPlayoffAlliances:        8,
+			NumFields:                  1,
 			SelectionRound2Order:       "L",
 			SelectionRound3Order:       "",
 			SelectionShowUnpickedTeams: true,
@@ -38,8 +53,15 @@ func TestEventSettingsReadWrite(t *testing.T) {
 			EnergizedBonusThreshold:    100,
 			SuperchargedBonusThreshold: 360,
 			TraversalBonusThreshold:    50,
+			RankingWinPoints:           3,
+			RankingTiePoints:           1,
+			RankingLossPoints:          0,
+			RankingBonusPointsEnabled:  true,
+			RankingCriteria:            "RankingPoints,MatchPoints,AutoFuelPoints,TowerPoints",
 			CompanionAddress:           "",
 			CompanionPort:              0,
+			MqttTopicPrefix:            "cheesy-arena",
+			SessionLifetimeHours:       72,
 		},
 		*eventSettings,
 	)
@@ -55,3 +77,24 @@ func TestEventSettingsReadWrite(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, eventSettings, eventSettings2)
 }
+
+func TestEventSettingsRankingRules(t *testing.T) {
+	// Settings from before the ranking rules were configurable should fall back to the game's defaults.
+	eventSettings := EventSettings{}
+	assert.Equal(t, game.CurrentGame.DefaultRankingRules(), eventSettings.RankingRules())
+
+	eventSettings.RankingWinPoints = 2
+	eventSettings.RankingTiePoints = 1
+	eventSettings.RankingDropLowestMatches = 1
+	eventSettings.RankingCriteria = "MatchPoints,Wins"
+	assert.Equal(
+		t,
+		game.RankingRules{
+			WinPoints:         2,
+			TiePoints:         1,
+			DropLowestMatches: 1,
+			Criteria:          []game.RankingCriterion{game.MatchPointsCriterion, game.WinsCriterion},
+		},
+		eventSettings.RankingRules(),
+	)
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the hardware configuration of an additional competition field.
+
+package model
+
+import "sort"
+
+type FieldSettings struct {
+	Id                   int `db:"id,manual"`
+	DriverStationAddress string
+	ApAddress            string
+	ApPassword           string
+	ApChannel            int
+	SwitchAddress        string
+	SwitchPassword       string
+	RedSCCAddress        string
+	BlueSCCAddress       string
+	PlcAddress           string
+	LedControllerAddress string
+	TeamSignRed1Id       int
+	TeamSignRed2Id       int
+	TeamSignRed3Id       int
+	TeamSignRedTimerId   int
+	TeamSignBlue1Id      int
+	TeamSignBlue2Id      int
+	TeamSignBlue3Id      int
+	TeamSignBlueTimerId  int
+	BlackmagicAddresses  string
+	CompanionAddress     string
+	CompanionPort        int
+	TbaEventCode         string // Only used when the field is running its own division.
+}
+
+func (database *Database) CreateFieldSettings(fieldSettings *FieldSettings) error {
+	return database.fieldSettingsTable.create(fieldSettings)
+}
+
+func (database *Database) GetFieldSettingsById(id int) (*FieldSettings, error) {
+	return database.fieldSettingsTable.getById(id)
+}
+
+func (database *Database) UpdateFieldSettings(fieldSettings *FieldSettings) error {
+	return database.fieldSettingsTable.update(fieldSettings)
+}
+
+func (database *Database) DeleteFieldSettings(id int) error {
+	return database.fieldSettingsTable.delete(id)
+}
+
+func (database *Database) GetAllFieldSettings() ([]FieldSettings, error) {
+	allFieldSettings, err := database.fieldSettingsTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		allFieldSettings,
+		func(i, j int) bool {
+			return allFieldSettings[i].Id < allFieldSettings[j].Id
+		},
+	)
+	return allFieldSettings, nil
+}
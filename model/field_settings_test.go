@@ -0,0 +1,62 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNonexistentFieldSettings(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	fieldSettings, err := db.GetFieldSettingsById(2)
+	assert.Nil(t, err)
+	assert.Nil(t, fieldSettings)
+}
+
+func TestFieldSettingsCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	fieldSettings := FieldSettings{Id: 3, DriverStationAddress: "10.0.200.5", ApAddress: "10.0.200.2", ApChannel: 149}
+	assert.Nil(t, db.CreateFieldSettings(&fieldSettings))
+	assert.Nil(t, db.CreateFieldSettings(&FieldSettings{Id: 2, PlcAddress: "10.0.100.40"}))
+	fieldSettings2, err := db.GetFieldSettingsById(3)
+	assert.Nil(t, err)
+	assert.Equal(t, fieldSettings, *fieldSettings2)
+
+	fieldSettings.PlcAddress = "10.0.200.40"
+	assert.Nil(t, db.UpdateFieldSettings(&fieldSettings))
+	allFieldSettings, err := db.GetAllFieldSettings()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(allFieldSettings)) {
+		assert.Equal(t, 2, allFieldSettings[0].Id)
+		assert.Equal(t, fieldSettings, allFieldSettings[1])
+	}
+
+	assert.Nil(t, db.DeleteFieldSettings(fieldSettings.Id))
+	fieldSettings2, err = db.GetFieldSettingsById(3)
+	assert.Nil(t, err)
+	assert.Nil(t, fieldSettings2)
+}
+
+func TestPrimaryFieldSettings(t *testing.T) {
+	eventSettings := EventSettings{
+		ApAddress: "10.0.100.2", ApChannel: 36, PlcAddress: "10.0.100.40", TeamSignBlue1Id: 55, CompanionPort: 51234,
+	}
+	assert.Equal(
+		t,
+		&FieldSettings{
+			Id:              1,
+			ApAddress:       "10.0.100.2",
+			ApChannel:       36,
+			PlcAddress:      "10.0.100.40",
+			TeamSignBlue1Id: 55,
+			CompanionPort:   51234,
+		},
+		eventSettings.PrimaryFieldSettings(),
+	)
+}
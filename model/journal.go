@@ -0,0 +1,619 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Append-only journal of every write made to the database, used to recover data lost since the last backup.
+
+package model
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.etcd.io/bbolt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	journalBucketName        = "_Journal"
+	maxRecentJournalEntries  = 5000
+	standbyExcludedTableName = "UserSession"
+)
+
+var (
+	journalSequenceKey         = []byte("sequence")
+	mirroredJournalSequenceKey = []byte("mirroredSequence")
+)
+
+// Represents a single create, update, delete or truncate operation on a table.
+type JournalEntry struct {
+	Sequence  int
+	Time      time.Time
+	Table     string
+	Operation string
+	RecordId  int             `json:",omitempty"`
+	Record    json.RawMessage `json:",omitempty"`
+}
+
+// Describes the outcome of replaying the journal on top of a backup.
+type RecoveryReport struct {
+	BackupSequence int
+	Applied        []JournalEntry
+	Skipped        []SkippedJournalEntry
+	TruncatedTail  bool
+}
+
+type SkippedJournalEntry struct {
+	Entry JournalEntry
+	Error string
+}
+
+type journal struct {
+	path           string
+	mutex          sync.Mutex
+	file           *os.File
+	lastSequence   int
+	recentEntries  []JournalEntry
+	writeMutex     sync.Mutex // Held for the duration of each journaled transaction so that entries stay in order.
+	pendingEntries []JournalEntry
+}
+
+// Returns the path of the journal belonging to the database at the given path.
+func JournalPath(dbPath string) string {
+	return dbPath + ".journal"
+}
+
+// Returns a human-readable one-line description of the journal entry.
+func (entry *JournalEntry) String() string {
+	description := fmt.Sprintf(
+		"#%d %s %s %s", entry.Sequence, entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Operation, entry.Table,
+	)
+	if entry.RecordId != 0 {
+		description += fmt.Sprintf(" %d", entry.RecordId)
+	}
+	return description
+}
+
+// Prepares the journal at the given path for appending, without creating the file until the first write. Only the
+// most recent entries are held in memory.
+func openJournal(path string) (*journal, error) {
+	journal := journal{path: path}
+	_, err := scanJournal(
+		path,
+		func(entry JournalEntry) {
+			journal.lastSequence = entry.Sequence
+			journal.recentEntries = append(journal.recentEntries, entry)
+			if len(journal.recentEntries) > 2*maxRecentJournalEntries {
+				journal.recentEntries = slices.Clone(journal.recentEntries[maxRecentJournalEntries:])
+			}
+		},
+	)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	journal.recentEntries = journal.recentEntries[max(len(journal.recentEntries)-maxRecentJournalEntries, 0):]
+	return &journal, nil
+}
+
+// Runs the given function within a read-write transaction on the given database, and appends the operations it
+// records to the journal only once the transaction has been committed, flushing them to disk before returning.
+func (journal *journal) update(bolt *bbolt.DB, fn func(tx *bbolt.Tx) error) error {
+	journal.writeMutex.Lock()
+	defer journal.writeMutex.Unlock()
+
+	journal.pendingEntries = nil
+	err := bolt.Update(fn)
+	entries := journal.pendingEntries
+	journal.pendingEntries = nil
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+	journal.lastSequence = entries[len(entries)-1].Sequence
+	journal.recentEntries = append(journal.recentEntries, entries...)
+	if len(journal.recentEntries) > maxRecentJournalEntries {
+		journal.recentEntries = journal.recentEntries[len(journal.recentEntries)-maxRecentJournalEntries:]
+	}
+
+	var lines []byte
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+	if journal.file == nil {
+		if journal.file, err = os.OpenFile(journal.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+			return err
+		}
+	}
+	if _, err = journal.file.Write(lines); err != nil {
+		return err
+	}
+	return journal.file.Sync()
+}
+
+// Records the given operation to be appended to the journal once the given transaction commits, and stores its
+// sequence number in the database as part of the transaction. Must be called from within journal.update.
+func (journal *journal) record(tx *bbolt.Tx, table, operation string, recordId int, record []byte) error {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(journalBucketName))
+	if err != nil {
+		return err
+	}
+
+	// Continue from the journal if it is ahead of the database, so that sequence numbers are never reused.
+	journal.mutex.Lock()
+	sequence := max(getJournalSequence(bucket), journal.lastSequence) + 1
+	journal.mutex.Unlock()
+	if err = bucket.Put(journalSequenceKey, idToKey(sequence)); err != nil {
+		return err
+	}
+
+	journal.pendingEntries = append(
+		journal.pendingEntries,
+		JournalEntry{
+			Sequence:  sequence,
+			Time:      time.Now(),
+			Table:     table,
+			Operation: operation,
+			RecordId:  recordId,
+			Record:    record,
+		},
+	)
+	return nil
+}
+
+func (journal *journal) close() error {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+	if journal.file == nil {
+		return nil
+	}
+	err := journal.file.Close()
+	journal.file = nil
+	return err
+}
+
+// Moves the journal file aside and starts a new one containing only the entries after the given sequence number.
+// Called once a backup including everything up to that sequence number has been taken, so that the journal only needs
+// to cover the writes since the most recent backup.
+func (journal *journal) rotate(backupSequence int) error {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+
+	if journal.file != nil {
+		if err := journal.file.Close(); err != nil {
+			return err
+		}
+		journal.file = nil
+	}
+	if err := archiveJournalFile(journal.path); err != nil {
+		return err
+	}
+
+	// Carry over any writes that were in progress while the backup was being taken.
+	var lines []byte
+	for _, entry := range journal.recentEntries {
+		if entry.Sequence > backupSequence {
+			line, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			lines = append(append(lines, line...), '\n')
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	var err error
+	if journal.file, err = os.OpenFile(journal.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+		return err
+	}
+	if _, err = journal.file.Write(lines); err != nil {
+		return err
+	}
+	return journal.file.Sync()
+}
+
+// Moves the journal file aside and starts over from an empty journal, for when the database contents have been
+// replaced by ones that the journal doesn't describe.
+func (journal *journal) reset() error {
+	journal.mutex.Lock()
+	defer journal.mutex.Unlock()
+
+	if journal.file != nil {
+		if err := journal.file.Close(); err != nil {
+			return err
+		}
+		journal.file = nil
+	}
+	journal.lastSequence = 0
+	journal.recentEntries = nil
+	return archiveJournalFile(journal.path)
+}
+
+// Reads all entries from the journal at the given path. Returns true if the last entry was only partially written, as
+// happens when the machine loses power mid-write, in which case that entry is omitted.
+func ReadJournal(path string) ([]JournalEntry, bool, error) {
+	var entries []JournalEntry
+	truncatedTail, err := scanJournal(path, func(entry JournalEntry) { entries = append(entries, entry) })
+	if err != nil {
+		return nil, false, err
+	}
+	return entries, truncatedTail, nil
+}
+
+// Passes each entry in the journal at the given path to the given function in turn, without holding them all in
+// memory. Returns true if the last entry was only partially written.
+func scanJournal(path string, handleEntry func(entry JournalEntry)) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// A final line without a newline was interrupted before it was fully written.
+			return len(line) > 0, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		var entry JournalEntry
+		if err = json.Unmarshal(line, &entry); err != nil {
+			return false, fmt.Errorf("invalid journal entry on line %d: %v", lineNumber, err)
+		}
+		handleEntry(entry)
+	}
+}
+
+// Moves the journal belonging to the database at the given path aside, so that a replacement database starts a new
+// journal. Used when the database is restored from an unrelated backup.
+func ArchiveJournal(dbPath string) error {
+	return archiveJournalFile(JournalPath(dbPath))
+}
+
+func archiveJournalFile(path string) error {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return os.Rename(path, fmt.Sprintf("%s.%s", path, time.Now().Format("20060102150405.000000")))
+}
+
+// Rebuilds a database at the given output path from the given backup plus all journal entries recorded after the
+// backup was taken.
+func RecoverDatabase(backupPath, journalPath, outputPath string) (*RecoveryReport, error) {
+	if _, err := os.Stat(outputPath); err == nil {
+		return nil, fmt.Errorf("output file %s already exists", outputPath)
+	}
+	entries, truncatedTail, err := ReadJournal(journalPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = copyFile(backupPath, outputPath); err != nil {
+		return nil, err
+	}
+
+	bolt, err := bbolt.Open(outputPath, 0644, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	defer bolt.Close()
+
+	report := RecoveryReport{TruncatedTail: truncatedTail}
+	err = bolt.View(
+		func(tx *bbolt.Tx) error {
+			if bucket := tx.Bucket([]byte(journalBucketName)); bucket != nil {
+				report.BackupSequence = getJournalSequence(bucket)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.Sequence <= report.BackupSequence {
+			continue
+		}
+		err = bolt.Update(
+			func(tx *bbolt.Tx) error {
+				if err := replayJournalEntry(tx, &entry); err != nil {
+					return err
+				}
+				bucket, err := tx.CreateBucketIfNotExists([]byte(journalBucketName))
+				if err != nil {
+					return err
+				}
+				return bucket.Put(journalSequenceKey, idToKey(entry.Sequence))
+			},
+		)
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedJournalEntry{Entry: entry, Error: err.Error()})
+		} else {
+			report.Applied = append(report.Applied, entry)
+		}
+	}
+	return &report, nil
+}
+
+// Applies the given journal entry to the raw Bolt buckets, mirroring the checks made by the table methods.
+func replayJournalEntry(tx *bbolt.Tx, entry *JournalEntry) error {
+	bucket, err := tx.CreateBucketIfNotExists([]byte(entry.Table))
+	if err != nil {
+		return err
+	}
+	key := idToKey(entry.RecordId)
+
+	switch entry.Operation {
+	case "create":
+		if bucket.Get(key) != nil {
+			return fmt.Errorf("%s with ID %d already exists", entry.Table, entry.RecordId)
+		}
+		if uint64(entry.RecordId) > bucket.Sequence() {
+			// Keep autogenerated IDs from colliding with the replayed record.
+			if err = bucket.SetSequence(uint64(entry.RecordId)); err != nil {
+				return err
+			}
+		}
+		return bucket.Put(key, entry.Record)
+	case "update":
+		if bucket.Get(key) == nil {
+			return fmt.Errorf("can't update non-existent %s with ID %d", entry.Table, entry.RecordId)
+		}
+		return bucket.Put(key, entry.Record)
+	case "delete":
+		if bucket.Get(key) == nil {
+			return fmt.Errorf("can't delete non-existent %s with ID %d", entry.Table, entry.RecordId)
+		}
+		return bucket.Delete(key)
+	case "truncate":
+		if err = tx.DeleteBucket([]byte(entry.Table)); err != nil {
+			return err
+		}
+		_, err = tx.CreateBucket([]byte(entry.Table))
+		return err
+	default:
+		return fmt.Errorf("unknown journal operation '%s'", entry.Operation)
+	}
+}
+
+// Returns the sequence number of the most recent journaled write to the database.
+func (database *Database) GetJournalSequence() (int, error) {
+	sequence := 0
+	err := database.bolt.View(
+		func(tx *bbolt.Tx) error {
+			if bucket := tx.Bucket([]byte(journalBucketName)); bucket != nil {
+				sequence = getJournalSequence(bucket)
+			}
+			return nil
+		},
+	)
+	database.journal.mutex.Lock()
+	defer database.journal.mutex.Unlock()
+	return max(sequence, database.journal.lastSequence), err
+}
+
+// Returns the journal entries written after the given sequence number, for mirroring to a standby server. Returns
+// false if some of them are no longer held in memory or the sequence is ahead of the database, in which case the
+// standby must copy the whole database instead.
+func (database *Database) GetJournalEntriesAfter(sequence int) ([]JournalEntry, bool, error) {
+	lastSequence, err := database.GetJournalSequence()
+	if err != nil {
+		return nil, false, err
+	}
+	if sequence > lastSequence {
+		return nil, false, nil
+	}
+
+	database.journal.mutex.Lock()
+	recentEntries := database.journal.recentEntries
+	database.journal.mutex.Unlock()
+	firstAvailableSequence := lastSequence + 1
+	if len(recentEntries) > 0 {
+		firstAvailableSequence = recentEntries[0].Sequence
+	}
+	if sequence < firstAvailableSequence-1 {
+		return nil, false, nil
+	}
+
+	entries := []JournalEntry{}
+	for _, entry := range recentEntries {
+		if entry.Sequence > sequence {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, true, nil
+}
+
+// Returns the sequence number of the last journal entry mirrored from a primary server.
+func (database *Database) GetMirroredJournalSequence() (int, error) {
+	sequence := 0
+	err := database.bolt.View(
+		func(tx *bbolt.Tx) error {
+			if bucket := tx.Bucket([]byte(journalBucketName)); bucket != nil {
+				sequence, _ = strconv.Atoi(string(bucket.Get(mirroredJournalSequenceKey)))
+			}
+			return nil
+		},
+	)
+	return sequence, err
+}
+
+// Records that the database mirrors the primary server's data up to and including the given sequence number.
+func (database *Database) SetMirroredJournalSequence(sequence int) error {
+	return database.bolt.Update(
+		func(tx *bbolt.Tx) error {
+			bucket, err := tx.CreateBucketIfNotExists([]byte(journalBucketName))
+			if err != nil {
+				return err
+			}
+			return bucket.Put(mirroredJournalSequenceKey, idToKey(sequence))
+		},
+	)
+}
+
+// Applies the given journal entries received from a primary server. Creates and updates overwrite any existing record
+// so that local writes on the standby can't block mirroring, and login sessions are left alone since they belong to
+// the local server. The entries are not journaled locally.
+func (database *Database) MirrorJournalEntries(entries []JournalEntry) error {
+	return database.bolt.Update(
+		func(tx *bbolt.Tx) error {
+			for _, entry := range entries {
+				if entry.Table == standbyExcludedTableName {
+					continue
+				}
+				bucket, err := tx.CreateBucketIfNotExists([]byte(entry.Table))
+				if err != nil {
+					return err
+				}
+				key := idToKey(entry.RecordId)
+				switch entry.Operation {
+				case "create", "update":
+					if uint64(entry.RecordId) > bucket.Sequence() {
+						if err = bucket.SetSequence(uint64(entry.RecordId)); err != nil {
+							return err
+						}
+					}
+					err = bucket.Put(key, entry.Record)
+				case "delete":
+					err = bucket.Delete(key)
+				default:
+					err = replayJournalEntry(tx, &entry)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(entries) == 0 {
+				return nil
+			}
+			bucket, err := tx.CreateBucketIfNotExists([]byte(journalBucketName))
+			if err != nil {
+				return err
+			}
+			return bucket.Put(mirroredJournalSequenceKey, idToKey(entries[len(entries)-1].Sequence))
+		},
+	)
+}
+
+// Replaces the contents of the database with those of the database snapshot at the given path, such as one copied
+// from a primary server. This happens in place within a single transaction so that anything holding on to this
+// database sees either the old contents or the new ones. Login sessions are kept since they belong to the local server,
+// and the journal is started over since it no longer describes the data.
+func (database *Database) ReplaceWithSnapshot(snapshotPath string) error {
+	snapshot, err := bbolt.Open(snapshotPath, 0644, &bbolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		return err
+	}
+	defer snapshot.Close()
+
+	// Keep any journaled write from being appended to the journal after it has been started over.
+	database.journal.writeMutex.Lock()
+	defer database.journal.writeMutex.Unlock()
+
+	return snapshot.View(
+		func(snapshotTx *bbolt.Tx) error {
+			return database.bolt.Update(
+				func(tx *bbolt.Tx) error {
+					var bucketNames [][]byte
+					err := tx.ForEach(
+						func(name []byte, _ *bbolt.Bucket) error {
+							bucketNames = append(bucketNames, slices.Clone(name))
+							return nil
+						},
+					)
+					if err != nil {
+						return err
+					}
+					for _, name := range bucketNames {
+						if string(name) != standbyExcludedTableName {
+							if err = tx.DeleteBucket(name); err != nil {
+								return err
+							}
+						}
+					}
+
+					err = snapshotTx.ForEach(
+						func(name []byte, snapshotBucket *bbolt.Bucket) error {
+							if string(name) == standbyExcludedTableName {
+								return nil
+							}
+							bucket, err := tx.CreateBucket(name)
+							if err != nil {
+								return err
+							}
+							return copyBucket(snapshotBucket, bucket)
+						},
+					)
+					if err != nil {
+						return err
+					}
+
+					// Make sure that every table still has a bucket even if the snapshot was missing some.
+					for _, name := range bucketNames {
+						if _, err = tx.CreateBucketIfNotExists(name); err != nil {
+							return err
+						}
+					}
+					return database.journal.reset()
+				},
+			)
+		},
+	)
+}
+
+// Copies all records, nested buckets and the ID sequence of the given source bucket into the given empty one.
+func copyBucket(source, dest *bbolt.Bucket) error {
+	if err := dest.SetSequence(source.Sequence()); err != nil {
+		return err
+	}
+	return source.ForEach(
+		func(key, value []byte) error {
+			if value != nil {
+				return dest.Put(key, value)
+			}
+			nestedDest, err := dest.CreateBucket(key)
+			if err != nil {
+				return err
+			}
+			return copyBucket(source.Bucket(key), nestedDest)
+		},
+	)
+}
+
+func getJournalSequence(bucket *bbolt.Bucket) int {
+	sequence, _ := strconv.Atoi(string(bucket.Get(journalSequenceKey)))
+	return sequence
+}
+
+func copyFile(sourcePath, destPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	dest, err := os.Create(destPath)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dest, source); err != nil {
+		dest.Close()
+		return err
+	}
+	return dest.Close()
+}
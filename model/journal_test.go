@@ -0,0 +1,422 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestJournalRecordsWrites(t *testing.T) {
+	db := setupTestDb(t)
+
+	team := Team{Id: 254, Name: "NASA"}
+	assert.Nil(t, db.CreateTeam(&team))
+	team.Nickname = "The Cheesy Poofs"
+	assert.Nil(t, db.UpdateTeam(&team))
+	assert.Nil(t, db.DeleteTeam(254))
+	assert.Nil(t, db.TruncateTeams())
+
+	// Failed writes should not be journaled.
+	assert.NotNil(t, db.UpdateTeam(&team))
+
+	entries, truncatedTail, err := ReadJournal(JournalPath(db.Path))
+	assert.Nil(t, err)
+	assert.False(t, truncatedTail)
+	if assert.Equal(t, 4, len(entries)) {
+		for i, operation := range []string{"create", "update", "delete", "truncate"} {
+			assert.Equal(t, i+1, entries[i].Sequence)
+			assert.Equal(t, "Team", entries[i].Table)
+			assert.Equal(t, operation, entries[i].Operation)
+		}
+		assert.Equal(t, 254, entries[1].RecordId)
+		assert.Contains(t, string(entries[1].Record), "The Cheesy Poofs")
+		assert.Nil(t, entries[2].Record)
+		assert.Equal(t, "#1 "+entries[0].Time.Local().Format("2006-01-02 15:04:05")+" create Team 254",
+			entries[0].String())
+	}
+}
+
+func TestJournalSkipsRolledBackWrites(t *testing.T) {
+	db := setupTestDb(t)
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254}))
+
+	err := db.journal.update(
+		db.bolt,
+		func(tx *bbolt.Tx) error {
+			if err := db.journal.record(tx, "Team", "delete", 254, nil); err != nil {
+				return err
+			}
+			return errors.New("rolled back")
+		},
+	)
+	assert.EqualError(t, err, "rolled back")
+	sequence, err := db.GetJournalSequence()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, sequence)
+	entries, _, err := db.GetJournalEntriesAfter(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(entries))
+
+	// Check that the next write reuses the sequence number that was never committed.
+	assert.Nil(t, db.CreateTeam(&Team{Id: 1114}))
+	entries, _, err = ReadJournal(JournalPath(db.Path))
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(entries)) {
+		assert.Equal(t, "create", entries[1].Operation)
+		assert.Equal(t, 2, entries[1].Sequence)
+	}
+}
+
+func TestJournalSequenceNotReusedAfterCrash(t *testing.T) {
+	db := setupTestDb(t)
+	dbPath := db.Path
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254}))
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	writeBackupFile(t, db, backupPath)
+	assert.Nil(t, db.CreateTeam(&Team{Id: 1114}))
+	assert.Nil(t, db.Close())
+
+	// Simulate losing the last unsynced write by swapping in the backup, then make another write.
+	assert.Nil(t, os.Remove(dbPath))
+	assert.Nil(t, copyFile(backupPath, dbPath))
+	db, err := OpenDatabase(dbPath)
+	assert.Nil(t, err)
+	assert.Nil(t, db.CreateTeam(&Team{Id: 2056}))
+	assert.Nil(t, db.Close())
+
+	entries, _, _ := ReadJournal(JournalPath(dbPath))
+	if assert.Equal(t, 3, len(entries)) {
+		assert.Equal(t, 3, entries[2].Sequence)
+	}
+}
+
+func TestRecoverDatabase(t *testing.T) {
+	db := setupTestDb(t)
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254, Name: "NASA"}))
+	match := Match{ShortName: "Q1"}
+	assert.Nil(t, db.CreateMatch(&match))
+
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	writeBackupFile(t, db, backupPath)
+
+	assert.Nil(t, db.CreateTeam(&Team{Id: 1114, Name: "Simbotics"}))
+	assert.Nil(t, db.UpdateTeam(&Team{Id: 254, Name: "Cheesy Poofs"}))
+	match2 := Match{ShortName: "Q2"}
+	assert.Nil(t, db.CreateMatch(&match2))
+	assert.Nil(t, db.DeleteMatch(match.Id))
+	assert.Nil(t, db.Close())
+
+	// Simulate a write that was interrupted by a crash.
+	journalFile, _ := os.OpenFile(JournalPath(db.Path), os.O_APPEND|os.O_WRONLY, 0644)
+	journalFile.WriteString(`{"Sequence":99,"Table":"Te`)
+	journalFile.Close()
+
+	outputPath := filepath.Join(t.TempDir(), "recovered.db")
+	report, err := RecoverDatabase(backupPath, JournalPath(db.Path), outputPath)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.BackupSequence)
+	assert.True(t, report.TruncatedTail)
+	assert.Empty(t, report.Skipped)
+	if assert.Equal(t, 4, len(report.Applied)) {
+		assert.Equal(t, "create", report.Applied[0].Operation)
+		assert.Equal(t, 1114, report.Applied[0].RecordId)
+		assert.Equal(t, "update", report.Applied[1].Operation)
+		assert.Equal(t, "Match", report.Applied[2].Table)
+		assert.Equal(t, "delete", report.Applied[3].Operation)
+	}
+
+	recoveredDb, err := OpenDatabase(outputPath)
+	assert.Nil(t, err)
+	defer recoveredDb.Close()
+	teams, _ := recoveredDb.GetAllTeams()
+	if assert.Equal(t, 2, len(teams)) {
+		assert.Equal(t, "Cheesy Poofs", teams[0].Name)
+		assert.Equal(t, "Simbotics", teams[1].Name)
+	}
+	deletedMatch, _ := recoveredDb.GetMatchById(match.Id)
+	assert.Nil(t, deletedMatch)
+	recoveredMatch, _ := recoveredDb.GetMatchById(match2.Id)
+	if assert.NotNil(t, recoveredMatch) {
+		assert.Equal(t, "Q2", recoveredMatch.ShortName)
+	}
+
+	// Check that autogenerated IDs continue past the replayed ones.
+	match3 := Match{ShortName: "Q3"}
+	assert.Nil(t, recoveredDb.CreateMatch(&match3))
+	assert.Equal(t, match2.Id+1, match3.Id)
+
+	// Check that recovery refuses to overwrite an existing file.
+	_, err = RecoverDatabase(backupPath, JournalPath(db.Path), outputPath)
+	assert.EqualError(t, err, "output file "+outputPath+" already exists")
+}
+
+func TestRecoverDatabaseSkipsConflictingEntries(t *testing.T) {
+	db := setupTestDb(t)
+	assert.Nil(t, db.CreateTeam(&Team{Id: 1114}))
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254}))
+	assert.Nil(t, db.Close())
+
+	// Replaying onto a backup from an unrelated database should skip the entries that conflict with it.
+	otherDb, err := OpenDatabase(filepath.Join(t.TempDir(), "other.db"))
+	assert.Nil(t, err)
+	assert.Nil(t, otherDb.CreateTeam(&Team{Id: 254}))
+	backupPath := filepath.Join(t.TempDir(), "backup.db")
+	writeBackupFile(t, otherDb, backupPath)
+	otherDb.Close()
+
+	report, err := RecoverDatabase(backupPath, JournalPath(db.Path), filepath.Join(t.TempDir(), "recovered.db"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.BackupSequence)
+	assert.Empty(t, report.Applied)
+	if assert.Equal(t, 1, len(report.Skipped)) {
+		assert.Equal(t, 2, report.Skipped[0].Entry.Sequence)
+		assert.Equal(t, "Team with ID 254 already exists", report.Skipped[0].Error)
+	}
+}
+
+func TestArchiveJournal(t *testing.T) {
+	db := setupTestDb(t)
+	assert.Nil(t, ArchiveJournal(db.Path))
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254}))
+	assert.Nil(t, db.Close())
+
+	assert.Nil(t, ArchiveJournal(db.Path))
+	_, err := os.Stat(JournalPath(db.Path))
+	assert.True(t, os.IsNotExist(err))
+	archivedPaths, _ := filepath.Glob(JournalPath(db.Path) + ".*")
+	assert.Equal(t, 1, len(archivedPaths))
+}
+
+func TestBackupRotatesJournal(t *testing.T) {
+	db := setupTestDb(t)
+	BaseDir = t.TempDir()
+	defer func() {
+		BaseDir = ".."
+	}()
+
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254, Name: "NASA"}))
+	assert.Nil(t, db.CreateTeam(&Team{Id: 1114}))
+	assert.Nil(t, db.Backup("Chezy Champs", "test"))
+	archivedPaths, _ := filepath.Glob(JournalPath(db.Path) + ".*")
+	assert.Equal(t, 1, len(archivedPaths))
+	_, err := os.Stat(JournalPath(db.Path))
+	assert.True(t, os.IsNotExist(err))
+
+	// Check that the new journal continues the sequence and covers only the writes since the backup.
+	assert.Nil(t, db.UpdateTeam(&Team{Id: 254, Name: "Cheesy Poofs"}))
+	entries, _, err := ReadJournal(JournalPath(db.Path))
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, 3, entries[0].Sequence)
+	}
+	entries, complete, _ := db.GetJournalEntriesAfter(0)
+	assert.True(t, complete)
+	assert.Equal(t, 3, len(entries))
+
+	backupPath, err := LatestBackupPath()
+	assert.Nil(t, err)
+	report, err := RecoverDatabase(backupPath, JournalPath(db.Path), filepath.Join(t.TempDir(), "recovered.db"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.BackupSequence)
+	assert.Equal(t, 1, len(report.Applied))
+	assert.Empty(t, report.Skipped)
+}
+
+func TestJournalRotationKeepsWritesAfterBackup(t *testing.T) {
+	db := setupTestDb(t)
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254}))
+	assert.Nil(t, db.CreateTeam(&Team{Id: 1114}))
+
+	// Simulate the second write still being in progress when the backup was taken.
+	assert.Nil(t, db.journal.rotate(1))
+	entries, _, err := ReadJournal(JournalPath(db.Path))
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(entries)) {
+		assert.Equal(t, 2, entries[0].Sequence)
+	}
+	assert.Nil(t, db.CreateTeam(&Team{Id: 2056}))
+	entries, _, _ = ReadJournal(JournalPath(db.Path))
+	assert.Equal(t, 2, len(entries))
+}
+
+func TestOpenJournalHoldsOnlyRecentEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db.journal")
+	var lines []byte
+	for sequence := 1; sequence <= 2*maxRecentJournalEntries+10; sequence++ {
+		lines = append(lines, fmt.Sprintf("{\"Sequence\":%d,\"Table\":\"Team\"}\n", sequence)...)
+	}
+	assert.Nil(t, os.WriteFile(path, lines, 0644))
+
+	journal, err := openJournal(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*maxRecentJournalEntries+10, journal.lastSequence)
+	if assert.Equal(t, maxRecentJournalEntries, len(journal.recentEntries)) {
+		assert.Equal(t, maxRecentJournalEntries+11, journal.recentEntries[0].Sequence)
+	}
+}
+
+func TestJournalConcurrentAccess(t *testing.T) {
+	db := setupTestDb(t)
+	var waitGroup sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		waitGroup.Go(func() { assert.Nil(t, db.CreateTeam(&Team{Id: i})) })
+		waitGroup.Go(
+			func() {
+				_, _, err := db.GetJournalEntriesAfter(0)
+				assert.Nil(t, err)
+			},
+		)
+	}
+	waitGroup.Wait()
+	sequence, _ := db.GetJournalSequence()
+	assert.Equal(t, 20, sequence)
+}
+
+func TestGetJournalEntriesAfter(t *testing.T) {
+	db := setupTestDb(t)
+	entries, complete, err := db.GetJournalEntriesAfter(0)
+	assert.Nil(t, err)
+	assert.True(t, complete)
+	assert.Empty(t, entries)
+
+	assert.Nil(t, db.CreateTeam(&Team{Id: 254}))
+	assert.Nil(t, db.CreateTeam(&Team{Id: 1114}))
+	assert.Nil(t, db.CreateTeam(&Team{Id: 2056}))
+	sequence, _ := db.GetJournalSequence()
+	assert.Equal(t, 3, sequence)
+
+	entries, complete, err = db.GetJournalEntriesAfter(1)
+	assert.Nil(t, err)
+	assert.True(t, complete)
+	if assert.Equal(t, 2, len(entries)) {
+		assert.Equal(t, 1114, entries[0].RecordId)
+		assert.Equal(t, 2056, entries[1].RecordId)
+	}
+	entries, complete, _ = db.GetJournalEntriesAfter(3)
+	assert.True(t, complete)
+	assert.Empty(t, entries)
+
+	// A sequence ahead of the database indicates that the requester has diverged and needs a full copy.
+	_, complete, _ = db.GetJournalEntriesAfter(4)
+	assert.False(t, complete)
+
+	// Entries that have aged out of memory are no longer available.
+	db.journal.recentEntries = db.journal.recentEntries[1:]
+	_, complete, _ = db.GetJournalEntriesAfter(1)
+	assert.True(t, complete)
+	_, complete, _ = db.GetJournalEntriesAfter(0)
+	assert.False(t, complete)
+
+	// Check that the recent entries are reloaded from the journal file when the database is reopened.
+	assert.Nil(t, db.Close())
+	db, err = OpenDatabase(db.Path)
+	assert.Nil(t, err)
+	defer db.Close()
+	entries, complete, _ = db.GetJournalEntriesAfter(0)
+	assert.True(t, complete)
+	assert.Equal(t, 3, len(entries))
+}
+
+func TestMirrorJournalEntries(t *testing.T) {
+	primaryDb := setupTestDb(t)
+	standbyDb, err := OpenDatabase(filepath.Join(t.TempDir(), "standby.db"))
+	assert.Nil(t, err)
+	defer standbyDb.Close()
+
+	// Local writes on the standby shouldn't get in the way of mirroring.
+	assert.Nil(t, standbyDb.CreateTeam(&Team{Id: 254, Name: "Stale"}))
+	assert.Nil(t, standbyDb.CreateUserSession(&UserSession{Token: "standby", Username: "admin"}))
+
+	assert.Nil(t, primaryDb.CreateTeam(&Team{Id: 254, Name: "NASA"}))
+	assert.Nil(t, primaryDb.CreateTeam(&Team{Id: 1114, Name: "Simbotics"}))
+	assert.Nil(t, primaryDb.DeleteTeam(1114))
+	match := Match{ShortName: "Q1"}
+	assert.Nil(t, primaryDb.CreateMatch(&match))
+	assert.Nil(t, primaryDb.CreateUserSession(&UserSession{Token: "primary", Username: "admin"}))
+	assert.Nil(t, primaryDb.TruncateScheduledBreaks())
+
+	entries, _, _ := primaryDb.GetJournalEntriesAfter(0)
+	assert.Nil(t, standbyDb.MirrorJournalEntries(entries))
+	mirroredSequence, err := standbyDb.GetMirroredJournalSequence()
+	assert.Nil(t, err)
+	assert.Equal(t, 6, mirroredSequence)
+
+	teams, _ := standbyDb.GetAllTeams()
+	if assert.Equal(t, 1, len(teams)) {
+		assert.Equal(t, "NASA", teams[0].Name)
+	}
+	mirroredMatch, _ := standbyDb.GetMatchById(match.Id)
+	if assert.NotNil(t, mirroredMatch) {
+		assert.Equal(t, "Q1", mirroredMatch.ShortName)
+	}
+	sessions, _ := standbyDb.GetAllUserSessions()
+	if assert.Equal(t, 1, len(sessions)) {
+		assert.Equal(t, "standby", sessions[0].Token)
+	}
+
+	// Check that autogenerated IDs on the standby continue past the mirrored ones once it is promoted.
+	match2 := Match{ShortName: "Q2"}
+	assert.Nil(t, standbyDb.CreateMatch(&match2))
+	assert.Equal(t, match.Id+1, match2.Id)
+
+	// Mirrored entries should not be journaled on the standby.
+	standbyEntries, _, _ := standbyDb.GetJournalEntriesAfter(0)
+	assert.Equal(t, 3, len(standbyEntries))
+
+	assert.Nil(t, standbyDb.SetMirroredJournalSequence(0))
+	mirroredSequence, _ = standbyDb.GetMirroredJournalSequence()
+	assert.Equal(t, 0, mirroredSequence)
+}
+
+func TestReplaceWithSnapshot(t *testing.T) {
+	primaryDb := setupTestDb(t)
+	standbyDb, err := OpenDatabase(filepath.Join(t.TempDir(), "standby.db"))
+	assert.Nil(t, err)
+	defer standbyDb.Close()
+
+	assert.Nil(t, standbyDb.CreateTeam(&Team{Id: 9999}))
+	assert.Nil(t, standbyDb.CreateUserSession(&UserSession{Token: "standby", Username: "admin"}))
+	assert.Nil(t, primaryDb.CreateTeam(&Team{Id: 254, Name: "NASA"}))
+	match := Match{ShortName: "Q1"}
+	assert.Nil(t, primaryDb.CreateMatch(&match))
+	assert.Nil(t, primaryDb.CreateUserSession(&UserSession{Token: "primary", Username: "admin"}))
+	snapshotPath := filepath.Join(t.TempDir(), "snapshot.db")
+	writeBackupFile(t, primaryDb, snapshotPath)
+
+	assert.Nil(t, standbyDb.ReplaceWithSnapshot(snapshotPath))
+	teams, _ := standbyDb.GetAllTeams()
+	if assert.Equal(t, 1, len(teams)) {
+		assert.Equal(t, "NASA", teams[0].Name)
+	}
+	sessions, _ := standbyDb.GetAllUserSessions()
+	if assert.Equal(t, 1, len(sessions)) {
+		assert.Equal(t, "standby", sessions[0].Token)
+	}
+	sequence, _ := standbyDb.GetJournalSequence()
+	primarySequence, _ := primaryDb.GetJournalSequence()
+	assert.Equal(t, primarySequence, sequence)
+	archivedPaths, _ := filepath.Glob(JournalPath(standbyDb.Path) + ".*")
+	assert.Equal(t, 1, len(archivedPaths))
+
+	// Check that autogenerated IDs continue past the copied ones.
+	match2 := Match{ShortName: "Q2"}
+	assert.Nil(t, standbyDb.CreateMatch(&match2))
+	assert.Equal(t, match.Id+1, match2.Id)
+
+	assert.NotNil(t, standbyDb.ReplaceWithSnapshot(filepath.Join(t.TempDir(), "missing.db")))
+}
+
+func writeBackupFile(t *testing.T, db *Database, path string) {
+	file, err := os.Create(path)
+	assert.Nil(t, err)
+	assert.Nil(t, db.WriteBackup(file))
+	assert.Nil(t, file.Close())
+}
@@ -57,6 +57,8 @@ type Match struct {
 	Status              game.MatchStatus
 	UseTiebreakCriteria bool
 	TbaMatchKey         TbaMatchKey
+	ScheduleNote        string
+	FieldNumber         int
 }
 
 type TbaMatchKey struct {
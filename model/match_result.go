@@ -7,17 +7,32 @@ package model
 
 import (
 	"github.com/Team254/cheesy-arena/game"
+	"sort"
+	"time"
 )
 
 type MatchResult struct {
-	Id         int `db:"id"`
-	MatchId    int
-	PlayNumber int
-	MatchType  MatchType
-	RedScore   *game.Score
-	BlueScore  *game.Score
-	RedCards   map[string]string
-	BlueCards  map[string]string
+	Id             int `db:"id"`
+	MatchId        int
+	PlayNumber     int
+	MatchType      MatchType
+	RedScore       *game.Score
+	BlueScore      *game.Score
+	RedCards       map[string]string
+	BlueCards      map[string]string
+	CommittedAt    time.Time
+	EditedBy       string
+	EditReason     string
+	RankingChanges []RankingChange
+}
+
+// Records how a team's qualification ranking moved as a result of a match review edit.
+type RankingChange struct {
+	TeamId                int
+	PreviousRank          int
+	Rank                  int
+	PreviousRankingPoints int
+	RankingPoints         int
 }
 
 // Returns a new match result object with empty slices instead of nil.
@@ -50,6 +65,28 @@ func (database *Database) GetMatchResultForMatch(matchId int) (*MatchResult, err
 	return mostRecentMatchResult, nil
 }
 
+// Returns all stored plays of the given match, ordered by play number.
+func (database *Database) GetMatchResultsForMatch(matchId int) ([]MatchResult, error) {
+	matchResults, err := database.matchResultTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchPlays []MatchResult
+	for _, matchResult := range matchResults {
+		if matchResult.MatchId == matchId {
+			matchPlays = append(matchPlays, matchResult)
+		}
+	}
+	sort.Slice(
+		matchPlays,
+		func(i, j int) bool {
+			return matchPlays[i].PlayNumber < matchPlays[j].PlayNumber
+		},
+	)
+	return matchPlays, nil
+}
+
 func (database *Database) UpdateMatchResult(matchResult *MatchResult) error {
 	return database.matchResultTable.update(matchResult)
 }
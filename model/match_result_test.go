@@ -70,6 +70,26 @@ func TestGetMatchResultForMatch(t *testing.T) {
 	assert.Equal(t, matchResult2, matchResult4)
 }
 
+func TestGetMatchResultsForMatch(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	assert.Nil(t, db.CreateMatchResult(BuildTestMatchResult(254, 2)))
+	assert.Nil(t, db.CreateMatchResult(BuildTestMatchResult(1114, 1)))
+	assert.Nil(t, db.CreateMatchResult(BuildTestMatchResult(254, 1)))
+
+	matchResults, err := db.GetMatchResultsForMatch(254)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(matchResults)) {
+		assert.Equal(t, 1, matchResults[0].PlayNumber)
+		assert.Equal(t, 2, matchResults[1].PlayNumber)
+	}
+
+	matchResults, err = db.GetMatchResultsForMatch(5)
+	assert.Nil(t, err)
+	assert.Empty(t, matchResults)
+}
+
 func TestCorrectPlayoffScoreResetsDqState(t *testing.T) {
 	matchResult := NewMatchResult()
 	matchResult.RedScore.PlayoffDq = true
@@ -0,0 +1,71 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the audit log of scoring commands entered from the scoring and referee panels.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type ScoreEvent struct {
+	Id           int `db:"id"`
+	MatchId      int
+	Time         time.Time
+	MatchTimeSec float64
+	Position     string
+	Username     string
+	Command      string
+	Alliance     string
+	Description  string
+
+	// Fields needed to revert the event; which of them are used depends on the command.
+	TeamPosition  int
+	FoulId        int
+	PreviousValue int
+	NewValue      int
+
+	// The ID of the earlier event that this one reverted, if this is an undo.
+	UndoOfId int
+	IsUndone bool
+}
+
+func (database *Database) CreateScoreEvent(scoreEvent *ScoreEvent) error {
+	return database.scoreEventTable.create(scoreEvent)
+}
+
+func (database *Database) GetScoreEventById(id int) (*ScoreEvent, error) {
+	return database.scoreEventTable.getById(id)
+}
+
+func (database *Database) UpdateScoreEvent(scoreEvent *ScoreEvent) error {
+	return database.scoreEventTable.update(scoreEvent)
+}
+
+func (database *Database) TruncateScoreEvents() error {
+	return database.scoreEventTable.truncate()
+}
+
+// Returns all logged events for the given match, in the order in which they occurred.
+func (database *Database) GetScoreEventsForMatch(matchId int) ([]ScoreEvent, error) {
+	scoreEvents, err := database.scoreEventTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchScoreEvents []ScoreEvent
+	for _, scoreEvent := range scoreEvents {
+		if scoreEvent.MatchId == matchId {
+			matchScoreEvents = append(matchScoreEvents, scoreEvent)
+		}
+	}
+	sort.Slice(
+		matchScoreEvents,
+		func(i, j int) bool {
+			return matchScoreEvents[i].Id < matchScoreEvents[j].Id
+		},
+	)
+	return matchScoreEvents, nil
+}
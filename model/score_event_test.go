@@ -0,0 +1,79 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetNonexistentScoreEvent(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	scoreEvent, err := db.GetScoreEventById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, scoreEvent)
+}
+
+func TestScoreEventCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	scoreEvent := ScoreEvent{
+		MatchId:       12,
+		Time:          time.Unix(1000, 0).UTC(),
+		MatchTimeSec:  17.5,
+		Position:      "red",
+		Username:      "scorer1",
+		Command:       "autoTower",
+		Alliance:      "red",
+		Description:   "Set auto tower for team 254 to Level 1 (was None).",
+		TeamPosition:  1,
+		PreviousValue: 0,
+	}
+	assert.Nil(t, db.CreateScoreEvent(&scoreEvent))
+	scoreEvent2, err := db.GetScoreEventById(scoreEvent.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, scoreEvent, *scoreEvent2)
+
+	scoreEvent.IsUndone = true
+	assert.Nil(t, db.UpdateScoreEvent(&scoreEvent))
+	scoreEvent2, err = db.GetScoreEventById(scoreEvent.Id)
+	assert.Nil(t, err)
+	assert.True(t, scoreEvent2.IsUndone)
+}
+
+func TestTruncateScoreEvents(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	scoreEvent := ScoreEvent{MatchId: 12, Command: "addFoul"}
+	assert.Nil(t, db.CreateScoreEvent(&scoreEvent))
+	assert.Nil(t, db.TruncateScoreEvents())
+	scoreEvent2, err := db.GetScoreEventById(scoreEvent.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, scoreEvent2)
+}
+
+func TestGetScoreEventsForMatch(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateScoreEvent(&ScoreEvent{MatchId: 1, Command: "autoTower"})
+	db.CreateScoreEvent(&ScoreEvent{MatchId: 2, Command: "endgame"})
+	db.CreateScoreEvent(&ScoreEvent{MatchId: 1, Command: "addFoul"})
+
+	scoreEvents, err := db.GetScoreEventsForMatch(1)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(scoreEvents)) {
+		assert.Equal(t, "autoTower", scoreEvents[0].Command)
+		assert.Equal(t, "addFoul", scoreEvents[1].Command)
+	}
+
+	scoreEvents, err = db.GetScoreEventsForMatch(3)
+	assert.Nil(t, err)
+	assert.Empty(t, scoreEvents)
+}
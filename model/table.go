@@ -17,11 +17,16 @@ import (
 // Encapsulates all persistence operations for a particular data type represented by a struct.
 type table[R any] struct {
 	bolt         *bbolt.DB
+	journal      *journal
 	recordType   reflect.Type
 	name         string
 	bucketKey    []byte
 	idFieldIndex *int
 	manualId     bool
+
+	// Number of the most recently created records to keep, with older ones deleted as new ones are created, or zero to
+	// keep all of them. Only applies to tables with autogenerated IDs.
+	maxRecords int
 }
 
 // Registers a new table for a struct.
@@ -34,8 +39,13 @@ func newTable[R any](database *Database) (*table[R], error) {
 
 	var table table[R]
 	table.bolt = database.bolt
+	table.journal = database.journal
 	table.recordType = reflect.TypeOf(recordType)
 	table.name = table.recordType.Name()
+	if database.Division > 0 {
+		// Keep each division's records apart from those of the event as a whole.
+		table.name = fmt.Sprintf("Division%d.%s", database.Division, table.name)
+	}
 	table.bucketKey = []byte(table.name)
 
 	// Determine which field in the struct is tagged as the ID and cache its index.
@@ -126,6 +136,39 @@ func (table *table[R]) getAll() ([]R, error) {
 	return records, err
 }
 
+// Returns up to the given number of the most recently created records, with the most recent first. Only applies to
+// tables with autogenerated IDs.
+func (table *table[R]) getRecent(limit int) ([]R, error) {
+	records := []R{}
+	err := table.bolt.View(
+		func(tx *bbolt.Tx) error {
+			bucket, err := table.getBucket(tx)
+			if err != nil {
+				return err
+			}
+
+			// Walk backwards from the last ID handed out, skipping over any deleted records.
+			lastId := int(bucket.Sequence())
+			for id := lastId; id > 0 && len(records) < limit; id-- {
+				if table.maxRecords > 0 && id <= lastId-table.maxRecords {
+					break
+				}
+				recordJson := bucket.Get(idToKey(id))
+				if recordJson == nil {
+					continue
+				}
+				var record R
+				if err = json.Unmarshal(recordJson, &record); err != nil {
+					return err
+				}
+				records = append(records, record)
+			}
+			return nil
+		},
+	)
+	return records, err
+}
+
 // Persists the given record as a new row in the table.
 func (table *table[R]) create(record *R) error {
 	// Validate that the record has its ID set to zero or not as expected, depending on whether it is configured for
@@ -140,7 +183,8 @@ func (table *table[R]) create(record *R) error {
 		)
 	}
 
-	return table.bolt.Update(
+	return table.journal.update(
+		table.bolt,
 		func(tx *bbolt.Tx) error {
 			bucket, err := table.getBucket(tx)
 			if err != nil {
@@ -168,7 +212,24 @@ func (table *table[R]) create(record *R) error {
 			if err != nil {
 				return err
 			}
-			return bucket.Put(key, recordJson)
+			if err = bucket.Put(key, recordJson); err != nil {
+				return err
+			}
+			if err = table.journal.record(tx, table.name, "create", id, recordJson); err != nil {
+				return err
+			}
+
+			if !table.manualId && table.maxRecords > 0 && id > table.maxRecords {
+				// Delete the record that has now fallen out of the most recent ones, if it is still around.
+				prunedId := id - table.maxRecords
+				if bucket.Get(idToKey(prunedId)) != nil {
+					if err = bucket.Delete(idToKey(prunedId)); err != nil {
+						return err
+					}
+					return table.journal.record(tx, table.name, "delete", prunedId, nil)
+				}
+			}
+			return nil
 		},
 	)
 }
@@ -183,7 +244,8 @@ func (table *table[R]) update(record *R) error {
 		return fmt.Errorf("can't update %s with zero ID", table.name)
 	}
 
-	return table.bolt.Update(
+	return table.journal.update(
+		table.bolt,
 		func(tx *bbolt.Tx) error {
 			bucket, err := table.getBucket(tx)
 			if err != nil {
@@ -201,14 +263,18 @@ func (table *table[R]) update(record *R) error {
 			if err != nil {
 				return err
 			}
-			return bucket.Put(key, recordJson)
+			if err = bucket.Put(key, recordJson); err != nil {
+				return err
+			}
+			return table.journal.record(tx, table.name, "update", id, recordJson)
 		},
 	)
 }
 
 // Deletes the record having the given ID from the table. Returns an error if the record does not exist.
 func (table *table[R]) delete(id int) error {
-	return table.bolt.Update(
+	return table.journal.update(
+		table.bolt,
 		func(tx *bbolt.Tx) error {
 			bucket, err := table.getBucket(tx)
 			if err != nil {
@@ -222,14 +288,18 @@ func (table *table[R]) delete(id int) error {
 				return fmt.Errorf("can't delete non-existent %s with ID %d", table.name, id)
 			}
 
-			return bucket.Delete(key)
+			if err = bucket.Delete(key); err != nil {
+				return err
+			}
+			return table.journal.record(tx, table.name, "delete", id, nil)
 		},
 	)
 }
 
 // Deletes all records from the table.
 func (table *table[R]) truncate() error {
-	return table.bolt.Update(
+	return table.journal.update(
+		table.bolt,
 		func(tx *bbolt.Tx) error {
 			_, err := table.getBucket(tx)
 			if err != nil {
@@ -241,8 +311,10 @@ func (table *table[R]) truncate() error {
 			if err != nil {
 				return err
 			}
-			_, err = tx.CreateBucket(table.bucketKey)
-			return err
+			if _, err = tx.CreateBucket(table.bucketKey); err != nil {
+				return err
+			}
+			return table.journal.record(tx, table.name, "truncate", 0, nil)
 		},
 	)
 }
@@ -88,6 +88,50 @@ func TestTableMultipleCrud(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestTableWithMaxRecords(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	table, err := newTable[validRecord](db)
+	if !assert.Nil(t, err) {
+		return
+	}
+	table.maxRecords = 3
+
+	for i := 1; i <= 5; i++ {
+		assert.Nil(t, table.create(&validRecord{IntData: i}))
+	}
+	records, err := table.getAll()
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(records)) {
+		assert.Equal(t, 3, records[0].IntData)
+		assert.Equal(t, 5, records[2].IntData)
+	}
+
+	// Check that the most recent records are returned newest first, skipping over any that have been deleted.
+	assert.Nil(t, table.delete(4))
+	records, err = table.getRecent(10)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(records)) {
+		assert.Equal(t, 5, records[0].IntData)
+		assert.Equal(t, 3, records[1].IntData)
+	}
+	records, err = table.getRecent(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []validRecord{{Id: 5, IntData: 5}}, records)
+
+	// Check that pruning copes with records that have already been deleted.
+	assert.Nil(t, table.create(&validRecord{IntData: 6}))
+	records, _ = table.getRecent(10)
+	assert.Equal(t, 2, len(records))
+	assert.Nil(t, table.create(&validRecord{IntData: 7}))
+	records, _ = table.getRecent(10)
+	if assert.Equal(t, 3, len(records)) {
+		assert.Equal(t, 7, records[0].IntData)
+		assert.Equal(t, 5, records[2].IntData)
+	}
+}
+
 func TestTableWithManualId(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
@@ -0,0 +1,128 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for a user account and the roles it holds.
+
+package model
+
+import (
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"slices"
+	"sort"
+)
+
+type Role string
+
+const (
+	AdminRole       Role = "admin"
+	HeadRefereeRole Role = "head_referee"
+	ScorerRole      Role = "scorer"
+	FtaRole         Role = "fta"
+	QueuerRole      Role = "queuer"
+	EmceeRole       Role = "emcee"
+)
+
+// All roles in the order in which they should be presented in the UI.
+var AllRoles = []Role{AdminRole, HeadRefereeRole, ScorerRole, FtaRole, QueuerRole, EmceeRole}
+
+var roleNames = map[Role]string{
+	AdminRole:       "Admin",
+	HeadRefereeRole: "Head Referee",
+	ScorerRole:      "Scorer",
+	FtaRole:         "FTA",
+	QueuerRole:      "Queuer",
+	EmceeRole:       "Emcee",
+}
+
+type User struct {
+	Id           int `db:"id"`
+	Username     string
+	PasswordHash string
+	Roles        []Role
+}
+
+// Returns the human-readable name of the role.
+func (role Role) Name() string {
+	if name, ok := roleNames[role]; ok {
+		return name
+	}
+	return string(role)
+}
+
+// Returns the role corresponding to the given string, or an error if it is not a valid role.
+func RoleFromString(roleString string) (Role, error) {
+	role := Role(roleString)
+	if _, ok := roleNames[role]; !ok {
+		return "", fmt.Errorf("Invalid role '%s'.", roleString)
+	}
+	return role, nil
+}
+
+// Hashes and stores the given plaintext password on the user.
+func (user *User) SetPassword(password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(hash)
+	return nil
+}
+
+// Returns true if the given plaintext password matches the one stored for the user.
+func (user *User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil
+}
+
+// Returns true if the user holds the given role.
+func (user *User) HasRole(role Role) bool {
+	return slices.Contains(user.Roles, role)
+}
+
+func (database *Database) CreateUser(user *User) error {
+	return database.userTable.create(user)
+}
+
+func (database *Database) GetUserById(id int) (*User, error) {
+	return database.userTable.getById(id)
+}
+
+func (database *Database) GetUserByUsername(username string) (*User, error) {
+	users, err := database.userTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (database *Database) UpdateUser(user *User) error {
+	return database.userTable.update(user)
+}
+
+func (database *Database) DeleteUser(id int) error {
+	return database.userTable.delete(id)
+}
+
+func (database *Database) TruncateUsers() error {
+	return database.userTable.truncate()
+}
+
+func (database *Database) GetAllUsers() ([]User, error) {
+	users, err := database.userTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		users,
+		func(i, j int) bool {
+			return users[i].Username < users[j].Username
+		},
+	)
+	return users, nil
+}
@@ -5,19 +5,43 @@
 
 package model
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 type UserSession struct {
 	Id        int `db:"id"`
 	Token     string
 	Username  string
 	CreatedAt time.Time
+	IpAddress string
+	UserAgent string
+}
+
+// Returns the time at which the session expires given the configured session lifetime, or the zero time if sessions
+// do not expire.
+func (session *UserSession) ExpiresAt(lifetimeHours int) time.Time {
+	if lifetimeHours <= 0 {
+		return time.Time{}
+	}
+	return session.CreatedAt.Add(time.Duration(lifetimeHours) * time.Hour)
+}
+
+// Returns true if the session has outlived the configured session lifetime.
+func (session *UserSession) IsExpired(lifetimeHours int) bool {
+	expiresAt := session.ExpiresAt(lifetimeHours)
+	return !expiresAt.IsZero() && time.Now().After(expiresAt)
 }
 
 func (database *Database) CreateUserSession(session *UserSession) error {
 	return database.userSessionTable.create(session)
 }
 
+func (database *Database) GetUserSessionById(id int) (*UserSession, error) {
+	return database.userSessionTable.getById(id)
+}
+
 func (database *Database) GetUserSessionByToken(token string) (*UserSession, error) {
 	userSessions, err := database.userSessionTable.getAll()
 	if err != nil {
@@ -36,6 +60,57 @@ func (database *Database) DeleteUserSession(id int) error {
 	return database.userSessionTable.delete(id)
 }
 
+// Returns all sessions, with the most recently created first.
+func (database *Database) GetAllUserSessions() ([]UserSession, error) {
+	userSessions, err := database.userSessionTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		userSessions,
+		func(i, j int) bool {
+			return userSessions[i].CreatedAt.After(userSessions[j].CreatedAt)
+		},
+	)
+	return userSessions, nil
+}
+
+// Deletes all sessions that have outlived the given lifetime and returns the number deleted.
+func (database *Database) DeleteExpiredUserSessions(lifetimeHours int) (int, error) {
+	userSessions, err := database.userSessionTable.getAll()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, userSession := range userSessions {
+		if userSession.IsExpired(lifetimeHours) {
+			if err = database.userSessionTable.delete(userSession.Id); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Deletes all sessions belonging to the given user, forcing them to log in again.
+func (database *Database) DeleteUserSessionsByUsername(username string) error {
+	userSessions, err := database.userSessionTable.getAll()
+	if err != nil {
+		return err
+	}
+
+	for _, userSession := range userSessions {
+		if userSession.Username == username {
+			if err = database.userSessionTable.delete(userSession.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (database *Database) TruncateUserSessions() error {
 	return database.userSessionTable.truncate()
 }
@@ -22,7 +22,7 @@ func TestUserSessionCrud(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	session := UserSession{0, "token1", "Bertha", time.Now()}
+	session := UserSession{0, "token1", "Bertha", time.Now(), "10.0.100.5", "Firefox"}
 	err := db.CreateUserSession(&session)
 	assert.Nil(t, err)
 	session2, err := db.GetUserSessionByToken("token1")
@@ -41,10 +41,69 @@ func TestTruncateUserSessions(t *testing.T) {
 	db := setupTestDb(t)
 	defer db.Close()
 
-	session := UserSession{0, "token1", "Bertha", time.Now()}
+	session := UserSession{0, "token1", "Bertha", time.Now(), "10.0.100.5", "Firefox"}
 	db.CreateUserSession(&session)
 	db.TruncateUserSessions()
 	session2, err := db.GetUserSessionByToken("token1")
 	assert.Nil(t, err)
 	assert.Nil(t, session2)
 }
+
+func TestDeleteUserSessionsByUsername(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateUserSession(&UserSession{Token: "token1", Username: "Bertha", CreatedAt: time.Now()})
+	db.CreateUserSession(&UserSession{Token: "token2", Username: "Ernie", CreatedAt: time.Now()})
+	db.CreateUserSession(&UserSession{Token: "token3", Username: "Bertha", CreatedAt: time.Now()})
+	assert.Nil(t, db.DeleteUserSessionsByUsername("Bertha"))
+	session, err := db.GetUserSessionByToken("token1")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+	session, err = db.GetUserSessionByToken("token3")
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+	session, err = db.GetUserSessionByToken("token2")
+	assert.Nil(t, err)
+	assert.NotNil(t, session)
+}
+
+func TestUserSessionExpiry(t *testing.T) {
+	session := UserSession{CreatedAt: time.Now().Add(-5 * time.Hour)}
+	assert.False(t, session.IsExpired(6))
+	assert.True(t, session.IsExpired(4))
+	assert.False(t, session.IsExpired(0))
+	assert.True(t, session.ExpiresAt(0).IsZero())
+	assert.True(t, session.ExpiresAt(6).Equal(session.CreatedAt.Add(6*time.Hour)))
+}
+
+func TestGetAllAndDeleteExpiredUserSessions(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	db.CreateUserSession(&UserSession{Token: "token1", Username: "Bertha", CreatedAt: time.Now().Add(-48 * time.Hour)})
+	db.CreateUserSession(&UserSession{Token: "token2", Username: "Ernie", CreatedAt: time.Now()})
+	db.CreateUserSession(&UserSession{Token: "token3", Username: "Bert", CreatedAt: time.Now().Add(-2 * time.Hour)})
+	sessions, err := db.GetAllUserSessions()
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(sessions)) {
+		assert.Equal(t, "token2", sessions[0].Token)
+		assert.Equal(t, "token3", sessions[1].Token)
+		assert.Equal(t, "token1", sessions[2].Token)
+	}
+
+	count, err := db.DeleteExpiredUserSessions(0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+	count, err = db.DeleteExpiredUserSessions(24)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	count, err = db.DeleteExpiredUserSessions(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	sessions, err = db.GetAllUserSessions()
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(sessions)) {
+		assert.Equal(t, "token2", sessions[0].Token)
+	}
+}
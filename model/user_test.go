@@ -0,0 +1,90 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetNonexistentUser(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	user, err := db.GetUserById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+	user, err = db.GetUserByUsername("blorpy")
+	assert.Nil(t, err)
+	assert.Nil(t, user)
+}
+
+func TestUserCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	user := User{Username: "redscorer", Roles: []Role{ScorerRole}}
+	assert.Nil(t, user.SetPassword("fuel"))
+	assert.Nil(t, db.CreateUser(&user))
+	user2, err := db.GetUserById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, user, *user2)
+	user2, err = db.GetUserByUsername("redscorer")
+	assert.Nil(t, err)
+	assert.Equal(t, user, *user2)
+
+	user.Roles = append(user.Roles, HeadRefereeRole)
+	assert.Nil(t, db.UpdateUser(&user))
+	user2, err = db.GetUserById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []Role{ScorerRole, HeadRefereeRole}, user2.Roles)
+
+	assert.Nil(t, db.CreateUser(&User{Username: "announcer"}))
+	users, err := db.GetAllUsers()
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(users)) {
+		assert.Equal(t, "announcer", users[0].Username)
+		assert.Equal(t, "redscorer", users[1].Username)
+	}
+
+	assert.Nil(t, db.DeleteUser(user.Id))
+	user2, err = db.GetUserById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, user2)
+}
+
+func TestTruncateUsers(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	user := User{Username: "redscorer"}
+	db.CreateUser(&user)
+	db.TruncateUsers()
+	user2, err := db.GetUserById(1)
+	assert.Nil(t, err)
+	assert.Nil(t, user2)
+}
+
+func TestUserPassword(t *testing.T) {
+	user := User{Username: "redscorer"}
+	assert.Nil(t, user.SetPassword("fuel"))
+	assert.NotEqual(t, "fuel", user.PasswordHash)
+	assert.True(t, user.CheckPassword("fuel"))
+	assert.False(t, user.CheckPassword("Fuel"))
+	assert.False(t, user.CheckPassword(""))
+}
+
+func TestUserRoles(t *testing.T) {
+	user := User{Username: "ref", Roles: []Role{HeadRefereeRole, ScorerRole}}
+	assert.True(t, user.HasRole(HeadRefereeRole))
+	assert.True(t, user.HasRole(ScorerRole))
+	assert.False(t, user.HasRole(AdminRole))
+
+	role, err := RoleFromString("fta")
+	assert.Nil(t, err)
+	assert.Equal(t, FtaRole, role)
+	assert.Equal(t, "FTA", role.Name())
+	_, err = RoleFromString("blorpy")
+	assert.EqualError(t, err, "Invalid role 'blorpy'.")
+}
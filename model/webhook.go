@@ -0,0 +1,49 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for an outbound webhook notified of event lifecycle changes.
+
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+type Webhook struct {
+	Id        int `db:"id"`
+	Url       string
+	Secret    string
+	Events    []string
+	Enabled   bool
+	CreatedAt time.Time
+}
+
+// Returns true if the webhook should be notified of the given event; an empty filter subscribes it to everything.
+func (webhook *Webhook) IsSubscribedTo(event string) bool {
+	return len(webhook.Events) == 0 || slices.Contains(webhook.Events, event)
+}
+
+func (database *Database) CreateWebhook(webhook *Webhook) error {
+	return database.webhookTable.create(webhook)
+}
+
+func (database *Database) GetWebhookById(id int) (*Webhook, error) {
+	return database.webhookTable.getById(id)
+}
+
+func (database *Database) UpdateWebhook(webhook *Webhook) error {
+	return database.webhookTable.update(webhook)
+}
+
+func (database *Database) DeleteWebhook(id int) error {
+	return database.webhookTable.delete(id)
+}
+
+func (database *Database) TruncateWebhooks() error {
+	return database.webhookTable.truncate()
+}
+
+func (database *Database) GetAllWebhooks() ([]Webhook, error) {
+	return database.webhookTable.getAll()
+}
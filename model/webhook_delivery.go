@@ -0,0 +1,38 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the record of an attempt to deliver an event to a webhook.
+
+package model
+
+import "time"
+
+// Number of the most recent delivery attempts to keep, since each one holds a copy of the full payload.
+const maxWebhookDeliveries = 500
+
+type WebhookDelivery struct {
+	Id         int `db:"id"`
+	WebhookId  int
+	Url        string
+	DeliveryId string
+	Event      string
+	Attempt    int
+	Time       time.Time
+	StatusCode int
+	Error      string
+	Delivered  bool
+	Payload    string
+}
+
+func (database *Database) CreateWebhookDelivery(delivery *WebhookDelivery) error {
+	return database.webhookDeliveryTable.create(delivery)
+}
+
+// Returns up to the given number of delivery attempts, with the most recent first.
+func (database *Database) GetRecentWebhookDeliveries(limit int) ([]WebhookDelivery, error) {
+	return database.webhookDeliveryTable.getRecent(limit)
+}
+
+func (database *Database) TruncateWebhookDeliveries() error {
+	return database.webhookDeliveryTable.truncate()
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetRecentWebhookDeliveries(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	deliveries, err := db.GetRecentWebhookDeliveries(10)
+	assert.Nil(t, err)
+	assert.Empty(t, deliveries)
+
+	for i := 1; i <= 5; i++ {
+		delivery := WebhookDelivery{
+			WebhookId:  1,
+			Url:        "https://example.com/hook",
+			DeliveryId: fmt.Sprintf("delivery%d", i),
+			Event:      "match_started",
+			Attempt:    1,
+			Time:       time.Unix(int64(i), 0),
+			StatusCode: 200,
+			Delivered:  true,
+		}
+		assert.Nil(t, db.CreateWebhookDelivery(&delivery))
+	}
+	deliveries, err = db.GetRecentWebhookDeliveries(3)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(deliveries)) {
+		assert.Equal(t, "delivery5", deliveries[0].DeliveryId)
+		assert.Equal(t, "delivery3", deliveries[2].DeliveryId)
+	}
+
+	// Check that only the most recent delivery attempts are kept.
+	for i := 6; i <= maxWebhookDeliveries+1; i++ {
+		assert.Nil(t, db.CreateWebhookDelivery(&WebhookDelivery{DeliveryId: fmt.Sprintf("delivery%d", i)}))
+	}
+	deliveries, err = db.GetRecentWebhookDeliveries(2 * maxWebhookDeliveries)
+	assert.Nil(t, err)
+	if assert.Equal(t, maxWebhookDeliveries, len(deliveries)) {
+		assert.Equal(t, fmt.Sprintf("delivery%d", maxWebhookDeliveries+1), deliveries[0].DeliveryId)
+		assert.Equal(t, "delivery2", deliveries[maxWebhookDeliveries-1].DeliveryId)
+	}
+
+	assert.Nil(t, db.TruncateWebhookDeliveries())
+	deliveries, err = db.GetRecentWebhookDeliveries(3)
+	assert.Nil(t, err)
+	assert.Empty(t, deliveries)
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetNonexistentWebhook(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	webhook, err := db.GetWebhookById(1114)
+	assert.Nil(t, err)
+	assert.Nil(t, webhook)
+}
+
+func TestWebhookCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	webhook := Webhook{
+		Url:       "https://example.com/hook",
+		Secret:    "hunter2",
+		Events:    []string{"match_started", "score_posted"},
+		Enabled:   true,
+		CreatedAt: time.Unix(1000, 0).UTC(),
+	}
+	assert.Nil(t, db.CreateWebhook(&webhook))
+	webhook2, err := db.GetWebhookById(webhook.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, webhook, *webhook2)
+
+	webhook.Enabled = false
+	assert.Nil(t, db.UpdateWebhook(&webhook))
+	webhook2, err = db.GetWebhookById(webhook.Id)
+	assert.Nil(t, err)
+	assert.False(t, webhook2.Enabled)
+
+	assert.Nil(t, db.DeleteWebhook(webhook.Id))
+	webhook2, err = db.GetWebhookById(webhook.Id)
+	assert.Nil(t, err)
+	assert.Nil(t, webhook2)
+}
+
+func TestTruncateWebhooks(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	webhook := Webhook{Url: "https://example.com/hook"}
+	assert.Nil(t, db.CreateWebhook(&webhook))
+	assert.Nil(t, db.TruncateWebhooks())
+	webhooks, err := db.GetAllWebhooks()
+	assert.Nil(t, err)
+	assert.Empty(t, webhooks)
+}
+
+func TestWebhookIsSubscribedTo(t *testing.T) {
+	webhook := Webhook{}
+	assert.True(t, webhook.IsSubscribedTo("match_started"))
+	assert.True(t, webhook.IsSubscribedTo("award_revealed"))
+
+	webhook.Events = []string{"match_started"}
+	assert.True(t, webhook.IsSubscribedTo("match_started"))
+	assert.False(t, webhook.IsSubscribedTo("award_revealed"))
+}
@@ -0,0 +1,475 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client for bridging the field state to an MQTT broker for use by field lighting and show control systems, and for
+// accepting a limited set of commands from them. Implements the subset of MQTT 3.1.1 needed to publish retained
+// messages and to receive messages at QoS 0.
+
+package partner
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	mqttDefaultPort       = 1883
+	mqttTimeoutSec        = 3
+	mqttKeepAliveSec      = 30
+	mqttRetryIntervalSec  = 3
+	mqttLoopPeriodMs      = 50
+	mqttMaxPacketBytes    = 65536
+	mqttCommandBufferSize = 10
+)
+
+// MQTT control packet types, as found in the upper four bits of the first byte of each packet.
+const (
+	mqttConnect    byte = 1
+	mqttConnAck    byte = 2
+	mqttPublish    byte = 3
+	mqttSubscribe  byte = 8
+	mqttPingReq    byte = 12
+	mqttDisconnect byte = 14
+)
+
+// Flags set in the connect packet and in the first byte of a publish packet.
+const (
+	mqttCleanSessionFlag byte = 0x02
+	mqttWillFlag         byte = 0x04
+	mqttWillRetainFlag   byte = 0x20
+	mqttPasswordFlag     byte = 0x40
+	mqttUsernameFlag     byte = 0x80
+	mqttRetainFlag       byte = 0x01
+)
+
+// MqttCommand represents the commands that can be sent to the arena over MQTT.
+type MqttCommand string
+
+const (
+	MqttCommandSignalVolunteers MqttCommand = "signal_volunteers"
+	MqttCommandSignalReset      MqttCommand = "signal_reset"
+)
+
+var mqttCommands = []MqttCommand{MqttCommandSignalVolunteers, MqttCommandSignalReset}
+
+// MqttFieldState holds the arena state that is published to the broker.
+type MqttFieldState struct {
+	MatchState   string
+	MatchTimeSec int
+	RedScore     int
+	BlueScore    int
+	StackLights  MqttStackLights
+	RedLedMode   string
+	BlueLedMode  string
+	FieldEStop   bool
+	TeamEStops   map[string]bool // Keyed by alliance station, e.g. "R1".
+	TeamAStops   map[string]bool
+}
+
+type MqttStackLights struct {
+	Red    bool
+	Blue   bool
+	Orange bool
+	Green  bool
+}
+
+type MqttClient struct {
+	mutex      sync.Mutex
+	writeMutex sync.Mutex // Serializes writes to the connection; acquired after the mutex when both are needed.
+	settings   mqttSettings
+	clientId   string
+	conn       net.Conn
+	lastSent   time.Time
+	state      map[string]string
+	published  map[string]string
+	commands   chan MqttCommand
+}
+
+type mqttSettings struct {
+	address     string
+	port        int
+	username    string
+	password    string
+	topicPrefix string
+}
+
+// Creates a new MQTT client, which remains disabled until it is given a broker address.
+func NewMqttClient() *MqttClient {
+	return &MqttClient{
+		clientId: "cheesy-arena-" + rand.Text()[:8],
+		state:    map[string]string{},
+		commands: make(chan MqttCommand, mqttCommandBufferSize),
+	}
+}
+
+// Sets the broker to connect to and the prefix under which to publish topics, reconnecting if they have changed. A
+// blank address disables the client.
+func (client *MqttClient) SetSettings(address string, port int, username, password, topicPrefix string) {
+	if port == 0 {
+		port = mqttDefaultPort
+	}
+	settings := mqttSettings{address, port, username, password, strings.Trim(topicPrefix, "/")}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if settings != client.settings {
+		client.resetConnection(true)
+		client.settings = settings
+	}
+}
+
+// Returns true if a broker address is configured.
+func (client *MqttClient) IsEnabled() bool {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.settings.address != ""
+}
+
+// Returns true if the client is currently connected to the broker.
+func (client *MqttClient) IsConnected() bool {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.conn != nil
+}
+
+// Returns the channel on which commands received from the broker are delivered.
+func (client *MqttClient) Commands() <-chan MqttCommand {
+	return client.commands
+}
+
+// Updates the state to be published; only the values that have changed are sent to the broker.
+func (client *MqttClient) SetState(state *MqttFieldState) {
+	topics := state.topics()
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.state = topics
+}
+
+// Loops indefinitely to maintain the connection to the broker and publish any changes to the state.
+func (client *MqttClient) Run() {
+	for {
+		client.mutex.Lock()
+		conn := client.conn
+		isEnabled := client.settings.address != ""
+		client.mutex.Unlock()
+
+		if conn == nil && isEnabled {
+			var reader *bufio.Reader
+			var err error
+			if conn, reader, err = client.connect(); err != nil {
+				log.Printf("MQTT error: %v", err)
+				time.Sleep(time.Second * mqttRetryIntervalSec)
+				continue
+			}
+			go client.receive(conn, reader)
+		}
+		if conn != nil {
+			if err := client.publishChanges(conn); err != nil {
+				log.Printf("MQTT error: %v", err)
+				client.mutex.Lock()
+				if client.conn == conn {
+					client.resetConnection(false)
+				}
+				client.mutex.Unlock()
+			}
+		}
+
+		time.Sleep(time.Millisecond * mqttLoopPeriodMs)
+	}
+}
+
+// Returns the topic names and payloads representing the given state, relative to the topic prefix.
+func (state *MqttFieldState) topics() map[string]string {
+	topics := map[string]string{
+		"match/state":        state.MatchState,
+		"match/time_sec":     strconv.Itoa(state.MatchTimeSec),
+		"score/red":          strconv.Itoa(state.RedScore),
+		"score/blue":         strconv.Itoa(state.BlueScore),
+		"stack_light/red":    mqttOnOff(state.StackLights.Red),
+		"stack_light/blue":   mqttOnOff(state.StackLights.Blue),
+		"stack_light/orange": mqttOnOff(state.StackLights.Orange),
+		"stack_light/green":  mqttOnOff(state.StackLights.Green),
+		"led/red":            state.RedLedMode,
+		"led/blue":           state.BlueLedMode,
+		"estop/field":        mqttOnOff(state.FieldEStop),
+	}
+	for station, eStop := range state.TeamEStops {
+		topics["estop/"+station] = mqttOnOff(eStop)
+	}
+	for station, aStop := range state.TeamAStops {
+		topics["astop/"+station] = mqttOnOff(aStop)
+	}
+	return topics
+}
+
+func mqttOnOff(value bool) string {
+	if value {
+		return "on"
+	}
+	return "off"
+}
+
+// Opens a session with the broker, subscribes to the command topics and announces that the arena is online.
+func (client *MqttClient) connect() (net.Conn, *bufio.Reader, error) {
+	client.mutex.Lock()
+	settings := client.settings
+	client.mutex.Unlock()
+
+	address := net.JoinHostPort(settings.address, strconv.Itoa(settings.port))
+	conn, err := net.DialTimeout("tcp", address, time.Second*mqttTimeoutSec)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader := bufio.NewReader(conn)
+	conn.SetDeadline(time.Now().Add(time.Second * mqttTimeoutSec))
+	if err = client.handshake(conn, reader, settings); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.settings != settings {
+		conn.Close()
+		return nil, nil, errors.New("settings changed while connecting to the broker")
+	}
+	log.Printf("Connected to MQTT broker at %s", address)
+	client.conn = conn
+	client.lastSent = time.Now()
+	client.published = map[string]string{}
+	return conn, reader, nil
+}
+
+// Performs the exchange of packets that begins each session with the broker.
+func (client *MqttClient) handshake(conn net.Conn, reader *bufio.Reader, settings mqttSettings) error {
+	// Have the broker mark the arena as offline if the connection is lost without a clean disconnection.
+	flags := mqttCleanSessionFlag | mqttWillFlag | mqttWillRetainFlag
+	payload := mqttString(client.clientId)
+	payload = append(payload, mqttString(settings.topicPrefix+"/status")...)
+	payload = append(payload, mqttString("offline")...)
+	if settings.username != "" {
+		flags |= mqttUsernameFlag
+		payload = append(payload, mqttString(settings.username)...)
+		if settings.password != "" {
+			flags |= mqttPasswordFlag
+			payload = append(payload, mqttString(settings.password)...)
+		}
+	}
+	body := mqttString("MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, mqttKeepAliveSec)
+	client.writeMutex.Lock()
+	err := writeMqttPacket(conn, mqttConnect<<4, append(body, payload...))
+	client.writeMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	header, body, err := readMqttPacket(reader)
+	if err != nil {
+		return err
+	}
+	if header>>4 != mqttConnAck || len(body) != 2 {
+		return fmt.Errorf("expected connection acknowledgement from broker but got packet type %d", header>>4)
+	}
+	if body[1] != 0 {
+		return fmt.Errorf("broker refused connection with return code %d", body[1])
+	}
+
+	body = binary.BigEndian.AppendUint16(nil, 1)
+	body = append(body, mqttString(settings.topicPrefix+"/command/+")...)
+	body = append(body, 0)
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+	if err = writeMqttPacket(conn, mqttSubscribe<<4|0x02, body); err != nil {
+		return err
+	}
+	return writeMqttPublish(conn, settings.topicPrefix+"/status", "online")
+}
+
+// Publishes every topic whose value differs from what was last sent on the current connection, and keeps the
+// connection alive when there is nothing to send.
+func (client *MqttClient) publishChanges(conn net.Conn) error {
+	client.mutex.Lock()
+	topicPrefix := client.settings.topicPrefix
+	changes := map[string]string{}
+	for topic, value := range client.state {
+		if publishedValue, ok := client.published[topic]; !ok || publishedValue != value {
+			changes[topic] = value
+		}
+	}
+	needsPing := time.Since(client.lastSent) > time.Second*mqttKeepAliveSec/2
+	client.mutex.Unlock()
+	if len(changes) == 0 && !needsPing {
+		return nil
+	}
+
+	if err := client.writeChanges(conn, topicPrefix, changes); err != nil {
+		return err
+	}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.lastSent = time.Now()
+	for topic, value := range changes {
+		client.published[topic] = value
+	}
+	return nil
+}
+
+// Writes the given changes to the broker, or a ping if there are none. Must be called without the mutex held.
+func (client *MqttClient) writeChanges(conn net.Conn, topicPrefix string, changes map[string]string) error {
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(time.Second * mqttTimeoutSec))
+	for topic, value := range changes {
+		if err := writeMqttPublish(conn, topicPrefix+"/"+topic, value); err != nil {
+			return err
+		}
+	}
+	if len(changes) == 0 {
+		return writeMqttPacket(conn, mqttPingReq<<4, nil)
+	}
+	return nil
+}
+
+// Reads packets from the broker until the connection is closed, passing any commands on to the arena.
+func (client *MqttClient) receive(conn net.Conn, reader *bufio.Reader) {
+	for {
+		// Expect to hear back from the broker at least once per keep-alive period in response to the pings.
+		conn.SetReadDeadline(time.Now().Add(time.Second * mqttKeepAliveSec * 3 / 2))
+		header, body, err := readMqttPacket(reader)
+		if err != nil {
+			client.mutex.Lock()
+			if client.conn == conn {
+				log.Printf("MQTT error: %v", err)
+				client.resetConnection(false)
+			}
+			client.mutex.Unlock()
+			return
+		}
+		if header>>4 != mqttPublish {
+			// Acknowledgements of the subscription and pings need no handling.
+			continue
+		}
+		if header&mqttRetainFlag != 0 {
+			// Don't act upon a stale command that was left retained on the broker.
+			continue
+		}
+
+		topic, err := parseMqttPublishTopic(body)
+		if err != nil {
+			log.Printf("MQTT error: %v", err)
+			continue
+		}
+		client.mutex.Lock()
+		commandPrefix := client.settings.topicPrefix + "/command/"
+		client.mutex.Unlock()
+		command := MqttCommand(strings.TrimPrefix(topic, commandPrefix))
+		if !strings.HasPrefix(topic, commandPrefix) || !slices.Contains(mqttCommands, command) {
+			log.Printf("Ignoring MQTT message on unrecognized topic %q.", topic)
+			continue
+		}
+		select {
+		case client.commands <- command:
+		default:
+			log.Printf("Dropping MQTT command %q since the arena isn't keeping up.", command)
+		}
+	}
+}
+
+// Closes the connection to the broker, if any, first announcing that the arena is going offline if it is a deliberate
+// disconnection. Must be called with the mutex held.
+func (client *MqttClient) resetConnection(isDeliberate bool) {
+	if client.conn == nil {
+		return
+	}
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+	if isDeliberate {
+		client.conn.SetWriteDeadline(time.Now().Add(time.Second * mqttTimeoutSec))
+		writeMqttPublish(client.conn, client.settings.topicPrefix+"/status", "offline")
+		writeMqttPacket(client.conn, mqttDisconnect<<4, nil)
+	}
+	client.conn.Close()
+	client.conn = nil
+}
+
+// Returns the given string in the length-prefixed form used by MQTT.
+func mqttString(value string) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(len(value))), value...)
+}
+
+// Writes a single retained publish packet at QoS 0.
+func writeMqttPublish(writer io.Writer, topic, payload string) error {
+	return writeMqttPacket(writer, mqttPublish<<4|mqttRetainFlag, append(mqttString(topic), payload...))
+}
+
+// Writes a packet with the given first byte (type and flags) and body, prefixed with the encoded body length.
+func writeMqttPacket(writer io.Writer, header byte, body []byte) error {
+	packet := []byte{header}
+	length := len(body)
+	for {
+		encodedByte := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			encodedByte |= 0x80
+		}
+		packet = append(packet, encodedByte)
+		if length == 0 {
+			break
+		}
+	}
+	_, err := writer.Write(append(packet, body...))
+	return err
+}
+
+// Reads a single packet, returning its first byte (type and flags) and its body.
+func readMqttPacket(reader *bufio.Reader) (byte, []byte, error) {
+	header, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length := 0
+	for shift := 0; ; shift += 7 {
+		if shift > 21 {
+			return 0, nil, errors.New("malformed MQTT packet length")
+		}
+		encodedByte, err := reader.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length |= int(encodedByte&0x7f) << shift
+		if encodedByte&0x80 == 0 {
+			break
+		}
+	}
+	if length > mqttMaxPacketBytes {
+		return 0, nil, fmt.Errorf("MQTT packet of %d bytes is too large", length)
+	}
+	body := make([]byte, length)
+	if _, err = io.ReadFull(reader, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+// Returns the topic of the given publish packet body.
+func parseMqttPublishTopic(body []byte) (string, error) {
+	if len(body) < 2 || len(body) < 2+int(binary.BigEndian.Uint16(body)) {
+		return "", errors.New("malformed MQTT publish packet")
+	}
+	return string(body[2 : 2+int(binary.BigEndian.Uint16(body))]), nil
+}
@@ -0,0 +1,372 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMqttPublishesState(t *testing.T) {
+	broker := newTestMqttBroker(t)
+	client := NewMqttClient()
+	client.SetSettings("127.0.0.1", broker.port(), "", "", "/arena/")
+	assert.True(t, client.IsEnabled())
+	assert.False(t, client.IsConnected())
+	conn, reader, err := client.connect()
+	if !assert.Nil(t, err) {
+		return
+	}
+	go client.receive(conn, reader)
+	assert.True(t, client.IsConnected())
+	assert.Equal(t, "online", broker.waitForRetained("arena/status"))
+	broker.waitForSubscription("arena/command/+")
+
+	state := MqttFieldState{
+		MatchState:   "teleop",
+		MatchTimeSec: 42,
+		RedScore:     101,
+		BlueScore:    99,
+		StackLights:  MqttStackLights{Orange: true},
+		RedLedMode:   "Red",
+		BlueLedMode:  "Off",
+		TeamEStops:   map[string]bool{"R1": false, "B3": true},
+		TeamAStops:   map[string]bool{"R1": true, "B3": false},
+	}
+	client.SetState(&state)
+	assert.Nil(t, client.publishChanges(conn))
+	assert.Equal(t, "teleop", broker.waitForRetained("arena/match/state"))
+	assert.Equal(t, "42", broker.waitForRetained("arena/match/time_sec"))
+	assert.Equal(t, "101", broker.waitForRetained("arena/score/red"))
+	assert.Equal(t, "99", broker.waitForRetained("arena/score/blue"))
+	assert.Equal(t, "on", broker.waitForRetained("arena/stack_light/orange"))
+	assert.Equal(t, "off", broker.waitForRetained("arena/stack_light/green"))
+	assert.Equal(t, "Red", broker.waitForRetained("arena/led/red"))
+	assert.Equal(t, "Off", broker.waitForRetained("arena/led/blue"))
+	assert.Equal(t, "off", broker.waitForRetained("arena/estop/field"))
+	assert.Equal(t, "on", broker.waitForRetained("arena/estop/B3"))
+	assert.Equal(t, "on", broker.waitForRetained("arena/astop/R1"))
+
+	// Check that only the values that have changed are published again.
+	numPublishes := broker.numPublishes()
+	state.MatchTimeSec = 43
+	state.FieldEStop = true
+	client.SetState(&state)
+	assert.Nil(t, client.publishChanges(conn))
+	assert.Eventually(
+		t, func() bool { return broker.numPublishes() == numPublishes+2 }, time.Second, time.Millisecond,
+	)
+	assert.Equal(t, "43", broker.waitForRetained("arena/match/time_sec"))
+	assert.Equal(t, "on", broker.waitForRetained("arena/estop/field"))
+
+	// Check that the broker marks the arena offline if the connection drops.
+	conn.Close()
+	assert.Eventually(
+		t, func() bool { return broker.retained("arena/status") == "offline" }, time.Second, time.Millisecond,
+	)
+	assert.Eventually(t, func() bool { return !client.IsConnected() }, time.Second, time.Millisecond)
+}
+
+func TestMqttCommands(t *testing.T) {
+	broker := newTestMqttBroker(t)
+	client := NewMqttClient()
+	client.SetSettings("127.0.0.1", broker.port(), "", "", "arena")
+	conn, reader, err := client.connect()
+	if !assert.Nil(t, err) {
+		return
+	}
+	go client.receive(conn, reader)
+	broker.waitForSubscription("arena/command/+")
+
+	broker.publish("arena/command/signal_volunteers", false)
+	broker.publish("arena/command/start_match", false)
+	broker.publish("arena/command/signal_reset", true)
+	broker.publish("arena/command/signal_reset", false)
+	for _, expectedCommand := range []MqttCommand{MqttCommandSignalVolunteers, MqttCommandSignalReset} {
+		select {
+		case command := <-client.Commands():
+			assert.Equal(t, expectedCommand, command)
+		case <-time.After(time.Second):
+			assert.Fail(t, "Timed out waiting for MQTT command")
+		}
+	}
+	select {
+	case command := <-client.Commands():
+		assert.Fail(t, "Unexpected MQTT command", command)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Check that changing the settings disconnects cleanly.
+	client.SetSettings("127.0.0.1", broker.port(), "", "", "other")
+	assert.False(t, client.IsConnected())
+	assert.Eventually(
+		t, func() bool { return broker.retained("arena/status") == "offline" }, time.Second, time.Millisecond,
+	)
+}
+
+func TestMqttConcurrentWrites(t *testing.T) {
+	broker := newTestMqttBroker(t)
+	client := NewMqttClient()
+	client.SetSettings("127.0.0.1", broker.port(), "", "", "arena")
+	conn, reader, err := client.connect()
+	if !assert.Nil(t, err) {
+		return
+	}
+	go client.receive(conn, reader)
+	assert.Equal(t, "online", broker.waitForRetained("arena/status"))
+
+	// Keep publishing state changes while the settings change out from under the connection.
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+		for i := 0; ; i++ {
+			client.SetState(&MqttFieldState{MatchTimeSec: i})
+			if client.publishChanges(conn) != nil {
+				return
+			}
+		}
+	}()
+	assert.Eventually(t, func() bool { return broker.numPublishes() > 100 }, time.Second, time.Millisecond)
+	client.SetSettings("127.0.0.1", broker.port(), "", "", "other")
+	waitGroup.Wait()
+	assert.False(t, client.IsConnected())
+	assert.Eventually(
+		t, func() bool { return broker.retained("arena/status") == "offline" }, time.Second, time.Millisecond,
+	)
+}
+
+func TestMqttAuthentication(t *testing.T) {
+	broker := newTestMqttBroker(t)
+	broker.username = "arena"
+	broker.password = "hunter2"
+	client := NewMqttClient()
+
+	client.SetSettings("127.0.0.1", broker.port(), "arena", "wrong", "arena")
+	_, _, err := client.connect()
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "return code 5")
+	}
+	assert.False(t, client.IsConnected())
+
+	client.SetSettings("127.0.0.1", broker.port(), "arena", "hunter2", "arena")
+	conn, _, err := client.connect()
+	assert.Nil(t, err)
+	assert.True(t, client.IsConnected())
+	conn.Close()
+
+	// Check that a broker that isn't listening results in an error.
+	client.SetSettings("127.0.0.1", 1, "", "", "arena")
+	_, _, err = client.connect()
+	assert.NotNil(t, err)
+}
+
+func TestMqttPacketEncoding(t *testing.T) {
+	for _, length := range []int{0, 127, 128, 16383, 16384, 60000} {
+		var buffer bytes.Buffer
+		body := bytes.Repeat([]byte{0xab}, length)
+		assert.Nil(t, writeMqttPacket(&buffer, mqttPublish<<4, body))
+		header, readBody, err := readMqttPacket(bufio.NewReader(&buffer))
+		assert.Nil(t, err)
+		assert.Equal(t, mqttPublish<<4, header)
+		assert.Equal(t, length, len(readBody))
+	}
+
+	_, _, err := readMqttPacket(bufio.NewReader(bytes.NewReader([]byte{0x30, 0xff, 0xff, 0xff, 0xff, 0x01})))
+	assert.NotNil(t, err)
+	_, _, err = readMqttPacket(bufio.NewReader(bytes.NewReader([]byte{0x30, 0x05, 0x00})))
+	assert.NotNil(t, err)
+
+	topic, err := parseMqttPublishTopic(append(mqttString("a/b"), "payload"...))
+	assert.Nil(t, err)
+	assert.Equal(t, "a/b", topic)
+	_, err = parseMqttPublishTopic([]byte{0x00, 0x05, 'a'})
+	assert.NotNil(t, err)
+}
+
+// A minimal in-process MQTT broker supporting just what the arena client uses.
+type testMqttBroker struct {
+	t              *testing.T
+	listener       net.Listener
+	username       string
+	password       string
+	mutex          sync.Mutex
+	retainedValues map[string]string
+	subscriptions  map[net.Conn][]string
+	publishCount   int
+}
+
+func newTestMqttBroker(t *testing.T) *testMqttBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	broker := &testMqttBroker{
+		t:              t,
+		listener:       listener,
+		retainedValues: map[string]string{},
+		subscriptions:  map[net.Conn][]string{},
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go broker.handleConnection(conn)
+		}
+	}()
+	return broker
+}
+
+func (broker *testMqttBroker) port() int {
+	return broker.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (broker *testMqttBroker) handleConnection(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	header, body, err := readMqttPacket(reader)
+	if err != nil || header>>4 != mqttConnect {
+		return
+	}
+
+	// Parse the connect packet, skipping over the protocol name, level, flags and keep-alive.
+	flags := body[7]
+	fields := body[10:]
+	readField := func() string {
+		length := int(binary.BigEndian.Uint16(fields))
+		value := string(fields[2 : 2+length])
+		fields = fields[2+length:]
+		return value
+	}
+	readField()
+	var willTopic, willMessage, username, password string
+	if flags&mqttWillFlag != 0 {
+		willTopic = readField()
+		willMessage = readField()
+	}
+	if flags&mqttUsernameFlag != 0 {
+		username = readField()
+	}
+	if flags&mqttPasswordFlag != 0 {
+		password = readField()
+	}
+	if broker.username != "" && (username != broker.username || password != broker.password) {
+		writeMqttPacket(conn, mqttConnAck<<4, []byte{0, 5})
+		return
+	}
+	writeMqttPacket(conn, mqttConnAck<<4, []byte{0, 0})
+
+	for {
+		header, body, err = readMqttPacket(reader)
+		if err != nil {
+			break
+		}
+		switch header >> 4 {
+		case mqttPublish:
+			topic, _ := parseMqttPublishTopic(body)
+			broker.mutex.Lock()
+			broker.publishCount++
+			if header&mqttRetainFlag != 0 {
+				broker.retainedValues[topic] = string(body[2+len(topic):])
+			}
+			broker.mutex.Unlock()
+		case mqttSubscribe:
+			length := int(binary.BigEndian.Uint16(body[2:]))
+			broker.mutex.Lock()
+			broker.subscriptions[conn] = append(broker.subscriptions[conn], string(body[4:4+length]))
+			broker.mutex.Unlock()
+			writeMqttPacket(conn, 9<<4, []byte{body[0], body[1], 0})
+		case mqttPingReq:
+			writeMqttPacket(conn, 13<<4, nil)
+		case mqttDisconnect:
+			willTopic = ""
+		}
+	}
+
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	delete(broker.subscriptions, conn)
+	if willTopic != "" {
+		broker.retainedValues[willTopic] = willMessage
+	}
+}
+
+// Sends an empty message on the given topic to all connections subscribed to it.
+func (broker *testMqttBroker) publish(topic string, retain bool) {
+	header := mqttPublish << 4
+	if retain {
+		header |= mqttRetainFlag
+	}
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	for conn, filters := range broker.subscriptions {
+		for _, filter := range filters {
+			if mqttTopicMatches(filter, topic) {
+				writeMqttPacket(conn, header, mqttString(topic))
+			}
+		}
+	}
+}
+
+func (broker *testMqttBroker) retained(topic string) string {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	return broker.retainedValues[topic]
+}
+
+func (broker *testMqttBroker) numPublishes() int {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	return broker.publishCount
+}
+
+func (broker *testMqttBroker) waitForRetained(topic string) string {
+	assert.Eventually(
+		broker.t, func() bool { return broker.retained(topic) != "" }, time.Second, time.Millisecond, topic,
+	)
+	return broker.retained(topic)
+}
+
+func (broker *testMqttBroker) waitForSubscription(filter string) {
+	assert.Eventually(
+		broker.t,
+		func() bool {
+			broker.mutex.Lock()
+			defer broker.mutex.Unlock()
+			for _, filters := range broker.subscriptions {
+				for _, subscribedFilter := range filters {
+					if subscribedFilter == filter {
+						return true
+					}
+				}
+			}
+			return false
+		},
+		time.Second,
+		time.Millisecond,
+	)
+}
+
+// Returns true if the given topic matches the given filter, which may contain single-level wildcards.
+func mqttTopicMatches(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	if len(filterLevels) != len(topicLevels) {
+		return false
+	}
+	for i := range filterLevels {
+		if filterLevels[i] != "+" && filterLevels[i] != topicLevels[i] {
+			return false
+		}
+	}
+	return true
+}
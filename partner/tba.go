@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
-	"github.com/mitchellh/mapstructure"
 	"io"
 	"log"
 	"net/http"
@@ -53,51 +52,6 @@ type TbaAlliance struct {
 	Score      *int     `json:"score"`
 }
 
-type TbaScoreBreakdown struct {
-	AutoTowerRobot1      string `mapstructure:"autoTowerRobot1"`
-	AutoTowerRobot2      string `mapstructure:"autoTowerRobot2"`
-	AutoTowerRobot3      string `mapstructure:"autoTowerRobot3"`
-	AutoTowerPoints      int    `mapstructure:"autoTowerPoints"`
-	HubScore             tbaHub `mapstructure:"hubScore"`
-	EndGameTowerRobot1   string `mapstructure:"endGameTowerRobot1"`
-	EndGameTowerRobot2   string `mapstructure:"endGameTowerRobot2"`
-	EndGameTowerRobot3   string `mapstructure:"endGameTowerRobot3"`
-	EndGameTowerPoints   int    `mapstructure:"endGameTowerPoints"`
-	MinorFoulCount       int    `mapstructure:"minorFoulCount"`
-	MajorFoulCount       int    `mapstructure:"majorFoulCount"`
-	FoulPoints           int    `mapstructure:"foulPoints"`
-	TotalTowerPoints     int    `mapstructure:"totalTowerPoints"`
-	TotalAutoPoints      int    `mapstructure:"totalAutoPoints"`
-	TotalTeleopPoints    int    `mapstructure:"totalTeleopPoints"`
-	TotalPoints          int    `mapstructure:"totalPoints"`
-	EnergizedAchieved    bool   `mapstructure:"energizedAchieved"`
-	SuperchargedAchieved bool   `mapstructure:"superchargedAchieved"`
-	TraversalAchieved    bool   `mapstructure:"traversalAchieved"`
-	G206Penalty          bool   `mapstructure:"g206Penalty"`
-	RP                   int    `mapstructure:"rp"`
-}
-
-type tbaHub struct {
-	AutoCount        int `mapstructure:"autoCount"`
-	AutoPoints       int `mapstructure:"autoPoints"`
-	TransitionCount  int `mapstructure:"transitionCount"`
-	TransitionPoints int `mapstructure:"transitionPoints"`
-	Shift1Count      int `mapstructure:"shift1Count"`
-	Shift1Points     int `mapstructure:"shift1Points"`
-	Shift2Count      int `mapstructure:"shift2Count"`
-	Shift2Points     int `mapstructure:"shift2Points"`
-	Shift3Count      int `mapstructure:"shift3Count"`
-	Shift3Points     int `mapstructure:"shift3Points"`
-	Shift4Count      int `mapstructure:"shift4Count"`
-	Shift4Points     int `mapstructure:"shift4Points"`
-	EndgameCount     int `mapstructure:"endgameCount"`
-	EndgamePoints    int `mapstructure:"endgamePoints"`
-	TeleopCount      int `mapstructure:"teleopCount"`
-	TeleopPoints     int `mapstructure:"teleopPoints"`
-	TotalCount       int `mapstructure:"totalCount"`
-	TotalPoints      int `mapstructure:"totalPoints"`
-}
-
 type TbaRanking struct {
 	TeamKey  string `json:"team_key"`
 	Rank     int    `json:"rank"`
@@ -155,12 +109,6 @@ type TbaPublishedAward struct {
 }
 
 var leaveMapping = map[bool]string{false: "No", true: "Yes"}
-var towerStatusMapping = map[game.TowerStatus]string{
-	game.TowerNone:   "None",
-	game.TowerLevel1: "Level1",
-	game.TowerLevel2: "Level2",
-	game.TowerLevel3: "Level3",
-}
 
 func NewTbaClient(eventCode, secretId, secret string) *TbaClient {
 	return &TbaClient{
@@ -448,6 +396,11 @@ func (client *TbaClient) PublishAlliances(database *model.Database) error {
 		for _, allianceTeamId := range alliance.TeamIds {
 			tbaAlliances[i] = append(tbaAlliances[i], getTbaTeam(allianceTeamId))
 		}
+
+		// Any called-up backup team occupies the slot after the alliance's picks.
+		if alliance.BackupTeamId != 0 {
+			tbaAlliances[i] = append(tbaAlliances[i], getTbaTeam(alliance.BackupTeamId))
+		}
 	}
 	jsonBody, err := json.Marshal(tbaAlliances)
 	if err != nil {
@@ -470,6 +423,18 @@ func (client *TbaClient) PublishAlliances(database *model.Database) error {
 	playoffType := 0
 	if eventSettings.PlayoffType == model.DoubleEliminationPlayoff {
 		playoffType = 10
+		if eventSettings.NumPlayoffAlliances == 6 {
+			// TBA has no six-alliance double-elimination bracket, so use its custom type.
+			playoffType = 8
+		}
+	} else if eventSettings.PlayoffType == model.RoundRobinPlayoff {
+		playoffType = 8
+		if eventSettings.NumPlayoffAlliances == 6 {
+			playoffType = 4
+		}
+	} else if eventSettings.PlayoffType == model.CustomPlayoff {
+		// A user-defined bracket doesn't match any of TBA's built-in formats.
+		playoffType = 8
 	}
 	resp, err = client.postRequest("info", "update", []byte(fmt.Sprintf("{\"playoff_type\":%d}", playoffType)))
 	if err != nil {
@@ -633,7 +598,6 @@ func createTbaScoringBreakdown(
 	matchResult *model.MatchResult,
 	alliance string,
 ) (map[string]any, error) {
-	var breakdown TbaScoreBreakdown
 	var score *game.Score
 	var scoreSummary, opponentScoreSummary *game.ScoreSummary
 	if alliance == "red" {
@@ -646,72 +610,13 @@ func createTbaScoringBreakdown(
 		opponentScoreSummary = matchResult.RedScoreSummary()
 	}
 
-	breakdown.AutoTowerRobot1 = towerStatusMapping[score.AutoTowerStatuses[0]]
-	breakdown.AutoTowerRobot2 = towerStatusMapping[score.AutoTowerStatuses[1]]
-	breakdown.AutoTowerRobot3 = towerStatusMapping[score.AutoTowerStatuses[2]]
-	breakdown.AutoTowerPoints = scoreSummary.AutoTowerPoints
-	breakdown.HubScore.AutoCount = score.Hub.GetShiftCount(game.ShiftAuto, true)
-	breakdown.HubScore.AutoPoints = score.Hub.GetShiftCount(game.ShiftAuto, true)
-	breakdown.HubScore.TransitionCount = score.Hub.GetShiftCount(game.ShiftTransition, true)
-	breakdown.HubScore.TransitionPoints = score.Hub.GetShiftCount(game.ShiftTransition, true)
-	breakdown.HubScore.Shift1Count = score.Hub.GetShiftCount(game.Shift1, true)
-	breakdown.HubScore.Shift1Points = score.Hub.GetShiftCount(game.Shift1, true)
-	breakdown.HubScore.Shift2Count = score.Hub.GetShiftCount(game.Shift2, true)
-	breakdown.HubScore.Shift2Points = score.Hub.GetShiftCount(game.Shift2, true)
-	breakdown.HubScore.Shift3Count = score.Hub.GetShiftCount(game.Shift3, true)
-	breakdown.HubScore.Shift3Points = score.Hub.GetShiftCount(game.Shift3, true)
-	breakdown.HubScore.Shift4Count = score.Hub.GetShiftCount(game.Shift4, true)
-	breakdown.HubScore.Shift4Points = score.Hub.GetShiftCount(game.Shift4, true)
-	breakdown.HubScore.EndgameCount = score.Hub.GetShiftCount(game.ShiftEndgame, true) +
-		score.Hub.GetShiftCount(game.ShiftPostMatch, true)
-	breakdown.HubScore.EndgamePoints = score.Hub.GetShiftCount(game.ShiftEndgame, true) +
-		score.Hub.GetShiftCount(game.ShiftPostMatch, true)
-	breakdown.HubScore.TeleopCount = breakdown.HubScore.TransitionCount + breakdown.HubScore.Shift1Count +
-		breakdown.HubScore.Shift2Count + breakdown.HubScore.Shift3Count + breakdown.HubScore.Shift4Count +
-		breakdown.HubScore.EndgameCount
-	breakdown.HubScore.TeleopPoints = score.Hub.GetTeleopActiveFuelCount()
-	breakdown.HubScore.TotalCount = breakdown.HubScore.AutoCount + breakdown.HubScore.TeleopCount
-	breakdown.HubScore.TotalPoints = breakdown.HubScore.AutoPoints + breakdown.HubScore.TeleopPoints
-	breakdown.EndGameTowerRobot1 = towerStatusMapping[score.EndgameTowerStatuses[0]]
-	breakdown.EndGameTowerRobot2 = towerStatusMapping[score.EndgameTowerStatuses[1]]
-	breakdown.EndGameTowerRobot3 = towerStatusMapping[score.EndgameTowerStatuses[2]]
-	breakdown.EndGameTowerPoints = scoreSummary.TeleopTowerPoints
-	breakdown.TotalTowerPoints = scoreSummary.AutoTowerPoints + scoreSummary.TeleopTowerPoints
-	breakdown.TotalAutoPoints = scoreSummary.AutoFuelPoints + scoreSummary.AutoTowerPoints
-	breakdown.TotalTeleopPoints = scoreSummary.TeleopFuelPoints + scoreSummary.TeleopTowerPoints
-	breakdown.EnergizedAchieved = scoreSummary.EnergizedBonusRankingPoint
-	breakdown.SuperchargedAchieved = scoreSummary.SuperchargedBonusRankingPoint
-	breakdown.TraversalAchieved = scoreSummary.TraversalBonusRankingPoint
-
-	for _, foul := range score.Fouls {
-		if foul.IsMajor {
-			breakdown.MajorFoulCount++
-		} else if foul.PointValue() > 0 {
-			breakdown.MinorFoulCount++
-		}
-		if foul.Rule() != nil && foul.Rule().IsRankingPoint {
-			switch foul.Rule().RuleNumber {
-			case "G206":
-				breakdown.G206Penalty = true
-			}
-		}
-	}
-	breakdown.FoulPoints = scoreSummary.FoulPoints
-	breakdown.TotalPoints = scoreSummary.Score
-
+	rankingPoints := 0
 	if match.ShouldUpdateRankings() {
-		// Calculate and set the ranking points for the match.
+		// Calculate the ranking points for the match.
 		var ranking game.Ranking
 		ranking.AddScoreSummary(scoreSummary, opponentScoreSummary, false)
-		breakdown.RP = ranking.RankingPoints
-	}
-
-	// Turn the breakdown struct into a map in order to be able to remove any fields that are disabled based on the
-	// event settings (none in 2026).
-	breakdownMap := make(map[string]any)
-	if err := mapstructure.Decode(breakdown, &breakdownMap); err != nil {
-		return nil, err
+		rankingPoints = ranking.RankingPoints
 	}
 
-	return breakdownMap, nil
+	return game.CurrentGame.TbaScoreBreakdown(score, scoreSummary, opponentScoreSummary, rankingPoints)
 }
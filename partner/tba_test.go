@@ -6,6 +6,7 @@ package partner
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
@@ -119,6 +120,9 @@ func TestPublishAlliances(t *testing.T) {
 	database := setupTestDb(t)
 
 	model.BuildTestAlliances(database)
+	expectedAlliances := "[[\"frc254\",\"frc469\",\"frc2848\",\"frc74\",\"frc3175\"]," +
+		"[\"frc1718\",\"frc2451\",\"frc1619\"]]"
+	expectedPlayoffType := "{\"playoff_type\":10}"
 
 	// Mock the TBA server.
 	tbaServer := httptest.NewServer(
@@ -127,13 +131,9 @@ func TestPublishAlliances(t *testing.T) {
 				var reader bytes.Buffer
 				reader.ReadFrom(r.Body)
 				if strings.Contains(r.URL.String(), "alliance_selections") {
-					assert.Equal(
-						t,
-						"[[\"frc254\",\"frc469\",\"frc2848\",\"frc74\",\"frc3175\"],[\"frc1718\",\"frc2451\",\"frc1619\"]]",
-						reader.String(),
-					)
+					assert.Equal(t, expectedAlliances, reader.String())
 				} else {
-					assert.Equal(t, "{\"playoff_type\":10}", reader.String())
+					assert.Equal(t, expectedPlayoffType, reader.String())
 				}
 			},
 		),
@@ -143,6 +143,35 @@ func TestPublishAlliances(t *testing.T) {
 	client.BaseUrl = tbaServer.URL
 
 	assert.Nil(t, client.PublishAlliances(database))
+
+	playoffTypeTests := []struct {
+		playoffType         model.PlayoffType
+		numAlliances        int
+		expectedPlayoffType int
+	}{
+		{model.DoubleEliminationPlayoff, 8, 10},
+		{model.DoubleEliminationPlayoff, 6, 8},
+		{model.SingleEliminationPlayoff, 8, 0},
+		{model.RoundRobinPlayoff, 8, 8},
+		{model.RoundRobinPlayoff, 6, 4},
+		{model.CustomPlayoff, 8, 8},
+	}
+	eventSettings, _ := database.GetEventSettings()
+	for _, test := range playoffTypeTests {
+		eventSettings.PlayoffType = test.playoffType
+		eventSettings.NumPlayoffAlliances = test.numAlliances
+		assert.Nil(t, database.UpdateEventSettings(eventSettings))
+		expectedPlayoffType = fmt.Sprintf("{\"playoff_type\":%d}", test.expectedPlayoffType)
+		assert.Nil(t, client.PublishAlliances(database))
+	}
+
+	// A called-up backup team is published after the alliance's picks.
+	alliance, _ := database.GetAllianceById(2)
+	assert.Nil(t, alliance.CallUpBackup(188, 2451, time.Unix(1000, 0)))
+	assert.Nil(t, database.UpdateAlliance(alliance))
+	expectedAlliances = "[[\"frc254\",\"frc469\",\"frc2848\",\"frc74\",\"frc3175\"]," +
+		"[\"frc1718\",\"frc2451\",\"frc1619\",\"frc188\"]]"
+	assert.Nil(t, client.PublishAlliances(database))
 }
 
 func TestPublishingErrors(t *testing.T) {
@@ -184,8 +213,8 @@ func TestCheckTbaPostResponseClosesBody(t *testing.T) {
 func TestPublishAwards(t *testing.T) {
 	database := setupTestDb(t)
 
-	database.CreateAward(&model.Award{0, model.JudgedAward, "Saftey Award", 254, ""})
-	database.CreateAward(&model.Award{0, model.JudgedAward, "Spirt Award", 0, "Bob Dorough"})
+	database.CreateAward(&model.Award{0, model.JudgedAward, "Saftey Award", 254, "", false})
+	database.CreateAward(&model.Award{0, model.JudgedAward, "Spirt Award", 0, "Bob Dorough", false})
 
 	// Mock the TBA server.
 	tbaServer := httptest.NewServer(
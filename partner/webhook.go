@@ -0,0 +1,318 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client for notifying admin-configured webhooks of changes over the lifecycle of the event.
+
+package partner
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	webhookTimeout          = 5 * time.Second
+	webhookEventHeader      = "X-Cheesy-Arena-Event"
+	webhookDeliveryHeader   = "X-Cheesy-Arena-Delivery"
+	webhookSignatureHeader  = "X-Cheesy-Arena-Signature"
+	webhookMaxResponseBytes = 1024
+)
+
+// WebhookEvent represents the different events that webhooks can subscribe to.
+type WebhookEvent string
+
+const (
+	WebhookMatchLoaded              WebhookEvent = "match_loaded"
+	WebhookMatchStarted             WebhookEvent = "match_started"
+	WebhookMatchEnded               WebhookEvent = "match_ended"
+	WebhookScorePosted              WebhookEvent = "score_posted"
+	WebhookRankingsUpdated          WebhookEvent = "rankings_updated"
+	WebhookAllianceSelectionChanged WebhookEvent = "alliance_selection_changed"
+	WebhookAwardRevealed            WebhookEvent = "award_revealed"
+)
+
+// All events that webhooks can subscribe to, in the order they are listed when configuring a webhook.
+var WebhookEvents = []WebhookEvent{
+	WebhookMatchLoaded,
+	WebhookMatchStarted,
+	WebhookMatchEnded,
+	WebhookScorePosted,
+	WebhookRankingsUpdated,
+	WebhookAllianceSelectionChanged,
+	WebhookAwardRevealed,
+}
+
+// The delays before each successive retry of a failed delivery, after which it is abandoned.
+var webhookRetryDelays = []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute, 10 * time.Minute}
+
+type WebhookClient struct {
+	database    *model.Database
+	httpClient  *http.Client
+	retryDelays []time.Duration
+	deliveries  sync.WaitGroup
+}
+
+// The body of each request sent to a webhook; the data differs by event.
+type webhookPayload struct {
+	Event      WebhookEvent `json:"event"`
+	DeliveryId string       `json:"deliveryId"`
+	Timestamp  time.Time    `json:"timestamp"`
+	Data       any          `json:"data"`
+}
+
+type WebhookMatch struct {
+	Id          int    `json:"id"`
+	Type        string `json:"type"`
+	ShortName   string `json:"shortName"`
+	LongName    string `json:"longName"`
+	FieldNumber int    `json:"fieldNumber"`
+	RedTeams    []int  `json:"redTeams"`
+	BlueTeams   []int  `json:"blueTeams"`
+}
+
+type WebhookMatchEnd struct {
+	Match   WebhookMatch `json:"match"`
+	Aborted bool         `json:"aborted"`
+}
+
+type WebhookScore struct {
+	Match     WebhookMatch `json:"match"`
+	RedScore  int          `json:"redScore"`
+	BlueScore int          `json:"blueScore"`
+	Winner    string       `json:"winner"`
+	IsEdit    bool         `json:"isEdit"`
+}
+
+type WebhookRanking struct {
+	Rank          int `json:"rank"`
+	TeamNumber    int `json:"teamNumber"`
+	RankingPoints int `json:"rankingPoints"`
+	Wins          int `json:"wins"`
+	Losses        int `json:"losses"`
+	Ties          int `json:"ties"`
+	Played        int `json:"played"`
+}
+
+type WebhookRankings struct {
+	Rankings []WebhookRanking `json:"rankings"`
+}
+
+type WebhookAlliance struct {
+	Id    int   `json:"id"`
+	Teams []int `json:"teams"`
+}
+
+type WebhookAllianceSelection struct {
+	Alliances []WebhookAlliance `json:"alliances"`
+}
+
+type WebhookAward struct {
+	Name       string `json:"name"`
+	TeamNumber int    `json:"teamNumber"`
+	PersonName string `json:"personName"`
+}
+
+// Creates a new webhook client that delivers events to the webhooks configured in the given database.
+func NewWebhookClient(database *model.Database) *WebhookClient {
+	return &WebhookClient{
+		database:    database,
+		httpClient:  &http.Client{Timeout: webhookTimeout},
+		retryDelays: webhookRetryDelays,
+	}
+}
+
+// Sends the given event to every enabled webhook subscribed to it. Delivery happens in the background, with failed
+// attempts retried with increasing delays.
+func (client *WebhookClient) SendEvent(event WebhookEvent, data any) {
+	webhooks, err := client.database.GetAllWebhooks()
+	if err != nil {
+		log.Printf("Failed to get webhooks: %v", err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Enabled || !webhook.IsSubscribedTo(string(event)) {
+			continue
+		}
+		payload := webhookPayload{Event: event, DeliveryId: rand.Text(), Timestamp: time.Now().UTC(), Data: data}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("Failed to encode webhook payload for event %s: %v", event, err)
+			return
+		}
+		client.deliveries.Go(func() {
+			client.deliver(webhook, event, payload.DeliveryId, body)
+		})
+	}
+}
+
+// Returns the value of the signature header for the given request body, allowing the receiver to verify that the
+// request came from this server.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Returns the webhook representation of the given match.
+func NewWebhookMatch(match *model.Match) WebhookMatch {
+	return WebhookMatch{
+		Id:          match.Id,
+		Type:        strings.ToLower(match.Type.String()),
+		ShortName:   match.ShortName,
+		LongName:    match.LongName,
+		FieldNumber: match.FieldNumber,
+		RedTeams:    []int{match.Red1, match.Red2, match.Red3},
+		BlueTeams:   []int{match.Blue1, match.Blue2, match.Blue3},
+	}
+}
+
+// Returns the webhook representation of the given committed match score.
+func NewWebhookScore(match *model.Match, redScore, blueScore int, isEdit bool) WebhookScore {
+	winner := ""
+	switch match.Status {
+	case game.RedWonMatch:
+		winner = "red"
+	case game.BlueWonMatch:
+		winner = "blue"
+	case game.TieMatch:
+		winner = "tie"
+	}
+	return WebhookScore{
+		Match:     NewWebhookMatch(match),
+		RedScore:  redScore,
+		BlueScore: blueScore,
+		Winner:    winner,
+		IsEdit:    isEdit,
+	}
+}
+
+// Returns the webhook representation of the given rankings.
+func NewWebhookRankings(rankings game.Rankings) WebhookRankings {
+	webhookRankings := WebhookRankings{Rankings: []WebhookRanking{}}
+	for _, ranking := range rankings {
+		webhookRankings.Rankings = append(
+			webhookRankings.Rankings,
+			WebhookRanking{
+				Rank:          ranking.Rank,
+				TeamNumber:    ranking.TeamId,
+				RankingPoints: ranking.RankingPoints,
+				Wins:          ranking.Wins,
+				Losses:        ranking.Losses,
+				Ties:          ranking.Ties,
+				Played:        ranking.Played,
+			},
+		)
+	}
+	return webhookRankings
+}
+
+// Returns the webhook representation of the given alliances as they stand during alliance selection.
+func NewWebhookAllianceSelection(alliances []model.Alliance) WebhookAllianceSelection {
+	allianceSelection := WebhookAllianceSelection{Alliances: []WebhookAlliance{}}
+	for _, alliance := range alliances {
+		teams := []int{}
+		for _, teamId := range alliance.TeamIds {
+			if teamId > 0 {
+				teams = append(teams, teamId)
+			}
+		}
+		allianceSelection.Alliances = append(allianceSelection.Alliances, WebhookAlliance{alliance.Id, teams})
+	}
+	return allianceSelection
+}
+
+// Returns the webhook representation of the given award.
+func NewWebhookAward(award *model.Award) WebhookAward {
+	return WebhookAward{Name: award.AwardName, TeamNumber: award.TeamId, PersonName: award.PersonName}
+}
+
+// Attempts delivery of the given request body to the given webhook until it succeeds or the retries are exhausted,
+// recording each attempt in the delivery log.
+func (client *WebhookClient) deliver(webhook model.Webhook, event WebhookEvent, deliveryId string, body []byte) {
+	for attempt := 1; ; attempt++ {
+		delivery := model.WebhookDelivery{
+			WebhookId:  webhook.Id,
+			Url:        webhook.Url,
+			DeliveryId: deliveryId,
+			Event:      string(event),
+			Attempt:    attempt,
+			Time:       time.Now(),
+			Payload:    string(body),
+		}
+		var err error
+		delivery.StatusCode, err = client.post(webhook, event, deliveryId, body)
+		if err == nil {
+			delivery.Delivered = true
+		} else {
+			delivery.Error = err.Error()
+		}
+		if err := client.database.CreateWebhookDelivery(&delivery); err != nil {
+			log.Printf("Failed to record webhook delivery: %v", err)
+		}
+
+		if delivery.Delivered {
+			return
+		}
+		if attempt > len(client.retryDelays) {
+			log.Printf("Giving up on delivering %s event to webhook %s: %v", event, webhook.Url, err)
+			return
+		}
+		time.Sleep(client.retryDelays[attempt-1])
+
+		// Pick up any changes made to the webhook in the meantime, and stop if it no longer wants the event.
+		latestWebhook, err := client.database.GetWebhookById(webhook.Id)
+		if err != nil {
+			log.Printf("Failed to get webhook %d: %v", webhook.Id, err)
+			return
+		}
+		if latestWebhook == nil || !latestWebhook.Enabled || !latestWebhook.IsSubscribedTo(string(event)) {
+			log.Printf("Abandoning delivery of %s event to webhook %s since it was removed or changed.", event, webhook.Url)
+			return
+		}
+		webhook = *latestWebhook
+	}
+}
+
+// Sends a single signed request to the given webhook and returns the resulting status code.
+func (client *WebhookClient) post(
+	webhook model.Webhook, event WebhookEvent, deliveryId string, body []byte,
+) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Cheesy-Arena")
+	request.Header.Set(webhookEventHeader, string(event))
+	request.Header.Set(webhookDeliveryHeader, deliveryId)
+	if webhook.Secret != "" {
+		request.Header.Set(webhookSignatureHeader, SignWebhookPayload(webhook.Secret, body))
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, webhookMaxResponseBytes))
+		return response.StatusCode, fmt.Errorf(
+			"got status %s: %s", response.Status, strings.TrimSpace(string(responseBody)),
+		)
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookDelivery(t *testing.T) {
+	database := setupTestDb(t)
+
+	var mutex sync.Mutex
+	var requests []*http.Request
+	var bodies [][]byte
+	webhookServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				mutex.Lock()
+				defer mutex.Unlock()
+				requests = append(requests, r)
+				bodies = append(bodies, body)
+			},
+		),
+	)
+	defer webhookServer.Close()
+
+	assert.Nil(t, database.CreateWebhook(&model.Webhook{Url: webhookServer.URL, Secret: "hunter2", Enabled: true}))
+	assert.Nil(
+		t,
+		database.CreateWebhook(
+			&model.Webhook{Url: webhookServer.URL + "/scores", Events: []string{"score_posted"}, Enabled: true},
+		),
+	)
+	assert.Nil(t, database.CreateWebhook(&model.Webhook{Url: webhookServer.URL + "/disabled"}))
+	client := NewWebhookClient(database)
+
+	match := model.Match{Id: 7, Type: model.Qualification, ShortName: "Q7", Red1: 254, Blue3: 1114}
+	client.SendEvent(WebhookMatchStarted, NewWebhookMatch(&match))
+	client.deliveries.Wait()
+	if assert.Equal(t, 1, len(requests)) {
+		assert.Equal(t, "/", requests[0].URL.Path)
+		assert.Equal(t, "match_started", requests[0].Header.Get("X-Cheesy-Arena-Event"))
+		assert.Equal(t, SignWebhookPayload("hunter2", bodies[0]), requests[0].Header.Get("X-Cheesy-Arena-Signature"))
+		var payload struct {
+			Event      string
+			DeliveryId string
+			Data       WebhookMatch
+		}
+		assert.Nil(t, json.Unmarshal(bodies[0], &payload))
+		assert.Equal(t, "match_started", payload.Event)
+		assert.Equal(t, requests[0].Header.Get("X-Cheesy-Arena-Delivery"), payload.DeliveryId)
+		assert.Equal(t, "qualification", payload.Data.Type)
+		assert.Equal(t, []int{254, 0, 0}, payload.Data.RedTeams)
+		assert.Equal(t, []int{0, 0, 1114}, payload.Data.BlueTeams)
+	}
+
+	// Check that only the subscribed webhooks are notified.
+	requests = nil
+	match.Status = game.BlueWonMatch
+	client.SendEvent(WebhookScorePosted, NewWebhookScore(&match, 10, 20, false))
+	client.deliveries.Wait()
+	if assert.Equal(t, 2, len(requests)) {
+		assert.ElementsMatch(t, []string{"/", "/scores"}, []string{requests[0].URL.Path, requests[1].URL.Path})
+		for _, request := range requests {
+			if request.URL.Path == "/scores" {
+				assert.Equal(t, "", request.Header.Get("X-Cheesy-Arena-Signature"))
+			}
+		}
+	}
+
+	deliveries, err := database.GetRecentWebhookDeliveries(10)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(deliveries)) {
+		for _, delivery := range deliveries {
+			assert.True(t, delivery.Delivered)
+			assert.Equal(t, 200, delivery.StatusCode)
+			assert.Equal(t, 1, delivery.Attempt)
+		}
+		assert.Contains(t, deliveries[0].Payload, "\"winner\":\"blue\"")
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	database := setupTestDb(t)
+
+	numAttempts := 0
+	webhookServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				numAttempts++
+				if numAttempts < 3 {
+					http.Error(w, "Not ready yet", 503)
+				}
+			},
+		),
+	)
+	defer webhookServer.Close()
+
+	assert.Nil(t, database.CreateWebhook(&model.Webhook{Url: webhookServer.URL, Enabled: true}))
+	client := NewWebhookClient(database)
+	client.retryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+
+	client.SendEvent(WebhookAwardRevealed, NewWebhookAward(&model.Award{AwardName: "Safety Award", TeamId: 254}))
+	client.deliveries.Wait()
+	assert.Equal(t, 3, numAttempts)
+	deliveries, err := database.GetRecentWebhookDeliveries(10)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(deliveries)) {
+		assert.True(t, deliveries[0].Delivered)
+		assert.Equal(t, 3, deliveries[0].Attempt)
+		assert.False(t, deliveries[2].Delivered)
+		assert.Equal(t, 1, deliveries[2].Attempt)
+		assert.Equal(t, 503, deliveries[2].StatusCode)
+		assert.Contains(t, deliveries[2].Error, "Not ready yet")
+		assert.Equal(t, deliveries[0].DeliveryId, deliveries[2].DeliveryId)
+	}
+
+	// Check that delivery is abandoned once the retries are exhausted.
+	assert.Nil(t, database.TruncateWebhookDeliveries())
+	numAttempts = -10
+	client.SendEvent(WebhookAwardRevealed, NewWebhookAward(&model.Award{AwardName: "Safety Award", TeamId: 254}))
+	client.deliveries.Wait()
+	deliveries, err = database.GetRecentWebhookDeliveries(10)
+	assert.Nil(t, err)
+	if assert.Equal(t, 4, len(deliveries)) {
+		for _, delivery := range deliveries {
+			assert.False(t, delivery.Delivered)
+		}
+	}
+
+	// Check that retries stop once the webhook is disabled.
+	assert.Nil(t, database.TruncateWebhookDeliveries())
+	numAttempts = -10
+	client.retryDelays = []time.Duration{50 * time.Millisecond, time.Millisecond, time.Millisecond}
+	client.SendEvent(WebhookAwardRevealed, NewWebhookAward(&model.Award{AwardName: "Safety Award", TeamId: 254}))
+	webhook, _ := database.GetWebhookById(1)
+	webhook.Enabled = false
+	assert.Nil(t, database.UpdateWebhook(webhook))
+	client.deliveries.Wait()
+	deliveries, err = database.GetRecentWebhookDeliveries(10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(deliveries))
+
+	// Check that retries stop once the webhook is deleted.
+	assert.Nil(t, database.TruncateWebhookDeliveries())
+	webhook.Enabled = true
+	assert.Nil(t, database.UpdateWebhook(webhook))
+	client.SendEvent(WebhookAwardRevealed, NewWebhookAward(&model.Award{AwardName: "Safety Award", TeamId: 254}))
+	assert.Nil(t, database.DeleteWebhook(webhook.Id))
+	client.deliveries.Wait()
+	deliveries, err = database.GetRecentWebhookDeliveries(10)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(deliveries))
+
+	// Check that an unreachable webhook is recorded as a failure.
+	assert.Nil(t, database.TruncateWebhookDeliveries())
+	assert.Nil(t, database.CreateWebhook(&model.Webhook{Url: webhookServer.URL, Enabled: true}))
+	client.retryDelays = nil
+	webhookServer.Close()
+	client.SendEvent(WebhookAwardRevealed, NewWebhookAward(&model.Award{AwardName: "Safety Award", TeamId: 254}))
+	client.deliveries.Wait()
+	deliveries, err = database.GetRecentWebhookDeliveries(10)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(deliveries)) {
+		assert.False(t, deliveries[0].Delivered)
+		assert.Equal(t, 0, deliveries[0].StatusCode)
+		assert.NotEmpty(t, deliveries[0].Error)
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	assert.Equal(
+		t,
+		"sha256=603176255680307a81ec5b984e3a7b4143d0aef1fd1576987618e55c50868ad7",
+		SignWebhookPayload("hunter2", []byte("{}")),
+	)
+}
+
+func TestNewWebhookAllianceSelection(t *testing.T) {
+	alliances := []model.Alliance{{Id: 1, TeamIds: []int{254, 1114, 0, 0}}, {Id: 2, TeamIds: []int{0, 0, 0, 0}}}
+	allianceSelection := NewWebhookAllianceSelection(alliances)
+	assert.Equal(
+		t,
+		WebhookAllianceSelection{Alliances: []WebhookAlliance{{1, []int{254, 1114}}, {2, []int{}}}},
+		allianceSelection,
+	)
+}
+
+func TestNewWebhookRankings(t *testing.T) {
+	rankings := game.Rankings{
+		{TeamId: 254, Rank: 1, RankingFields: game.RankingFields{RankingPoints: 20, Wins: 5, Played: 6, Ties: 1}},
+	}
+	assert.Equal(
+		t,
+		WebhookRankings{
+			Rankings: []WebhookRanking{
+				{Rank: 1, TeamNumber: 254, RankingPoints: 20, Wins: 5, Losses: 0, Ties: 1, Played: 6},
+			},
+		},
+		NewWebhookRankings(rankings),
+	)
+	assert.Equal(t, WebhookRankings{Rankings: []WebhookRanking{}}, NewWebhookRankings(nil))
+}
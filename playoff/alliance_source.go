@@ -97,3 +97,39 @@ func (source matchupSource) traverse(visitFunction func(MatchGroup) error) error
 	}
 	return nil
 }
+
+// Represents a playoff spot that is filled by the alliance finishing at a given rank in an earlier round robin.
+type roundRobinSource struct {
+	roundRobin *RoundRobin
+	rank       int
+}
+
+func (source roundRobinSource) AllianceId() int {
+	if !source.roundRobin.IsComplete() || source.rank > len(source.roundRobin.Standings) {
+		return 0
+	}
+	return source.roundRobin.Standings[source.rank-1].AllianceId
+}
+
+func (source roundRobinSource) displayName() string {
+	return fmt.Sprintf("%s #%d", source.roundRobin.Id(), source.rank)
+}
+
+func (source roundRobinSource) setDestination(destination MatchGroup) {
+	source.roundRobin.finalDestination = destination
+}
+
+func (source roundRobinSource) update(playoffMatchResults map[int]playoffMatchResult) {
+	// Only update if this source is for the top rank, to avoid visiting the same match group more than once.
+	if source.rank == 1 {
+		source.roundRobin.update(playoffMatchResults)
+	}
+}
+
+func (source roundRobinSource) traverse(visitFunction func(MatchGroup) error) error {
+	// Only traverse if this source is for the top rank, to avoid visiting the same match group more than once.
+	if source.rank == 1 {
+		return source.roundRobin.traverse(visitFunction)
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Defines a playoff bracket structure from a YAML or JSON description rather than from Go code, so that new formats
+// can be added without modifying the application.
+
+package playoff
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"gopkg.in/yaml.v3"
+	"os"
+	"sort"
+)
+
+// BracketDefinition describes a complete playoff bracket. Since YAML is a superset of JSON, it can be written in
+// either.
+type BracketDefinition struct {
+	Name         string              `yaml:"name"`
+	NumAlliances int                 `yaml:"numAlliances"`
+	Matchups     []MatchupDefinition `yaml:"matchups"`
+	Breaks       []BreakDefinition   `yaml:"breaks"`
+}
+
+// MatchupDefinition describes a series of matches between two alliances. The matchup whose winner doesn't advance
+// anywhere is the tournament final and must have the ID "F".
+type MatchupDefinition struct {
+	Id               string            `yaml:"id"`
+	NumWinsToAdvance int               `yaml:"numWinsToAdvance"`
+	Red              SourceDefinition  `yaml:"red"`
+	Blue             SourceDefinition  `yaml:"blue"`
+	Matches          []MatchDefinition `yaml:"matches"`
+}
+
+// SourceDefinition describes where an alliance in a matchup comes from: exactly one of the alliance selection number,
+// the ID of the matchup whose winner advances, or the ID of the matchup whose loser advances.
+type SourceDefinition struct {
+	Alliance int    `yaml:"alliance"`
+	Winner   string `yaml:"winner"`
+	Loser    string `yaml:"loser"`
+}
+
+// MatchDefinition describes a single match within a matchup. Hidden matches (such as overtimes) are only scheduled if
+// they are needed to decide the matchup.
+type MatchDefinition struct {
+	LongName            string `yaml:"longName"`
+	ShortName           string `yaml:"shortName"`
+	NameDetail          string `yaml:"nameDetail"`
+	Order               int    `yaml:"order"`
+	DurationSec         int    `yaml:"durationSec"`
+	UseTiebreakCriteria bool   `yaml:"useTiebreakCriteria"`
+	Hidden              bool   `yaml:"hidden"`
+	TbaCompLevel        string `yaml:"tbaCompLevel"`
+	TbaSetNumber        int    `yaml:"tbaSetNumber"`
+	TbaMatchNumber      int    `yaml:"tbaMatchNumber"`
+}
+
+// BreakDefinition describes a scheduled break before the match with the given order.
+type BreakDefinition struct {
+	OrderBefore int    `yaml:"orderBefore"`
+	DurationSec int    `yaml:"durationSec"`
+	Description string `yaml:"description"`
+}
+
+// LoadBracketDefinition reads and validates the bracket definition in the given YAML or JSON file.
+func LoadBracketDefinition(path string) (*BracketDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBracketDefinition(data)
+}
+
+// ParseBracketDefinition parses and validates the given YAML or JSON bracket definition. Unrecognized fields are
+// rejected so that typos don't go unnoticed.
+func ParseBracketDefinition(data []byte) (*BracketDefinition, error) {
+	var definition BracketDefinition
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&definition); err != nil {
+		return nil, fmt.Errorf("failed to parse bracket definition: %v", err)
+	}
+	if err := definition.validate(); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// NewCustomPlayoffTournament creates a new playoff tournament from the given bracket definition.
+func NewCustomPlayoffTournament(definition *BracketDefinition) (*PlayoffTournament, error) {
+	if err := definition.validate(); err != nil {
+		return nil, err
+	}
+	finalMatchup, breakSpecs := definition.build()
+	return newPlayoffTournament(finalMatchup, breakSpecs)
+}
+
+// Checks that the definition describes a well-formed bracket, returning an error describing the first problem found.
+func (definition *BracketDefinition) validate() error {
+	if definition.NumAlliances < 2 {
+		return fmt.Errorf("bracket definition must have at least 2 alliances")
+	}
+	if len(definition.Matchups) == 0 {
+		return fmt.Errorf("bracket definition must have at least one matchup")
+	}
+
+	matchups := make(map[string]*MatchupDefinition)
+	for i, matchup := range definition.Matchups {
+		if matchup.Id == "" {
+			return fmt.Errorf("matchup %d is missing an ID", i+1)
+		}
+		if _, ok := matchups[matchup.Id]; ok {
+			return fmt.Errorf("matchup with ID %q defined more than once", matchup.Id)
+		}
+		if matchup.NumWinsToAdvance < 1 {
+			return fmt.Errorf("matchup %q must require at least one win to advance", matchup.Id)
+		}
+		if len(matchup.Matches) < matchup.NumWinsToAdvance {
+			return fmt.Errorf(
+				"matchup %q must have at least %d matches to allow for %d wins",
+				matchup.Id,
+				matchup.NumWinsToAdvance,
+				matchup.NumWinsToAdvance,
+			)
+		}
+		for _, match := range matchup.Matches {
+			if match.LongName == "" || match.ShortName == "" {
+				return fmt.Errorf("matches in matchup %q must have both a long and a short name", matchup.Id)
+			}
+			if match.Order < 1 {
+				return fmt.Errorf("match %q must have a positive order", match.LongName)
+			}
+			if match.DurationSec < 0 {
+				return fmt.Errorf("match %q must not have a negative duration", match.LongName)
+			}
+		}
+		matchups[matchup.Id] = &definition.Matchups[i]
+	}
+
+	// Check that each source refers to something that exists and that nothing is used more than once.
+	allianceSeen := make(map[int]bool)
+	winnerSeen := make(map[string]bool)
+	loserSeen := make(map[string]bool)
+	for _, matchup := range definition.Matchups {
+		if err := definition.validateSource(
+			matchups, matchup.Id, "red", matchup.Red, allianceSeen, winnerSeen, loserSeen,
+		); err != nil {
+			return err
+		}
+		if err := definition.validateSource(
+			matchups, matchup.Id, "blue", matchup.Blue, allianceSeen, winnerSeen, loserSeen,
+		); err != nil {
+			return err
+		}
+	}
+	for allianceId := 1; allianceId <= definition.NumAlliances; allianceId++ {
+		if !allianceSeen[allianceId] {
+			return fmt.Errorf("alliance %d never enters the bracket", allianceId)
+		}
+	}
+
+	// The final is the only matchup whose winner doesn't advance, and must feed back into every other matchup.
+	var finalIds []string
+	for _, matchup := range definition.Matchups {
+		if !winnerSeen[matchup.Id] {
+			finalIds = append(finalIds, matchup.Id)
+		}
+	}
+	if len(finalIds) != 1 {
+		return fmt.Errorf(
+			"bracket must have exactly one final matchup whose winner doesn't advance; found %d: %v",
+			len(finalIds),
+			finalIds,
+		)
+	}
+	if finalIds[0] != "F" {
+		return fmt.Errorf("final matchup must have ID \"F\" but has ID %q", finalIds[0])
+	}
+	if loserSeen["F"] {
+		return fmt.Errorf("loser of the final matchup cannot advance")
+	}
+
+	// Walk the graph from the final to detect cycles and any matchups that can't be reached from it.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make(map[string]int)
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch states[id] {
+		case visiting:
+			return fmt.Errorf("matchup %q depends on its own outcome", id)
+		case visited:
+			return nil
+		}
+		states[id] = visiting
+		matchup := matchups[id]
+		for _, source := range []SourceDefinition{matchup.Red, matchup.Blue} {
+			sourceId := source.Winner + source.Loser
+			if sourceId == "" {
+				continue
+			}
+			if err := visit(sourceId); err != nil {
+				return err
+			}
+			if minMatchOrder(matchups[sourceId]) >= minMatchOrder(matchup) {
+				return fmt.Errorf("matchup %q cannot begin before matchup %q that feeds it", id, sourceId)
+			}
+		}
+		states[id] = visited
+		return nil
+	}
+	if err := visit("F"); err != nil {
+		return err
+	}
+	for _, matchup := range definition.Matchups {
+		if states[matchup.Id] != visited {
+			return fmt.Errorf("matchup %q is not connected to the final", matchup.Id)
+		}
+	}
+
+	matchOrders := make(map[int]bool)
+	for _, matchup := range definition.Matchups {
+		for _, match := range matchup.Matches {
+			matchOrders[match.Order] = true
+		}
+	}
+	for _, breakDefinition := range definition.Breaks {
+		if !matchOrders[breakDefinition.OrderBefore] {
+			return fmt.Errorf(
+				"break %q comes before nonexistent match %d", breakDefinition.Description, breakDefinition.OrderBefore,
+			)
+		}
+		if breakDefinition.DurationSec <= 0 {
+			return fmt.Errorf("break %q must have a positive duration", breakDefinition.Description)
+		}
+	}
+
+	return nil
+}
+
+// Constructs the matchup graph and break specifications from the definition, which must already have been validated.
+func (definition *BracketDefinition) build() (*Matchup, []breakSpec) {
+	matchups := make(map[string]*Matchup)
+	for _, matchupDefinition := range definition.Matchups {
+		matchup := Matchup{id: matchupDefinition.Id, NumWinsToAdvance: matchupDefinition.NumWinsToAdvance}
+		for _, match := range matchupDefinition.Matches {
+			tbaMatchKey := model.TbaMatchKey{match.TbaCompLevel, match.TbaSetNumber, match.TbaMatchNumber}
+			matchup.matchSpecs = append(
+				matchup.matchSpecs,
+				&matchSpec{
+					longName:            match.LongName,
+					shortName:           match.ShortName,
+					nameDetail:          match.NameDetail,
+					order:               match.Order,
+					durationSec:         match.DurationSec,
+					useTiebreakCriteria: match.UseTiebreakCriteria,
+					isHidden:            match.Hidden,
+					tbaMatchKey:         tbaMatchKey,
+				},
+			)
+		}
+		matchups[matchup.id] = &matchup
+	}
+	for _, matchupDefinition := range definition.Matchups {
+		matchup := matchups[matchupDefinition.Id]
+		matchup.redAllianceSource = matchupDefinition.Red.build(matchups)
+		matchup.blueAllianceSource = matchupDefinition.Blue.build(matchups)
+	}
+
+	var breakSpecs []breakSpec
+	for _, breakDefinition := range definition.Breaks {
+		breakSpecs = append(
+			breakSpecs,
+			breakSpec{breakDefinition.OrderBefore, breakDefinition.DurationSec, breakDefinition.Description},
+		)
+	}
+	sort.SliceStable(breakSpecs, func(i, j int) bool {
+		return breakSpecs[i].orderBefore < breakSpecs[j].orderBefore
+	})
+
+	return matchups["F"], breakSpecs
+}
+
+// Constructs the alliance source described by the definition.
+func (source SourceDefinition) build(matchups map[string]*Matchup) allianceSource {
+	if source.Winner != "" {
+		return matchupSource{matchup: matchups[source.Winner], useWinner: true}
+	}
+	if source.Loser != "" {
+		return matchupSource{matchup: matchups[source.Loser], useWinner: false}
+	}
+	return allianceSelectionSource{source.Alliance}
+}
+
+// Checks that the given source of a matchup specifies exactly one alliance or outcome, that it refers to something that
+// exists, and that the same alliance or outcome hasn't already been used elsewhere.
+func (definition *BracketDefinition) validateSource(
+	matchups map[string]*MatchupDefinition,
+	matchupId string,
+	color string,
+	source SourceDefinition,
+	allianceSeen map[int]bool,
+	winnerSeen map[string]bool,
+	loserSeen map[string]bool,
+) error {
+	numSpecified := 0
+	if source.Alliance != 0 {
+		numSpecified++
+	}
+	if source.Winner != "" {
+		numSpecified++
+	}
+	if source.Loser != "" {
+		numSpecified++
+	}
+	if numSpecified != 1 {
+		return fmt.Errorf(
+			"%s source of matchup %q must specify exactly one of alliance, winner or loser", color, matchupId,
+		)
+	}
+
+	if source.Alliance != 0 {
+		if source.Alliance < 1 || source.Alliance > definition.NumAlliances {
+			return fmt.Errorf(
+				"matchup %q refers to alliance %d but there are only %d alliances",
+				matchupId,
+				source.Alliance,
+				definition.NumAlliances,
+			)
+		}
+		if allianceSeen[source.Alliance] {
+			return fmt.Errorf("alliance %d enters the bracket more than once", source.Alliance)
+		}
+		allianceSeen[source.Alliance] = true
+		return nil
+	}
+
+	sourceId, seen, outcome := source.Winner, winnerSeen, "winner"
+	if source.Loser != "" {
+		sourceId, seen, outcome = source.Loser, loserSeen, "loser"
+	}
+	if _, ok := matchups[sourceId]; !ok {
+		return fmt.Errorf("matchup %q refers to nonexistent matchup %q", matchupId, sourceId)
+	}
+	if sourceId == matchupId {
+		return fmt.Errorf("matchup %q cannot be its own source", matchupId)
+	}
+	if seen[sourceId] {
+		return fmt.Errorf("%s of matchup %q advances to more than one place", outcome, sourceId)
+	}
+	seen[sourceId] = true
+	return nil
+}
+
+// Returns the lowest order of any match in the given matchup.
+func minMatchOrder(matchup *MatchupDefinition) int {
+	minOrder := matchup.Matches[0].Order
+	for _, match := range matchup.Matches[1:] {
+		minOrder = min(minOrder, match.Order)
+	}
+	return minOrder
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package playoff
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// A minimal valid bracket in which two semifinal winners meet in a single-match final.
+const testBracketDefinition = `
+name: Test Bracket
+numAlliances: 4
+matchups:
+  - id: SF1
+    numWinsToAdvance: 1
+    red: {alliance: 1}
+    blue: {alliance: 4}
+    matches: [{longName: Semifinal 1, shortName: SF1, order: 1, tbaCompLevel: sf, tbaSetNumber: 1}]
+  - id: SF2
+    numWinsToAdvance: 1
+    red: {alliance: 2}
+    blue: {alliance: 3}
+    matches: [{longName: Semifinal 2, shortName: SF2, order: 2, tbaCompLevel: sf, tbaSetNumber: 2}]
+  - id: F
+    numWinsToAdvance: 1
+    red: {winner: SF1}
+    blue: {winner: SF2}
+    matches: [{longName: Final, shortName: F, order: 3, tbaCompLevel: f, tbaSetNumber: 1}]
+breaks:
+  - {orderBefore: 3, durationSec: 600, description: Field Break}
+`
+
+func TestBracketDefinitionMatchesBuiltInFormats(t *testing.T) {
+	testCases := []struct {
+		path         string
+		playoffType  model.PlayoffType
+		numAlliances int
+	}{
+		{"../brackets/double_elimination_4.yaml", model.DoubleEliminationPlayoff, 4},
+		{"../brackets/single_elimination_4.json", model.SingleEliminationPlayoff, 4},
+	}
+
+	for _, testCase := range testCases {
+		definition, err := LoadBracketDefinition(testCase.path)
+		if !assert.Nil(t, err, testCase.path) {
+			continue
+		}
+		assert.Equal(t, testCase.numAlliances, definition.NumAlliances)
+		customTournament, err := NewCustomPlayoffTournament(definition)
+		assert.Nil(t, err)
+		builtInTournament, err := NewPlayoffTournament(testCase.playoffType, testCase.numAlliances)
+		assert.Nil(t, err)
+
+		assert.Equal(t, builtInTournament.matchSpecs, customTournament.matchSpecs, testCase.path)
+		assert.Equal(t, builtInTournament.breakSpecs, customTournament.breakSpecs, testCase.path)
+		for id := range builtInTournament.MatchGroups() {
+			assert.Contains(t, customTournament.MatchGroups(), id)
+		}
+
+		// Both tournaments should progress identically.
+		playoffMatchResults := map[int]playoffMatchResult{}
+		for _, match := range builtInTournament.matchSpecs {
+			playoffMatchResults[match.order] = playoffMatchResult{game.BlueWonMatch}
+		}
+		builtInTournament.FinalMatchup().update(playoffMatchResults)
+		customTournament.FinalMatchup().update(playoffMatchResults)
+		assert.Equal(t, builtInTournament.matchSpecs, customTournament.matchSpecs, testCase.path)
+		assert.True(t, customTournament.IsComplete())
+		assert.Equal(t, builtInTournament.WinningAllianceId(), customTournament.WinningAllianceId())
+	}
+}
+
+func TestParseBracketDefinition(t *testing.T) {
+	definition, err := ParseBracketDefinition([]byte(testBracketDefinition))
+	assert.Nil(t, err)
+	assert.Equal(t, "Test Bracket", definition.Name)
+	assert.Equal(t, 3, len(definition.Matchups))
+	assert.Equal(t, "SF1", definition.Matchups[2].Red.Winner)
+
+	playoffTournament, err := NewCustomPlayoffTournament(definition)
+	assert.Nil(t, err)
+	assertMatchGroups(t, playoffTournament.MatchGroups(), "SF1", "SF2", "F")
+	finalMatchup := playoffTournament.FinalMatchup()
+	finalMatchup.update(map[int]playoffMatchResult{1: {game.BlueWonMatch}, 2: {game.RedWonMatch}})
+	assert.Equal(t, 4, finalMatchup.RedAllianceId)
+	assert.Equal(t, 2, finalMatchup.BlueAllianceId)
+	assertMatchupOutcome(t, playoffTournament.MatchGroups()["SF1"], "Eliminated", "Advances to Final")
+
+	// JSON is also accepted.
+	definition, err = ParseBracketDefinition(
+		[]byte(`{"numAlliances": 2, "matchups": [{"id": "F", "numWinsToAdvance": 1, "red": {"alliance": 1},
+		"blue": {"alliance": 2}, "matches": [{"longName": "Final", "shortName": "F", "order": 1}]}]}`),
+	)
+	assert.Nil(t, err)
+	if assert.NotNil(t, definition) {
+		assert.Equal(t, 2, definition.Matchups[0].Blue.Alliance)
+	}
+}
+
+func TestParseBracketDefinitionErrors(t *testing.T) {
+	testCases := []struct {
+		old           string
+		new           string
+		expectedError string
+	}{
+		{"numAlliances: 4", "numAlliances: 4\nfoo: bar", "field foo not found"},
+		{"numAlliances: 4", "numAlliances: 1", "bracket definition must have at least 2 alliances"},
+		{"id: SF2", "id: SF1", "matchup with ID \"SF1\" defined more than once"},
+		{"id: SF2", "id: ''", "matchup 2 is missing an ID"},
+		{"numWinsToAdvance: 1\n    red: {alliance: 2}", "numWinsToAdvance: 2\n    red: {alliance: 2}",
+			"matchup \"SF2\" must have at least 2 matches to allow for 2 wins"},
+		{"shortName: SF2", "shortName: ''", "matches in matchup \"SF2\" must have both a long and a short name"},
+		{"order: 2", "order: 0", "match \"Semifinal 2\" must have a positive order"},
+		{"red: {alliance: 2}", "red: {alliance: 2, winner: SF1}",
+			"red source of matchup \"SF2\" must specify exactly one of alliance, winner or loser"},
+		{"blue: {alliance: 3}", "blue: {}",
+			"blue source of matchup \"SF2\" must specify exactly one of alliance, winner or loser"},
+		{"blue: {alliance: 3}", "blue: {alliance: 5}", "matchup \"SF2\" refers to alliance 5 but there are only 4"},
+		{"blue: {alliance: 3}", "blue: {alliance: 1}", "alliance 1 enters the bracket more than once"},
+		{"numAlliances: 4", "numAlliances: 5", "alliance 5 never enters the bracket"},
+		{"blue: {winner: SF2}", "blue: {winner: SF3}", "matchup \"F\" refers to nonexistent matchup \"SF3\""},
+		{"blue: {winner: SF2}", "blue: {winner: F}", "matchup \"F\" cannot be its own source"},
+		{"blue: {winner: SF2}", "blue: {winner: SF1}", "winner of matchup \"SF1\" advances to more than one place"},
+		{"blue: {winner: SF2}", "blue: {loser: SF1}",
+			"bracket must have exactly one final matchup whose winner doesn't advance; found 2: [SF2 F]"},
+		{"id: F", "id: Final", "final matchup must have ID \"F\" but has ID \"Final\""},
+		{"order: 3", "order: 1", "matchup \"F\" cannot begin before matchup \"SF1\" that feeds it"},
+		{"orderBefore: 3", "orderBefore: 4", "break \"Field Break\" comes before nonexistent match 4"},
+		{"durationSec: 600", "durationSec: 0", "break \"Field Break\" must have a positive duration"},
+		{"tbaSetNumber: 2", "tbaSetNumber: 1", "match with TBA key"},
+	}
+
+	for _, testCase := range testCases {
+		data := strings.Replace(testBracketDefinition, testCase.old, testCase.new, 1)
+		assert.NotEqual(t, testBracketDefinition, data)
+		definition, err := ParseBracketDefinition([]byte(data))
+		if err == nil {
+			_, err = NewCustomPlayoffTournament(definition)
+		}
+		if assert.NotNil(t, err, testCase.expectedError) {
+			assert.Contains(t, err.Error(), testCase.expectedError)
+		}
+	}
+
+	_, err := LoadBracketDefinition("../brackets/nonexistent.yaml")
+	assert.NotNil(t, err)
+	_, err = NewPlayoffTournament(model.CustomPlayoff, 4)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "custom playoff tournament must be created from a bracket definition", err.Error())
+	}
+}
+
+func TestParseBracketDefinitionGraphErrors(t *testing.T) {
+	// Two matchups that only feed each other can't be reached from the final.
+	disconnected := strings.Replace(testBracketDefinition, "breaks:", `  - id: X1
+    numWinsToAdvance: 1
+    red: {winner: X2}
+    blue: {loser: SF1}
+    matches: [{longName: X1, shortName: X1, order: 4}]
+  - id: X2
+    numWinsToAdvance: 1
+    red: {winner: X1}
+    blue: {loser: SF2}
+    matches: [{longName: X2, shortName: X2, order: 5}]
+breaks:`, 1)
+	_, err := ParseBracketDefinition([]byte(disconnected))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "matchup \"X1\" is not connected to the final", err.Error())
+	}
+
+	// A matchup that depends on its own outcome through the loser of a later matchup.
+	cyclic := `
+numAlliances: 3
+matchups:
+  - id: A
+    numWinsToAdvance: 1
+    red: {alliance: 1}
+    blue: {loser: B}
+    matches: [{longName: A, shortName: A, order: 1}]
+  - id: B
+    numWinsToAdvance: 1
+    red: {alliance: 2}
+    blue: {winner: A}
+    matches: [{longName: B, shortName: B, order: 2}]
+  - id: F
+    numWinsToAdvance: 1
+    red: {winner: B}
+    blue: {alliance: 3}
+    matches: [{longName: F, shortName: F, order: 3}]
+`
+	_, err = ParseBracketDefinition([]byte(cyclic))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "matchup \"B\" depends on its own outcome", err.Error())
+	}
+
+	// The loser of the final has nowhere to go.
+	finalLoser := strings.Replace(testBracketDefinition, "red: {alliance: 2}", "red: {loser: F}", 1)
+	finalLoser = strings.Replace(finalLoser, "numAlliances: 4", "numAlliances: 3", 1)
+	finalLoser = strings.Replace(finalLoser, "blue: {alliance: 4}", "blue: {alliance: 2}", 1)
+	_, err = ParseBracketDefinition([]byte(finalLoser))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "loser of the final matchup cannot advance", err.Error())
+	}
+}
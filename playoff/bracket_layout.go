@@ -0,0 +1,366 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Computes where to draw each match group of a playoff tournament and the lines connecting them, based only on the
+// structure of the tournament so that any format can be drawn without hand-placed coordinates.
+
+package playoff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Dimensions of the elements making up a bracket drawing, in SVG user units.
+const (
+	matchupNodeWidth     = 205
+	matchupNodeHeight    = 175
+	matchupRedInletY     = 56
+	matchupBlueInletY    = 121
+	matchupWinnerOutletY = 88
+	matchupLoserOutletY  = 108
+	standingsNodeWidth   = 1080
+	standingsRowHeight   = 80
+	bracketColumnGap     = 92
+	bracketRowGap        = 15
+	bracketSeparatorGap  = 60
+)
+
+// BracketLayout describes the position of every match group in a playoff tournament and the connectors between them.
+// Coordinates are relative to the top-left corner of the bracket.
+type BracketLayout struct {
+	Width         int
+	Height        int
+	Columns       []BracketColumn
+	Nodes         []BracketNode
+	Connectors    []BracketConnector
+	SeparatorY    int
+	FinalSubtitle string
+}
+
+// BracketColumn represents a vertical band of match groups that take place at roughly the same stage of the
+// tournament.
+type BracketColumn struct {
+	X     int
+	Width int
+	Title string
+}
+
+// BracketNode represents the position of a single match group within the bracket.
+type BracketNode struct {
+	MatchGroupId   string
+	IsRoundRobin   bool
+	Column         int
+	X              int
+	Y              int
+	Width          int
+	Height         int
+	IsLowerBracket bool
+	IsFinal        bool
+}
+
+// BracketConnector represents the line along which an alliance advances from one match group to the next.
+type BracketConnector struct {
+	FromMatchGroupId string
+	ToMatchGroupId   string
+	IsLoser          bool
+	Points           []BracketPoint
+}
+
+// BracketPoint represents a single vertex of a connector.
+type BracketPoint struct {
+	X int
+	Y int
+}
+
+// Intermediate representation of a match group while the layout is being computed.
+type layoutNode struct {
+	matchGroup     MatchGroup
+	inEdges        []*layoutEdge
+	outEdges       []*layoutEdge
+	children       []*layoutNode
+	earliestColumn int
+	column         int
+	isLowerBracket bool
+	y              int
+	width          int
+	height         int
+}
+
+// Intermediate representation of an alliance advancing from one match group to the next.
+type layoutEdge struct {
+	from    *layoutNode
+	to      *layoutNode
+	isLoser bool
+	outletY int
+	inletY  int
+	gap     int
+}
+
+// Layout computes the positions of the tournament's match groups and the connectors between them. Match groups are
+// arranged in columns by how many rounds precede them, pulled as far right as their destinations allow, and stacked
+// vertically so that each one sits alongside the match groups whose winners feed it. Match groups fed by a losing
+// alliance are drawn below the rest as a lower bracket.
+func (tournament *PlayoffTournament) Layout() *BracketLayout {
+	// Build the graph of match groups and the alliances flowing between them.
+	var nodes []*layoutNode
+	nodesById := make(map[string]*layoutNode)
+	_ = tournament.Traverse(func(matchGroup MatchGroup) error {
+		node := layoutNode{matchGroup: matchGroup, width: matchupNodeWidth, height: matchupNodeHeight}
+		if roundRobin, ok := matchGroup.(*RoundRobin); ok {
+			node.width = standingsNodeWidth
+			node.height = standingsRowHeight * (roundRobin.numAlliances + 1)
+		}
+		nodes = append(nodes, &node)
+		nodesById[matchGroup.Id()] = &node
+		return nil
+	})
+	var edges []*layoutEdge
+	for _, node := range nodes {
+		matchup, ok := node.matchGroup.(*Matchup)
+		if !ok {
+			continue
+		}
+		for _, source := range []struct {
+			allianceSource allianceSource
+			inletY         int
+		}{{matchup.redAllianceSource, matchupRedInletY}, {matchup.blueAllianceSource, matchupBlueInletY}} {
+			edge := layoutEdge{to: node, inletY: source.inletY}
+			switch allianceSource := source.allianceSource.(type) {
+			case matchupSource:
+				edge.from = nodesById[allianceSource.matchup.Id()]
+				edge.isLoser = !allianceSource.useWinner
+				edge.outletY = matchupWinnerOutletY
+				if edge.isLoser {
+					edge.outletY = matchupLoserOutletY
+				}
+			case roundRobinSource:
+				edge.from = nodesById[allianceSource.roundRobin.Id()]
+				edge.outletY = standingsRowHeight*allianceSource.rank + standingsRowHeight/2
+			default:
+				continue
+			}
+			edges = append(edges, &edge)
+			edge.from.outEdges = append(edge.from.outEdges, &edge)
+			node.inEdges = append(node.inEdges, &edge)
+			if !edge.isLoser && (len(node.children) == 0 || node.children[len(node.children)-1] != edge.from) {
+				node.children = append(node.children, edge.from)
+			}
+		}
+	}
+	final := nodesById[tournament.FinalMatchup().Id()]
+
+	// Assign columns, first as early as possible and then as late as the match groups being fed allow. Every
+	// destination has a later earliest column than its sources, so visiting in that order handles destinations first.
+	assignEarliestColumns(final)
+	numColumns := final.earliestColumn + 1
+	columnOrder := append([]*layoutNode{}, nodes...)
+	sort.SliceStable(columnOrder, func(i, j int) bool {
+		return columnOrder[i].earliestColumn > columnOrder[j].earliestColumn
+	})
+	for _, node := range columnOrder {
+		node.column = numColumns - 1
+		for _, edge := range node.outEdges {
+			node.column = min(node.column, edge.to.column-1)
+		}
+	}
+	for _, node := range nodes {
+		node.isLowerBracket = isLowerBracket(node, final)
+	}
+
+	// Stack the match groups vertically, leaving a gap between the upper and lower brackets.
+	layout := BracketLayout{}
+	nextY := make([]int, numColumns)
+	var shift func(node *layoutNode, deltaY int)
+	shift = func(node *layoutNode, deltaY int) {
+		node.y += deltaY
+		nextY[node.column] = max(nextY[node.column], node.y+node.height+bracketRowGap)
+		for _, child := range node.children {
+			shift(child, deltaY)
+		}
+	}
+	var place func(node *layoutNode)
+	place = func(node *layoutNode) {
+		sort.SliceStable(node.children, func(i, j int) bool {
+			return !node.children[i].isLowerBracket && node.children[j].isLowerBracket
+		})
+		for _, child := range node.children {
+			if child.isLowerBracket && !node.isLowerBracket && layout.SeparatorY == 0 {
+				floorY := 0
+				for _, y := range nextY {
+					floorY = max(floorY, y)
+				}
+				layout.SeparatorY = floorY - bracketRowGap + (bracketSeparatorGap+bracketRowGap)/2
+				for column := 0; column < node.column; column++ {
+					nextY[column] = floorY + bracketSeparatorGap
+				}
+			}
+			place(child)
+		}
+		if len(node.children) > 0 {
+			firstChild := node.children[0]
+			lastChild := node.children[len(node.children)-1]
+			centerY := (firstChild.y + firstChild.height/2 + lastChild.y + lastChild.height/2) / 2
+			node.y = centerY - node.height/2
+		}
+		shift(node, max(0, nextY[node.column]-node.y))
+	}
+	place(final)
+
+	// Lay out the columns from left to right, each as wide as its widest match group.
+	layout.Columns = make([]BracketColumn, numColumns)
+	for _, node := range nodes {
+		layout.Columns[node.column].Width = max(layout.Columns[node.column].Width, node.width)
+	}
+	for i := range layout.Columns {
+		if i > 0 {
+			layout.Columns[i].X = layout.Columns[i-1].X + layout.Columns[i-1].Width + bracketColumnGap
+		}
+		layout.Columns[i].Title = columnTitle(nodes, i, final)
+	}
+	layout.Width = layout.Columns[numColumns-1].X + layout.Columns[numColumns-1].Width
+
+	for _, node := range nodes {
+		_, isRoundRobin := node.matchGroup.(*RoundRobin)
+		layout.Nodes = append(
+			layout.Nodes,
+			BracketNode{
+				MatchGroupId:   node.matchGroup.Id(),
+				IsRoundRobin:   isRoundRobin,
+				Column:         node.column,
+				X:              layout.Columns[node.column].X,
+				Y:              node.y,
+				Width:          node.width,
+				Height:         node.height,
+				IsLowerBracket: node.isLowerBracket,
+				IsFinal:        node == final,
+			},
+		)
+		layout.Height = max(layout.Height, node.y+node.height)
+	}
+	sort.SliceStable(layout.Nodes, func(i, j int) bool {
+		if layout.Nodes[i].Column != layout.Nodes[j].Column {
+			return layout.Nodes[i].Column < layout.Nodes[j].Column
+		}
+		return layout.Nodes[i].Y < layout.Nodes[j].Y
+	})
+	if numWinsToAdvance := tournament.FinalMatchup().NumWinsToAdvance; numWinsToAdvance > 1 {
+		layout.FinalSubtitle = fmt.Sprintf("Best-of-%d", 2*numWinsToAdvance-1)
+	}
+
+	// Route each connector horizontally out of its source, vertically through a lane in one of the gaps between
+	// columns, and horizontally into its destination. Connectors spanning several columns use the rightmost gap that
+	// keeps their horizontal runs clear of other match groups, and connectors sharing a gap each get their own lane.
+	for _, edge := range edges {
+		startY := edge.from.y + edge.outletY
+		endY := edge.to.y + edge.inletY
+		edge.gap = edge.to.column
+		for gap := edge.to.column; gap > edge.from.column; gap-- {
+			startRunIsClear := !crossesNode(nodes, edge.from.column+1, gap-1, startY)
+			if startRunIsClear && !crossesNode(nodes, gap, edge.to.column-1, endY) {
+				edge.gap = gap
+				break
+			}
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].gap != edges[j].gap {
+			return edges[i].gap < edges[j].gap
+		}
+		return edges[i].from.y+edges[i].outletY < edges[j].from.y+edges[j].outletY
+	})
+	for i := 0; i < len(edges); {
+		gap := edges[i].gap
+		numLanes := 0
+		for i+numLanes < len(edges) && edges[i+numLanes].gap == gap {
+			numLanes++
+		}
+		for lane, edge := range edges[i : i+numLanes] {
+			startX := layout.Columns[edge.from.column].X + edge.from.width
+			startY := edge.from.y + edge.outletY
+			endX := layout.Columns[edge.to.column].X
+			endY := edge.to.y + edge.inletY
+			laneX := layout.Columns[gap].X - bracketColumnGap + bracketColumnGap*(lane+1)/(numLanes+1)
+			layout.Connectors = append(
+				layout.Connectors,
+				BracketConnector{
+					FromMatchGroupId: edge.from.matchGroup.Id(),
+					ToMatchGroupId:   edge.to.matchGroup.Id(),
+					IsLoser:          edge.isLoser,
+					Points:           []BracketPoint{{startX, startY}, {laneX, startY}, {laneX, endY}, {endX, endY}},
+				},
+			)
+		}
+		i += numLanes
+	}
+
+	return &layout
+}
+
+// Sets the earliest column in which each match group can be drawn, which is just after all of its sources.
+func assignEarliestColumns(node *layoutNode) {
+	node.earliestColumn = 0
+	for _, edge := range node.inEdges {
+		assignEarliestColumns(edge.from)
+		node.earliestColumn = max(node.earliestColumn, edge.from.earliestColumn+1)
+	}
+}
+
+// Returns true if a horizontal line at the given height through the given range of columns would cross any match
+// group.
+func crossesNode(nodes []*layoutNode, firstColumn, lastColumn, y int) bool {
+	for _, node := range nodes {
+		if node.column >= firstColumn && node.column <= lastColumn && y >= node.y && y <= node.y+node.height {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if the given match group is fed, directly or indirectly, by the loser of another match group.
+func isLowerBracket(node, final *layoutNode) bool {
+	if node == final {
+		return false
+	}
+	for _, edge := range node.inEdges {
+		if edge.isLoser || isLowerBracket(edge.from, final) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the heading to show beneath the given column, derived from the names of the matches within it where they
+// agree on a round name (e.g. "Quarterfinal 2-1" yields "Quarterfinals").
+func columnTitle(nodes []*layoutNode, column int, final *layoutNode) string {
+	if final.column == column {
+		return "Finals"
+	}
+	roundName := ""
+	for _, node := range nodes {
+		if node.column != column {
+			continue
+		}
+		if _, ok := node.matchGroup.(*RoundRobin); ok {
+			return "Round Robin"
+		}
+		matchSpecs := node.matchGroup.MatchSpecs()
+		if len(matchSpecs) == 0 {
+			continue
+		}
+		name := strings.TrimRightFunc(matchSpecs[0].longName, func(r rune) bool {
+			return unicode.IsDigit(r) || r == '-' || r == ' '
+		})
+		if roundName != "" && name != roundName {
+			roundName = ""
+			break
+		}
+		roundName = name
+	}
+	if roundName == "" || roundName == "Match" {
+		return fmt.Sprintf("Round %d", column+1)
+	}
+	return roundName + "s"
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package playoff
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBracketLayoutSingleElimination(t *testing.T) {
+	playoffTournament, err := NewPlayoffTournament(model.SingleEliminationPlayoff, 8)
+	assert.Nil(t, err)
+	layout := playoffTournament.Layout()
+
+	assert.Equal(
+		t,
+		[]BracketColumn{{0, 205, "Quarterfinals"}, {297, 205, "Semifinals"}, {594, 205, "Finals"}},
+		layout.Columns,
+	)
+	assert.Equal(t, 799, layout.Width)
+	assert.Equal(t, 745, layout.Height)
+	assert.Equal(t, 0, layout.SeparatorY)
+	assert.Equal(t, "Best-of-3", layout.FinalSubtitle)
+	assertBracketNodes(
+		t,
+		layout,
+		map[string]BracketPoint{
+			"QF1": {0, 0}, "QF2": {0, 190}, "QF3": {0, 380}, "QF4": {0, 570}, "SF1": {297, 95}, "SF2": {297, 475},
+			"F": {594, 285},
+		},
+	)
+	if assert.Equal(t, 6, len(layout.Connectors)) {
+		assert.Equal(
+			t,
+			BracketConnector{"QF1", "SF1", false, []BracketPoint{{205, 88}, {223, 88}, {223, 151}, {297, 151}}},
+			layout.Connectors[0],
+		)
+		assert.Equal(
+			t,
+			BracketConnector{"SF2", "F", false, []BracketPoint{{502, 563}, {563, 563}, {563, 406}, {594, 406}}},
+			layout.Connectors[5],
+		)
+	}
+
+	// Matchups that aren't needed for smaller brackets are left out entirely.
+	playoffTournament, err = NewPlayoffTournament(model.SingleEliminationPlayoff, 2)
+	assert.Nil(t, err)
+	layout = playoffTournament.Layout()
+	assert.Equal(t, []BracketColumn{{0, 205, "Finals"}}, layout.Columns)
+	assertBracketNodes(t, layout, map[string]BracketPoint{"F": {0, 0}})
+	assert.Empty(t, layout.Connectors)
+}
+
+func TestBracketLayoutDoubleElimination(t *testing.T) {
+	playoffTournament, err := NewPlayoffTournament(model.DoubleEliminationPlayoff, 4)
+	assert.Nil(t, err)
+	layout := playoffTournament.Layout()
+
+	assert.Equal(t, []string{"Round 1", "Round 2", "Round 3", "Finals"}, columnTitles(layout))
+	assert.Equal(t, 402, layout.SeparatorY)
+	assertBracketNodes(
+		t,
+		layout,
+		map[string]BracketPoint{
+			"M1": {0, 0}, "M2": {0, 190}, "M3": {297, 95}, "M4": {297, 440}, "M5": {594, 440}, "F": {891, 267},
+		},
+	)
+	for _, node := range layout.Nodes {
+		assert.Equal(t, node.MatchGroupId == "M4" || node.MatchGroupId == "M5", node.IsLowerBracket)
+		assert.Equal(t, node.MatchGroupId == "F", node.IsFinal)
+	}
+	var numLoserConnectors int
+	for _, connector := range layout.Connectors {
+		if connector.IsLoser {
+			numLoserConnectors++
+		}
+	}
+	assert.Equal(t, 3, numLoserConnectors)
+
+	// The upper bracket final is pulled right to sit alongside the lower bracket match that its loser feeds.
+	playoffTournament, err = NewPlayoffTournament(model.DoubleEliminationPlayoff, 8)
+	assert.Nil(t, err)
+	layout = playoffTournament.Layout()
+	assert.Equal(t, 6, len(layout.Columns))
+	assert.Equal(t, 3, getBracketNode(layout, "M11").Column)
+	assert.Equal(t, 3, getBracketNode(layout, "M12").Column)
+	assert.Equal(t, 1, getBracketNode(layout, "M5").Column)
+}
+
+func TestBracketLayoutRoundRobin(t *testing.T) {
+	playoffTournament, err := NewPlayoffTournament(model.RoundRobinPlayoff, 5)
+	assert.Nil(t, err)
+	layout := playoffTournament.Layout()
+
+	assert.Equal(t, []BracketColumn{{0, 1080, "Round Robin"}, {1172, 205, "Finals"}}, layout.Columns)
+	roundRobinNode := getBracketNode(layout, "RR")
+	assert.True(t, roundRobinNode.IsRoundRobin)
+	assert.Equal(t, 480, roundRobinNode.Height)
+	assertBracketNodes(t, layout, map[string]BracketPoint{"RR": {0, 0}, "F": {1172, 153}})
+
+	// The top two rows of the standings feed the final.
+	if assert.Equal(t, 2, len(layout.Connectors)) {
+		assert.Equal(t, BracketPoint{1080, 120}, layout.Connectors[0].Points[0])
+		assert.Equal(t, BracketPoint{1080, 200}, layout.Connectors[1].Points[0])
+	}
+}
+
+func TestBracketLayoutCustom(t *testing.T) {
+	definition, err := ParseBracketDefinition([]byte(testBracketDefinition))
+	assert.Nil(t, err)
+	playoffTournament, err := NewCustomPlayoffTournament(definition)
+	assert.Nil(t, err)
+	layout := playoffTournament.Layout()
+
+	assert.Equal(t, []string{"Semifinals", "Finals"}, columnTitles(layout))
+	assert.Equal(t, "", layout.FinalSubtitle)
+	assertBracketNodes(t, layout, map[string]BracketPoint{"SF1": {0, 0}, "SF2": {0, 190}, "F": {297, 95}})
+}
+
+func TestBracketLayoutIsConsistent(t *testing.T) {
+	testCases := []struct {
+		playoffType  model.PlayoffType
+		numAlliances int
+	}{
+		{model.DoubleEliminationPlayoff, 4},
+		{model.DoubleEliminationPlayoff, 6},
+		{model.DoubleEliminationPlayoff, 8},
+		{model.RoundRobinPlayoff, 3},
+		{model.RoundRobinPlayoff, 8},
+	}
+	for numAlliances := 2; numAlliances <= 16; numAlliances++ {
+		testCases = append(testCases, struct {
+			playoffType  model.PlayoffType
+			numAlliances int
+		}{model.SingleEliminationPlayoff, numAlliances})
+	}
+
+	for _, testCase := range testCases {
+		playoffTournament, err := NewPlayoffTournament(testCase.playoffType, testCase.numAlliances)
+		assert.Nil(t, err)
+		layout := playoffTournament.Layout()
+		assert.Equal(t, len(playoffTournament.MatchGroups()), len(layout.Nodes), "%v", testCase)
+
+		// No two match groups overlap, and they all fit within the bracket.
+		for i, node := range layout.Nodes {
+			assert.GreaterOrEqual(t, node.Y, 0)
+			assert.LessOrEqual(t, node.X+node.Width, layout.Width)
+			assert.LessOrEqual(t, node.Y+node.Height, layout.Height)
+			if layout.SeparatorY > 0 && !node.IsFinal {
+				assert.Equal(t, node.IsLowerBracket, node.Y > layout.SeparatorY, "%v %s", testCase, node.MatchGroupId)
+			}
+			for _, other := range layout.Nodes[i+1:] {
+				overlaps := node.X < other.X+other.Width && other.X < node.X+node.Width &&
+					node.Y < other.Y+other.Height && other.Y < node.Y+node.Height
+				assert.False(t, overlaps, "%v %s %s", testCase, node.MatchGroupId, other.MatchGroupId)
+			}
+		}
+
+		// Every connector runs rightward from its source to its destination using only horizontal and vertical
+		// segments.
+		for _, connector := range layout.Connectors {
+			from := getBracketNode(layout, connector.FromMatchGroupId)
+			to := getBracketNode(layout, connector.ToMatchGroupId)
+			points := connector.Points
+			assert.Equal(t, from.X+from.Width, points[0].X)
+			assert.Equal(t, to.X, points[len(points)-1].X)
+			assert.Less(t, from.Column, to.Column)
+			for i := 1; i < len(points); i++ {
+				assert.True(t, points[i].X == points[i-1].X || points[i].Y == points[i-1].Y)
+				assert.GreaterOrEqual(t, points[i].X, points[i-1].X)
+			}
+		}
+	}
+}
+
+// Asserts that the layout contains exactly the given match groups at the given positions.
+func assertBracketNodes(t *testing.T, layout *BracketLayout, expectedPositions map[string]BracketPoint) {
+	positions := make(map[string]BracketPoint)
+	for _, node := range layout.Nodes {
+		positions[node.MatchGroupId] = BracketPoint{node.X, node.Y}
+	}
+	assert.Equal(t, expectedPositions, positions)
+}
+
+func getBracketNode(layout *BracketLayout, matchGroupId string) BracketNode {
+	for _, node := range layout.Nodes {
+		if node.MatchGroupId == matchGroupId {
+			return node
+		}
+	}
+	return BracketNode{}
+}
+
+func columnTitles(layout *BracketLayout) []string {
+	var titles []string
+	for _, column := range layout.Columns {
+		titles = append(titles, column.Title)
+	}
+	return titles
+}
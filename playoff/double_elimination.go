@@ -11,15 +11,17 @@ import (
 )
 
 // Creates a double-elimination bracket and returns the root matchup comprising the tournament finals along with
-// scheduled breaks. Only supports having exactly four or eight alliances.
+// scheduled breaks. Only supports having exactly four, six or eight alliances.
 func newDoubleEliminationBracket(numAlliances int) (*Matchup, []breakSpec, error) {
 	switch numAlliances {
 	case 4:
 		return newFourAllianceDoubleEliminationBracket()
+	case 6:
+		return newSixAllianceDoubleEliminationBracket()
 	case 8:
 		return newEightAllianceDoubleEliminationBracket()
 	default:
-		return nil, nil, fmt.Errorf("double-elimination bracket must have exactly 4 or 8 alliances")
+		return nil, nil, fmt.Errorf("double-elimination bracket must have exactly 4, 6 or 8 alliances")
 	}
 }
 
@@ -87,6 +89,104 @@ func newFourAllianceDoubleEliminationBracket() (*Matchup, []breakSpec, error) {
 	return &final, breakSpecs, nil
 }
 
+// Creates a six-alliance double-elimination bracket, in which the top two alliances receive a bye through the first
+// round.
+func newSixAllianceDoubleEliminationBracket() (*Matchup, []breakSpec, error) {
+	// Define Round 1 matches.
+	m1 := Matchup{
+		id:                 "M1",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  allianceSelectionSource{3},
+		blueAllianceSource: allianceSelectionSource{6},
+		matchSpecs:         newDoubleEliminationMatch(1, "Round 1 Upper", 540),
+	}
+	m2 := Matchup{
+		id:                 "M2",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  allianceSelectionSource{4},
+		blueAllianceSource: allianceSelectionSource{5},
+		matchSpecs:         newDoubleEliminationMatch(2, "Round 1 Upper", 540),
+	}
+
+	// Define Round 2 matches.
+	m3 := Matchup{
+		id:                 "M3",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  allianceSelectionSource{1},
+		blueAllianceSource: matchupSource{matchup: &m2, useWinner: true},
+		matchSpecs:         newDoubleEliminationMatch(3, "Round 2 Upper", 540),
+	}
+	m4 := Matchup{
+		id:                 "M4",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  allianceSelectionSource{2},
+		blueAllianceSource: matchupSource{matchup: &m1, useWinner: true},
+		matchSpecs:         newDoubleEliminationMatch(4, "Round 2 Upper", 540),
+	}
+	m5 := Matchup{
+		id:                 "M5",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  matchupSource{matchup: &m1, useWinner: false},
+		blueAllianceSource: matchupSource{matchup: &m2, useWinner: false},
+		matchSpecs:         newDoubleEliminationMatch(5, "Round 2 Lower", 540),
+	}
+
+	// Define Round 3 matches.
+	m6 := Matchup{
+		id:                 "M6",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  matchupSource{matchup: &m3, useWinner: false},
+		blueAllianceSource: matchupSource{matchup: &m4, useWinner: false},
+		matchSpecs:         newDoubleEliminationMatch(6, "Round 3 Lower", 540),
+	}
+	m7 := Matchup{
+		id:                 "M7",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  matchupSource{matchup: &m3, useWinner: true},
+		blueAllianceSource: matchupSource{matchup: &m4, useWinner: true},
+		matchSpecs:         newDoubleEliminationMatch(7, "Round 3 Upper", 300),
+	}
+
+	// Define Round 4 matches.
+	m8 := Matchup{
+		id:                 "M8",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  matchupSource{matchup: &m6, useWinner: true},
+		blueAllianceSource: matchupSource{matchup: &m5, useWinner: true},
+		matchSpecs:         newDoubleEliminationMatch(8, "Round 4 Lower", 300),
+	}
+
+	// Define Round 5 matches.
+	m9 := Matchup{
+		id:                 "M9",
+		NumWinsToAdvance:   1,
+		redAllianceSource:  matchupSource{matchup: &m7, useWinner: false},
+		blueAllianceSource: matchupSource{matchup: &m8, useWinner: true},
+		matchSpecs:         newDoubleEliminationMatch(9, "Round 5 Lower", 300),
+	}
+
+	// Define final matches.
+	final := Matchup{
+		id:                 "F",
+		NumWinsToAdvance:   2,
+		redAllianceSource:  matchupSource{matchup: &m7, useWinner: true},
+		blueAllianceSource: matchupSource{matchup: &m9, useWinner: true},
+		matchSpecs:         newFinalMatches(10),
+	}
+
+	// Define scheduled breaks.
+	breakSpecs := []breakSpec{
+		{6, 360, "Field Break"},
+		{8, 360, "Field Break"},
+		{9, 900, "Awards Break"},
+		{10, 900, "Awards Break"},
+		{11, 900, "Awards Break"},
+		{12, 900, "Awards Break *"},
+	}
+
+	return &final, breakSpecs, nil
+}
+
 // Creates an eight-alliance double-elimination bracket.
 func newEightAllianceDoubleEliminationBracket() (*Matchup, []breakSpec, error) {
 	// Define Round 1 matches.
@@ -118,15 +118,74 @@ func TestDoubleEliminationFourAllianceInitial(t *testing.T) {
 	assertMatchGroups(t, matchGroups, "M1", "M2", "M3", "M4", "M5", "F")
 }
 
+func TestDoubleEliminationSixAllianceInitial(t *testing.T) {
+	finalMatchup, breakSpecs, err := newDoubleEliminationBracket(6)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []breakSpec{
+		{6, 360, "Field Break"},
+		{8, 360, "Field Break"},
+		{9, 900, "Awards Break"},
+		{10, 900, "Awards Break"},
+		{11, 900, "Awards Break"},
+		{12, 900, "Awards Break *"},
+	}, breakSpecs)
+
+	matchSpecs, err := collectMatchSpecs(finalMatchup)
+	assert.Nil(t, err)
+	if assert.Equal(t, 15, len(matchSpecs)) {
+		assertMatchSpecs(
+			t,
+			matchSpecs,
+			[]expectedMatchSpec{
+				{"Match 1", "M1", "Round 1 Upper", 1, "M1", true, false, "sf", 1, 1},
+				{"Match 2", "M2", "Round 1 Upper", 2, "M2", true, false, "sf", 2, 1},
+				{"Match 3", "M3", "Round 2 Upper", 3, "M3", true, false, "sf", 3, 1},
+				{"Match 4", "M4", "Round 2 Upper", 4, "M4", true, false, "sf", 4, 1},
+				{"Match 5", "M5", "Round 2 Lower", 5, "M5", true, false, "sf", 5, 1},
+				{"Match 6", "M6", "Round 3 Lower", 6, "M6", true, false, "sf", 6, 1},
+				{"Match 7", "M7", "Round 3 Upper", 7, "M7", true, false, "sf", 7, 1},
+				{"Match 8", "M8", "Round 4 Lower", 8, "M8", true, false, "sf", 8, 1},
+				{"Match 9", "M9", "Round 5 Lower", 9, "M9", true, false, "sf", 9, 1},
+				{"Final 1", "F1", "", 10, "F", false, false, "f", 1, 1},
+				{"Final 2", "F2", "", 11, "F", false, false, "f", 1, 2},
+				{"Final 3", "F3", "", 12, "F", false, false, "f", 1, 3},
+				{"Overtime 1", "O1", "", 13, "F", true, true, "f", 1, 4},
+				{"Overtime 2", "O2", "", 14, "F", true, true, "f", 1, 5},
+				{"Overtime 3", "O3", "", 15, "F", true, true, "f", 1, 6},
+			},
+		)
+	}
+
+	finalMatchup.update(map[int]playoffMatchResult{})
+	assertMatchSpecAlliances(
+		t,
+		matchSpecs[0:4],
+		[]expectedAlliances{
+			{3, 6},
+			{4, 5},
+			{1, 0},
+			{2, 0},
+		},
+	)
+	for i := 4; i < 15; i++ {
+		assertMatchSpecAlliances(t, matchSpecs[i:i+1], []expectedAlliances{{0, 0}})
+	}
+
+	matchGroups, err := collectMatchGroups(finalMatchup)
+	assert.Nil(t, err)
+	assertMatchGroups(t, matchGroups, "M1", "M2", "M3", "M4", "M5", "M6", "M7", "M8", "M9", "F")
+}
+
 func TestDoubleEliminationErrors(t *testing.T) {
-	_, _, err := newDoubleEliminationBracket(7)
+	_, _, err := newDoubleEliminationBracket(5)
 	if assert.NotNil(t, err) {
-		assert.Equal(t, "double-elimination bracket must have exactly 4 or 8 alliances", err.Error())
+		assert.Equal(t, "double-elimination bracket must have exactly 4, 6 or 8 alliances", err.Error())
 	}
 
 	_, _, err = newDoubleEliminationBracket(9)
 	if assert.NotNil(t, err) {
-		assert.Equal(t, "double-elimination bracket must have exactly 4 or 8 alliances", err.Error())
+		assert.Equal(t, "double-elimination bracket must have exactly 4, 6 or 8 alliances", err.Error())
 	}
 }
 
@@ -204,6 +263,58 @@ func TestDoubleEliminationFourAllianceProgression(t *testing.T) {
 	assertMatchupOutcome(t, matchGroups["F"], "Tournament Finalist", "Tournament Winner")
 }
 
+func TestDoubleEliminationSixAllianceProgression(t *testing.T) {
+	playoffTournament, err := NewPlayoffTournament(model.DoubleEliminationPlayoff, 6)
+	assert.Nil(t, err)
+	finalMatchup := playoffTournament.FinalMatchup()
+	matchSpecs := playoffTournament.matchSpecs
+	matchGroups := playoffTournament.MatchGroups()
+	playoffMatchResults := map[int]playoffMatchResult{}
+
+	playoffMatchResults[1] = playoffMatchResult{game.RedWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	assertMatchSpecAlliances(t, matchSpecs[2:5], []expectedAlliances{{1, 0}, {2, 3}, {6, 0}})
+	assertMatchupOutcome(
+		t, matchGroups["M1"], "Advances to Match 4 &ndash; Round 2 Upper", "Advances to Match 5 &ndash; Round 2 Lower",
+	)
+
+	playoffMatchResults[2] = playoffMatchResult{game.BlueWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	assertMatchSpecAlliances(t, matchSpecs[2:5], []expectedAlliances{{1, 5}, {2, 3}, {6, 4}})
+
+	playoffMatchResults[3] = playoffMatchResult{game.RedWonMatch}
+	playoffMatchResults[4] = playoffMatchResult{game.BlueWonMatch}
+	playoffMatchResults[5] = playoffMatchResult{game.RedWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	assertMatchSpecAlliances(t, matchSpecs[5:8], []expectedAlliances{{5, 2}, {1, 3}, {0, 6}})
+	assertMatchupOutcome(t, matchGroups["M5"], "Advances to Match 8 &ndash; Round 4 Lower", "Eliminated")
+
+	playoffMatchResults[6] = playoffMatchResult{game.BlueWonMatch}
+	playoffMatchResults[7] = playoffMatchResult{game.RedWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	assertMatchSpecAlliances(t, matchSpecs[7:9], []expectedAlliances{{2, 6}, {3, 0}})
+	for i := 9; i < 15; i++ {
+		assertMatchSpecAlliances(t, matchSpecs[i:i+1], []expectedAlliances{{1, 0}})
+	}
+	assertMatchupOutcome(t, matchGroups["M6"], "Eliminated", "Advances to Match 8 &ndash; Round 4 Lower")
+	assertMatchupOutcome(t, matchGroups["M7"], "Advances to Final 1", "Advances to Match 9 &ndash; Round 5 Lower")
+
+	playoffMatchResults[8] = playoffMatchResult{game.BlueWonMatch}
+	playoffMatchResults[9] = playoffMatchResult{game.BlueWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	for i := 9; i < 15; i++ {
+		assertMatchSpecAlliances(t, matchSpecs[i:i+1], []expectedAlliances{{1, 6}})
+	}
+
+	playoffMatchResults[10] = playoffMatchResult{game.BlueWonMatch}
+	playoffMatchResults[11] = playoffMatchResult{game.BlueWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	assert.True(t, finalMatchup.IsComplete())
+	assert.Equal(t, 6, finalMatchup.WinningAllianceId())
+	assert.Equal(t, 1, finalMatchup.LosingAllianceId())
+	assertMatchupOutcome(t, matchGroups["F"], "Tournament Finalist", "Tournament Winner")
+}
+
 func TestDoubleEliminationProgression(t *testing.T) {
 	playoffTournament, err := NewPlayoffTournament(model.DoubleEliminationPlayoff, 8)
 	assert.Nil(t, err)
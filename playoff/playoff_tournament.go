@@ -30,13 +30,21 @@ func NewPlayoffTournament(playoffType model.PlayoffType, numPlayoffAlliances int
 		finalMatchup, breakSpecs, err = newDoubleEliminationBracket(numPlayoffAlliances)
 	case model.SingleEliminationPlayoff:
 		finalMatchup, breakSpecs, err = newSingleEliminationBracket(numPlayoffAlliances)
+	case model.RoundRobinPlayoff:
+		finalMatchup, breakSpecs, err = newRoundRobinBracket(numPlayoffAlliances)
+	case model.CustomPlayoff:
+		err = fmt.Errorf("custom playoff tournament must be created from a bracket definition")
 	default:
 		err = fmt.Errorf("invalid playoff type: %v", playoffType)
 	}
 	if err != nil {
 		return nil, err
 	}
+	return newPlayoffTournament(finalMatchup, breakSpecs)
+}
 
+// Creates a playoff tournament from the given bracket and breaks, validating that the bracket is well-formed.
+func newPlayoffTournament(finalMatchup *Matchup, breakSpecs []breakSpec) (*PlayoffTournament, error) {
 	matchGroups, err := collectMatchGroups(finalMatchup)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,257 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Defines the tournament structure for a round-robin stage, in which every alliance plays every other alliance once,
+// culminating in a best-of-three final between the top two alliances.
+
+package playoff
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"sort"
+)
+
+// RoundRobin is a match group in which every alliance plays every other alliance once and is ranked by its record.
+type RoundRobin struct {
+	id               string
+	numAlliances     int
+	pairings         []roundRobinPairing
+	matchSpecs       []*matchSpec
+	Standings        []RoundRobinStanding
+	NumMatchesPlayed int
+	finalDestination MatchGroup
+}
+
+// RoundRobinStanding represents a single alliance's record within the round robin.
+type RoundRobinStanding struct {
+	AllianceId int
+	Wins       int
+	Losses     int
+	Ties       int
+	Played     int
+}
+
+// The two alliances meeting in a given round-robin match.
+type roundRobinPairing struct {
+	redAllianceId  int
+	blueAllianceId int
+}
+
+// Creates a round-robin stage followed by a best-of-three final between its top two alliances, and returns the root
+// matchup comprising the tournament finals along with scheduled breaks. Supports between three and eight alliances.
+func newRoundRobinBracket(numAlliances int) (*Matchup, []breakSpec, error) {
+	if numAlliances < 3 || numAlliances > 8 {
+		return nil, nil, fmt.Errorf("round-robin playoff must have between 3 and 8 alliances")
+	}
+
+	roundRobin := newRoundRobin("RR", numAlliances)
+	final := Matchup{
+		id:                 "F",
+		NumWinsToAdvance:   2,
+		redAllianceSource:  roundRobinSource{roundRobin: roundRobin, rank: 1},
+		blueAllianceSource: roundRobinSource{roundRobin: roundRobin, rank: 2},
+		matchSpecs:         newFinalMatches(len(roundRobin.matchSpecs) + 1),
+	}
+
+	// Define scheduled breaks, with a field break halfway through the round robin.
+	numRoundRobinMatches := len(roundRobin.matchSpecs)
+	breakSpecs := []breakSpec{
+		{roundRobin.firstMatchOfRound(roundRobin.numRounds()/2 + 1), 360, "Field Break"},
+		{numRoundRobinMatches + 1, 900, "Awards Break"},
+		{numRoundRobinMatches + 2, 900, "Awards Break"},
+		{numRoundRobinMatches + 3, 900, "Awards Break *"},
+	}
+
+	return &final, breakSpecs, nil
+}
+
+// Creates a round robin between the given number of alliances, with its matches numbered from one.
+func newRoundRobin(id string, numAlliances int) *RoundRobin {
+	roundRobin := RoundRobin{id: id, numAlliances: numAlliances}
+	for round, pairings := range roundRobinSchedule(numAlliances) {
+		for _, pairing := range pairings {
+			number := len(roundRobin.matchSpecs) + 1
+			roundRobin.pairings = append(roundRobin.pairings, pairing)
+			roundRobin.matchSpecs = append(
+				roundRobin.matchSpecs,
+				&matchSpec{
+					longName:            fmt.Sprintf("Match %d", number),
+					shortName:           fmt.Sprintf("M%d", number),
+					nameDetail:          fmt.Sprintf("Round %d", round+1),
+					order:               number,
+					durationSec:         540,
+					useTiebreakCriteria: true,
+					tbaMatchKey:         model.TbaMatchKey{"sf", number, 1},
+				},
+			)
+		}
+	}
+	return &roundRobin
+}
+
+func (roundRobin *RoundRobin) Id() string {
+	return roundRobin.id
+}
+
+func (roundRobin *RoundRobin) MatchSpecs() []*matchSpec {
+	return roundRobin.matchSpecs
+}
+
+func (roundRobin *RoundRobin) update(playoffMatchResults map[int]playoffMatchResult) {
+	standings := make([]RoundRobinStanding, roundRobin.numAlliances)
+	for i := range standings {
+		standings[i].AllianceId = i + 1
+	}
+
+	roundRobin.NumMatchesPlayed = 0
+	for i, match := range roundRobin.matchSpecs {
+		pairing := roundRobin.pairings[i]
+		match.redAllianceId = pairing.redAllianceId
+		match.blueAllianceId = pairing.blueAllianceId
+
+		matchResult, ok := playoffMatchResults[match.order]
+		if !ok {
+			continue
+		}
+		red := &standings[pairing.redAllianceId-1]
+		blue := &standings[pairing.blueAllianceId-1]
+		switch matchResult.status {
+		case game.RedWonMatch:
+			red.Wins++
+			blue.Losses++
+		case game.BlueWonMatch:
+			blue.Wins++
+			red.Losses++
+		case game.TieMatch:
+			red.Ties++
+			blue.Ties++
+		default:
+			continue
+		}
+		red.Played++
+		blue.Played++
+		roundRobin.NumMatchesPlayed++
+	}
+
+	// Rank by ranking points, then by wins, and finally by alliance selection seed.
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points() != standings[j].Points() {
+			return standings[i].Points() > standings[j].Points()
+		}
+		if standings[i].Wins != standings[j].Wins {
+			return standings[i].Wins > standings[j].Wins
+		}
+		return standings[i].AllianceId < standings[j].AllianceId
+	})
+	roundRobin.Standings = standings
+}
+
+func (roundRobin *RoundRobin) traverse(visitFunction func(MatchGroup) error) error {
+	return visitFunction(roundRobin)
+}
+
+// IsComplete returns true if every round-robin match has been played.
+func (roundRobin *RoundRobin) IsComplete() bool {
+	return roundRobin.NumMatchesPlayed == len(roundRobin.matchSpecs)
+}
+
+// EliminatedAllianceIds returns the alliances that did not finish in the top two of the round robin, or an empty
+// slice if it is not yet complete.
+func (roundRobin *RoundRobin) EliminatedAllianceIds() []int {
+	var allianceIds []int
+	if roundRobin.IsComplete() {
+		for _, standing := range roundRobin.Standings[2:] {
+			allianceIds = append(allianceIds, standing.AllianceId)
+		}
+	}
+	return allianceIds
+}
+
+// AllianceDestination returns a string representing the given alliance's next destination in the tournament, or an
+// empty string if the round robin is not yet complete.
+func (roundRobin *RoundRobin) AllianceDestination(allianceId int) string {
+	if !roundRobin.IsComplete() {
+		return ""
+	}
+	for _, standing := range roundRobin.Standings[:2] {
+		if standing.AllianceId == allianceId {
+			return fmt.Sprintf("Advances to %s", formatDestinationMatchName(roundRobin.finalDestination))
+		}
+	}
+	return "Eliminated"
+}
+
+// Points returns the number of ranking points the alliance has earned: two for each win and one for each tie.
+func (standing RoundRobinStanding) Points() int {
+	return 2*standing.Wins + standing.Ties
+}
+
+// Returns the number of rounds in the round robin.
+func (roundRobin *RoundRobin) numRounds() int {
+	return roundRobin.numAlliances - 1 + roundRobin.numAlliances%2
+}
+
+// Returns the order of the first match in the given one-indexed round.
+func (roundRobin *RoundRobin) firstMatchOfRound(round int) int {
+	matchesPerRound := roundRobin.numAlliances / 2
+	return (round-1)*matchesPerRound + 1
+}
+
+// Returns the pairings for each round of a round robin between the given number of alliances, using the circle method.
+// When there is an odd number of alliances, one sits out each round. Colors are balanced as evenly as possible, and the
+// matches within each round are ordered so that no alliance plays twice in a row across the boundary between rounds
+// where that can be avoided.
+func roundRobinSchedule(numAlliances int) [][]roundRobinPairing {
+	// Alliance zero represents a bye.
+	var slots []int
+	for i := 1; i <= numAlliances; i++ {
+		slots = append(slots, i)
+	}
+	if len(slots)%2 == 1 {
+		slots = append(slots, 0)
+	}
+	numSlots := len(slots)
+
+	var rounds [][]roundRobinPairing
+	var previousPairing *roundRobinPairing
+	for round := 0; round < numSlots-1; round++ {
+		var pairings []roundRobinPairing
+		for i := 0; i < numSlots/2; i++ {
+			a, b := slots[i], slots[numSlots-1-i]
+			if a == 0 || b == 0 {
+				continue
+			}
+			// Each rotating alliance spends about half of the rounds in each half of the circle, so assigning colors
+			// by half balances them. The fixed alliance alternates colors instead.
+			red, blue := a, b
+			if i == 0 && round%2 == 1 {
+				red, blue = b, a
+			}
+			pairings = append(pairings, roundRobinPairing{red, blue})
+		}
+
+		// Move a match that doesn't involve either alliance from the previous round's last match to the front.
+		if previousPairing != nil {
+			for i, pairing := range pairings {
+				if !pairing.sharesAllianceWith(*previousPairing) {
+					pairings[0], pairings[i] = pairings[i], pairings[0]
+					break
+				}
+			}
+		}
+		previousPairing = &pairings[len(pairings)-1]
+		rounds = append(rounds, pairings)
+
+		// Rotate every slot but the first one position clockwise.
+		slots = append([]int{slots[0], slots[numSlots-1]}, slots[1:numSlots-1]...)
+	}
+	return rounds
+}
+
+// Returns true if the two pairings have an alliance in common.
+func (pairing roundRobinPairing) sharesAllianceWith(other roundRobinPairing) bool {
+	return pairing.redAllianceId == other.redAllianceId || pairing.redAllianceId == other.blueAllianceId ||
+		pairing.blueAllianceId == other.redAllianceId || pairing.blueAllianceId == other.blueAllianceId
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package playoff
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRoundRobinInitial(t *testing.T) {
+	finalMatchup, breakSpecs, err := newRoundRobinBracket(4)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []breakSpec{
+		{3, 360, "Field Break"},
+		{7, 900, "Awards Break"},
+		{8, 900, "Awards Break"},
+		{9, 900, "Awards Break *"},
+	}, breakSpecs)
+
+	matchSpecs, err := collectMatchSpecs(finalMatchup)
+	assert.Nil(t, err)
+	if assert.Equal(t, 12, len(matchSpecs)) {
+		assertMatchSpecs(
+			t,
+			matchSpecs,
+			[]expectedMatchSpec{
+				{"Match 1", "M1", "Round 1", 1, "RR", true, false, "sf", 1, 1},
+				{"Match 2", "M2", "Round 1", 2, "RR", true, false, "sf", 2, 1},
+				{"Match 3", "M3", "Round 2", 3, "RR", true, false, "sf", 3, 1},
+				{"Match 4", "M4", "Round 2", 4, "RR", true, false, "sf", 4, 1},
+				{"Match 5", "M5", "Round 3", 5, "RR", true, false, "sf", 5, 1},
+				{"Match 6", "M6", "Round 3", 6, "RR", true, false, "sf", 6, 1},
+				{"Final 1", "F1", "", 7, "F", false, false, "f", 1, 1},
+				{"Final 2", "F2", "", 8, "F", false, false, "f", 1, 2},
+				{"Final 3", "F3", "", 9, "F", false, false, "f", 1, 3},
+				{"Overtime 1", "O1", "", 10, "F", true, true, "f", 1, 4},
+				{"Overtime 2", "O2", "", 11, "F", true, true, "f", 1, 5},
+				{"Overtime 3", "O3", "", 12, "F", true, true, "f", 1, 6},
+			},
+		)
+	}
+
+	finalMatchup.update(map[int]playoffMatchResult{})
+	assertMatchSpecAlliances(
+		t,
+		matchSpecs[0:6],
+		[]expectedAlliances{
+			{1, 4},
+			{2, 3},
+			{3, 1},
+			{4, 2},
+			{1, 2},
+			{3, 4},
+		},
+	)
+	for i := 6; i < 12; i++ {
+		assertMatchSpecAlliances(t, matchSpecs[i:i+1], []expectedAlliances{{0, 0}})
+	}
+	assert.Equal(t, "RR #1", finalMatchup.RedAllianceSourceDisplayName())
+	assert.Equal(t, "RR #2", finalMatchup.BlueAllianceSourceDisplayName())
+
+	matchGroups, err := collectMatchGroups(finalMatchup)
+	assert.Nil(t, err)
+	assertMatchGroups(t, matchGroups, "RR", "F")
+}
+
+func TestRoundRobinSchedule(t *testing.T) {
+	for numAlliances := 3; numAlliances <= 8; numAlliances++ {
+		rounds := roundRobinSchedule(numAlliances)
+		assert.Equal(t, numAlliances-1+numAlliances%2, len(rounds))
+
+		meetings := make(map[[2]int]int)
+		redCounts := make(map[int]int)
+		blueCounts := make(map[int]int)
+		var previousPairing *roundRobinPairing
+		numBackToBack := 0
+		for _, pairings := range rounds {
+			assert.Equal(t, numAlliances/2, len(pairings))
+			playedThisRound := make(map[int]bool)
+			for i, pairing := range pairings {
+				assert.False(t, playedThisRound[pairing.redAllianceId])
+				assert.False(t, playedThisRound[pairing.blueAllianceId])
+				playedThisRound[pairing.redAllianceId] = true
+				playedThisRound[pairing.blueAllianceId] = true
+				redCounts[pairing.redAllianceId]++
+				blueCounts[pairing.blueAllianceId]++
+				key := [2]int{min(pairing.redAllianceId, pairing.blueAllianceId),
+					max(pairing.redAllianceId, pairing.blueAllianceId)}
+				meetings[key]++
+				if i == 0 && previousPairing != nil && pairing.sharesAllianceWith(*previousPairing) {
+					numBackToBack++
+				}
+			}
+			previousPairing = &pairings[len(pairings)-1]
+		}
+
+		// Every alliance meets every other alliance exactly once, with as even a split of colors as possible.
+		assert.Equal(t, numAlliances*(numAlliances-1)/2, len(meetings))
+		for _, count := range meetings {
+			assert.Equal(t, 1, count)
+		}
+		for allianceId := 1; allianceId <= numAlliances; allianceId++ {
+			assert.LessOrEqual(t, redCounts[allianceId]-blueCounts[allianceId], 1)
+			assert.GreaterOrEqual(t, redCounts[allianceId]-blueCounts[allianceId], -1)
+		}
+
+		// Back-to-back matches across rounds are only unavoidable when each round has fewer than three matches.
+		if numAlliances >= 6 {
+			assert.Equal(t, 0, numBackToBack, "%d alliances", numAlliances)
+		}
+	}
+}
+
+func TestRoundRobinErrors(t *testing.T) {
+	_, _, err := newRoundRobinBracket(2)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "round-robin playoff must have between 3 and 8 alliances", err.Error())
+	}
+
+	_, _, err = newRoundRobinBracket(9)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "round-robin playoff must have between 3 and 8 alliances", err.Error())
+	}
+}
+
+func TestRoundRobinProgression(t *testing.T) {
+	playoffTournament, err := NewPlayoffTournament(model.RoundRobinPlayoff, 4)
+	assert.Nil(t, err)
+	finalMatchup := playoffTournament.FinalMatchup()
+	matchSpecs := playoffTournament.matchSpecs
+	roundRobin := playoffTournament.MatchGroups()["RR"].(*RoundRobin)
+	playoffMatchResults := map[int]playoffMatchResult{}
+
+	playoffMatchResults[1] = playoffMatchResult{game.RedWonMatch}
+	playoffMatchResults[2] = playoffMatchResult{game.RedWonMatch}
+	playoffMatchResults[3] = playoffMatchResult{game.BlueWonMatch}
+	playoffMatchResults[4] = playoffMatchResult{game.RedWonMatch}
+	playoffMatchResults[5] = playoffMatchResult{game.RedWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	assert.False(t, roundRobin.IsComplete())
+	assert.Equal(t, 5, roundRobin.NumMatchesPlayed)
+	assert.Equal(
+		t,
+		[]RoundRobinStanding{
+			{AllianceId: 1, Wins: 3, Played: 3},
+			{AllianceId: 2, Wins: 1, Losses: 2, Played: 3},
+			{AllianceId: 4, Wins: 1, Losses: 1, Played: 2},
+			{AllianceId: 3, Losses: 2, Played: 2},
+		},
+		roundRobin.Standings,
+	)
+	for i := 6; i < 12; i++ {
+		assertMatchSpecAlliances(t, matchSpecs[i:i+1], []expectedAlliances{{0, 0}})
+	}
+	assert.Empty(t, roundRobin.EliminatedAllianceIds())
+	assert.Equal(t, "", roundRobin.AllianceDestination(1))
+
+	// A tie in the last match lifts alliance 4 above alliance 2 on ranking points.
+	playoffMatchResults[6] = playoffMatchResult{game.TieMatch}
+	finalMatchup.update(playoffMatchResults)
+	assert.True(t, roundRobin.IsComplete())
+	assert.Equal(t, 3, roundRobin.Standings[1].Points())
+	assert.Equal(t, []int{2, 3}, roundRobin.EliminatedAllianceIds())
+	assert.Equal(t, "Advances to Final 1", roundRobin.AllianceDestination(4))
+	assert.Equal(t, "Eliminated", roundRobin.AllianceDestination(2))
+	for i := 6; i < 12; i++ {
+		assertMatchSpecAlliances(t, matchSpecs[i:i+1], []expectedAlliances{{1, 4}})
+	}
+
+	// Reverse a previous outcome.
+	playoffMatchResults[4] = playoffMatchResult{game.BlueWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	assert.Equal(t, []int{3, 4}, roundRobin.EliminatedAllianceIds())
+	for i := 6; i < 12; i++ {
+		assertMatchSpecAlliances(t, matchSpecs[i:i+1], []expectedAlliances{{1, 2}})
+	}
+
+	playoffMatchResults[7] = playoffMatchResult{game.BlueWonMatch}
+	playoffMatchResults[8] = playoffMatchResult{game.RedWonMatch}
+	playoffMatchResults[9] = playoffMatchResult{game.BlueWonMatch}
+	finalMatchup.update(playoffMatchResults)
+	assert.True(t, playoffTournament.IsComplete())
+	assert.Equal(t, 2, playoffTournament.WinningAllianceId())
+	assert.Equal(t, 1, playoffTournament.FinalistAllianceId())
+	assertMatchupOutcome(t, playoffTournament.MatchGroups()["F"], "Tournament Finalist", "Tournament Winner")
+}
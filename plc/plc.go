@@ -19,7 +19,7 @@ type Plc interface {
 	IsEnabled() bool
 	IsHealthy() bool
 	IoChangeNotifier() *websocket.Notifier
-	Run()
+	Run(stop <-chan struct{})
 	GetArmorBlockStatuses() map[string]bool
 	GetFieldEStop() bool
 	GetTeamEStops() ([3]bool, [3]bool)
@@ -184,8 +184,9 @@ func (plc *ModbusPlc) IoChangeNotifier() *websocket.Notifier {
 	return plc.ioChangeNotifier
 }
 
-// Loops indefinitely to read inputs from and write outputs to PLC.
-func (plc *ModbusPlc) Run() {
+// Loops until the stop channel is closed to read inputs from and write outputs to the PLC, then disconnects.
+func (plc *ModbusPlc) Run(stop <-chan struct{}) {
+	defer plc.resetConnection()
 	for {
 		if plc.handler == nil {
 			if !plc.IsEnabled() {
@@ -195,8 +196,10 @@ func (plc *ModbusPlc) Run() {
 				err := plc.connect()
 				if err != nil {
 					log.Printf("PLC error: %v", err)
-					time.Sleep(time.Second * plcRetryIntevalSec)
 					plc.isHealthy = false
+					if !sleepUntilStopped(stop, time.Second*plcRetryIntevalSec) {
+						return
+					}
 					continue
 				}
 			}
@@ -204,7 +207,19 @@ func (plc *ModbusPlc) Run() {
 
 		startTime := time.Now()
 		plc.update()
-		time.Sleep(time.Until(startTime.Add(time.Millisecond * plcLoopPeriodMs)))
+		if !sleepUntilStopped(stop, time.Until(startTime.Add(time.Millisecond*plcLoopPeriodMs))) {
+			return
+		}
+	}
+}
+
+// Waits for the given duration and returns true, or returns false as soon as the stop channel is closed.
+func sleepUntilStopped(stop <-chan struct{}, duration time.Duration) bool {
+	select {
+	case <-stop:
+		return false
+	case <-time.After(duration):
+		return true
 	}
 }
 
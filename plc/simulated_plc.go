@@ -0,0 +1,194 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Software stand-in for the field PLC, for rehearsing matches and training volunteers without the real field.
+
+package plc
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/websocket"
+	"time"
+)
+
+// Bitmask for the fieldIoConnection register indicating that all ArmorBlocks are connected.
+const allArmorBlocksConnected = 1<<armorBlockCount - 1
+
+// SimulatedPlc behaves like a healthy PLC whose inputs are set through its methods instead of by field hardware. It
+// shares the I/O state handling of ModbusPlc but never opens a connection.
+type SimulatedPlc struct {
+	ModbusPlc
+}
+
+// Status of the simulated PLC in terms of the field elements it stands in for, for display on the simulator page.
+type SimulatedPlcStatus struct {
+	FieldEStop       bool
+	RedEStops        [3]bool
+	BlueEStops       [3]bool
+	RedAStops        [3]bool
+	BlueAStops       [3]bool
+	RedEthernets     [3]bool
+	BlueEthernets    [3]bool
+	FtaReady         bool
+	RedHubCount      int
+	BlueHubCount     int
+	StackLightRed    bool
+	StackLightBlue   bool
+	StackLightOrange bool
+	StackLightGreen  bool
+	StackBuzzer      bool
+	FieldResetLight  bool
+	AwardsModeLight  bool
+	RedHubMotor      bool
+	BlueHubMotor     bool
+	RedHubLight      bool
+	BlueHubLight     bool
+}
+
+// Inputs corresponding to the E-stop, A-stop and Ethernet connection of each alliance station, respectively.
+var simulatedStationInputs = map[string][3]input{
+	"R1": {red1EStop, red1AStop, redConnected1},
+	"R2": {red2EStop, red2AStop, redConnected2},
+	"R3": {red3EStop, red3AStop, redConnected3},
+	"B1": {blue1EStop, blue1AStop, blueConnected1},
+	"B2": {blue2EStop, blue2AStop, blueConnected2},
+	"B3": {blue3EStop, blue3AStop, blueConnected3},
+}
+
+// Creates a simulated PLC with no stop buttons pressed and all stations connected.
+func NewSimulatedPlc() *SimulatedPlc {
+	plc := new(SimulatedPlc)
+	plc.ioChangeNotifier = websocket.NewNotifier("plcIoChange", plc.generateSimulatedIoChangeMessage)
+	plc.inputs[fieldEStop] = true
+	for _, stationInputs := range simulatedStationInputs {
+		for _, stationInput := range stationInputs {
+			plc.inputs[stationInput] = true
+		}
+	}
+	plc.registers[fieldIoConnection] = allArmorBlocksConnected
+	return plc
+}
+
+// Ignores the address since there is no hardware to connect to.
+func (plc *SimulatedPlc) SetAddress(address string) {
+}
+
+// Returns true since the simulated PLC is only in use when it has been enabled in the settings.
+func (plc *SimulatedPlc) IsEnabled() bool {
+	return true
+}
+
+// Returns true since there is no connection that can fail.
+func (plc *SimulatedPlc) IsHealthy() bool {
+	return true
+}
+
+// Loops until the stop channel is closed to update the simulated outputs and notify listeners of I/O changes.
+func (plc *SimulatedPlc) Run(stop <-chan struct{}) {
+	for {
+		startTime := time.Now()
+		plc.update()
+		if !sleepUntilStopped(stop, time.Until(startTime.Add(time.Millisecond*plcLoopPeriodMs))) {
+			return
+		}
+	}
+}
+
+// Sets whether the field emergency stop button is pressed.
+func (plc *SimulatedPlc) SetFieldEStop(active bool) {
+	plc.inputs[fieldEStop] = !active
+}
+
+// Sets whether the emergency stop button at the given alliance station (e.g. "R1") is pressed.
+func (plc *SimulatedPlc) SetTeamEStop(station string, active bool) error {
+	return plc.setStationInput(station, 0, !active)
+}
+
+// Sets whether the autonomous stop button at the given alliance station (e.g. "R1") is pressed.
+func (plc *SimulatedPlc) SetTeamAStop(station string, active bool) error {
+	return plc.setStationInput(station, 1, !active)
+}
+
+// Sets whether anything is plugged into the Ethernet port of the given alliance station (e.g. "R1").
+func (plc *SimulatedPlc) SetEthernetConnected(station string, connected bool) error {
+	return plc.setStationInput(station, 2, connected)
+}
+
+// Sets whether the FTA ready dead-man switch is active.
+func (plc *SimulatedPlc) SetFtaReady(ready bool) {
+	plc.inputs[ftaReady] = ready
+}
+
+// Adds the given number of scored pieces to the given alliance's ("red" or "blue") Hub count.
+func (plc *SimulatedPlc) AddHubCount(alliance string, count int) error {
+	var totalRegister register
+	switch alliance {
+	case "red":
+		totalRegister = redHubTotal
+	case "blue":
+		totalRegister = blueHubTotal
+	default:
+		return fmt.Errorf("invalid alliance '%s'", alliance)
+	}
+	plc.registers[totalRegister] = uint16(max(int(plc.registers[totalRegister])+count, 0))
+	return nil
+}
+
+// Returns the current state of the simulated inputs and outputs.
+func (plc *SimulatedPlc) GetStatus() SimulatedPlcStatus {
+	coils := plc.getEffectiveCoils()
+	status := SimulatedPlcStatus{
+		FieldEStop:       plc.GetFieldEStop(),
+		FtaReady:         plc.IsFtaReady(),
+		StackLightRed:    coils[stackLightRed],
+		StackLightBlue:   coils[stackLightBlue],
+		StackLightOrange: coils[stackLightOrange],
+		StackLightGreen:  coils[stackLightGreen],
+		StackBuzzer:      coils[stackLightBuzzer],
+		FieldResetLight:  coils[fieldResetLight],
+		AwardsModeLight:  coils[awardsModeLight],
+		RedHubMotor:      coils[redHubMotor],
+		BlueHubMotor:     coils[blueHubMotor],
+		RedHubLight:      coils[redHubLight],
+		BlueHubLight:     coils[blueHubLight],
+	}
+	status.RedEStops, status.BlueEStops = plc.GetTeamEStops()
+	status.RedAStops, status.BlueAStops = plc.GetTeamAStops()
+	status.RedEthernets, status.BlueEthernets = plc.GetEthernetConnected()
+	status.RedHubCount, status.BlueHubCount = plc.GetHubCounts()
+	return status
+}
+
+// Performs a single iteration of the simulated PLC logic.
+func (plc *SimulatedPlc) update() {
+	// Mimic the PLC program clearing the match reset pulse after a few cycles.
+	plc.coils[heartbeat] = true
+	if plc.matchResetCycles > 5 {
+		plc.coils[matchReset] = false
+	} else {
+		plc.matchResetCycles++
+	}
+
+	plc.ModbusPlc.update()
+}
+
+func (plc *SimulatedPlc) setStationInput(station string, inputIndex int, state bool) error {
+	stationInputs, ok := simulatedStationInputs[station]
+	if !ok {
+		return fmt.Errorf("invalid alliance station '%s'", station)
+	}
+	plc.inputs[stationInputs[inputIndex]] = state
+	return nil
+}
+
+func (plc *SimulatedPlc) generateSimulatedIoChangeMessage() any {
+	effectiveCoils := plc.getEffectiveCoils()
+	coilOverrideStates := plc.getCoilOverrideStates()
+	return &struct {
+		Inputs        []bool
+		Registers     []uint16
+		Coils         []bool
+		CoilOverrides []string
+		Simulated     SimulatedPlcStatus
+	}{plc.inputs[:], plc.registers[:], effectiveCoils[:], coilOverrideStates[:], plc.GetStatus()}
+}
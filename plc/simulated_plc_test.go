@@ -0,0 +1,96 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package plc
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSimulatedPlcInitialState(t *testing.T) {
+	plc := NewSimulatedPlc()
+	plc.SetAddress("10.0.100.40")
+	assert.True(t, plc.IsEnabled())
+	assert.True(t, plc.IsHealthy())
+	assert.NotNil(t, plc.IoChangeNotifier())
+
+	assert.False(t, plc.GetFieldEStop())
+	redEStops, blueEStops := plc.GetTeamEStops()
+	assert.Equal(t, [3]bool{}, redEStops)
+	assert.Equal(t, [3]bool{}, blueEStops)
+	redAStops, blueAStops := plc.GetTeamAStops()
+	assert.Equal(t, [3]bool{}, redAStops)
+	assert.Equal(t, [3]bool{}, blueAStops)
+	redEthernets, blueEthernets := plc.GetEthernetConnected()
+	assert.Equal(t, [3]bool{true, true, true}, redEthernets)
+	assert.Equal(t, [3]bool{true, true, true}, blueEthernets)
+	assert.False(t, plc.IsFtaReady())
+	assert.Equal(t, map[string]bool{"RedDs": true, "BlueDs": true, "RedIoLink": true, "BlueIoLink": true},
+		plc.GetArmorBlockStatuses())
+}
+
+func TestSimulatedPlcInputs(t *testing.T) {
+	plc := NewSimulatedPlc()
+
+	plc.SetFieldEStop(true)
+	assert.True(t, plc.GetFieldEStop())
+	plc.SetFtaReady(true)
+	assert.True(t, plc.IsFtaReady())
+
+	assert.Nil(t, plc.SetTeamEStop("R2", true))
+	assert.Nil(t, plc.SetTeamAStop("B3", true))
+	assert.Nil(t, plc.SetEthernetConnected("B1", false))
+	redEStops, _ := plc.GetTeamEStops()
+	assert.Equal(t, [3]bool{false, true, false}, redEStops)
+	_, blueAStops := plc.GetTeamAStops()
+	assert.Equal(t, [3]bool{false, false, true}, blueAStops)
+	_, blueEthernets := plc.GetEthernetConnected()
+	assert.Equal(t, [3]bool{false, true, true}, blueEthernets)
+
+	assert.EqualError(t, plc.SetTeamEStop("R4", true), "invalid alliance station 'R4'")
+
+	assert.Nil(t, plc.AddHubCount("red", 3))
+	assert.Nil(t, plc.AddHubCount("blue", 1))
+	assert.Nil(t, plc.AddHubCount("red", 2))
+	redHubCount, blueHubCount := plc.GetHubCounts()
+	assert.Equal(t, 5, redHubCount)
+	assert.Equal(t, 1, blueHubCount)
+	assert.Nil(t, plc.AddHubCount("blue", -5))
+	_, blueHubCount = plc.GetHubCounts()
+	assert.Equal(t, 0, blueHubCount)
+	assert.EqualError(t, plc.AddHubCount("green", 1), "invalid alliance 'green'")
+
+	// Check that resetting the match clears the hub counts but leaves the field connected.
+	plc.ResetMatch()
+	redHubCount, _ = plc.GetHubCounts()
+	assert.Equal(t, 0, redHubCount)
+	assert.True(t, plc.GetArmorBlockStatuses()["RedDs"])
+}
+
+func TestSimulatedPlcOutputs(t *testing.T) {
+	plc := NewSimulatedPlc()
+	plc.SetStackLights(true, false, true, false)
+	plc.SetHubMotors(false, true)
+	plc.SetHubLights(true, true)
+	plc.SetCoilOverride(int(fieldResetLight), true)
+
+	status := plc.GetStatus()
+	assert.True(t, status.StackLightRed)
+	assert.False(t, status.StackLightBlue)
+	assert.True(t, status.StackLightOrange)
+	assert.False(t, status.RedHubMotor)
+	assert.True(t, status.BlueHubMotor)
+	assert.True(t, status.RedHubLight)
+	assert.True(t, status.FieldResetLight)
+
+	// Check that the match reset pulse is cleared after a few cycles.
+	plc.ResetMatch()
+	plc.update()
+	assert.True(t, plc.coils[matchReset])
+	assert.True(t, plc.coils[heartbeat])
+	for i := 0; i < 6; i++ {
+		plc.update()
+	}
+	assert.False(t, plc.coils[matchReset])
+}
@@ -31,7 +31,7 @@ var handleMatchLoad = function (data) {
   const teams = $("#teams");
   teams.empty();
 
-  fetch("/displays/announcer/match_load")
+  fetch("/displays/announcer/match_load" + window.location.search)
     .then(response => response.text())
     .then(html => teams.html(html));
 };
@@ -59,7 +59,7 @@ var handleScorePosted = function (data) {
   }
 
   const matchResult = document.getElementById("matchResult");
-  fetch("/displays/announcer/score_posted")
+  fetch("/displays/announcer/score_posted" + window.location.search)
     .then(response => response.text())
     .then(html => {
       matchResult.innerHTML = html;
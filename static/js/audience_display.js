@@ -135,13 +135,13 @@ const handleScorePosted = function (data) {
 
   $(`#${redSide}FinalScore`).text(data.RedScoreSummary.Score);
   $(`#${redSide}FinalAlliance`).text("Alliance " + data.Match.PlayoffRedAlliance);
-  setTeamInfo(redSide, 1, data.Match.Red1, data.RedCards, data.RedRankings);
-  setTeamInfo(redSide, 2, data.Match.Red2, data.RedCards, data.RedRankings);
-  setTeamInfo(redSide, 3, data.Match.Red3, data.RedCards, data.RedRankings);
+  setTeamInfo(redSide, 1, data.Match.Red1, data.RedCards, data.RedRankings, data.BackupTeams);
+  setTeamInfo(redSide, 2, data.Match.Red2, data.RedCards, data.RedRankings, data.BackupTeams);
+  setTeamInfo(redSide, 3, data.Match.Red3, data.RedCards, data.RedRankings, data.BackupTeams);
   if (data.RedOffFieldTeamIds.length > 0) {
-    setTeamInfo(redSide, 4, data.RedOffFieldTeamIds[0], data.RedCards, data.RedRankings);
+    setTeamInfo(redSide, 4, data.RedOffFieldTeamIds[0], data.RedCards, data.RedRankings, data.BackupTeams);
   } else {
-    setTeamInfo(redSide, 4, 0, data.RedCards, data.RedRankings);
+    setTeamInfo(redSide, 4, 0, data.RedCards, data.RedRankings, data.BackupTeams);
   }
   $(`#${redSide}FinalAutoFuelPoints`).text(data.RedScoreSummary.AutoFuelPoints);
   $(`#${redSide}FinalAutoTowerPoints`).text(data.RedScoreSummary.AutoTowerPoints);
@@ -175,13 +175,13 @@ const handleScorePosted = function (data) {
 
   $(`#${blueSide}FinalScore`).text(data.BlueScoreSummary.Score);
   $(`#${blueSide}FinalAlliance`).text("Alliance " + data.Match.PlayoffBlueAlliance);
-  setTeamInfo(blueSide, 1, data.Match.Blue1, data.BlueCards, data.BlueRankings);
-  setTeamInfo(blueSide, 2, data.Match.Blue2, data.BlueCards, data.BlueRankings);
-  setTeamInfo(blueSide, 3, data.Match.Blue3, data.BlueCards, data.BlueRankings);
+  setTeamInfo(blueSide, 1, data.Match.Blue1, data.BlueCards, data.BlueRankings, data.BackupTeams);
+  setTeamInfo(blueSide, 2, data.Match.Blue2, data.BlueCards, data.BlueRankings, data.BackupTeams);
+  setTeamInfo(blueSide, 3, data.Match.Blue3, data.BlueCards, data.BlueRankings, data.BackupTeams);
   if (data.BlueOffFieldTeamIds.length > 0) {
-    setTeamInfo(blueSide, 4, data.BlueOffFieldTeamIds[0], data.BlueCards, data.BlueRankings);
+    setTeamInfo(blueSide, 4, data.BlueOffFieldTeamIds[0], data.BlueCards, data.BlueRankings, data.BackupTeams);
   } else {
-    setTeamInfo(blueSide, 4, 0, data.BlueCards, data.BlueRankings);
+    setTeamInfo(blueSide, 4, 0, data.BlueCards, data.BlueRankings, data.BackupTeams);
   }
   $(`#${blueSide}FinalAutoFuelPoints`).text(data.BlueScoreSummary.AutoFuelPoints);
   $(`#${blueSide}FinalAutoTowerPoints`).text(data.BlueScoreSummary.AutoTowerPoints);
@@ -220,7 +220,10 @@ const handleScorePosted = function (data) {
   $("#finalMatchName").html(matchName);
 
   // Reload the bracket to reflect any changes.
-  $("#bracketSvg").attr("src", "/api/bracket/svg?activeMatch=saved&v=" + new Date().getTime());
+  const bracketParams = new URLSearchParams(window.location.search);
+  bracketParams.set("activeMatch", "saved");
+  bracketParams.set("v", new Date().getTime());
+  $("#bracketSvg").attr("src", "/api/bracket/svg?" + bracketParams.toString());
 
   if (data.Match.Type === matchTypePlayoff) {
     // Hide bonus ranking points and show playoff-only fields.
@@ -690,10 +693,11 @@ const getAvatarUrl = function (teamId) {
   return DisplayShared.getAvatarUrl(teamId);
 };
 
-const setTeamInfo = function (side, position, teamId, cards, rankings) {
+const setTeamInfo = function (side, position, teamId, cards, rankings, backupTeams) {
   const teamNumberElement = $(`#${side}FinalTeam${position}`);
   teamNumberElement.html(teamId);
   teamNumberElement.toggle(teamId > 0);
+  teamNumberElement.attr("data-backup", backupTeams[teamId.toString()] === true);
   const avatarElement = $(`#${side}FinalTeam${position}Avatar`);
   avatarElement.attr("src", getAvatarUrl(teamId));
   avatarElement.toggle(teamId > 0);
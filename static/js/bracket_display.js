@@ -7,7 +7,10 @@ var websocket;
 
 // Handles a websocket message to load a new match.
 const handleMatchLoad = function (data) {
-  fetch("/api/bracket/svg?activeMatch=current")
+  // Pass along the display's parameters so that the bracket reflects the field that the display is showing.
+  const params = new URLSearchParams(window.location.search);
+  params.set("activeMatch", "current");
+  fetch("/api/bracket/svg?" + params.toString())
     .then(response => response.text())
     .then(svg => $("#bracket").html(svg));
 };
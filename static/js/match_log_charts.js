@@ -0,0 +1,86 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Shared logic for plotting team match logs against match time, with the match periods and stop events overlaid.
+
+var matchLogEventColors = {
+  "E-Stop": "rgba(220, 53, 69, 0.25)",
+  "A-Stop": "rgba(253, 126, 20, 0.25)",
+  "Robot Link Lost": "rgba(108, 117, 125, 0.25)",
+  "Brownout": "rgba(255, 193, 7, 0.3)",
+};
+
+// Chart.js plugin that shades the match periods and events behind the plotted series.
+var matchLogOverlayPlugin = {
+  id: "matchLogOverlay",
+  beforeDatasetsDraw: function (chart, args, options) {
+    const ctx = chart.ctx;
+    const area = chart.chartArea;
+    const xScale = chart.scales.x;
+    const toPixel = function (value) {
+      return Math.min(Math.max(xScale.getPixelForValue(value), area.left), area.right);
+    };
+
+    ctx.save();
+    ctx.font = "10px sans-serif";
+    $.each(options.periods || [], function (i, period) {
+      const start = toPixel(period.StartSec);
+      const end = toPixel(period.EndSec);
+      if (i % 2 === 1) {
+        ctx.fillStyle = "rgba(0, 0, 0, 0.04)";
+        ctx.fillRect(start, area.top, end - start, area.bottom - area.top);
+      }
+      ctx.strokeStyle = "rgba(0, 0, 0, 0.3)";
+      ctx.setLineDash([4, 4]);
+      ctx.beginPath();
+      ctx.moveTo(start, area.top);
+      ctx.lineTo(start, area.bottom);
+      ctx.stroke();
+      ctx.fillStyle = "rgba(0, 0, 0, 0.5)";
+      ctx.fillText(period.Name, start + 2, area.top + 10);
+    });
+    $.each(options.events || [], function (i, event) {
+      const start = toPixel(event.StartSec);
+      const end = Math.max(toPixel(event.EndSec), start + 2);
+      ctx.fillStyle = matchLogEventColors[event.Type] || "rgba(0, 0, 0, 0.2)";
+      ctx.fillRect(start, area.top, end - start, area.bottom - area.top);
+    });
+    ctx.restore();
+  },
+};
+
+// Converts the given match log rows into Chart.js points using the given function to extract each value.
+var matchLogSeries = function (rows, valueFunction) {
+  return $.map(rows || [], function (row) {
+    return { x: row.MatchTimeSec, y: valueFunction(row) };
+  });
+};
+
+// Creates a line chart of the given datasets against match time, with the given periods and events overlaid.
+var createMatchLogChart = function (canvasId, title, datasets, periods, events, yScales) {
+  const lastPeriod = periods && periods.length > 0 ? periods[periods.length - 1] : null;
+  return new Chart(document.getElementById(canvasId), {
+    type: "line",
+    data: { datasets: datasets },
+    options: {
+      animation: false,
+      maintainAspectRatio: false,
+      plugins: {
+        title: { display: true, text: title },
+        matchLogOverlay: { periods: periods, events: events },
+      },
+      scales: $.extend(
+        {
+          x: {
+            type: "linear",
+            min: 0,
+            suggestedMax: lastPeriod ? lastPeriod.EndSec : undefined,
+            title: { display: true, text: "Match Time (s)" },
+          },
+        },
+        yScales || {},
+      ),
+    },
+    plugins: [matchLogOverlayPlugin],
+  });
+};
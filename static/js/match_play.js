@@ -319,7 +319,7 @@ const handleArenaStatus = function (data) {
 const handleMatchLoad = function (data) {
   isReplay = data.IsReplay;
 
-  fetch("/match_play/match_load")
+  fetch("/match_play/match_load" + window.location.search)
     .then(response => response.text())
     .then(html => $("#matchListColumn").html(html));
 
@@ -333,8 +333,18 @@ const handleMatchLoad = function (data) {
     teamId.val(team ? team.Id : "");
     teamId.prop("disabled", !data.AllowSubstitution);
   });
-  $("#playoffRedAllianceInfo").html(formatPlayoffAllianceInfo(data.Match.PlayoffRedAlliance, data.RedOffFieldTeams));
-  $("#playoffBlueAllianceInfo").html(formatPlayoffAllianceInfo(data.Match.PlayoffBlueAlliance, data.BlueOffFieldTeams));
+  $("#playoffRedAllianceInfo").html(formatPlayoffAllianceInfo(
+    data.Match.PlayoffRedAlliance,
+    [data.Match.Red1, data.Match.Red2, data.Match.Red3],
+    data.RedOffFieldTeams,
+    data.BackupTeams,
+  ));
+  $("#playoffBlueAllianceInfo").html(formatPlayoffAllianceInfo(
+    data.Match.PlayoffBlueAlliance,
+    [data.Match.Blue1, data.Match.Blue2, data.Match.Blue3],
+    data.BlueOffFieldTeams,
+    data.BackupTeams,
+  ));
 
   $("#substituteTeams").prop("disabled", true);
   $("#showOverlay").prop("disabled", false);
@@ -411,7 +421,7 @@ const handleEventStatus = function (data) {
   $("#earlyLateMessage").text(data.EarlyLateMessage);
 };
 
-const formatPlayoffAllianceInfo = function (allianceNumber, offFieldTeams) {
+const formatPlayoffAllianceInfo = function (allianceNumber, onFieldTeamIds, offFieldTeams, backupTeams) {
   if (allianceNumber === 0) {
     return "";
   }
@@ -419,6 +429,10 @@ const formatPlayoffAllianceInfo = function (allianceNumber, offFieldTeams) {
   if (offFieldTeams.length > 0) {
     allianceInfo += ` (not on field: ${offFieldTeams.map(team => team.Id).join(", ")})`;
   }
+  const backupTeamIds = onFieldTeamIds.concat(offFieldTeams.map(team => team.Id)).filter(teamId => backupTeams[teamId]);
+  if (backupTeamIds.length > 0) {
+    allianceInfo += ` <span class="badge bg-warning text-dark">Backup: ${backupTeamIds.join(", ")}</span>`;
+  }
   return allianceInfo;
 }
 
@@ -7,7 +7,7 @@ var websocket;
 
 // Handles a websocket message to update the teams for the current match.
 var handleMatchLoad = function (data) {
-  fetch("/displays/queueing/match_load")
+  fetch("/displays/queueing/match_load" + window.location.search)
     .then(response => response.text())
     .then(html => $("#matches").html(html));
 };
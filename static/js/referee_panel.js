@@ -155,7 +155,7 @@ const handleRealtimeScore = function (data) {
   if (newRedFoulsHashCode !== redFoulsHashCode || newBlueFoulsHashCode !== blueFoulsHashCode) {
     redFoulsHashCode = newRedFoulsHashCode;
     blueFoulsHashCode = newBlueFoulsHashCode;
-    fetch("/panels/referee/foul_list")
+    fetch("/panels/referee/foul_list" + window.location.search)
       .then(response => response.text())
       .then(svg => $("#foulList").html(svg));
   }
@@ -152,6 +152,11 @@ const handleEndgameClick = function (teamPosition, endgameTowerStatus) {
   websocket.send("endgame", {TeamPosition: teamPosition, EndgameTowerStatus: endgameTowerStatus});
 }
 
+// Sends a websocket message to revert the last scoring action taken from this panel by the current user.
+const undoLastAction = function () {
+  websocket.send("undo");
+};
+
 // Sends a websocket message to indicate that the score for this alliance is ready.
 const commitMatchScore = function () {
   websocket.send("commitMatch");
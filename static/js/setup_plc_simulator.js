@@ -0,0 +1,48 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client-side logic for the PLC Simulator page.
+
+var websocket;
+var stations = ["R1", "R2", "R3", "B1", "B2", "B3"];
+
+// Sends a websocket message to change one of the simulated PLC inputs.
+var setInput = function (messageType, data) {
+  websocket.send(messageType, data);
+};
+
+// Sends a websocket message to add to the given alliance's Hub count.
+var addHubCount = function (alliance, count) {
+  websocket.send("addHubCount", { Alliance: alliance, Count: count });
+};
+
+// Handles a websocket message to update the simulated PLC I/O status.
+var handlePlcIoChange = function (data) {
+  const status = data.Simulated;
+  $("#fieldEStop").prop("checked", status.FieldEStop);
+  $("#ftaReady").prop("checked", status.FtaReady);
+  $.each(stations, function (i, station) {
+    const index = i % 3;
+    const isRed = i < 3;
+    $("#eStop" + station).prop("checked", (isRed ? status.RedEStops : status.BlueEStops)[index]);
+    $("#aStop" + station).prop("checked", (isRed ? status.RedAStops : status.BlueAStops)[index]);
+    $("#ethernet" + station).prop("checked", (isRed ? status.RedEthernets : status.BlueEthernets)[index]);
+  });
+  $("#redHubCount").text(status.RedHubCount);
+  $("#blueHubCount").text(status.BlueHubCount);
+
+  $("[id^=output]").each(function (_index, element) {
+    const value = status[element.id.substring("output".length)];
+    $(element).text(value);
+    $(element).attr("data-plc-value", value);
+  });
+};
+
+$(function () {
+  // Set up the websocket back to the server.
+  websocket = new CheesyWebsocket("/setup/plc_simulator/websocket", {
+    plcIoChange: function (event) {
+      handlePlcIoChange(event.data);
+    },
+  });
+});
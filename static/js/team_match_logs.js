@@ -0,0 +1,37 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client-side logic for the page comparing a single team's logs across all of their matches.
+
+// Creates a chart overlaying the given value from each of the team's match logs.
+var renderComparisonChart = function (canvasId, title, matchLogs, periods, valueFunction) {
+  const datasets = $.map(matchLogs || [], function (matchLog) {
+    return {
+      label: matchLog.MatchShortName || matchLog.StartTime,
+      data: matchLogSeries(matchLog.Rows, valueFunction),
+      pointRadius: 0,
+      tension: 0.1,
+    };
+  });
+  createMatchLogChart(canvasId, title, datasets, periods, []);
+};
+
+// Creates all the comparison charts for the given team's match logs.
+var renderTeamMatchLogs = function (matchLogs, periods) {
+  renderComparisonChart("voltage_chart", "Voltage", matchLogs, periods, (row) =>
+    row.RobotLinked ? row.BatteryVoltage : null,
+  );
+  renderComparisonChart("latency_chart", "Latency (ms)", matchLogs, periods, (row) =>
+    row.RobotLinked ? row.DsRobotTripTimeMs : null,
+  );
+  renderComparisonChart(
+    "missed_packets_chart",
+    "Missed Packets Since Previous Sample",
+    matchLogs,
+    periods,
+    (row) => row.MissedPacketDelta,
+  );
+  renderComparisonChart("snr_chart", "SNR", matchLogs, periods, (row) =>
+    row.SignalNoiseRatio >= 0 ? row.SignalNoiseRatio : null,
+  );
+};
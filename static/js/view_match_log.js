@@ -0,0 +1,200 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client-side logic for the page showing the logs of a single match for a single team.
+
+// Returns a Chart.js category axis for a boolean series, stacked with the other axes of the same chart.
+var booleanAxis = function (color, isFirst) {
+  return {
+    type: "category",
+    labels: [true, false],
+    offset: !isFirst,
+    position: "left",
+    stack: "link",
+    stackWeight: 1,
+    border: { color: color },
+  };
+};
+
+// Creates all the charts for the given match log.
+var renderMatchLog = function (matchLog, teamId, periods) {
+  const suffix = matchLog.StartTime;
+  const rows = matchLog.Rows;
+  const events = matchLog.Events;
+
+  createMatchLogChart(
+    "link_chart_" + suffix,
+    "Link",
+    [
+      {
+        label: "DS Linked",
+        data: matchLogSeries(rows, (row) => row.DsLinked),
+        borderColor: "rgb(192, 75, 75)",
+        stepped: true,
+        yAxisID: "y",
+      },
+      {
+        label: "Radio Linked",
+        data: matchLogSeries(rows, (row) => row.DsLinked && row.RadioLinked),
+        borderColor: "rgb(75, 75, 192)",
+        stepped: true,
+        yAxisID: "y2",
+      },
+      {
+        label: "Rio Linked",
+        data: matchLogSeries(rows, (row) => row.DsLinked && row.RadioLinked && row.RioLinked),
+        borderColor: "rgb(192, 192, 75)",
+        stepped: true,
+        yAxisID: "y3",
+      },
+      {
+        label: "Robot Linked",
+        data: matchLogSeries(rows, (row) => row.DsLinked && row.RadioLinked && row.RioLinked && row.RobotLinked),
+        borderColor: "rgb(75, 192, 192)",
+        stepped: true,
+        yAxisID: "y4",
+      },
+      {
+        label: "Ethernet Connected",
+        data: matchLogSeries(rows, (row) => row.EthernetConnected),
+        borderColor: "rgb(128, 128, 128)",
+        stepped: true,
+        yAxisID: "y5",
+      },
+    ],
+    periods,
+    events,
+    {
+      y: booleanAxis("rgb(192, 75, 75)", true),
+      y2: booleanAxis("rgb(75, 75, 192)", false),
+      y3: booleanAxis("rgb(192, 192, 75)", false),
+      y4: booleanAxis("rgb(75, 192, 192)", false),
+      y5: booleanAxis("rgb(128, 128, 128)", false),
+    },
+  );
+
+  createMatchLogChart(
+    "mode_chart_" + suffix,
+    "Mode / Enable",
+    [
+      {
+        label: "Arena Auto",
+        data: matchLogSeries(rows, (row) => row.Auto),
+        borderColor: "rgb(192, 75, 75)",
+        stepped: true,
+      },
+      {
+        label: "DS Auto",
+        data: matchLogSeries(rows, (row) => (row.DsReportedStatusValid ? row.DsReportedAuto : null)),
+        borderColor: "rgb(192, 75, 75)",
+        borderDash: [6, 4],
+        stepped: true,
+      },
+      {
+        label: "Arena Enabled",
+        data: matchLogSeries(rows, (row) => row.Enabled),
+        borderColor: "rgb(75, 75, 192)",
+        stepped: true,
+      },
+      {
+        label: "DS Enabled",
+        data: matchLogSeries(rows, (row) => (row.DsReportedStatusValid ? row.DsReportedEnabled : null)),
+        borderColor: "rgb(75, 75, 192)",
+        borderDash: [6, 4],
+        stepped: true,
+      },
+      {
+        label: "E-Stop",
+        data: matchLogSeries(rows, (row) => row.EmergencyStop),
+        borderColor: "rgb(220, 53, 69)",
+        stepped: true,
+      },
+      {
+        label: "A-Stop",
+        data: matchLogSeries(rows, (row) => row.AutonomousStop),
+        borderColor: "rgb(253, 126, 20)",
+        stepped: true,
+      },
+    ],
+    periods,
+    events,
+    { y: { type: "category", labels: [true, false] } },
+  );
+
+  createMatchLogChart(
+    "voltage_chart_" + suffix,
+    "Voltage",
+    [
+      {
+        label: String(teamId),
+        data: matchLogSeries(rows, (row) => row.BatteryVoltage),
+        borderColor: "rgb(192, 75, 75)",
+        tension: 0.1,
+      },
+    ],
+    periods,
+    events,
+  );
+
+  createMatchLogChart(
+    "latency_chart_" + suffix,
+    "Latency (ms)",
+    [
+      {
+        label: String(teamId),
+        data: matchLogSeries(rows, (row) => row.DsRobotTripTimeMs),
+        tension: 0.1,
+      },
+    ],
+    periods,
+    events,
+  );
+
+  createMatchLogChart(
+    "missed_packets_chart_" + suffix,
+    "Missed Packets",
+    [
+      {
+        label: "Total",
+        data: matchLogSeries(rows, (row) => row.MissedPacketCount),
+        borderColor: "rgb(192, 192, 75)",
+        tension: 0.1,
+      },
+      {
+        label: "Since Previous Sample",
+        data: matchLogSeries(rows, (row) => row.MissedPacketDelta),
+        borderColor: "rgb(192, 75, 75)",
+        stepped: true,
+      },
+    ],
+    periods,
+    events,
+  );
+
+  createMatchLogChart(
+    "wifi_chart_" + suffix,
+    "Wi-Fi",
+    [
+      {
+        label: "TX Rate (Mbps)",
+        data: matchLogSeries(rows, (row) => (row.TxRate >= 0 ? row.TxRate : null)),
+        borderColor: "rgb(75, 75, 192)",
+        tension: 0.1,
+      },
+      {
+        label: "RX Rate (Mbps)",
+        data: matchLogSeries(rows, (row) => (row.RxRate >= 0 ? row.RxRate : null)),
+        borderColor: "rgb(75, 192, 75)",
+        tension: 0.1,
+      },
+      {
+        label: "SNR",
+        data: matchLogSeries(rows, (row) => (row.SignalNoiseRatio >= 0 ? row.SignalNoiseRatio : null)),
+        borderColor: "rgb(75, 192, 192)",
+        tension: 0.1,
+      },
+    ],
+    periods,
+    events,
+  );
+};
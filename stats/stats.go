@@ -0,0 +1,242 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Least-squares estimates of each team's contribution to its alliances' results (OPR and related statistics).
+
+package stats
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"math"
+	"sort"
+)
+
+// Amount added to the diagonal of the normal equations so that they remain solvable early in an event, when some
+// teams have only ever played together. It is small enough relative to the number of matches played not to bias the
+// results appreciably.
+const ridge = 1e-6
+
+// TeamStats holds the calculated statistics for a single team.
+type TeamStats struct {
+	TeamId     int
+	NumMatches int
+
+	// Offensive power rating: the team's estimated contribution to its alliance's score.
+	Opr float64
+
+	// Defensive power rating: the team's estimated contribution to its opponents' score.
+	Dpr float64
+
+	// Calculated contribution to winning margin, equal to OPR minus DPR.
+	Ccwm float64
+
+	// Component OPRs, estimating the team's contribution to each part of its alliance's score.
+	AutoFuelOpr   float64
+	TeleopFuelOpr float64
+	TowerOpr      float64
+	FoulsDrawnOpr float64
+}
+
+// The result of a single alliance in a single match.
+type allianceResult struct {
+	teamIds         []int
+	summary         game.ScoreSummary
+	opponentSummary game.ScoreSummary
+}
+
+// Keys by which a list of team statistics can be sorted, mapped to the value to sort by and whether smaller is better.
+var sortFields = map[string]struct {
+	value     func(stats *TeamStats) float64
+	ascending bool
+}{
+	"team":       {func(stats *TeamStats) float64 { return float64(stats.TeamId) }, true},
+	"matches":    {func(stats *TeamStats) float64 { return float64(stats.NumMatches) }, false},
+	"opr":        {func(stats *TeamStats) float64 { return stats.Opr }, false},
+	"dpr":        {func(stats *TeamStats) float64 { return stats.Dpr }, true},
+	"ccwm":       {func(stats *TeamStats) float64 { return stats.Ccwm }, false},
+	"autoFuel":   {func(stats *TeamStats) float64 { return stats.AutoFuelOpr }, false},
+	"teleopFuel": {func(stats *TeamStats) float64 { return stats.TeleopFuelOpr }, false},
+	"tower":      {func(stats *TeamStats) float64 { return stats.TowerOpr }, false},
+	"foulsDrawn": {func(stats *TeamStats) float64 { return stats.FoulsDrawnOpr }, false},
+}
+
+// CalculateTeamStats computes the statistics for every team that has played in a completed match of the given type,
+// ordered by descending OPR.
+func CalculateTeamStats(database *model.Database, matchType model.MatchType) ([]TeamStats, error) {
+	matches, err := database.GetMatchesByType(matchType, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []allianceResult
+	for _, match := range matches {
+		if !match.IsComplete() {
+			continue
+		}
+		matchResult, err := database.GetMatchResultForMatch(match.Id)
+		if err != nil {
+			return nil, err
+		}
+		if matchResult == nil {
+			return nil, fmt.Errorf("found no match result for match %d", match.Id)
+		}
+		redTeamIds := nonZeroTeamIds(match.Red1, match.Red2, match.Red3)
+		blueTeamIds := nonZeroTeamIds(match.Blue1, match.Blue2, match.Blue3)
+		redSummary, blueSummary := *matchResult.RedScoreSummary(), *matchResult.BlueScoreSummary()
+		results = append(
+			results,
+			allianceResult{teamIds: redTeamIds, summary: redSummary, opponentSummary: blueSummary},
+			allianceResult{teamIds: blueTeamIds, summary: blueSummary, opponentSummary: redSummary},
+		)
+	}
+	return calculate(results), nil
+}
+
+// Sorts the given statistics in place by the given key, from best to worst.
+func SortTeamStats(teamStats []TeamStats, key string) error {
+	field, ok := sortFields[key]
+	if !ok {
+		return fmt.Errorf("invalid sort key '%s'", key)
+	}
+	sort.SliceStable(teamStats, func(i, j int) bool {
+		a, b := field.value(&teamStats[i]), field.value(&teamStats[j])
+		if a == b {
+			return teamStats[i].TeamId < teamStats[j].TeamId
+		}
+		if field.ascending {
+			return a < b
+		}
+		return a > b
+	})
+	return nil
+}
+
+// Solves the least-squares problems for each statistic over the given alliance results.
+func calculate(results []allianceResult) []TeamStats {
+	teamIndices := make(map[int]int)
+	var teamStats []TeamStats
+	for _, result := range results {
+		for _, teamId := range result.teamIds {
+			index, ok := teamIndices[teamId]
+			if !ok {
+				index = len(teamStats)
+				teamIndices[teamId] = index
+				teamStats = append(teamStats, TeamStats{TeamId: teamId})
+			}
+			teamStats[index].NumMatches++
+		}
+	}
+	numTeams := len(teamStats)
+	if numTeams == 0 {
+		return []TeamStats{}
+	}
+
+	// Build the normal equations (A^T A) x = A^T b, where each row of A indicates the teams on an alliance and b holds
+	// that alliance's result for each statistic.
+	const (
+		oprColumn = iota
+		dprColumn
+		autoFuelColumn
+		teleopFuelColumn
+		towerColumn
+		foulsDrawnColumn
+		numColumns
+	)
+	matrix := make([][]float64, numTeams)
+	rhs := make([][]float64, numTeams)
+	for i := range matrix {
+		matrix[i] = make([]float64, numTeams)
+		matrix[i][i] = ridge
+		rhs[i] = make([]float64, numColumns)
+	}
+	for _, result := range results {
+		var values [numColumns]float64
+		values[oprColumn] = float64(result.summary.Score)
+		values[dprColumn] = float64(result.opponentSummary.Score)
+		values[autoFuelColumn] = float64(result.summary.AutoFuelPoints)
+		values[teleopFuelColumn] = float64(result.summary.TeleopFuelPoints)
+		values[towerColumn] = float64(result.summary.AutoTowerPoints + result.summary.TeleopTowerPoints)
+		values[foulsDrawnColumn] = float64(result.summary.FoulPoints)
+		for _, teamId := range result.teamIds {
+			i := teamIndices[teamId]
+			for _, otherTeamId := range result.teamIds {
+				matrix[i][teamIndices[otherTeamId]]++
+			}
+			for column, value := range values {
+				rhs[i][column] += value
+			}
+		}
+	}
+
+	solutions := solveSymmetric(matrix, rhs)
+	for i := range teamStats {
+		stats := &teamStats[i]
+		stats.Opr = solutions[i][oprColumn]
+		stats.Dpr = solutions[i][dprColumn]
+		stats.Ccwm = stats.Opr - stats.Dpr
+		stats.AutoFuelOpr = solutions[i][autoFuelColumn]
+		stats.TeleopFuelOpr = solutions[i][teleopFuelColumn]
+		stats.TowerOpr = solutions[i][towerColumn]
+		stats.FoulsDrawnOpr = solutions[i][foulsDrawnColumn]
+	}
+	_ = SortTeamStats(teamStats, "opr")
+	return teamStats
+}
+
+// Solves the given symmetric positive-definite system for each column of the right-hand side, using a Cholesky
+// decomposition. The matrix is overwritten in the process.
+func solveSymmetric(matrix [][]float64, rhs [][]float64) [][]float64 {
+	n := len(matrix)
+	for j := 0; j < n; j++ {
+		sum := matrix[j][j]
+		for k := 0; k < j; k++ {
+			sum -= matrix[j][k] * matrix[j][k]
+		}
+		matrix[j][j] = math.Sqrt(sum)
+		for i := j + 1; i < n; i++ {
+			sum := matrix[i][j]
+			for k := 0; k < j; k++ {
+				sum -= matrix[i][k] * matrix[j][k]
+			}
+			matrix[i][j] = sum / matrix[j][j]
+		}
+	}
+
+	numColumns := len(rhs[0])
+	solutions := make([][]float64, n)
+	for i := range solutions {
+		solutions[i] = make([]float64, numColumns)
+	}
+	for column := 0; column < numColumns; column++ {
+		// Forward substitution with the lower triangle, then back substitution with its transpose.
+		y := make([]float64, n)
+		for i := 0; i < n; i++ {
+			sum := rhs[i][column]
+			for k := 0; k < i; k++ {
+				sum -= matrix[i][k] * y[k]
+			}
+			y[i] = sum / matrix[i][i]
+		}
+		for i := n - 1; i >= 0; i-- {
+			sum := y[i]
+			for k := i + 1; k < n; k++ {
+				sum -= matrix[k][i] * solutions[k][column]
+			}
+			solutions[i][column] = sum / matrix[i][i]
+		}
+	}
+	return solutions
+}
+
+// Returns the given team IDs with any empty positions left out.
+func nonZeroTeamIds(teamIds ...int) []int {
+	var result []int
+	for _, teamId := range teamIds {
+		if teamId > 0 {
+			result = append(result, teamId)
+		}
+	}
+	return result
+}
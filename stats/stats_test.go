@@ -0,0 +1,141 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package stats
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCalculateExactContributions(t *testing.T) {
+	// Each alliance's result is exactly the sum of its teams' contributions, so the least-squares fit recovers them.
+	opr := map[int]int{1: 10, 2: 20, 3: 30, 4: 40}
+	autoFuel := map[int]int{1: 1, 2: 2, 3: 0, 4: 5}
+	fouls := map[int]int{1: 0, 2: 6, 3: 0, 4: 0}
+	alliances := [][]int{{1, 2, 3}, {1, 2, 4}, {1, 3, 4}, {2, 3, 4}}
+	var results []allianceResult
+	for i, teamIds := range alliances {
+		var summary game.ScoreSummary
+		for _, teamId := range teamIds {
+			summary.Score += opr[teamId]
+			summary.AutoFuelPoints += autoFuel[teamId]
+			summary.FoulPoints += fouls[teamId]
+		}
+		opponentSummary := game.ScoreSummary{Score: 10 * (i + 1)}
+		results = append(results, allianceResult{teamIds, summary, opponentSummary})
+	}
+
+	teamStats := calculate(results)
+	if assert.Equal(t, 4, len(teamStats)) {
+		// Ordered by descending OPR.
+		assert.Equal(t, 4, teamStats[0].TeamId)
+		assert.Equal(t, 1, teamStats[3].TeamId)
+		for _, stats := range teamStats {
+			assert.Equal(t, 3, stats.NumMatches)
+			assert.InDelta(t, opr[stats.TeamId], stats.Opr, 0.01)
+			assert.InDelta(t, autoFuel[stats.TeamId], stats.AutoFuelOpr, 0.01)
+			assert.InDelta(t, fouls[stats.TeamId], stats.FoulsDrawnOpr, 0.01)
+			assert.InDelta(t, 0, stats.TowerOpr, 0.01)
+			assert.InDelta(t, stats.Opr-stats.Dpr, stats.Ccwm, 1e-9)
+		}
+	}
+
+	// Team 1 sat out the alliance that gave up the most points, and team 4 the one that gave up the fewest.
+	assert.Equal(t, []int{1, 2, 3, 4}, teamIdsByKey(t, teamStats, "dpr"))
+}
+
+func TestCalculateSingularSystem(t *testing.T) {
+	// Teams that have only ever played together split their alliance's score evenly.
+	results := []allianceResult{
+		{teamIds: []int{1, 2, 3}, summary: game.ScoreSummary{Score: 90}},
+		{teamIds: []int{4, 5, 6}, summary: game.ScoreSummary{Score: 30}},
+	}
+	teamStats := calculate(results)
+	if assert.Equal(t, 6, len(teamStats)) {
+		for _, stats := range teamStats {
+			if stats.TeamId <= 3 {
+				assert.InDelta(t, 30, stats.Opr, 0.01)
+			} else {
+				assert.InDelta(t, 10, stats.Opr, 0.01)
+			}
+		}
+	}
+
+	assert.Equal(t, []TeamStats{}, calculate(nil))
+}
+
+func TestSortTeamStats(t *testing.T) {
+	teamStats := []TeamStats{
+		{TeamId: 254, Opr: 50, Dpr: 20, TowerOpr: 5},
+		{TeamId: 1114, Opr: 60, Dpr: 30, TowerOpr: 5},
+		{TeamId: 33, Opr: 40, Dpr: 10, TowerOpr: 8},
+	}
+	assert.Equal(t, []int{1114, 254, 33}, teamIdsByKey(t, teamStats, "opr"))
+	assert.Equal(t, []int{33, 254, 1114}, teamIdsByKey(t, teamStats, "dpr"))
+	assert.Equal(t, []int{33, 254, 1114}, teamIdsByKey(t, teamStats, "team"))
+	assert.Equal(t, []int{33, 254, 1114}, teamIdsByKey(t, teamStats, "tower"))
+
+	err := SortTeamStats(teamStats, "height")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid sort key 'height'", err.Error())
+	}
+}
+
+func TestCalculateTeamStats(t *testing.T) {
+	database := model.SetupTestDb(t)
+
+	teamStats, err := CalculateTeamStats(database, model.Qualification)
+	assert.Nil(t, err)
+	assert.Empty(t, teamStats)
+
+	match := model.Match{
+		Type:   model.Qualification,
+		Red1:   1,
+		Red2:   2,
+		Red3:   3,
+		Blue1:  4,
+		Blue2:  5,
+		Blue3:  6,
+		Status: game.BlueWonMatch,
+	}
+	assert.Nil(t, database.CreateMatch(&match))
+	assert.Nil(t, database.CreateMatch(&model.Match{Type: model.Qualification, Red1: 7, Blue1: 8}))
+	_, err = CalculateTeamStats(database, model.Qualification)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "found no match result for match 1", err.Error())
+	}
+
+	matchResult := model.BuildTestMatchResult(match.Id, 1)
+	assert.Nil(t, database.CreateMatchResult(matchResult))
+	teamStats, err = CalculateTeamStats(database, model.Qualification)
+	assert.Nil(t, err)
+	redSummary, blueSummary := matchResult.RedScoreSummary(), matchResult.BlueScoreSummary()
+	if assert.Equal(t, 6, len(teamStats)) {
+		for _, stats := range teamStats {
+			ownSummary, opponentSummary := redSummary, blueSummary
+			if stats.TeamId >= 4 {
+				ownSummary, opponentSummary = blueSummary, redSummary
+			}
+			assert.InDelta(t, float64(ownSummary.Score)/3, stats.Opr, 0.01)
+			assert.InDelta(t, float64(opponentSummary.Score)/3, stats.Dpr, 0.01)
+			assert.InDelta(t, float64(ownSummary.TeleopFuelPoints)/3, stats.TeleopFuelOpr, 0.01)
+		}
+	}
+
+	// Unplayed matches and other match types don't count.
+	teamStats, err = CalculateTeamStats(database, model.Playoff)
+	assert.Nil(t, err)
+	assert.Empty(t, teamStats)
+}
+
+func teamIdsByKey(t *testing.T, teamStats []TeamStats, key string) []int {
+	assert.Nil(t, SortTeamStats(teamStats, key))
+	var teamIds []int
+	for _, stats := range teamStats {
+		teamIds = append(teamIds, stats.TeamId)
+	}
+	return teamIds
+}
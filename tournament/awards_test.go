@@ -13,7 +13,7 @@ func TestCreateOrUpdateAwardWithIntro(t *testing.T) {
 	database := setupTestDb(t)
 	database.CreateTeam(&model.Team{Id: 254, Nickname: "Teh Chezy Pofs"})
 
-	award := model.Award{0, model.JudgedAward, "Safety Award", 0, ""}
+	award := model.Award{0, model.JudgedAward, "Safety Award", 0, "", false}
 	err := CreateOrUpdateAward(database, &award, true)
 	assert.Nil(t, err)
 	award2, _ := database.GetAwardById(award.Id)
@@ -54,7 +54,7 @@ func TestCreateOrUpdateAwardWithoutIntro(t *testing.T) {
 	otherLowerThird := model.LowerThird{TopText: "Marco", BottomText: "Polo"}
 	database.CreateLowerThird(&otherLowerThird)
 
-	award := model.Award{0, model.WinnerAward, "Winner", 0, "Bob Dorough"}
+	award := model.Award{0, model.WinnerAward, "Winner", 0, "Bob Dorough", false}
 	err := CreateOrUpdateAward(database, &award, false)
 	assert.Nil(t, err)
 	award2, _ := database.GetAwardById(award.Id)
@@ -104,14 +104,14 @@ func TestCreateOrUpdateWinnerAndFinalistAwards(t *testing.T) {
 	assert.Nil(t, err)
 	awards, _ := database.GetAllAwards()
 	if assert.Equal(t, 8, len(awards)) {
-		assert.Equal(t, model.Award{1, model.FinalistAward, "Finalist", 101, ""}, awards[0])
-		assert.Equal(t, model.Award{2, model.FinalistAward, "Finalist", 102, ""}, awards[1])
-		assert.Equal(t, model.Award{3, model.FinalistAward, "Finalist", 103, ""}, awards[2])
-		assert.Equal(t, model.Award{4, model.FinalistAward, "Finalist", 104, ""}, awards[3])
-		assert.Equal(t, model.Award{5, model.WinnerAward, "Winner", 201, ""}, awards[4])
-		assert.Equal(t, model.Award{6, model.WinnerAward, "Winner", 202, ""}, awards[5])
-		assert.Equal(t, model.Award{7, model.WinnerAward, "Winner", 203, ""}, awards[6])
-		assert.Equal(t, model.Award{8, model.WinnerAward, "Winner", 204, ""}, awards[7])
+		assert.Equal(t, model.Award{1, model.FinalistAward, "Finalist", 101, "", false}, awards[0])
+		assert.Equal(t, model.Award{2, model.FinalistAward, "Finalist", 102, "", false}, awards[1])
+		assert.Equal(t, model.Award{3, model.FinalistAward, "Finalist", 103, "", false}, awards[2])
+		assert.Equal(t, model.Award{4, model.FinalistAward, "Finalist", 104, "", false}, awards[3])
+		assert.Equal(t, model.Award{5, model.WinnerAward, "Winner", 201, "", false}, awards[4])
+		assert.Equal(t, model.Award{6, model.WinnerAward, "Winner", 202, "", false}, awards[5])
+		assert.Equal(t, model.Award{7, model.WinnerAward, "Winner", 203, "", false}, awards[6])
+		assert.Equal(t, model.Award{8, model.WinnerAward, "Winner", 204, "", false}, awards[7])
 	}
 	lowerThirds, _ := database.GetAllLowerThirds()
 	if assert.Equal(t, 10, len(lowerThirds)) {
@@ -129,14 +129,14 @@ func TestCreateOrUpdateWinnerAndFinalistAwards(t *testing.T) {
 	assert.Nil(t, err)
 	awards, _ = database.GetAllAwards()
 	if assert.Equal(t, 8, len(awards)) {
-		assert.Equal(t, model.Award{9, model.FinalistAward, "Finalist", 201, ""}, awards[0])
-		assert.Equal(t, model.Award{10, model.FinalistAward, "Finalist", 202, ""}, awards[1])
-		assert.Equal(t, model.Award{11, model.FinalistAward, "Finalist", 203, ""}, awards[2])
-		assert.Equal(t, model.Award{12, model.FinalistAward, "Finalist", 204, ""}, awards[3])
-		assert.Equal(t, model.Award{13, model.WinnerAward, "Winner", 101, ""}, awards[4])
-		assert.Equal(t, model.Award{14, model.WinnerAward, "Winner", 102, ""}, awards[5])
-		assert.Equal(t, model.Award{15, model.WinnerAward, "Winner", 103, ""}, awards[6])
-		assert.Equal(t, model.Award{16, model.WinnerAward, "Winner", 104, ""}, awards[7])
+		assert.Equal(t, model.Award{9, model.FinalistAward, "Finalist", 201, "", false}, awards[0])
+		assert.Equal(t, model.Award{10, model.FinalistAward, "Finalist", 202, "", false}, awards[1])
+		assert.Equal(t, model.Award{11, model.FinalistAward, "Finalist", 203, "", false}, awards[2])
+		assert.Equal(t, model.Award{12, model.FinalistAward, "Finalist", 204, "", false}, awards[3])
+		assert.Equal(t, model.Award{13, model.WinnerAward, "Winner", 101, "", false}, awards[4])
+		assert.Equal(t, model.Award{14, model.WinnerAward, "Winner", 102, "", false}, awards[5])
+		assert.Equal(t, model.Award{15, model.WinnerAward, "Winner", 103, "", false}, awards[6])
+		assert.Equal(t, model.Award{16, model.WinnerAward, "Winner", 104, "", false}, awards[7])
 	}
 	lowerThirds, _ = database.GetAllLowerThirds()
 	if assert.Equal(t, 10, len(lowerThirds)) {
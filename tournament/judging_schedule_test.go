@@ -14,6 +14,7 @@ import (
 func TestBuildJudgingSchedule(t *testing.T) {
 	randomizer := rand.New(rand.NewSource(0))
 	schedulePerm = randomizer.Perm
+	scheduleRandSeed = func() int64 { return 0 }
 	judgingShuffle = randomizer.Shuffle
 	database := setupTestDb(t)
 
@@ -99,7 +100,9 @@ func TestBuildJudgingSchedule(t *testing.T) {
 			assert.True(t, slot.Time.Before(breakStartTime))
 		}
 		if slot.Time.After(breakStartTime) {
-			assert.True(t, slot.Time.After(scheduleBlocks[1].StartTime))
+			// A judge whose next slot would have started during the break resumes right as the next block starts, so a
+			// slot may start exactly at that time.
+			assert.False(t, slot.Time.Before(scheduleBlocks[1].StartTime))
 		}
 	}
 	if assert.Equal(t, 3, len(judgeTeamCounts)) {
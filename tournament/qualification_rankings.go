@@ -13,13 +13,14 @@ import (
 	"strconv"
 )
 
-// Determines the rankings from the stored match results, and saves them to the database.
+// Determines the rankings from the stored match results using the current ranking rules, and saves them to the
+// database.
 func CalculateRankings(database *model.Database, preservePreviousRank bool) (game.Rankings, error) {
 	matches, err := database.GetMatchesByType(model.Qualification, false)
 	if err != nil {
 		return nil, err
 	}
-	rankings := make(map[int]*game.Ranking)
+	matchFields := make(map[int][]game.RankingFields)
 	for _, match := range matches {
 		if !match.IsComplete() {
 			continue
@@ -28,24 +29,14 @@ func CalculateRankings(database *model.Database, preservePreviousRank bool) (gam
 		if err != nil {
 			return nil, err
 		}
-		if !match.Red1IsSurrogate {
-			addMatchResultToRankings(rankings, match.Red1, matchResult, true)
-		}
-		if !match.Red2IsSurrogate {
-			addMatchResultToRankings(rankings, match.Red2, matchResult, true)
-		}
-		if !match.Red3IsSurrogate {
-			addMatchResultToRankings(rankings, match.Red3, matchResult, true)
-		}
-		if !match.Blue1IsSurrogate {
-			addMatchResultToRankings(rankings, match.Blue1, matchResult, false)
-		}
-		if !match.Blue2IsSurrogate {
-			addMatchResultToRankings(rankings, match.Blue2, matchResult, false)
-		}
-		if !match.Blue3IsSurrogate {
-			addMatchResultToRankings(rankings, match.Blue3, matchResult, false)
-		}
+		addMatchToRankings(matchFields, &match, matchResult)
+	}
+	withdrawnTeamIds, err := getWithdrawnTeamIds(database)
+	if err != nil {
+		return nil, err
+	}
+	for teamId := range withdrawnTeamIds {
+		delete(matchFields, teamId)
 	}
 
 	// Retrieve old rankings so that we can display changes in rank as a result of this calculation.
@@ -58,7 +49,7 @@ func CalculateRankings(database *model.Database, preservePreviousRank bool) (gam
 		oldRankingsMap[ranking.TeamId] = ranking
 	}
 
-	sortedRankings := sortRankings(rankings)
+	sortedRankings := combineRankings(matchFields)
 	for rank, ranking := range sortedRankings {
 		sortedRankings[rank].Rank = rank + 1
 		if oldRank, ok := oldRankingsMap[ranking.TeamId]; ok {
@@ -131,14 +122,20 @@ func CalculateTeamCards(database *model.Database, matchType model.MatchType) err
 	return nil
 }
 
-// Incrementally accounts for the given match result in the set of rankings that are being built.
-func addMatchResultToRankings(rankings map[int]*game.Ranking, teamId int, matchResult *model.MatchResult, isRed bool) {
-	ranking := rankings[teamId]
-	if ranking == nil {
-		ranking = &game.Ranking{TeamId: teamId}
-		rankings[teamId] = ranking
+// Records the ranking fields of each non-surrogate team in the given match.
+func addMatchToRankings(matchFields map[int][]game.RankingFields, match *model.Match, matchResult *model.MatchResult) {
+	teamIds, surrogates := matchTeams(match)
+	for position, teamId := range teamIds {
+		if !surrogates[position] {
+			addMatchResultToRankings(matchFields, teamId, matchResult, position < 3)
+		}
 	}
+}
 
+// Records the team's ranking fields from the given match result in the set of per-match fields that are being built.
+func addMatchResultToRankings(
+	matchFields map[int][]game.RankingFields, teamId int, matchResult *model.MatchResult, isRed bool,
+) {
 	// Determine whether the team was disqualified.
 	var cards map[string]string
 	if isRed {
@@ -151,18 +148,23 @@ func addMatchResultToRankings(rankings map[int]*game.Ranking, teamId int, matchR
 		disqualified = true
 	}
 
+	var fields game.RankingFields
 	if isRed {
-		ranking.AddScoreSummary(matchResult.RedScoreSummary(), matchResult.BlueScoreSummary(), disqualified)
+		fields.AddScoreSummary(matchResult.RedScoreSummary(), matchResult.BlueScoreSummary(), disqualified)
 	} else {
-		ranking.AddScoreSummary(matchResult.BlueScoreSummary(), matchResult.RedScoreSummary(), disqualified)
+		fields.AddScoreSummary(matchResult.BlueScoreSummary(), matchResult.RedScoreSummary(), disqualified)
 	}
+	matchFields[teamId] = append(matchFields[teamId], fields)
 }
 
-func sortRankings(rankings map[int]*game.Ranking) game.Rankings {
-	var sortedRankings game.Rankings
-	for _, ranking := range rankings {
-		sortedRankings = append(sortedRankings, *ranking)
-	}
-	sort.Sort(sortedRankings)
-	return sortedRankings
+// Combines each team's matches into its overall record according to the active ranking rules, and sorts the result.
+func combineRankings(matchFields map[int][]game.RankingFields) game.Rankings {
+	rankings := make(game.Rankings, 0, len(matchFields))
+	for teamId, fields := range matchFields {
+		rankings = append(
+			rankings, game.Ranking{TeamId: teamId, RankingFields: game.CurrentRankingRules.CombineMatches(fields)},
+		)
+	}
+	sort.Sort(rankings)
+	return rankings
 }
@@ -105,8 +105,44 @@ func TestCalculateRankings(t *testing.T) {
 
 }
 
+func TestCalculateRankingsWithCustomRules(t *testing.T) {
+	randomizer := rand.New(rand.NewSource(1))
+	game.RankingRandomFloat64 = randomizer.Float64
+	defer func() {
+		game.CurrentRankingRules = game.CurrentGame.DefaultRankingRules()
+	}()
+	database := setupTestDb(t)
+	setupMatchResultsForRankings(database)
+
+	game.CurrentRankingRules = game.RankingRules{
+		WinPoints: 2, Criteria: []game.RankingCriterion{game.MatchPointsCriterion},
+	}
+	rankings, err := CalculateRankings(database, false)
+	assert.Nil(t, err)
+	for _, ranking := range rankings {
+		// Ties and bonuses are worth nothing under these rules.
+		assert.Equal(t, 2*ranking.Wins, ranking.RankingPoints)
+	}
+	for i := 1; i < len(rankings); i++ {
+		previous := rankings[i-1]
+		assert.GreaterOrEqual(t, previous.MatchPoints*rankings[i].Played, rankings[i].MatchPoints*previous.Played)
+	}
+
+	// Dropping each team's worst match should leave one fewer match in the record of teams that played several.
+	unmodifiedPlayed := make(map[int]int)
+	for _, ranking := range rankings {
+		unmodifiedPlayed[ranking.TeamId] = ranking.Played
+	}
+	game.CurrentRankingRules.DropLowestMatches = 1
+	rankings, err = CalculateRankings(database, false)
+	assert.Nil(t, err)
+	for _, ranking := range rankings {
+		assert.Equal(t, max(unmodifiedPlayed[ranking.TeamId]-1, 1), ranking.Played)
+	}
+}
+
 func TestAddMatchResultToRankingsHandleCards(t *testing.T) {
-	rankings := map[int]*game.Ranking{}
+	rankings := map[int][]game.RankingFields{}
 	matchResult := model.BuildTestMatchResult(1, 1)
 	matchResult.RedCards = map[string]string{"1": "yellow", "2": "red", "3": "dq"}
 	matchResult.BlueCards = map[string]string{"4": "red", "5": "dq", "6": "yellow"}
@@ -116,12 +152,12 @@ func TestAddMatchResultToRankingsHandleCards(t *testing.T) {
 	addMatchResultToRankings(rankings, 4, matchResult, false)
 	addMatchResultToRankings(rankings, 5, matchResult, false)
 	addMatchResultToRankings(rankings, 6, matchResult, false)
-	assert.Equal(t, 0, rankings[1].Disqualifications)
-	assert.Equal(t, 1, rankings[2].Disqualifications)
-	assert.Equal(t, 1, rankings[3].Disqualifications)
-	assert.Equal(t, 1, rankings[4].Disqualifications)
-	assert.Equal(t, 1, rankings[5].Disqualifications)
-	assert.Equal(t, 0, rankings[6].Disqualifications)
+	assert.Equal(t, 0, rankings[1][0].Disqualifications)
+	assert.Equal(t, 1, rankings[2][0].Disqualifications)
+	assert.Equal(t, 1, rankings[3][0].Disqualifications)
+	assert.Equal(t, 1, rankings[4][0].Disqualifications)
+	assert.Equal(t, 1, rankings[5][0].Disqualifications)
+	assert.Equal(t, 0, rankings[6][0].Disqualifications)
 }
 
 // Sets up a schedule and results that touches on all possible variables.
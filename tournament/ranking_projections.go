@@ -0,0 +1,363 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Monte Carlo projection of the final qualification rankings from the results so far.
+
+package tournament
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultNumRankingSimulations = 1000
+	MaxNumRankingSimulations     = 20000
+)
+
+var projectionRandSeed = func() int64 {
+	return time.Now().UnixNano()
+}
+
+// TeamRankingProjection holds the distribution of a team's final rank over all simulations.
+type TeamRankingProjection struct {
+	TeamId       int
+	CurrentRank  int
+	BestRank     int
+	WorstRank    int
+	ExpectedRank float64
+
+	// Fraction of simulations in which the team finishes at or above the cutoff rank.
+	CutoffProbability float64
+
+	// Whether the team is guaranteed to finish at or above the cutoff, or can no longer do so, respectively, however
+	// the remaining matches turn out. These are worked out from the best and worst cases rather than the simulations.
+	Clinched   bool
+	Eliminated bool
+}
+
+// RankingProjections holds the projected final rankings, ordered by expected rank.
+type RankingProjections struct {
+	Cutoff              int
+	NumSimulations      int
+	NumRemainingMatches int
+	Teams               []TeamRankingProjection
+}
+
+// Returns the projection for the given team, or nil if the team isn't in the qualification schedule.
+func (projections *RankingProjections) TeamProjection(teamId int) *TeamRankingProjection {
+	for i := range projections.Teams {
+		if projections.Teams[i].TeamId == teamId {
+			return &projections.Teams[i]
+		}
+	}
+	return nil
+}
+
+// ProjectRankings simulates the remaining qualification matches the given number of times to determine the range of
+// final ranks each team can achieve and how likely each is to finish at or above the given cutoff. Each alliance's
+// score in a simulated match is drawn from the scores of its teams' completed matches. The given outcomes (keyed by
+// match ID) are imposed on the corresponding matches in every simulation, to answer "what if" questions.
+func ProjectRankings(
+	database *model.Database, cutoff, numSimulations int, forcedOutcomes map[int]game.MatchStatus,
+) (*RankingProjections, error) {
+	if numSimulations < 1 || numSimulations > MaxNumRankingSimulations {
+		return nil, fmt.Errorf("number of simulations must be between 1 and %d", MaxNumRankingSimulations)
+	}
+	matches, err := database.GetMatchesByType(model.Qualification, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gather the results so far, along with the distribution of scores each team has been a part of.
+	matchFields := make(map[int][]game.RankingFields)
+	teamSummaries := make(map[int][]game.ScoreSummary)
+	var allSummaries []game.ScoreSummary
+	var remainingMatches []model.Match
+	for _, match := range matches {
+		teamIds, surrogates := matchTeams(&match)
+		for position, teamId := range teamIds {
+			if _, ok := matchFields[teamId]; !ok && teamId > 0 && !surrogates[position] {
+				matchFields[teamId] = nil
+			}
+		}
+		if !match.IsComplete() {
+			remainingMatches = append(remainingMatches, match)
+			continue
+		}
+		matchResult, err := database.GetMatchResultForMatch(match.Id)
+		if err != nil {
+			return nil, err
+		}
+		if matchResult == nil {
+			return nil, fmt.Errorf("found no match result for match %d", match.Id)
+		}
+		addMatchToRankings(matchFields, &match, matchResult)
+		redSummary, blueSummary := *matchResult.RedScoreSummary(), *matchResult.BlueScoreSummary()
+		for position, teamId := range teamIds {
+			if position < 3 {
+				teamSummaries[teamId] = append(teamSummaries[teamId], redSummary)
+			} else {
+				teamSummaries[teamId] = append(teamSummaries[teamId], blueSummary)
+			}
+		}
+		allSummaries = append(allSummaries, redSummary, blueSummary)
+	}
+	withdrawnTeamIds, err := getWithdrawnTeamIds(database)
+	if err != nil {
+		return nil, err
+	}
+	for teamId := range withdrawnTeamIds {
+		delete(matchFields, teamId)
+	}
+
+	// Reuse the random tiebreakers already drawn for the stored rankings so that the projections agree with them.
+	rankings, err := database.GetAllRankings()
+	if err != nil {
+		return nil, err
+	}
+	for _, ranking := range rankings {
+		if fields := matchFields[ranking.TeamId]; len(fields) > 0 {
+			fields[len(fields)-1].Random = ranking.Random
+		}
+	}
+
+	projections := RankingProjections{
+		Cutoff:              cutoff,
+		NumSimulations:      numSimulations,
+		NumRemainingMatches: len(remainingMatches),
+	}
+	projectionsByTeam := make(map[int]*TeamRankingProjection, len(matchFields))
+	for rank, ranking := range combineRankings(matchFields) {
+		projection := &TeamRankingProjection{TeamId: ranking.TeamId, BestRank: math.MaxInt}
+		if len(matchFields[ranking.TeamId]) > 0 {
+			projection.CurrentRank = rank + 1
+		}
+		projectionsByTeam[ranking.TeamId] = projection
+	}
+
+	random := rand.New(rand.NewSource(projectionRandSeed()))
+	simulatedFields := make(map[int][]game.RankingFields, len(matchFields))
+	cutoffCounts := make(map[int]int, len(matchFields))
+	for i := 0; i < numSimulations; i++ {
+		for teamId, fields := range matchFields {
+			simulatedFields[teamId] = append(simulatedFields[teamId][:0], fields...)
+		}
+		for _, match := range remainingMatches {
+			simulateMatch(simulatedFields, &match, teamSummaries, allSummaries, forcedOutcomes[match.Id], random)
+		}
+		for rank, ranking := range combineRankings(simulatedFields) {
+			projection := projectionsByTeam[ranking.TeamId]
+			projection.BestRank = min(projection.BestRank, rank+1)
+			projection.WorstRank = max(projection.WorstRank, rank+1)
+			projection.ExpectedRank += float64(rank+1) / float64(numSimulations)
+			if rank < cutoff {
+				cutoffCounts[ranking.TeamId]++
+			}
+		}
+	}
+
+	bounds := projectPrimaryCriterionBounds(matchFields, remainingMatches, forcedOutcomes)
+	for teamId, projection := range projectionsByTeam {
+		projection.CutoffProbability = float64(cutoffCounts[teamId]) / float64(numSimulations)
+		if len(remainingMatches) == 0 {
+			// The simulations all reproduce the final rankings exactly, random tiebreaker included.
+			projection.Clinched = cutoffCounts[teamId] == numSimulations
+			projection.Eliminated = cutoffCounts[teamId] == 0
+		} else {
+			numPossiblyAhead, numCertainlyAhead := 0, 0
+			for otherTeamId, otherBounds := range bounds {
+				if otherTeamId == teamId {
+					continue
+				}
+				if !bounds[teamId].certainlyAhead(otherBounds) {
+					numPossiblyAhead++
+				}
+				if otherBounds.certainlyAhead(bounds[teamId]) {
+					numCertainlyAhead++
+				}
+			}
+			projection.Clinched = numPossiblyAhead < cutoff
+			projection.Eliminated = numCertainlyAhead >= cutoff
+		}
+		projections.Teams = append(projections.Teams, *projection)
+	}
+	sort.Slice(projections.Teams, func(i, j int) bool {
+		if projections.Teams[i].ExpectedRank != projections.Teams[j].ExpectedRank {
+			return projections.Teams[i].ExpectedRank < projections.Teams[j].ExpectedRank
+		}
+		return projections.Teams[i].TeamId < projections.Teams[j].TeamId
+	})
+	return &projections, nil
+}
+
+// The range of totals of the primary ranking criterion that a team can finish with, over the number of matches that
+// will count toward its record.
+type primaryCriterionBounds struct {
+	worstTotal int
+	bestTotal  int
+	bounded    bool
+	played     int
+}
+
+// Returns true if the team is guaranteed to finish ranked ahead of the other one, by the same cross-multiplied
+// comparison of averages that the rankings use.
+func (bounds primaryCriterionBounds) certainlyAhead(otherBounds primaryCriterionBounds) bool {
+	return bounds.bounded && otherBounds.bounded &&
+		bounds.worstTotal*otherBounds.played > otherBounds.bestTotal*bounds.played
+}
+
+// Works out the best and worst cases for each team's primary ranking criterion, assuming that it is disqualified from
+// or wins each of its remaining matches, respectively, within the given outcomes (keyed by match ID).
+func projectPrimaryCriterionBounds(
+	matchFields map[int][]game.RankingFields, remainingMatches []model.Match, forcedOutcomes map[int]game.MatchStatus,
+) map[int]primaryCriterionBounds {
+	rules := game.CurrentRankingRules
+	worstValues := make(map[int][]int, len(matchFields))
+	bestValues := make(map[int][]int, len(matchFields))
+	bounds := make(map[int]primaryCriterionBounds, len(matchFields))
+	for teamId, fields := range matchFields {
+		for _, matchFields := range fields {
+			value := rules.PrimaryCriterionValue(&matchFields)
+			worstValues[teamId] = append(worstValues[teamId], value)
+			bestValues[teamId] = append(bestValues[teamId], value)
+		}
+		bounds[teamId] = primaryCriterionBounds{bounded: true}
+	}
+
+	for _, match := range remainingMatches {
+		teamIds, surrogates := matchTeams(&match)
+		for position, teamId := range teamIds {
+			teamBounds, ok := bounds[teamId]
+			if !ok || surrogates[position] {
+				continue
+			}
+			canWin, canTie, canLose := true, true, true
+			switch forcedOutcomes[match.Id] {
+			case game.RedWonMatch:
+				canWin, canTie, canLose = position < 3, false, position >= 3
+			case game.BlueWonMatch:
+				canWin, canTie, canLose = position >= 3, false, position < 3
+			case game.TieMatch:
+				canWin, canLose = false, false
+			}
+			maxValue, bounded := rules.MaxPrimaryCriterionPerMatch(canWin, canTie, canLose)
+			teamBounds.bounded = teamBounds.bounded && bounded
+			bounds[teamId] = teamBounds
+			worstValues[teamId] = append(worstValues[teamId], 0)
+			bestValues[teamId] = append(bestValues[teamId], maxValue)
+		}
+	}
+
+	// Only the best matches count if the lowest ones are dropped, which is the same set in both cases since the
+	// criterion is the first thing the matches are ordered by.
+	sumBest := func(values []int, count int) int {
+		slices.SortFunc(values, func(a, b int) int { return b - a })
+		total := 0
+		for _, value := range values[:count] {
+			total += value
+		}
+		return total
+	}
+	for teamId, teamBounds := range bounds {
+		teamBounds.played = len(worstValues[teamId])
+		if rules.DropLowestMatches > 0 && teamBounds.played > rules.DropLowestMatches {
+			teamBounds.played -= rules.DropLowestMatches
+		}
+		teamBounds.worstTotal = sumBest(worstValues[teamId], teamBounds.played)
+		teamBounds.bestTotal = sumBest(bestValues[teamId], teamBounds.played)
+		bounds[teamId] = teamBounds
+	}
+	return bounds
+}
+
+// Simulates a single match and records the resulting ranking fields of each non-surrogate team in it, imposing the
+// given outcome if it is a completed one.
+func simulateMatch(
+	matchFields map[int][]game.RankingFields,
+	match *model.Match,
+	teamSummaries map[int][]game.ScoreSummary,
+	allSummaries []game.ScoreSummary,
+	forcedOutcome game.MatchStatus,
+	random *rand.Rand,
+) {
+	teamIds, surrogates := matchTeams(match)
+	redSummary := simulateAllianceSummary(teamIds[:3], teamSummaries, allSummaries, random)
+	blueSummary := simulateAllianceSummary(teamIds[3:], teamSummaries, allSummaries, random)
+	switch forcedOutcome {
+	case game.RedWonMatch:
+		if blueSummary.Score > redSummary.Score {
+			redSummary, blueSummary = blueSummary, redSummary
+		} else if blueSummary.Score == redSummary.Score {
+			redSummary.Score++
+		}
+	case game.BlueWonMatch:
+		if redSummary.Score > blueSummary.Score {
+			redSummary, blueSummary = blueSummary, redSummary
+		} else if redSummary.Score == blueSummary.Score {
+			blueSummary.Score++
+		}
+	case game.TieMatch:
+		blueSummary.Score = redSummary.Score
+	}
+
+	for position, teamId := range teamIds {
+		if teamId == 0 || surrogates[position] {
+			continue
+		}
+		var fields game.RankingFields
+		if position < 3 {
+			fields.AddScoreSummary(&redSummary, &blueSummary, false)
+		} else {
+			fields.AddScoreSummary(&blueSummary, &redSummary, false)
+		}
+		matchFields[teamId] = append(matchFields[teamId], fields)
+	}
+}
+
+// Returns a plausible score summary for an alliance of the given teams, taken as the average of a randomly chosen past
+// result of each team. Teams that haven't played yet are represented by a random result from across the event.
+func simulateAllianceSummary(
+	teamIds []int, teamSummaries map[int][]game.ScoreSummary, allSummaries []game.ScoreSummary, random *rand.Rand,
+) game.ScoreSummary {
+	var totals game.ScoreSummary
+	numSamples := 0
+	for _, teamId := range teamIds {
+		summaries := teamSummaries[teamId]
+		if len(summaries) == 0 {
+			summaries = allSummaries
+		}
+		if len(summaries) == 0 {
+			continue
+		}
+		sample := summaries[random.Intn(len(summaries))]
+		totals.Score += sample.Score
+		totals.MatchPoints += sample.MatchPoints
+		totals.AutoFuelPoints += sample.AutoFuelPoints
+		totals.AutoTowerPoints += sample.AutoTowerPoints
+		totals.TeleopTowerPoints += sample.TeleopTowerPoints
+		totals.BonusRankingPoints += sample.BonusRankingPoints
+		numSamples++
+	}
+	if numSamples == 0 {
+		return totals
+	}
+
+	average := func(total int) int {
+		return int(math.Round(float64(total) / float64(numSamples)))
+	}
+	return game.ScoreSummary{
+		Score:              average(totals.Score),
+		MatchPoints:        average(totals.MatchPoints),
+		AutoFuelPoints:     average(totals.AutoFuelPoints),
+		AutoTowerPoints:    average(totals.AutoTowerPoints),
+		TeleopTowerPoints:  average(totals.TeleopTowerPoints),
+		BonusRankingPoints: average(totals.BonusRankingPoints),
+	}
+}
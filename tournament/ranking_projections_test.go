@@ -0,0 +1,135 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestProjectRankings(t *testing.T) {
+	projectionRandSeed = func() int64 { return 0 }
+	game.RankingRandomFloat64 = rand.New(rand.NewSource(0)).Float64
+	defer func() {
+		game.CurrentRankingRules = game.CurrentGame.DefaultRankingRules()
+	}()
+	game.CurrentRankingRules = game.RankingRules{
+		WinPoints: 3, TiePoints: 1, Criteria: []game.RankingCriterion{game.RankingPointsCriterion},
+	}
+	database := setupTestDb(t)
+
+	// Blue wins the completed match, given the test scores.
+	match1 := model.Match{
+		Type:      model.Qualification,
+		TypeOrder: 1,
+		Red1:      1,
+		Red2:      2,
+		Red3:      3,
+		Blue1:     4,
+		Blue2:     5,
+		Blue3:     6,
+		Status:    game.BlueWonMatch,
+	}
+	assert.Nil(t, database.CreateMatch(&match1))
+	matchResult := model.BuildTestMatchResult(match1.Id, 1)
+	assert.Greater(t, matchResult.BlueScoreSummary().Score, matchResult.RedScoreSummary().Score)
+	assert.Nil(t, database.CreateMatchResult(matchResult))
+
+	projections, err := ProjectRankings(database, 3, 100, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, projections.NumRemainingMatches)
+	if assert.Equal(t, 6, len(projections.Teams)) {
+		for _, projection := range projections.Teams {
+			assert.Equal(t, projection.CurrentRank, projection.BestRank)
+			assert.Equal(t, projection.CurrentRank, projection.WorstRank)
+			assert.Equal(t, projection.TeamId >= 4, projection.Clinched)
+			assert.Equal(t, projection.TeamId < 4, projection.Eliminated)
+		}
+	}
+
+	match2 := model.Match{
+		Type:      model.Qualification,
+		TypeOrder: 2,
+		Red1:      1,
+		Red2:      4,
+		Red3:      5,
+		Blue1:     2,
+		Blue2:     3,
+		Blue3:     6,
+		Status:    game.MatchScheduled,
+	}
+	assert.Nil(t, database.CreateMatch(&match2))
+	projections, err = ProjectRankings(database, 2, 500, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, projections.NumRemainingMatches)
+	if assert.Equal(t, 6, len(projections.Teams)) {
+		totalProbability := 0.0
+		for _, projection := range projections.Teams {
+			totalProbability += projection.CutoffProbability
+			assert.LessOrEqual(t, projection.BestRank, projection.WorstRank)
+			assert.GreaterOrEqual(t, projection.ExpectedRank, float64(projection.BestRank))
+			assert.LessOrEqual(t, projection.ExpectedRank, float64(projection.WorstRank))
+		}
+		assert.InDelta(t, 2.0, totalProbability, 1e-9)
+
+		// Teams 4 and 5 win their second match in most simulations but could still drop out of the top two.
+		assert.False(t, projections.TeamProjection(4).Eliminated)
+		assert.Equal(t, 1, projections.TeamProjection(4).BestRank)
+	}
+
+	// If team 6's alliance wins the remaining match, team 6 takes the top spot in every simulation and the four teams
+	// on one win each are left to the random tiebreaker for the second. Team 6 hasn't clinched, though, since it could
+	// still be disqualified and drop into the tie.
+	forcedOutcomes := map[int]game.MatchStatus{match2.Id: game.BlueWonMatch}
+	projections, err = ProjectRankings(database, 2, 500, forcedOutcomes)
+	assert.Nil(t, err)
+	assert.False(t, projections.TeamProjection(6).Clinched)
+	assert.Equal(t, 1, projections.TeamProjection(6).WorstRank)
+	assert.InDelta(t, 0.25, projections.TeamProjection(4).CutoffProbability, 0.1)
+	assert.True(t, projections.TeamProjection(1).Eliminated)
+	assert.Equal(t, 6, projections.TeamProjection(1).BestRank)
+	assert.Nil(t, projections.TeamProjection(254))
+
+	// Teams 4, 5 and 6 are certain to finish ahead of team 1 at worst, however the match turns out, but teams 2 and 3
+	// could still be disqualified and tie with it.
+	projections, err = ProjectRankings(database, 5, 100, forcedOutcomes)
+	assert.Nil(t, err)
+	assert.True(t, projections.TeamProjection(6).Clinched)
+	assert.True(t, projections.TeamProjection(4).Clinched)
+	assert.False(t, projections.TeamProjection(2).Clinched)
+	assert.False(t, projections.TeamProjection(1).Eliminated)
+
+	// Nothing is certain while matches remain if the rankings are ordered by points, which have no upper limit.
+	game.CurrentRankingRules.Criteria = []game.RankingCriterion{game.MatchPointsCriterion}
+	projections, err = ProjectRankings(database, 2, 100, forcedOutcomes)
+	assert.Nil(t, err)
+	for _, projection := range projections.Teams {
+		assert.False(t, projection.Clinched)
+		assert.False(t, projection.Eliminated)
+	}
+	game.CurrentRankingRules.Criteria = []game.RankingCriterion{game.RankingPointsCriterion}
+
+	_, err = ProjectRankings(database, 8, 0, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "number of simulations must be between 1 and 20000", err.Error())
+	}
+}
+
+func TestSimulateAllianceSummary(t *testing.T) {
+	random := rand.New(rand.NewSource(0))
+	teamSummaries := map[int][]game.ScoreSummary{
+		1: {{Score: 100, MatchPoints: 90, BonusRankingPoints: 1}},
+		2: {{Score: 50, MatchPoints: 40, BonusRankingPoints: 0}},
+	}
+	allSummaries := []game.ScoreSummary{{Score: 30, MatchPoints: 20, BonusRankingPoints: 2}}
+
+	// Team 3 hasn't played yet, so is represented by the event-wide results.
+	summary := simulateAllianceSummary([]int{1, 2, 3}, teamSummaries, allSummaries, random)
+	assert.Equal(t, game.ScoreSummary{Score: 60, MatchPoints: 50, BonusRankingPoints: 1}, summary)
+
+	assert.Equal(t, game.ScoreSummary{}, simulateAllianceSummary([]int{3, 4, 5}, nil, nil, random))
+}
@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -28,7 +29,17 @@ var schedulePerm = rand.Perm
 func BuildRandomSchedule(
 	teams []model.Team, scheduleBlocks []model.ScheduleBlock, matchType model.MatchType,
 ) ([]model.Match, error) {
-	// Load the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
+	return BuildConstrainedSchedule(teams, scheduleBlocks, matchType, ScheduleConstraints{})
+}
+
+// Creates a random schedule for the given parameters that satisfies the given hard constraints, and returns it as a
+// list of matches.
+func BuildConstrainedSchedule(
+	teams []model.Team,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	constraints ScheduleConstraints,
+) ([]model.Match, error) {
 	numTeams := len(teams)
 	numMatches := countMatches(scheduleBlocks)
 	matchesPerTeam := int(float32(numMatches*TeamsPerMatch) / float32(numTeams))
@@ -36,6 +47,37 @@ func BuildRandomSchedule(
 	// Adjust the number of matches to remove any excess from non-perfect block scheduling.
 	numMatches = int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / TeamsPerMatch))
 
+	// Generate a random permutation of the team ordering to fill into the anonymized schedule.
+	teamShuffle := schedulePerm(numTeams)
+	teamConstraints, err := constraints.toAnonymized(teams, teamShuffle, getMatchTimes(scheduleBlocks, numMatches))
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the anonymized schedule natively, falling back to a pre-randomized template if that isn't possible.
+	anonSchedule, err := generateSchedule(numTeams, matchesPerTeam, teamConstraints)
+	if err != nil {
+		if teamConstraints != nil {
+			return nil, err
+		}
+		anonSchedule, err = loadScheduleTemplate(numTeams, matchesPerTeam, numMatches)
+		if err != nil {
+			return nil, err
+		}
+	}
+	matches, err := buildScheduleMatches(teams, scheduleBlocks, matchType, anonSchedule, teamShuffle)
+	if err != nil {
+		return nil, err
+	}
+
+	if violations := constraints.Violations(matches); len(violations) > 0 {
+		return nil, fmt.Errorf("unable to satisfy the schedule constraints: %s", strings.Join(violations, "; "))
+	}
+	return matches, nil
+}
+
+// Loads the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
+func loadScheduleTemplate(numTeams, matchesPerTeam, numMatches int) ([][12]int, error) {
 	file, err := os.Open(
 		fmt.Sprintf("%s/%d_%d.csv", filepath.Join(model.BaseDir, schedulesDir), numTeams, matchesPerTeam),
 	)
@@ -62,9 +104,19 @@ func BuildRandomSchedule(
 			}
 		}
 	}
+	return anonSchedule, nil
+}
 
-	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
-	teamShuffle := schedulePerm(numTeams)
+// Fills the given teams into the given anonymized schedule using the given permutation of the team ordering, and
+// assigns match times from the given schedule blocks.
+func buildScheduleMatches(
+	teams []model.Team,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	anonSchedule [][12]int,
+	teamShuffle []int,
+) ([]model.Match, error) {
+	numMatches := len(anonSchedule)
 	matches := make([]model.Match, numMatches)
 	for i, anonMatch := range anonSchedule {
 		matches[i].Type = matchType
@@ -96,17 +148,36 @@ func BuildRandomSchedule(
 	}
 
 	// Fill in the match times.
-	matchIndex := 0
-	for _, block := range scheduleBlocks {
-		for i := 0; i < block.NumMatches && matchIndex < numMatches; i++ {
-			matches[matchIndex].Time = block.StartTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second)
-			matchIndex++
-		}
+	for i, matchTime := range getMatchTimes(scheduleBlocks, numMatches) {
+		matches[i].Time = matchTime
 	}
 
 	return matches, nil
 }
 
+// Assigns the given matches to the given number of fields in rotation, so that consecutive matches alternate between
+// the fields. The matches are left unassigned, to be played on whichever field is free, if there is only one field.
+func AssignFields(matches []model.Match, numFields int) {
+	for i := range matches {
+		if numFields > 1 {
+			matches[i].FieldNumber = i%numFields + 1
+		} else {
+			matches[i].FieldNumber = 0
+		}
+	}
+}
+
+// Returns the start times of the first given number of matches within the given schedule blocks.
+func getMatchTimes(scheduleBlocks []model.ScheduleBlock, numMatches int) []time.Time {
+	var matchTimes []time.Time
+	for _, block := range scheduleBlocks {
+		for i := 0; i < block.NumMatches && len(matchTimes) < numMatches; i++ {
+			matchTimes = append(matchTimes, block.StartTime.Add(time.Duration(i*block.MatchSpacingSec)*time.Second))
+		}
+	}
+	return matchTimes
+}
+
 // Returns the total number of matches that can be run within the given schedule blocks.
 func countMatches(scheduleBlocks []model.ScheduleBlock) int {
 	numMatches := 0
@@ -0,0 +1,141 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Hard constraints on which matches teams may be scheduled into.
+
+package tournament
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"sort"
+	"time"
+)
+
+// ScheduleConstraints holds the hard constraints that a generated schedule must satisfy.
+type ScheduleConstraints struct {
+	// Times before which the given teams are not available to play (e.g. due to arriving late), keyed by team ID.
+	AvailableFrom map[int]time.Time
+
+	// Times after which the given teams are not available to play (e.g. due to leaving early), keyed by team ID.
+	AvailableUntil map[int]time.Time
+
+	// Pairs of teams that share a robot or pit crew and so must not play in the same or consecutive matches.
+	SharedTeams [][2]int
+}
+
+// Holds the constraints for a single team in terms of anonymized team numbers and match indices.
+type teamConstraint struct {
+	earliestMatch int
+	latestMatch   int
+	sharedTeams   []int
+}
+
+// Returns whether there are no constraints.
+func (constraints *ScheduleConstraints) IsEmpty() bool {
+	return len(constraints.AvailableFrom) == 0 && len(constraints.AvailableUntil) == 0 &&
+		len(constraints.SharedTeams) == 0
+}
+
+// Returns a description of each way in which the given matches violate the constraints.
+func (constraints *ScheduleConstraints) Violations(matches []model.Match) []string {
+	var violations []string
+	teamMatchIndices := make(map[int][]int)
+	for i, match := range matches {
+		for _, teamId := range []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			teamMatchIndices[teamId] = append(teamMatchIndices[teamId], i)
+			if availableFrom, ok := constraints.AvailableFrom[teamId]; ok && match.Time.Before(availableFrom) {
+				violations = append(
+					violations,
+					fmt.Sprintf("Team %d plays in %s before it is available", teamId, match.ShortName),
+				)
+			}
+			if availableUntil, ok := constraints.AvailableUntil[teamId]; ok && match.Time.After(availableUntil) {
+				violations = append(
+					violations,
+					fmt.Sprintf("Team %d plays in %s after it is no longer available", teamId, match.ShortName),
+				)
+			}
+		}
+	}
+
+	for _, pair := range constraints.SharedTeams {
+		for _, index1 := range teamMatchIndices[pair[0]] {
+			for _, index2 := range teamMatchIndices[pair[1]] {
+				if index2 >= index1-1 && index2 <= index1+1 {
+					violations = append(
+						violations,
+						fmt.Sprintf(
+							"Teams %d and %d play back-to-back in %s and %s",
+							pair[0],
+							pair[1],
+							matches[index1].ShortName,
+							matches[index2].ShortName,
+						),
+					)
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// Converts the constraints into per-team constraints on the anonymized schedule, given the permutation that maps
+// anonymized team numbers to the given teams and the start times of the matches. Returns nil if there are no
+// constraints.
+func (constraints *ScheduleConstraints) toAnonymized(
+	teams []model.Team, teamShuffle []int, matchTimes []time.Time,
+) ([]teamConstraint, error) {
+	if constraints.IsEmpty() {
+		return nil, nil
+	}
+
+	anonTeams := make(map[int]int)
+	teamConstraints := make([]teamConstraint, len(teams))
+	for anonTeam, teamIndex := range teamShuffle {
+		anonTeams[teams[teamIndex].Id] = anonTeam
+		teamConstraints[anonTeam].latestMatch = len(matchTimes) - 1
+	}
+	getAnonTeam := func(teamId int) (int, error) {
+		anonTeam, ok := anonTeams[teamId]
+		if !ok {
+			return 0, fmt.Errorf("schedule constraint refers to team %d, which is not in the team list", teamId)
+		}
+		return anonTeam, nil
+	}
+
+	for teamId, availableFrom := range constraints.AvailableFrom {
+		anonTeam, err := getAnonTeam(teamId)
+		if err != nil {
+			return nil, err
+		}
+		teamConstraints[anonTeam].earliestMatch = sort.Search(len(matchTimes), func(i int) bool {
+			return !matchTimes[i].Before(availableFrom)
+		})
+	}
+	for teamId, availableUntil := range constraints.AvailableUntil {
+		anonTeam, err := getAnonTeam(teamId)
+		if err != nil {
+			return nil, err
+		}
+		teamConstraints[anonTeam].latestMatch = sort.Search(len(matchTimes), func(i int) bool {
+			return matchTimes[i].After(availableUntil)
+		}) - 1
+	}
+	for _, pair := range constraints.SharedTeams {
+		anonTeam1, err := getAnonTeam(pair[0])
+		if err != nil {
+			return nil, err
+		}
+		anonTeam2, err := getAnonTeam(pair[1])
+		if err != nil {
+			return nil, err
+		}
+		if anonTeam1 == anonTeam2 {
+			return nil, fmt.Errorf("team %d can't share a robot with itself", pair[0])
+		}
+		teamConstraints[anonTeam1].sharedTeams = append(teamConstraints[anonTeam1].sharedTeams, anonTeam2)
+		teamConstraints[anonTeam2].sharedTeams = append(teamConstraints[anonTeam2].sharedTeams, anonTeam1)
+	}
+	return teamConstraints, nil
+}
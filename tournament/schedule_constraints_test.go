@@ -0,0 +1,93 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBuildConstrainedSchedule(t *testing.T) {
+	scheduleRandSeed = func() int64 { return 0 }
+	defer func() { scheduleRandSeed = func() int64 { return time.Now().UnixNano() } }()
+
+	teams := make([]model.Team, 24)
+	for i := 0; i < 24; i++ {
+		teams[i].Id = i + 101
+	}
+	startTime := time.Unix(0, 0).UTC()
+	scheduleBlocks := []model.ScheduleBlock{
+		{MatchType: model.Qualification, StartTime: startTime, NumMatches: 32, MatchSpacingSec: 60},
+	}
+	constraints := ScheduleConstraints{
+		AvailableFrom:  map[int]time.Time{101: startTime.Add(10 * time.Minute)},
+		AvailableUntil: map[int]time.Time{102: startTime.Add(20 * time.Minute)},
+		SharedTeams:    [][2]int{{103, 104}, {105, 106}},
+	}
+	matches, err := BuildConstrainedSchedule(teams, scheduleBlocks, model.Qualification, constraints)
+	assert.Nil(t, err)
+	assert.Equal(t, 32, len(matches))
+	assert.Empty(t, constraints.Violations(matches))
+
+	teamMatchIndices := make(map[int][]int)
+	for i, match := range matches {
+		for _, teamId := range []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			teamMatchIndices[teamId] = append(teamMatchIndices[teamId], i)
+		}
+	}
+	assert.Equal(t, 8, len(teamMatchIndices[101]))
+	assert.GreaterOrEqual(t, teamMatchIndices[101][0], 10)
+	assert.Equal(t, 8, len(teamMatchIndices[102]))
+	assert.LessOrEqual(t, teamMatchIndices[102][7], 20)
+
+	// Check that an infeasible constraint results in an error.
+	constraints.AvailableFrom[107] = startTime.Add(31 * time.Minute)
+	_, err = BuildConstrainedSchedule(teams, scheduleBlocks, model.Qualification, constraints)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unable to satisfy the schedule constraints: Team 107 plays in Q")
+	}
+
+	// Check that constraints referring to teams not in the team list are rejected.
+	constraints = ScheduleConstraints{SharedTeams: [][2]int{{101, 254}}}
+	_, err = BuildConstrainedSchedule(teams, scheduleBlocks, model.Qualification, constraints)
+	assert.EqualError(t, err, "schedule constraint refers to team 254, which is not in the team list")
+	constraints = ScheduleConstraints{SharedTeams: [][2]int{{101, 101}}}
+	_, err = BuildConstrainedSchedule(teams, scheduleBlocks, model.Qualification, constraints)
+	assert.EqualError(t, err, "team 101 can't share a robot with itself")
+}
+
+func TestScheduleConstraintsViolations(t *testing.T) {
+	startTime := time.Unix(1000, 0).UTC()
+	matches := []model.Match{
+		{ShortName: "Q1", Time: startTime, Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{ShortName: "Q2", Time: startTime.Add(time.Minute), Red1: 7, Red2: 8, Red3: 9, Blue1: 10, Blue2: 11, Blue3: 12},
+		{
+			ShortName: "Q3", Time: startTime.Add(2 * time.Minute), Red1: 1, Red2: 7, Red3: 3, Blue1: 4, Blue2: 8, Blue3: 6,
+		},
+	}
+
+	constraints := ScheduleConstraints{}
+	assert.True(t, constraints.IsEmpty())
+	assert.Empty(t, constraints.Violations(matches))
+
+	constraints = ScheduleConstraints{
+		AvailableFrom:  map[int]time.Time{7: startTime.Add(2 * time.Minute), 2: startTime.Add(time.Second)},
+		AvailableUntil: map[int]time.Time{3: startTime.Add(time.Minute)},
+		SharedTeams:    [][2]int{{2, 9}, {5, 11}},
+	}
+	assert.False(t, constraints.IsEmpty())
+	assert.Equal(
+		t,
+		[]string{
+			"Team 2 plays in Q1 before it is available",
+			"Team 7 plays in Q2 before it is available",
+			"Team 3 plays in Q3 after it is no longer available",
+			"Teams 2 and 9 play back-to-back in Q1 and Q2",
+			"Teams 5 and 11 play back-to-back in Q1 and Q2",
+		},
+		constraints.Violations(matches),
+	)
+}
@@ -0,0 +1,342 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Native generation of anonymized match schedules, optimized with simulated annealing for the usual FRC criteria.
+
+package tournament
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	maxGeneratedMatchesPerTeam = 20
+
+	// Relative weights of the criteria that the schedule generator optimizes for.
+	duplicateTeamPenalty   = 1000000
+	constraintPenalty      = 100000
+	turnaroundPenalty      = 500
+	partnerRepeatPenalty   = 40
+	opponentRepeatPenalty  = 8
+	allianceBalancePenalty = 20
+	stationBalancePenalty  = 1
+
+	// Parameters of the annealing process.
+	annealingIterationsPerSlot = 1500
+	maxAnnealingIterations     = 3000000
+	annealingStartTemperature  = 20.0
+	annealingEndTemperature    = 0.05
+)
+
+var scheduleRandSeed = func() int64 {
+	return time.Now().UnixNano()
+}
+
+// Holds the state of a schedule while it is being optimized. Slot i belongs to match i / TeamsPerMatch, with the
+// first half of each match's slots belonging to the red alliance.
+type scheduleGenerator struct {
+	numTeams       int
+	numMatches     int
+	minTurnaround  int
+	slots          []int
+	teamSlots      [][]int
+	partnerCounts  [][]int
+	opponentCounts [][]int
+	teamCosts      []int
+	pairCost       int
+	constraints    []teamConstraint
+	rand           *rand.Rand
+}
+
+// Generates an anonymized schedule for the given number of teams and matches per team, in the same format as the
+// pre-baked schedule templates. Teams are numbered from 1, and the extra appearances needed to fill out the last match
+// are flagged as surrogates. The given per-team constraints are optional and may be nil.
+func generateSchedule(numTeams, matchesPerTeam int, constraints []teamConstraint) ([][12]int, error) {
+	if numTeams < TeamsPerMatch {
+		return nil, fmt.Errorf("at least %d teams are required to generate a schedule", TeamsPerMatch)
+	}
+	if matchesPerTeam < 1 || matchesPerTeam > maxGeneratedMatchesPerTeam {
+		return nil, fmt.Errorf(
+			"cannot generate a schedule with %d matches per team; must be between 1 and %d",
+			matchesPerTeam,
+			maxGeneratedMatchesPerTeam,
+		)
+	}
+
+	generator := newScheduleGenerator(
+		numTeams, matchesPerTeam, constraints, rand.New(rand.NewSource(scheduleRandSeed())),
+	)
+	generator.anneal()
+	if generator.hasDuplicateTeams() {
+		return nil, fmt.Errorf(
+			"unable to generate a valid schedule for %d teams and %d matches", numTeams, matchesPerTeam,
+		)
+	}
+	return generator.anonSchedule(matchesPerTeam), nil
+}
+
+// Builds the initial schedule out of one random permutation of the teams per round, with randomly chosen teams filling
+// out the remaining slots in the last match.
+func newScheduleGenerator(
+	numTeams, matchesPerTeam int, constraints []teamConstraint, random *rand.Rand,
+) *scheduleGenerator {
+	numMatches := int(math.Ceil(float64(numTeams*matchesPerTeam) / TeamsPerMatch))
+	generator := scheduleGenerator{
+		numTeams:    numTeams,
+		numMatches:  numMatches,
+		constraints: constraints,
+		rand:        random,
+	}
+
+	// Aim for each team to have at least two thirds of a round between their matches, capped at a reasonable gap.
+	matchesPerRound := float64(numTeams) / TeamsPerMatch
+	generator.minTurnaround = max(1, min(6, int(matchesPerRound*2/3)))
+
+	for round := 0; round < matchesPerTeam; round++ {
+		generator.slots = append(generator.slots, random.Perm(numTeams)...)
+	}
+	numSurrogateSlots := numMatches*TeamsPerMatch - len(generator.slots)
+	generator.slots = append(generator.slots, random.Perm(numTeams)[:numSurrogateSlots]...)
+	generator.computeCosts()
+	return &generator
+}
+
+// Computes the per-team appearances, pairing counts and costs from scratch based on the current slot assignments.
+func (generator *scheduleGenerator) computeCosts() {
+	generator.teamSlots = make([][]int, generator.numTeams)
+	generator.teamCosts = make([]int, generator.numTeams)
+	generator.partnerCounts = make([][]int, generator.numTeams)
+	generator.opponentCounts = make([][]int, generator.numTeams)
+	for team := 0; team < generator.numTeams; team++ {
+		generator.partnerCounts[team] = make([]int, generator.numTeams)
+		generator.opponentCounts[team] = make([]int, generator.numTeams)
+	}
+	for slot, team := range generator.slots {
+		generator.teamSlots[team] = append(generator.teamSlots[team], slot)
+	}
+	for team := 0; team < generator.numTeams; team++ {
+		generator.teamCosts[team] = generator.teamCost(team)
+	}
+	generator.pairCost = 0
+	for match := 0; match < generator.numMatches; match++ {
+		generator.pairCost += generator.updatePairCounts(match, 1)
+	}
+}
+
+// Returns the total cost of the schedule in its current state.
+func (generator *scheduleGenerator) cost() int {
+	cost := generator.pairCost
+	for _, teamCost := range generator.teamCosts {
+		cost += teamCost
+	}
+	return cost
+}
+
+// Optimizes the schedule by repeatedly swapping two random slots, keeping swaps that improve the schedule and
+// occasionally accepting ones that make it worse so as to escape local minima.
+func (generator *scheduleGenerator) anneal() {
+	numSlots := len(generator.slots)
+	iterations := min(numSlots*annealingIterationsPerSlot, maxAnnealingIterations)
+	cooling := math.Pow(annealingEndTemperature/annealingStartTemperature, 1/float64(iterations))
+	temperature := annealingStartTemperature
+	for i := 0; i < iterations; i++ {
+		slot1 := generator.rand.Intn(numSlots)
+		slot2 := generator.rand.Intn(numSlots)
+		if generator.slots[slot1] != generator.slots[slot2] {
+			delta := generator.swap(slot1, slot2)
+			if delta > 0 && generator.rand.Float64() >= math.Exp(-float64(delta)/temperature) {
+				// Revert the swap.
+				generator.swap(slot1, slot2)
+			}
+		}
+		temperature *= cooling
+	}
+}
+
+// Swaps the teams in the given slots and returns the resulting change in the cost of the schedule.
+func (generator *scheduleGenerator) swap(slot1, slot2 int) int {
+	match1 := slot1 / TeamsPerMatch
+	match2 := slot2 / TeamsPerMatch
+	team1 := generator.slots[slot1]
+	team2 := generator.slots[slot2]
+
+	delta := generator.updatePairCounts(match1, -1)
+	if match2 != match1 {
+		delta += generator.updatePairCounts(match2, -1)
+	}
+	generator.slots[slot1], generator.slots[slot2] = team2, team1
+	replaceSlot(generator.teamSlots[team1], slot1, slot2)
+	replaceSlot(generator.teamSlots[team2], slot2, slot1)
+	delta += generator.updatePairCounts(match1, 1)
+	if match2 != match1 {
+		delta += generator.updatePairCounts(match2, 1)
+	}
+	generator.pairCost += delta
+
+	// Recompute the costs of the swapped teams, along with those of any teams whose constraints involve them.
+	affectedTeams := []int{team1, team2}
+	if generator.constraints != nil {
+		affectedTeams = append(affectedTeams, generator.constraints[team1].sharedTeams...)
+		affectedTeams = append(affectedTeams, generator.constraints[team2].sharedTeams...)
+	}
+	for i, team := range affectedTeams {
+		if slices.Contains(affectedTeams[:i], team) {
+			continue
+		}
+		teamCost := generator.teamCost(team)
+		delta += teamCost - generator.teamCosts[team]
+		generator.teamCosts[team] = teamCost
+	}
+	return delta
+}
+
+// Adds or removes the partner and opponent pairings of the given match and returns the resulting change in cost.
+func (generator *scheduleGenerator) updatePairCounts(match, increment int) int {
+	delta := 0
+	base := match * TeamsPerMatch
+	for i := 0; i < TeamsPerMatch; i++ {
+		for j := i + 1; j < TeamsPerMatch; j++ {
+			team1 := generator.slots[base+i]
+			team2 := generator.slots[base+j]
+			if team1 == team2 {
+				continue
+			}
+			counts := generator.opponentCounts
+			penalty := opponentRepeatPenalty
+			if i/3 == j/3 {
+				counts = generator.partnerCounts
+				penalty = partnerRepeatPenalty
+			}
+			before := repeatCost(counts[team1][team2])
+			counts[team1][team2] += increment
+			counts[team2][team1] += increment
+			delta += penalty * (repeatCost(counts[team1][team2]) - before)
+		}
+	}
+	return delta
+}
+
+// Returns the cost of the given team's individual schedule: duplicate appearances in a match, violated constraints,
+// short turnarounds between matches, and imbalance in alliance color and station position.
+func (generator *scheduleGenerator) teamCost(team int) int {
+	slots := generator.teamSlots[team]
+	cost := 0
+	redCount := 0
+	var stationCounts [3]int
+	for i, slot := range slots {
+		match := slot / TeamsPerMatch
+		position := slot % TeamsPerMatch
+		if position < 3 {
+			redCount++
+		}
+		stationCounts[position%3]++
+		if i > 0 {
+			gap := match - slots[i-1]/TeamsPerMatch
+			if gap == 0 {
+				cost += duplicateTeamPenalty
+			} else if gap < generator.minTurnaround {
+				shortfall := generator.minTurnaround - gap
+				cost += turnaroundPenalty * shortfall * shortfall
+			}
+		}
+	}
+
+	if generator.constraints != nil {
+		cost += generator.constraintCost(team)
+	}
+
+	colorImbalance := max(0, abs(2*redCount-len(slots))-1)
+	cost += allianceBalancePenalty * colorImbalance * colorImbalance
+	for _, stationCount := range stationCounts {
+		stationImbalance := 3*stationCount - len(slots)
+		cost += stationBalancePenalty * stationImbalance * stationImbalance
+	}
+	return cost
+}
+
+// Returns the cost of the given team's violations of its constraints, increasing with the severity of each violation so
+// as to guide the optimizer towards a valid schedule.
+func (generator *scheduleGenerator) constraintCost(team int) int {
+	constraint := generator.constraints[team]
+	cost := 0
+	for _, slot := range generator.teamSlots[team] {
+		match := slot / TeamsPerMatch
+		if match < constraint.earliestMatch {
+			cost += constraintPenalty * (constraint.earliestMatch - match)
+		}
+		if match > constraint.latestMatch {
+			cost += constraintPenalty * (match - constraint.latestMatch)
+		}
+		for _, sharedTeam := range constraint.sharedTeams {
+			for _, sharedSlot := range generator.teamSlots[sharedTeam] {
+				if abs(sharedSlot/TeamsPerMatch-match) <= 1 {
+					cost += constraintPenalty
+				}
+			}
+		}
+	}
+	return cost
+}
+
+// Returns whether any team appears more than once in the same match.
+func (generator *scheduleGenerator) hasDuplicateTeams() bool {
+	for _, slots := range generator.teamSlots {
+		for i := 1; i < len(slots); i++ {
+			if slots[i]/TeamsPerMatch == slots[i-1]/TeamsPerMatch {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Converts the schedule into the anonymized template format, flagging each team's third match as a surrogate
+// appearance if the team plays more matches than the rest.
+func (generator *scheduleGenerator) anonSchedule(matchesPerTeam int) [][12]int {
+	surrogateSlots := make(map[int]bool)
+	for _, slots := range generator.teamSlots {
+		if len(slots) > matchesPerTeam {
+			surrogateSlots[slots[min(2, len(slots)-1)]] = true
+		}
+	}
+
+	anonSchedule := make([][12]int, generator.numMatches)
+	for slot, team := range generator.slots {
+		anonSchedule[slot/TeamsPerMatch][2*(slot%TeamsPerMatch)] = team + 1
+		if surrogateSlots[slot] {
+			anonSchedule[slot/TeamsPerMatch][2*(slot%TeamsPerMatch)+1] = 1
+		}
+	}
+	return anonSchedule
+}
+
+// Replaces the given old slot with the new one in the given sorted list of slots, keeping it sorted.
+func replaceSlot(slots []int, oldSlot, newSlot int) {
+	for i, slot := range slots {
+		if slot == oldSlot {
+			slots[i] = newSlot
+			break
+		}
+	}
+	sort.Ints(slots)
+}
+
+// Returns the penalty multiplier for a pair of teams being matched up the given number of times.
+func repeatCost(count int) int {
+	if count <= 1 {
+		return 0
+	}
+	return (count - 1) * (count - 1)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
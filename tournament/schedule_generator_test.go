@@ -0,0 +1,158 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGenerateScheduleErrors(t *testing.T) {
+	_, err := generateSchedule(5, 2, nil)
+	assert.EqualError(t, err, "at least 6 teams are required to generate a schedule")
+	_, err = generateSchedule(18, 0, nil)
+	assert.EqualError(t, err, "cannot generate a schedule with 0 matches per team; must be between 1 and 20")
+	_, err = generateSchedule(18, 21, nil)
+	assert.EqualError(t, err, "cannot generate a schedule with 21 matches per team; must be between 1 and 20")
+}
+
+func TestGenerateScheduleQuality(t *testing.T) {
+	scheduleRandSeed = func() int64 { return 0 }
+	defer func() { scheduleRandSeed = func() int64 { return time.Now().UnixNano() } }()
+
+	for _, params := range []struct {
+		numTeams               int
+		matchesPerTeam         int
+		minTurnaround          int
+		maxPartnerCount        int
+		expectBalancedAlliance bool
+	}{
+		{6, 3, 1, 3, false},
+		{18, 2, 2, 1, true},
+		{24, 10, 2, 2, true},
+		{38, 10, 4, 2, true},
+		{60, 12, 6, 2, true},
+	} {
+		t.Run(fmt.Sprintf("%d_%d", params.numTeams, params.matchesPerTeam), func(t *testing.T) {
+			anonSchedule, err := generateSchedule(params.numTeams, params.matchesPerTeam, nil)
+			assert.Nil(t, err)
+			expectedNumMatches := (params.numTeams*params.matchesPerTeam + TeamsPerMatch - 1) / TeamsPerMatch
+			assert.Equal(t, expectedNumMatches, len(anonSchedule))
+
+			appearances := make(map[int][]int)
+			surrogateAppearances := make(map[int]int)
+			redCounts := make(map[int]int)
+			partnerCounts := make(map[[2]int]int)
+			for matchIndex, anonMatch := range anonSchedule {
+				teamsInMatch := make(map[int]bool)
+				for position := 0; position < TeamsPerMatch; position++ {
+					team := anonMatch[2*position]
+					assert.False(t, teamsInMatch[team], "team %d appears twice in match %d", team, matchIndex+1)
+					teamsInMatch[team] = true
+					appearances[team] = append(appearances[team], matchIndex)
+					if anonMatch[2*position+1] == 1 {
+						surrogateAppearances[team]++
+					} else if position < 3 {
+						redCounts[team]++
+					}
+					for otherPosition := position + 1; otherPosition < TeamsPerMatch; otherPosition++ {
+						if position/3 == otherPosition/3 {
+							otherTeam := anonMatch[2*otherPosition]
+							partnerCounts[[2]int{min(team, otherTeam), max(team, otherTeam)}]++
+						}
+					}
+				}
+			}
+
+			// Check that every team plays the same number of non-surrogate matches, with the minimum number of
+			// surrogate appearances to fill out the schedule.
+			assert.Equal(t, params.numTeams, len(appearances))
+			numSurrogates := 0
+			for team, teamAppearances := range appearances {
+				assert.Equal(t, params.matchesPerTeam, len(teamAppearances)-surrogateAppearances[team])
+				numSurrogates += surrogateAppearances[team]
+				for i := 1; i < len(teamAppearances); i++ {
+					assert.GreaterOrEqual(t, teamAppearances[i]-teamAppearances[i-1], params.minTurnaround)
+				}
+				if params.expectBalancedAlliance {
+					blueCount := len(teamAppearances) - surrogateAppearances[team] - redCounts[team]
+					assert.LessOrEqual(t, redCounts[team]-blueCount, 2)
+					assert.GreaterOrEqual(t, redCounts[team]-blueCount, -2)
+				}
+			}
+			assert.Equal(t, expectedNumMatches*TeamsPerMatch-params.numTeams*params.matchesPerTeam, numSurrogates)
+
+			for pair, count := range partnerCounts {
+				assert.LessOrEqual(t, count, params.maxPartnerCount, "teams %v are partnered %d times", pair, count)
+			}
+		})
+	}
+}
+
+func TestBuildRandomScheduleWithoutTemplate(t *testing.T) {
+	// Use a team count for which there is no pre-baked template.
+	numTeams := 130
+	teams := make([]model.Team, numTeams)
+	for i := 0; i < numTeams; i++ {
+		teams[i].Id = i + 1001
+	}
+	scheduleBlocks := []model.ScheduleBlock{
+		{MatchType: model.Qualification, StartTime: time.Unix(0, 0).UTC(), NumMatches: 130, MatchSpacingSec: 360},
+	}
+	startTime := time.Now()
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	assert.Nil(t, err)
+	// The bound is generous so as not to be flaky on a slow machine; it only catches a generator that has blown up.
+	assert.Less(t, time.Since(startTime), 10*time.Second)
+	assert.Equal(t, 130, len(matches))
+	assert.Equal(t, "Q130", matches[129].ShortName)
+	assert.Equal(t, time.Unix(129*360, 0).UTC(), matches[129].Time)
+}
+
+func TestBuildRandomScheduleFallsBackToTemplate(t *testing.T) {
+	// The native generator doesn't support this many matches per team, so a template should be used if present.
+	model.BaseDir = ".."
+	filename := fmt.Sprintf("%s/6_21.csv", filepath.Join(model.BaseDir, schedulesDir))
+	line := "1,0,2,0,3,0,4,0,5,0,6,0\n"
+	contents := ""
+	for i := 0; i < 21; i++ {
+		contents += line
+	}
+	assert.Nil(t, os.WriteFile(filename, []byte(contents), 0644))
+	defer os.Remove(filename)
+
+	teams := make([]model.Team, 6)
+	for i := 0; i < 6; i++ {
+		teams[i].Id = i + 101
+	}
+	scheduleBlocks := []model.ScheduleBlock{
+		{MatchType: model.Practice, StartTime: time.Unix(0, 0).UTC(), NumMatches: 21, MatchSpacingSec: 60},
+	}
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Practice)
+	assert.Nil(t, err)
+	assert.Equal(t, 21, len(matches))
+
+	scheduleBlocks[0].NumMatches = 22
+	_, err = BuildRandomSchedule(teams, scheduleBlocks, model.Practice)
+	assert.EqualError(t, err, "No schedule template exists for 6 teams and 22 matches")
+}
+
+func TestScheduleGeneratorIncrementalCost(t *testing.T) {
+	generator := newScheduleGenerator(24, 8, nil, rand.New(rand.NewSource(0)))
+	cost := generator.cost()
+	for i := 0; i < 1000; i++ {
+		cost += generator.swap(generator.rand.Intn(len(generator.slots)), generator.rand.Intn(len(generator.slots)))
+	}
+	assert.Equal(t, cost, generator.cost())
+
+	// Check that the incrementally tracked cost matches one computed from scratch.
+	generator.computeCosts()
+	assert.Equal(t, cost, generator.cost())
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Statistics for evaluating the fairness of a match schedule.
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"sort"
+)
+
+// TeamScheduleStats holds the statistics for a single team's matches within a schedule.
+type TeamScheduleStats struct {
+	TeamId              int
+	NumMatches          int
+	NumSurrogateMatches int
+	SurrogateMatches    []string
+	MinMatchesBetween   int
+	NumRepeatPartners   int
+	NumRepeatOpponents  int
+	NumRed              int
+	NumBlue             int
+	StationCounts       [3]int
+	FirstMatch          string
+	LastMatch           string
+}
+
+// ScheduleReport holds the per-team and overall statistics for a schedule.
+type ScheduleReport struct {
+	Teams                  []TeamScheduleStats
+	MinMatchesBetween      int
+	NumRepeatPartnerPairs  int
+	NumRepeatOpponentPairs int
+	MaxRedBlueImbalance    int
+	NumSurrogateMatches    int
+	ConstraintViolations   []string
+}
+
+// BuildScheduleReport computes the statistics for the given schedule, checking it against the given constraints.
+func BuildScheduleReport(matches []model.Match, constraints ScheduleConstraints) *ScheduleReport {
+	teamStats := make(map[int]*TeamScheduleStats)
+	lastMatchIndices := make(map[int]int)
+	partnerCounts := make(map[[2]int]int)
+	opponentCounts := make(map[[2]int]int)
+	report := ScheduleReport{MinMatchesBetween: -1}
+
+	for i, match := range matches {
+		teamIds, surrogates := matchTeams(&match)
+		for position, teamId := range teamIds {
+			if teamId == 0 {
+				continue
+			}
+			stats, ok := teamStats[teamId]
+			if !ok {
+				stats = &TeamScheduleStats{TeamId: teamId, MinMatchesBetween: -1, FirstMatch: match.ShortName}
+				teamStats[teamId] = stats
+			}
+			stats.NumMatches++
+			stats.LastMatch = match.ShortName
+			if surrogates[position] {
+				stats.NumSurrogateMatches++
+				stats.SurrogateMatches = append(stats.SurrogateMatches, match.ShortName)
+				report.NumSurrogateMatches++
+			}
+			if position < 3 {
+				stats.NumRed++
+			} else {
+				stats.NumBlue++
+			}
+			stats.StationCounts[position%3]++
+			if lastIndex, ok := lastMatchIndices[teamId]; ok {
+				matchesBetween := i - lastIndex - 1
+				if stats.MinMatchesBetween == -1 || matchesBetween < stats.MinMatchesBetween {
+					stats.MinMatchesBetween = matchesBetween
+				}
+			}
+			lastMatchIndices[teamId] = i
+
+			for otherPosition := position + 1; otherPosition < 6; otherPosition++ {
+				otherTeamId := teamIds[otherPosition]
+				if otherTeamId == 0 {
+					continue
+				}
+				pair := [2]int{min(teamId, otherTeamId), max(teamId, otherTeamId)}
+				if position/3 == otherPosition/3 {
+					partnerCounts[pair]++
+				} else {
+					opponentCounts[pair]++
+				}
+			}
+		}
+	}
+
+	// Tally up the repeated pairings for each team and overall.
+	for pair, count := range partnerCounts {
+		if count > 1 {
+			report.NumRepeatPartnerPairs++
+			teamStats[pair[0]].NumRepeatPartners++
+			teamStats[pair[1]].NumRepeatPartners++
+		}
+	}
+	for pair, count := range opponentCounts {
+		if count > 1 {
+			report.NumRepeatOpponentPairs++
+			teamStats[pair[0]].NumRepeatOpponents++
+			teamStats[pair[1]].NumRepeatOpponents++
+		}
+	}
+
+	for _, stats := range teamStats {
+		if stats.MinMatchesBetween != -1 &&
+			(report.MinMatchesBetween == -1 || stats.MinMatchesBetween < report.MinMatchesBetween) {
+			report.MinMatchesBetween = stats.MinMatchesBetween
+		}
+		report.MaxRedBlueImbalance = max(report.MaxRedBlueImbalance, abs(stats.NumRed-stats.NumBlue))
+		report.Teams = append(report.Teams, *stats)
+	}
+	sort.Slice(report.Teams, func(i, j int) bool {
+		return report.Teams[i].TeamId < report.Teams[j].TeamId
+	})
+
+	report.ConstraintViolations = constraints.Violations(matches)
+	return &report
+}
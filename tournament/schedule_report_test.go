@@ -0,0 +1,63 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBuildScheduleReport(t *testing.T) {
+	startTime := time.Unix(1000, 0).UTC()
+	matches := []model.Match{
+		{ShortName: "Q1", Time: startTime, Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{ShortName: "Q2", Time: startTime.Add(time.Minute), Red1: 7, Red2: 8, Red3: 9, Blue1: 10, Blue2: 11, Blue3: 12},
+		{
+			ShortName:        "Q3",
+			Time:             startTime.Add(2 * time.Minute),
+			Red1:             4,
+			Red2:             5,
+			Red3:             7,
+			Blue1:            1,
+			Blue2:            2,
+			Blue3:            8,
+			Blue3IsSurrogate: true,
+		},
+	}
+	constraints := ScheduleConstraints{SharedTeams: [][2]int{{3, 7}}}
+	report := BuildScheduleReport(matches, constraints)
+
+	assert.Equal(t, 12, len(report.Teams))
+	assert.Equal(t, 0, report.MinMatchesBetween)
+	assert.Equal(t, 2, report.NumRepeatPartnerPairs)
+	assert.Equal(t, 4, report.NumRepeatOpponentPairs)
+	assert.Equal(t, 2, report.MaxRedBlueImbalance)
+	assert.Equal(t, 1, report.NumSurrogateMatches)
+	assert.Equal(t, []string{"Teams 3 and 7 play back-to-back in Q1 and Q2"}, report.ConstraintViolations)
+
+	team1 := report.Teams[0]
+	assert.Equal(t, 1, team1.TeamId)
+	assert.Equal(t, 2, team1.NumMatches)
+	assert.Equal(t, 1, team1.MinMatchesBetween)
+	assert.Equal(t, 1, team1.NumRepeatPartners)
+	assert.Equal(t, 2, team1.NumRepeatOpponents)
+	assert.Equal(t, 1, team1.NumRed)
+	assert.Equal(t, 1, team1.NumBlue)
+	assert.Equal(t, [3]int{2, 0, 0}, team1.StationCounts)
+	assert.Equal(t, "Q1", team1.FirstMatch)
+	assert.Equal(t, "Q3", team1.LastMatch)
+
+	team8 := report.Teams[7]
+	assert.Equal(t, 8, team8.TeamId)
+	assert.Equal(t, 0, team8.MinMatchesBetween)
+	assert.Equal(t, 1, team8.NumSurrogateMatches)
+	assert.Equal(t, []string{"Q3"}, team8.SurrogateMatches)
+	assert.Equal(t, 0, team8.NumRepeatOpponents)
+
+	team3 := report.Teams[2]
+	assert.Equal(t, -1, team3.MinMatchesBetween)
+	assert.Equal(t, 1, team3.NumMatches)
+}
@@ -52,7 +52,7 @@ func TestMalformedSchedule(t *testing.T) {
 	}
 }
 
-func TestScheduleTeams(t *testing.T) {
+func TestScheduleTeamsFromTemplate(t *testing.T) {
 	randomizer := rand.New(rand.NewSource(0))
 	schedulePerm = randomizer.Perm
 
@@ -61,8 +61,10 @@ func TestScheduleTeams(t *testing.T) {
 	for i := 0; i < numTeams; i++ {
 		teams[i].Id = i + 101
 	}
+	anonSchedule, err := loadScheduleTemplate(numTeams, 2, 6)
+	assert.Nil(t, err)
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Practice, time.Unix(0, 0).UTC(), 6, 60}}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Practice)
+	matches, err := buildScheduleMatches(teams, scheduleBlocks, model.Practice, anonSchedule, schedulePerm(numTeams))
 	assert.Nil(t, err)
 	assertMatch(t, matches[0], model.Practice, 1, 0, "P1", "Practice 1", "p", 115, 111, 108, 109, 116, 117)
 	assertMatch(t, matches[1], model.Practice, 2, 60, "P2", "Practice 2", "p", 114, 112, 103, 101, 104, 118)
@@ -75,12 +77,15 @@ func TestScheduleTeams(t *testing.T) {
 	scheduleBlocks = []model.ScheduleBlock{{0, model.Practice, time.Unix(0, 0).UTC(), 7, 60}}
 	matches, err = BuildRandomSchedule(teams, scheduleBlocks, model.Practice)
 	assert.Nil(t, err)
+	assert.Equal(t, 6, len(matches))
 
 	// Check with qualification matches.
 	randomizer = rand.New(rand.NewSource(0))
 	schedulePerm = randomizer.Perm
 	scheduleBlocks = []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 6, 60}}
-	matches, err = BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, err = buildScheduleMatches(
+		teams, scheduleBlocks, model.Qualification, anonSchedule, schedulePerm(numTeams),
+	)
 	assert.Nil(t, err)
 	assertMatch(t, matches[0], model.Qualification, 1, 0, "Q1", "Qualification 1", "qm", 115, 111, 108, 109, 116, 117)
 	assertMatch(t, matches[1], model.Qualification, 2, 60, "Q2", "Qualification 2", "qm", 114, 112, 103, 101, 104, 118)
@@ -107,7 +112,24 @@ func TestScheduleTiming(t *testing.T) {
 	assert.Equal(t, time.Unix(100406, 0).UTC(), matches[29].Time)
 }
 
-func TestScheduleSurrogates(t *testing.T) {
+func TestScheduleAssignFields(t *testing.T) {
+	teams := make([]model.Team, 18)
+	scheduleBlocks := []model.ScheduleBlock{{0, model.Qualification, time.Unix(100, 0).UTC(), 9, 60}}
+	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	assert.Nil(t, err)
+	for _, match := range matches {
+		assert.Equal(t, 0, match.FieldNumber)
+	}
+
+	AssignFields(matches, 2)
+	assert.Equal(t, []int{1, 2, 1, 2, 1, 2, 1, 2, 1}, getFieldNumbers(matches))
+	AssignFields(matches, 3)
+	assert.Equal(t, []int{1, 2, 3, 1, 2, 3, 1, 2, 3}, getFieldNumbers(matches))
+	AssignFields(matches, 1)
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 0, 0, 0, 0}, getFieldNumbers(matches))
+}
+
+func TestScheduleSurrogatesFromTemplate(t *testing.T) {
 	randomizer := rand.New(rand.NewSource(0))
 	schedulePerm = randomizer.Perm
 
@@ -116,8 +138,12 @@ func TestScheduleSurrogates(t *testing.T) {
 	for i := 0; i < numTeams; i++ {
 		teams[i].Id = i + 101
 	}
+	anonSchedule, err := loadScheduleTemplate(numTeams, 10, 64)
+	assert.Nil(t, err)
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 64, 60}}
-	matches, _ := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, _ := buildScheduleMatches(
+		teams, scheduleBlocks, model.Qualification, anonSchedule, schedulePerm(numTeams),
+	)
 	for i, match := range matches {
 		if i == 13 || i == 14 {
 			if !match.Red1IsSurrogate || match.Red2IsSurrogate || match.Red3IsSurrogate ||
@@ -160,3 +186,11 @@ func assertMatch(
 	assert.Equal(t, 0, match.TbaMatchKey.SetNumber)
 	assert.Equal(t, typeOrder, match.TbaMatchKey.MatchNumber)
 }
+
+func getFieldNumbers(matches []model.Match) []int {
+	var fieldNumbers []int
+	for _, match := range matches {
+		fieldNumbers = append(fieldNumbers, match.FieldNumber)
+	}
+	return fieldNumbers
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Logic for withdrawing a team mid-event and filling its remaining qualification slots with surrogates.
+
+package tournament
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"slices"
+	"sort"
+)
+
+// Number of matches between appearances beyond which a longer gap no longer makes a team a better replacement.
+const maxReplacementGap = 5
+
+// WithdrawTeam marks the given team as withdrawn and replaces it in each of its remaining unplayed qualification
+// matches with a surrogate chosen from the active teams, preferring those with the most rest around the match and then
+// those with the fewest extra appearances so far. The team is also dropped from the rankings. Returns the matches that
+// were changed.
+func WithdrawTeam(database *model.Database, teamId int) ([]model.Match, error) {
+	team, err := database.GetTeamById(teamId)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, fmt.Errorf("no such team: %d", teamId)
+	}
+	if team.Withdrawn {
+		return nil, fmt.Errorf("team %d has already been withdrawn", teamId)
+	}
+
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	matches, err := database.GetMatchesByType(model.Qualification, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tally up where each team appears in the schedule, so that replacements can be spread out sensibly.
+	matchIndices := make(map[int][]int)
+	surrogateCounts := make(map[int]int)
+	for i, match := range matches {
+		teamIds, surrogates := matchTeams(&match)
+		for position, matchTeamId := range teamIds {
+			matchIndices[matchTeamId] = append(matchIndices[matchTeamId], i)
+			if surrogates[position] {
+				surrogateCounts[matchTeamId]++
+			}
+		}
+	}
+	var candidates []int
+	for _, candidate := range teams {
+		if !candidate.Withdrawn && candidate.Id != teamId {
+			candidates = append(candidates, candidate.Id)
+		}
+	}
+
+	var changedMatches []model.Match
+	for i := range matches {
+		match := &matches[i]
+		if match.IsComplete() {
+			continue
+		}
+		teamIds, _ := matchTeams(match)
+		position := slices.Index(teamIds[:], teamId)
+		if position == -1 {
+			continue
+		}
+
+		replacementId := chooseReplacementTeam(candidates, teamIds, i, matchIndices, surrogateCounts)
+		if replacementId == 0 {
+			return nil, fmt.Errorf("no team is available to replace team %d in %s", teamId, match.ShortName)
+		}
+		setMatchTeam(match, position, replacementId)
+		note := fmt.Sprintf("%d replaces withdrawn team %d", replacementId, teamId)
+		if match.ScheduleNote == "" {
+			match.ScheduleNote = note
+		} else {
+			match.ScheduleNote += "; " + note
+		}
+		matchIndices[replacementId] = append(matchIndices[replacementId], i)
+		surrogateCounts[replacementId]++
+		changedMatches = append(changedMatches, *match)
+	}
+
+	// Update the matches before the team so that if something fails partway, the team is still active and withdrawing it
+	// again picks up where this attempt left off.
+	for _, match := range changedMatches {
+		if err = database.UpdateMatch(&match); err != nil {
+			return nil, err
+		}
+	}
+	team.Withdrawn = true
+	if err = database.UpdateTeam(team); err != nil {
+		return nil, err
+	}
+
+	// Drop the team from the rankings now rather than waiting for the next match to be committed.
+	if _, err = CalculateRankings(database, true); err != nil {
+		return nil, err
+	}
+	return changedMatches, nil
+}
+
+// Returns the set of teams that have withdrawn from the event, which are left out of the rankings and can't be picked
+// for an alliance.
+func getWithdrawnTeamIds(database *model.Database) (map[int]bool, error) {
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	withdrawnTeamIds := make(map[int]bool)
+	for _, team := range teams {
+		if team.Withdrawn {
+			withdrawnTeamIds[team.Id] = true
+		}
+	}
+	return withdrawnTeamIds, nil
+}
+
+// Returns the best of the given candidates to fill a slot in the match at the given index, or zero if none of them is
+// eligible.
+func chooseReplacementTeam(
+	candidates []int, matchTeamIds [6]int, matchIndex int, matchIndices map[int][]int, surrogateCounts map[int]int,
+) int {
+	gaps := make(map[int]int)
+	var eligible []int
+	for _, candidate := range candidates {
+		if slices.Contains(matchTeamIds[:], candidate) {
+			continue
+		}
+		gap := maxReplacementGap
+		for _, index := range matchIndices[candidate] {
+			gap = min(gap, abs(index-matchIndex))
+		}
+		gaps[candidate] = gap
+		eligible = append(eligible, candidate)
+	}
+	if len(eligible) == 0 {
+		return 0
+	}
+
+	sort.Slice(eligible, func(i, j int) bool {
+		team1, team2 := eligible[i], eligible[j]
+		if gaps[team1] != gaps[team2] {
+			return gaps[team1] > gaps[team2]
+		}
+		if surrogateCounts[team1] != surrogateCounts[team2] {
+			return surrogateCounts[team1] < surrogateCounts[team2]
+		}
+		if len(matchIndices[team1]) != len(matchIndices[team2]) {
+			return len(matchIndices[team1]) < len(matchIndices[team2])
+		}
+		return team1 < team2
+	})
+	return eligible[0]
+}
+
+// Returns the teams in the given match and whether each is a surrogate, in red 1 through blue 3 order.
+func matchTeams(match *model.Match) ([6]int, [6]bool) {
+	return [6]int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3},
+		[6]bool{
+			match.Red1IsSurrogate,
+			match.Red2IsSurrogate,
+			match.Red3IsSurrogate,
+			match.Blue1IsSurrogate,
+			match.Blue2IsSurrogate,
+			match.Blue3IsSurrogate,
+		}
+}
+
+// Puts the given team into the given position of the match as a surrogate.
+func setMatchTeam(match *model.Match, position, teamId int) {
+	switch position {
+	case 0:
+		match.Red1, match.Red1IsSurrogate = teamId, true
+	case 1:
+		match.Red2, match.Red2IsSurrogate = teamId, true
+	case 2:
+		match.Red3, match.Red3IsSurrogate = teamId, true
+	case 3:
+		match.Blue1, match.Blue1IsSurrogate = teamId, true
+	case 4:
+		match.Blue2, match.Blue2IsSurrogate = teamId, true
+	case 5:
+		match.Blue3, match.Blue3IsSurrogate = teamId, true
+	}
+}
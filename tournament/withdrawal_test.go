@@ -0,0 +1,105 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWithdrawTeam(t *testing.T) {
+	database := setupTestDb(t)
+	for teamId := 1; teamId <= 13; teamId++ {
+		assert.Nil(t, database.CreateTeam(&model.Team{Id: teamId}))
+	}
+	matches := []model.Match{
+		{
+			TypeOrder: 1,
+			ShortName: "Q1",
+			Red1:      1,
+			Red2:      2,
+			Red3:      3,
+			Blue1:     4,
+			Blue2:     5,
+			Blue3:     6,
+			Status:    game.RedWonMatch,
+		},
+		{TypeOrder: 2, ShortName: "Q2", Red1: 7, Red2: 8, Red3: 9, Blue1: 10, Blue2: 11, Blue3: 12},
+		{TypeOrder: 3, ShortName: "Q3", Red1: 1, Red2: 2, Red3: 3, Blue1: 7, Blue2: 8, Blue3: 9},
+		{TypeOrder: 4, ShortName: "Q4", Red1: 4, Red2: 5, Red3: 6, Blue1: 10, Blue2: 11, Blue3: 12},
+	}
+	for _, match := range matches {
+		match.Type = model.Qualification
+		assert.Nil(t, database.CreateMatch(&match))
+		if match.IsComplete() {
+			assert.Nil(t, database.CreateMatchResult(model.BuildTestMatchResult(match.Id, 1)))
+		}
+	}
+	_, err := CalculateRankings(database, false)
+	assert.Nil(t, err)
+
+	// Team 13 isn't otherwise scheduled, so it should be preferred as the surrogate.
+	changedMatches, err := WithdrawTeam(database, 1)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(changedMatches)) {
+		assert.Equal(t, "Q3", changedMatches[0].ShortName)
+	}
+	team, _ := database.GetTeamById(1)
+	assert.True(t, team.Withdrawn)
+	match, _ := database.GetMatchByTypeOrder(model.Qualification, 1)
+	assert.Equal(t, 1, match.Red1)
+	assert.False(t, match.Red1IsSurrogate)
+	assert.Equal(t, "", match.ScheduleNote)
+	match, _ = database.GetMatchByTypeOrder(model.Qualification, 3)
+	assert.Equal(t, 13, match.Red1)
+	assert.True(t, match.Red1IsSurrogate)
+	assert.Equal(t, "13 replaces withdrawn team 1", match.ScheduleNote)
+
+	// Check that the team is dropped from the rankings and the projections.
+	rankings, _ := database.GetAllRankings()
+	assert.Equal(t, 5, len(rankings))
+	for _, ranking := range rankings {
+		assert.NotEqual(t, 1, ranking.TeamId)
+	}
+	projections, err := ProjectRankings(database, 8, 10, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, projections.TeamProjection(1))
+	assert.NotNil(t, projections.TeamProjection(7))
+
+	// The remaining candidates are all equally rested, so the tie goes to the lowest team number not already playing.
+	changedMatches, err = WithdrawTeam(database, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(changedMatches))
+	match, _ = database.GetMatchByTypeOrder(model.Qualification, 3)
+	assert.Equal(t, 13, match.Red1)
+	assert.Equal(t, 4, match.Red2)
+	assert.True(t, match.Red2IsSurrogate)
+	assert.Equal(t, "13 replaces withdrawn team 1; 4 replaces withdrawn team 2", match.ScheduleNote)
+
+	_, err = WithdrawTeam(database, 1)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "team 1 has already been withdrawn", err.Error())
+	}
+	_, err = WithdrawTeam(database, 254)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "no such team: 254", err.Error())
+	}
+}
+
+func TestChooseReplacementTeam(t *testing.T) {
+	matchIndices := map[int][]int{1: {0, 10}, 2: {3, 8}, 3: {3, 9}, 4: {1, 9}}
+	matchTeamIds := [6]int{5, 6, 7, 8, 9, 10}
+
+	// Team 1 has the most rest on either side of the match.
+	assert.Equal(t, 1, chooseReplacementTeam([]int{1, 2, 3, 4}, matchTeamIds, 5, matchIndices, nil))
+
+	// Teams 2 and 3 are equally rested, so the one with fewer surrogate appearances wins.
+	surrogateCounts := map[int]int{2: 1}
+	assert.Equal(t, 3, chooseReplacementTeam([]int{2, 3}, matchTeamIds, 5, matchIndices, surrogateCounts))
+
+	// Teams already in the match are ineligible.
+	assert.Equal(t, 0, chooseReplacementTeam([]int{5, 6}, matchTeamIds, 5, matchIndices, nil))
+}
@@ -8,6 +8,7 @@ package web
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"io"
@@ -82,20 +83,20 @@ func (web *Web) allianceSelectionPostHandler(w http.ResponseWriter, r *http.Requ
 					}
 				}
 				if !found {
-					web.renderAllianceSelection(
-						w,
-						r,
-						fmt.Sprintf(
-							"Team %d has not played any matches at this event and is ineligible for selection.", teamId,
-						),
+					message := fmt.Sprintf(
+						"Team %d has not played any matches at this event and is ineligible for selection.", teamId,
 					)
+					if team, _ := web.arena.Database.GetTeamById(teamId); team != nil && team.Withdrawn {
+						message = fmt.Sprintf("Team %d has withdrawn from the event and is ineligible for selection.", teamId)
+					}
+					web.renderAllianceSelection(w, r, message)
 					return
 				}
 			}
 		}
 	}
 
-	web.arena.AllianceSelectionNotifier.Notify()
+	web.notifyAllianceSelectionChanged()
 	http.Redirect(w, r, "/alliance_selection", 303)
 }
 
@@ -114,6 +115,30 @@ func (web *Web) allianceSelectionStartHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if web.arena.HasDivisions() {
+		// The alliances for the finals are the division winners rather than being selected.
+		alliances, err := web.arena.ImportDivisionWinners()
+		if err != nil {
+			web.renderAllianceSelection(w, r, fmt.Sprintf("Can't start the finals: %s.", err.Error()))
+			return
+		}
+		web.arena.AllianceSelectionAlliances = alliances
+		web.arena.AllianceSelectionRankedTeams = []model.AllianceSelectionRankedTeam{}
+		for _, alliance := range alliances {
+			for _, teamId := range alliance.TeamIds {
+				web.arena.AllianceSelectionRankedTeams = append(
+					web.arena.AllianceSelectionRankedTeams,
+					model.AllianceSelectionRankedTeam{
+						Rank: len(web.arena.AllianceSelectionRankedTeams) + 1, TeamId: teamId, Picked: teamId > 0,
+					},
+				)
+			}
+		}
+		web.notifyAllianceSelectionChanged()
+		http.Redirect(w, r, "/alliance_selection", 303)
+		return
+	}
+
 	// Create a blank alliance set matching the event configuration.
 	web.arena.AllianceSelectionAlliances = make([]model.Alliance, web.arena.EventSettings.NumPlayoffAlliances)
 	teamsPerAlliance := 3
@@ -140,7 +165,7 @@ func (web *Web) allianceSelectionStartHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	web.arena.AllianceSelectionNotifier.Notify()
+	web.notifyAllianceSelectionChanged()
 	http.Redirect(w, r, "/alliance_selection", 303)
 }
 
@@ -170,7 +195,7 @@ func (web *Web) allianceSelectionResetHandler(w http.ResponseWriter, r *http.Req
 
 	web.arena.AllianceSelectionAlliances = []model.Alliance{}
 	web.arena.AllianceSelectionRankedTeams = []model.AllianceSelectionRankedTeam{}
-	web.arena.AllianceSelectionNotifier.Notify()
+	web.notifyAllianceSelectionChanged()
 	http.Redirect(w, r, "/alliance_selection", 303)
 }
 
@@ -237,7 +262,7 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	if web.arena.EventSettings.TbaPublishingEnabled {
+	if web.arena.ShouldPublishToTba() {
 		// Publish alliances and schedule to The Blue Alliance.
 		err = web.arena.TbaClient.PublishAlliances(web.arena.Database)
 		if err != nil {
@@ -365,6 +390,15 @@ func (web *Web) allianceSelectionWebsocketHandler(w http.ResponseWriter, r *http
 	}
 }
 
+// Notifies the displays and any subscribed webhooks that the alliances being selected have changed.
+func (web *Web) notifyAllianceSelectionChanged() {
+	web.arena.AllianceSelectionNotifier.Notify()
+	web.arena.SendWebhookEvent(
+		partner.WebhookAllianceSelectionChanged,
+		partner.NewWebhookAllianceSelection(web.arena.AllianceSelectionAlliances),
+	)
+}
+
 func (web *Web) renderAllianceSelection(w http.ResponseWriter, r *http.Request, errorMessage string) {
 	if len(web.arena.AllianceSelectionAlliances) == 0 {
 		// The application may have been restarted since the alliance selection was conducted; try reloading the
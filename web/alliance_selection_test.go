@@ -126,6 +126,10 @@ func TestAllianceSelectionErrors(t *testing.T) {
 	recorder = web.postHttpResponse("/alliance_selection", "selection0_0=100")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "ineligible for selection")
+	web.arena.Database.CreateTeam(&model.Team{Id: 107, Withdrawn: true})
+	recorder = web.postHttpResponse("/alliance_selection", "selection0_0=107")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 107 has withdrawn from the event")
 	recorder = web.postHttpResponse("/alliance_selection", "selection0_0=101&selection1_1=101")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "already part of an alliance")
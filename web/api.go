@@ -12,13 +12,26 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/playoff"
+	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"strconv"
 )
 
+// Region of the bracket SVG canvas within which the bracket is drawn, and the space reserved around it for its border
+// and column labels.
+const (
+	bracketSvgAreaX       = 70
+	bracketSvgAreaY       = 115
+	bracketSvgAreaWidth   = 1780
+	bracketSvgAreaHeight  = 900
+	bracketSvgMargin      = 40
+	bracketSvgLabelHeight = 120
+)
+
 type MatchResultWithSummary struct {
 	model.MatchResult
 	RedSummary  *game.ScoreSummary
@@ -33,6 +46,10 @@ type MatchWithResult struct {
 type RankingWithNickname struct {
 	game.Ranking
 	Nickname string
+
+	// Whether the team is guaranteed to finish inside or outside of the playoff alliance captain spots, respectively.
+	Clinched   bool
+	Eliminated bool
 }
 
 type allianceMatchup struct {
@@ -47,6 +64,20 @@ type allianceMatchup struct {
 	IsComplete         bool
 }
 
+type bracketState struct {
+	Layout    *playoff.BracketLayout
+	Matchups  map[string]*allianceMatchup
+	Standings []allianceStanding
+}
+
+type allianceStanding struct {
+	playoff.RoundRobinStanding
+	Rank        int
+	Alliance    *model.Alliance
+	IsActive    bool
+	IsAdvancing bool
+}
+
 // Generates a JSON dump of the matches and results.
 func (web *Web) matchesApiHandler(w http.ResponseWriter, r *http.Request) {
 	matchType, err := model.MatchTypeFromString(r.PathValue("type"))
@@ -145,8 +176,18 @@ func (web *Web) rankingsApiHandler(w http.ResponseWriter, r *http.Request) {
 	for _, team := range teams {
 		teamNicknames[team.Id] = team.Nickname
 	}
+	var projections *tournament.RankingProjections
+	if len(rankings) > 0 {
+		projections = web.rankingIndicatorProjections(rankings)
+	}
 	for i, ranking := range rankings {
-		rankingsWithNicknames[i] = RankingWithNickname{ranking, teamNicknames[ranking.TeamId]}
+		rankingsWithNicknames[i] = RankingWithNickname{Ranking: ranking, Nickname: teamNicknames[ranking.TeamId]}
+		if projections != nil {
+			if projection := projections.TeamProjection(ranking.TeamId); projection != nil {
+				rankingsWithNicknames[i].Clinched = projection.Clinched
+				rankingsWithNicknames[i].Eliminated = projection.Eliminated
+			}
+		}
 	}
 
 	// Get the last match scored so we can report that on the display.
@@ -234,32 +275,84 @@ func (web *Web) teamAvatarsApiHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (web *Web) bracketSvgApiHandler(w http.ResponseWriter, r *http.Request) {
-	var activeMatch *model.Match
-	if activeMatchValue, ok := r.URL.Query()["activeMatch"]; ok {
-		if activeMatchValue[0] == "current" {
-			activeMatch = web.arena.CurrentMatch
-		} else if activeMatchValue[0] == "saved" {
-			activeMatch = web.arena.SavedMatch
-		}
+	w.Header().Add("Content-Type", "image/svg+xml")
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	if err := web.generateBracketSvg(w, web.getBracketActiveMatch(r)); err != nil {
+		handleWebErr(w, err)
+		return
 	}
+}
 
-	w.Header().Add("Content-Type", "image/svg+xml")
+// Generates a JSON dump of the bracket layout along with the state of each match group, for clients that draw the
+// bracket themselves.
+func (web *Web) bracketLayoutApiHandler(w http.ResponseWriter, r *http.Request) {
+	bracket, err := web.getBracket(web.getBracketActiveMatch(r))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	jsonData, err := json.MarshalIndent(bracket, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
 	w.Header().Add("Access-Control-Allow-Origin", "*")
-	if err := web.generateBracketSvg(w, activeMatch); err != nil {
+	_, err = w.Write(jsonData)
+	if err != nil {
 		handleWebErr(w, err)
 		return
 	}
 }
 
-func (web *Web) generateBracketSvg(w io.Writer, activeMatch *model.Match) error {
+// Returns the match to highlight in the bracket as specified by the "activeMatch" query parameter, if any.
+func (web *Web) getBracketActiveMatch(r *http.Request) *model.Match {
+	if activeMatchValue, ok := r.URL.Query()["activeMatch"]; ok {
+		if activeMatchValue[0] == "current" {
+			return web.arena.CurrentMatch
+		} else if activeMatchValue[0] == "saved" {
+			return web.arena.SavedMatch
+		}
+	}
+	return nil
+}
+
+// Assembles the computed layout of the playoff bracket along with the alliances and status of each match group.
+func (web *Web) getBracket(activeMatch *model.Match) (*bracketState, error) {
 	alliances, err := web.arena.Database.GetAllAlliances()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	getAlliance := func(allianceId int) *model.Alliance {
+		if len(alliances) > 0 {
+			return &alliances[allianceId-1]
+		}
+		return &model.Alliance{Id: allianceId}
 	}
 
 	matchups := make(map[string]*allianceMatchup)
+	var standings []allianceStanding
 	if web.arena.PlayoffTournament != nil {
 		for _, matchGroup := range web.arena.PlayoffTournament.MatchGroups() {
+			if roundRobin, ok := matchGroup.(*playoff.RoundRobin); ok {
+				for i, standing := range roundRobin.Standings {
+					allianceStanding := allianceStanding{
+						RoundRobinStanding: standing,
+						Rank:               i + 1,
+						Alliance:           getAlliance(standing.AllianceId),
+						IsAdvancing:        roundRobin.IsComplete() && i < 2,
+					}
+					if activeMatch != nil && activeMatch.PlayoffMatchGroupId == roundRobin.Id() {
+						allianceStanding.IsActive = activeMatch.PlayoffRedAlliance == standing.AllianceId ||
+							activeMatch.PlayoffBlueAlliance == standing.AllianceId
+					}
+					standings = append(standings, allianceStanding)
+				}
+				continue
+			}
 			matchup, ok := matchGroup.(*playoff.Matchup)
 			if !ok {
 				continue
@@ -271,18 +364,10 @@ func (web *Web) generateBracketSvg(w io.Writer, activeMatch *model.Match) error
 				IsComplete:         matchup.IsComplete(),
 			}
 			if matchup.RedAllianceId > 0 {
-				if len(alliances) > 0 {
-					allianceMatchup.RedAlliance = &alliances[matchup.RedAllianceId-1]
-				} else {
-					allianceMatchup.RedAlliance = &model.Alliance{Id: matchup.RedAllianceId}
-				}
+				allianceMatchup.RedAlliance = getAlliance(matchup.RedAllianceId)
 			}
 			if matchup.BlueAllianceId > 0 {
-				if len(alliances) > 0 {
-					allianceMatchup.BlueAlliance = &alliances[matchup.BlueAllianceId-1]
-				} else {
-					allianceMatchup.BlueAlliance = &model.Alliance{Id: matchup.BlueAllianceId}
-				}
+				allianceMatchup.BlueAlliance = getAlliance(matchup.BlueAllianceId)
 			}
 			if activeMatch != nil {
 				allianceMatchup.IsActive = activeMatch.PlayoffMatchGroupId == matchup.Id()
@@ -292,20 +377,27 @@ func (web *Web) generateBracketSvg(w io.Writer, activeMatch *model.Match) error
 		}
 	}
 
-	bracketType := "double"
-	numAlliances := web.arena.EventSettings.NumPlayoffAlliances
-	if web.arena.EventSettings.PlayoffType == model.DoubleEliminationPlayoff && numAlliances == 4 {
-		bracketType = "double4"
-	} else if web.arena.EventSettings.PlayoffType == model.SingleEliminationPlayoff {
-		if numAlliances > 8 {
-			bracketType = "16"
-		} else if numAlliances > 4 {
-			bracketType = "8"
-		} else if numAlliances > 2 {
-			bracketType = "4"
-		} else {
-			bracketType = "2"
-		}
+	layout := &playoff.BracketLayout{}
+	if web.arena.PlayoffTournament != nil {
+		layout = web.arena.PlayoffTournament.Layout()
+	}
+	return &bracketState{Layout: layout, Matchups: matchups, Standings: standings}, nil
+}
+
+func (web *Web) generateBracketSvg(w io.Writer, activeMatch *model.Match) error {
+	bracket, err := web.getBracket(activeMatch)
+	if err != nil {
+		return err
+	}
+
+	// Shrink the bracket if necessary to fit within the drawing area (allowing for its border and column labels), and
+	// center it horizontally.
+	contentWidth := float64(bracket.Layout.Width + 2*bracketSvgMargin)
+	contentHeight := float64(bracket.Layout.Height + bracketSvgMargin + bracketSvgLabelHeight)
+	scale := math.Floor(1000*min(1, bracketSvgAreaWidth/contentWidth, bracketSvgAreaHeight/contentHeight)) / 1000
+	separatorEndX := 0
+	if numColumns := len(bracket.Layout.Columns); numColumns > 0 {
+		separatorEndX = bracket.Layout.Columns[numColumns-1].X - bracketSvgMargin
 	}
 
 	template, err := web.parseFiles("templates/bracket.svg")
@@ -313,8 +405,17 @@ func (web *Web) generateBracketSvg(w io.Writer, activeMatch *model.Match) error
 		return err
 	}
 	data := struct {
-		BracketType string
-		Matchups    map[string]*allianceMatchup
-	}{bracketType, matchups}
+		*bracketState
+		Scale         float64
+		OffsetX       int
+		OffsetY       int
+		SeparatorEndX int
+	}{
+		bracket,
+		scale,
+		int(bracketSvgAreaX + (bracketSvgAreaWidth-contentWidth*scale)/2 + bracketSvgMargin*scale),
+		int(bracketSvgAreaY + bracketSvgMargin*scale),
+		separatorEndX,
+	}
 	return template.ExecuteTemplate(w, "bracket", data)
 }
@@ -0,0 +1,255 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Token-authenticated web API allowing external tools, such as stream production software, to drive the match flow.
+
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	controlApiRateLimitBurst  = 10
+	controlApiRateLimitPerSec = 2
+	controlApiMaxBodyBytes    = 4096
+)
+
+var audienceDisplayModes = []string{
+	"blank", "intro", "match", "score", "bracket", "logo", "logoLuma", "sponsor", "allianceSelection", "timeout",
+}
+
+var allianceStationDisplayModes = []string{"blank", "match", "logo", "timeout", "fieldReset", "signalCount"}
+
+type apiV1ControlStatus struct {
+	MatchId                    int    `json:"matchId"`
+	MatchName                  string `json:"matchName"`
+	MatchState                 string `json:"matchState"`
+	AudienceDisplayMode        string `json:"audienceDisplayMode"`
+	AllianceStationDisplayMode string `json:"allianceStationDisplayMode"`
+}
+
+type apiV1LoadMatchRequest struct {
+	MatchId int `json:"matchId"`
+}
+
+type apiV1StartMatchRequest struct {
+	MuteMatchSounds bool `json:"muteMatchSounds"`
+}
+
+type apiV1StartTimeoutRequest struct {
+	DurationSec   int    `json:"durationSec"`
+	Description   string `json:"description,omitempty"`
+	NextMatchName string `json:"nextMatchName,omitempty"`
+}
+
+type apiV1DisplayModeRequest struct {
+	Mode string `json:"mode"`
+}
+
+// Represents a failed control command along with the HTTP status code to report it with.
+type controlApiError struct {
+	statusCode int
+	message    string
+}
+
+func (err *controlApiError) Error() string {
+	return err.message
+}
+
+// Executes a match control command on behalf of the holder of an API token.
+func (web *Web) controlApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	command := r.PathValue("command")
+	body, err := io.ReadAll(io.LimitReader(r.Body, controlApiMaxBodyBytes))
+	if err != nil {
+		writeApiV1Error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	auditEntry := model.ApiAuditEntry{
+		Time:      time.Now(),
+		IpAddress: getRemoteIp(r),
+		Command:   command,
+		Arguments: string(body),
+	}
+
+	err = web.authenticateControlApiRequest(r, &auditEntry)
+	if err == nil {
+		err = web.executeControlCommand(command, body)
+	}
+	var status apiV1ControlStatus
+	if err == nil {
+		auditEntry.StatusCode = http.StatusOK
+		status = web.getControlStatus()
+	} else {
+		var apiErr *controlApiError
+		if !errors.As(err, &apiErr) {
+			apiErr = &controlApiError{http.StatusInternalServerError, err.Error()}
+		}
+		auditEntry.StatusCode = apiErr.statusCode
+		auditEntry.Error = apiErr.message
+	}
+	if auditEntry.StatusCode == http.StatusTooManyRequests && auditEntry.TokenId == 0 {
+		// Don't let a client hammering the API without a valid token flood the audit log.
+		log.Printf("Rate-limited control API request from %s without a valid token.", auditEntry.IpAddress)
+	} else if err := web.arena.Database.CreateApiAuditEntry(&auditEntry); err != nil {
+		log.Printf("Failed to record API audit entry: %v", err)
+	}
+
+	if auditEntry.StatusCode != http.StatusOK {
+		if auditEntry.StatusCode == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", "Bearer realm=\"Cheesy Arena\"")
+		}
+		writeApiV1Error(w, auditEntry.StatusCode, auditEntry.Error)
+		return
+	}
+	jsonData, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+}
+
+// Checks the bearer token and the rate limit for the given request, filling in the token details on the audit entry.
+func (web *Web) authenticateControlApiRequest(r *http.Request, auditEntry *model.ApiAuditEntry) error {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	var apiToken *model.ApiToken
+	if ok && token != "" {
+		var err error
+		if apiToken, err = web.arena.Database.GetApiTokenByToken(strings.TrimSpace(token)); err != nil {
+			return err
+		}
+	}
+
+	// Rate limit failed attempts by client address to slow down any guessing of tokens.
+	rateLimitKey := "ip:" + auditEntry.IpAddress
+	if apiToken != nil {
+		rateLimitKey = "token:" + strconv.Itoa(apiToken.Id)
+		auditEntry.TokenId = apiToken.Id
+		auditEntry.TokenName = apiToken.Name
+	}
+	if allowed, retryAfter := web.controlApiRateLimiter.allow(rateLimitKey); !allowed {
+		return &controlApiError{
+			http.StatusTooManyRequests,
+			fmt.Sprintf("Rate limit exceeded; try again in %.1f seconds.", retryAfter.Seconds()),
+		}
+	}
+	if apiToken == nil {
+		return &controlApiError{http.StatusUnauthorized, "A valid API token is required."}
+	}
+
+	apiToken.LastUsedAt = auditEntry.Time
+	return web.arena.Database.UpdateApiToken(apiToken)
+}
+
+// Runs the given control command using the given JSON-encoded arguments.
+func (web *Web) executeControlCommand(command string, body []byte) error {
+	var err error
+	switch command {
+	case "loadMatch":
+		var request apiV1LoadMatchRequest
+		if err = decodeControlRequest(body, &request); err != nil {
+			return err
+		}
+		if request.MatchId != 0 {
+			match, err := web.arena.Database.GetMatchById(request.MatchId)
+			if err != nil {
+				return err
+			}
+			if match == nil {
+				return &controlApiError{http.StatusNotFound, fmt.Sprintf("Match %d does not exist.", request.MatchId)}
+			}
+		}
+		err = web.loadMatchById(request.MatchId)
+	case "startMatch":
+		var request apiV1StartMatchRequest
+		if err = decodeControlRequest(body, &request); err != nil {
+			return err
+		}
+		web.arena.MuteMatchSounds = request.MuteMatchSounds
+		err = web.arena.StartMatch()
+	case "abortMatch":
+		err = web.arena.AbortMatch()
+	case "commitAndPost":
+		err = web.commitPostAndLoadNextMatch()
+	case "discardResults":
+		err = web.discardResults()
+	case "startTimeout":
+		var request apiV1StartTimeoutRequest
+		if err = decodeControlRequest(body, &request); err != nil {
+			return err
+		}
+		if request.DurationSec <= 0 {
+			return &controlApiError{http.StatusBadRequest, "Timeout duration must be positive."}
+		}
+		if request.Description == "" {
+			request.Description = defaultTimeoutDescription
+		}
+		err = web.arena.StartAdHocTimeout(request.Description, request.NextMatchName, request.DurationSec)
+	case "setAudienceDisplay", "setAllianceStationDisplay":
+		var request apiV1DisplayModeRequest
+		if err = decodeControlRequest(body, &request); err != nil {
+			return err
+		}
+		if command == "setAudienceDisplay" {
+			if !slices.Contains(audienceDisplayModes, request.Mode) {
+				return &controlApiError{http.StatusBadRequest, fmt.Sprintf("Invalid display mode %q.", request.Mode)}
+			}
+			web.arena.SetAudienceDisplayMode(request.Mode)
+		} else {
+			if !slices.Contains(allianceStationDisplayModes, request.Mode) {
+				return &controlApiError{http.StatusBadRequest, fmt.Sprintf("Invalid display mode %q.", request.Mode)}
+			}
+			web.arena.SetAllianceStationDisplayMode(request.Mode)
+		}
+	default:
+		return &controlApiError{http.StatusNotFound, fmt.Sprintf("Invalid command %q.", command)}
+	}
+
+	if err != nil {
+		// Anything the arena refuses to do is a conflict with the current state of the match.
+		return &controlApiError{http.StatusConflict, err.Error()}
+	}
+	return nil
+}
+
+// Parses the given JSON request body, which may be empty if the command's arguments are all optional.
+func decodeControlRequest(body []byte, request any) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		return &controlApiError{http.StatusBadRequest, "Invalid request body: " + err.Error()}
+	}
+	return nil
+}
+
+// Returns a summary of the arena state following a control command.
+func (web *Web) getControlStatus() apiV1ControlStatus {
+	return apiV1ControlStatus{
+		MatchId:                    web.arena.CurrentMatch.Id,
+		MatchName:                  web.arena.CurrentMatch.ShortName,
+		MatchState:                 field.MatchStateNames[web.arena.MatchState],
+		AudienceDisplayMode:        web.arena.AudienceDisplayMode,
+		AllianceStationDisplayMode: web.arena.AllianceStationDisplayMode,
+	}
+}
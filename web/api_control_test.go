@@ -0,0 +1,161 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func (web *Web) postControlApiResponse(command, token, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request, _ := http.NewRequest("POST", "/api/v1/control/"+command, strings.NewReader(body))
+	request.RemoteAddr = "10.0.100.50:12345"
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+	web.newHandler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+func createTestApiToken(t *testing.T, web *Web, name, token string) *model.ApiToken {
+	apiToken := model.ApiToken{Name: name, TokenHash: model.HashApiToken(token), CreatedAt: time.Now()}
+	assert.Nil(t, web.arena.Database.CreateApiToken(&apiToken))
+	return &apiToken
+}
+
+func TestControlApiAuthentication(t *testing.T) {
+	web := setupTestWeb(t)
+	apiToken := createTestApiToken(t, web, "Stream Deck", "secret")
+
+	recorder := web.postControlApiResponse("abortMatch", "", "")
+	assert.Equal(t, 401, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("WWW-Authenticate"), "Bearer")
+	assert.Contains(t, recorder.Body.String(), "A valid API token is required.")
+	recorder = web.postControlApiResponse("abortMatch", "wrong", "")
+	assert.Equal(t, 401, recorder.Code)
+
+	recorder = web.postControlApiResponse("setAudienceDisplay", "secret", `{"mode": "score"}`)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "score", web.arena.AudienceDisplayMode)
+	apiToken, _ = web.arena.Database.GetApiTokenById(apiToken.Id)
+	assert.False(t, apiToken.LastUsedAt.IsZero())
+
+	// Check that every request is recorded in the audit trail.
+	auditEntries, err := web.arena.Database.GetRecentApiAuditEntries(10)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(auditEntries)) {
+		assert.Equal(t, "Stream Deck", auditEntries[0].TokenName)
+		assert.Equal(t, "setAudienceDisplay", auditEntries[0].Command)
+		assert.Equal(t, `{"mode": "score"}`, auditEntries[0].Arguments)
+		assert.Equal(t, 200, auditEntries[0].StatusCode)
+		assert.Equal(t, "10.0.100.50", auditEntries[0].IpAddress)
+		assert.Equal(t, 0, auditEntries[1].TokenId)
+		assert.Equal(t, 401, auditEntries[1].StatusCode)
+		assert.Equal(t, "A valid API token is required.", auditEntries[1].Error)
+	}
+
+	// Check that a revoked token no longer works.
+	assert.Nil(t, web.arena.Database.DeleteApiToken(apiToken.Id))
+	recorder = web.postControlApiResponse("abortMatch", "secret", "")
+	assert.Equal(t, 401, recorder.Code)
+}
+
+func TestControlApiRateLimit(t *testing.T) {
+	web := setupTestWeb(t)
+	web.controlApiRateLimiter = newRateLimiter(2, 0.5)
+	createTestApiToken(t, web, "Stream Deck", "secret")
+	createTestApiToken(t, web, "Overlay", "secret2")
+
+	for i := 0; i < 2; i++ {
+		recorder := web.postControlApiResponse("setAllianceStationDisplay", "secret", `{"mode": "logo"}`)
+		assert.Equal(t, 200, recorder.Code)
+	}
+	recorder := web.postControlApiResponse("setAllianceStationDisplay", "secret", `{"mode": "match"}`)
+	assert.Equal(t, 429, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Rate limit exceeded")
+	assert.Equal(t, "logo", web.arena.AllianceStationDisplayMode)
+
+	// Check that other tokens have their own limits.
+	recorder = web.postControlApiResponse("setAllianceStationDisplay", "secret2", `{"mode": "match"}`)
+	assert.Equal(t, 200, recorder.Code)
+
+	// Check that failed authentication attempts are limited too.
+	for i := 0; i < 2; i++ {
+		recorder = web.postControlApiResponse("abortMatch", "guess", "")
+		assert.Equal(t, 401, recorder.Code)
+	}
+	recorder = web.postControlApiResponse("abortMatch", "guess", "")
+	assert.Equal(t, 429, recorder.Code)
+
+	// Check that the rate-limited attempts without a valid token are left out of the audit log.
+	auditEntries, err := web.arena.Database.GetRecentApiAuditEntries(10)
+	assert.Nil(t, err)
+	if assert.Equal(t, 6, len(auditEntries)) {
+		assert.Equal(t, 401, auditEntries[0].StatusCode)
+		assert.Equal(t, 200, auditEntries[2].StatusCode)
+		assert.Equal(t, 429, auditEntries[3].StatusCode)
+		assert.Equal(t, "Stream Deck", auditEntries[3].TokenName)
+	}
+}
+
+func TestControlApiCommands(t *testing.T) {
+	web := setupTestWeb(t)
+	web.controlApiRateLimiter = newRateLimiter(100, 100)
+	createTestApiToken(t, web, "Stream Deck", "secret")
+	match := model.Match{Type: model.Qualification, TypeOrder: 1, ShortName: "Q1"}
+	web.arena.Database.CreateMatch(&match)
+
+	recorder := web.postControlApiResponse("loadMatch", "secret", `{"matchId": 1000}`)
+	assert.Equal(t, 404, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Match 1000 does not exist.")
+	recorder = web.postControlApiResponse("loadMatch", "secret", `{"matchId": "Q1"}`)
+	assert.Equal(t, 400, recorder.Code)
+	recorder = web.postControlApiResponse("loadMatch", "secret", `{"match": 1}`)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "unknown field")
+
+	recorder = web.postControlApiResponse("loadMatch", "secret", `{"matchId": 1}`)
+	assert.Equal(t, 200, recorder.Code)
+	var status apiV1ControlStatus
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, apiV1ControlStatus{1, "Q1", "pre_match", "blank", "match"}, status)
+	assert.Equal(t, match.Id, web.arena.CurrentMatch.Id)
+
+	// Check that commands the arena refuses are reported as conflicts.
+	recorder = web.postControlApiResponse("startMatch", "secret", "")
+	assert.Equal(t, 409, recorder.Code)
+	assert.Equal(t, field.PreMatch, web.arena.MatchState)
+	recorder = web.postControlApiResponse("abortMatch", "secret", "")
+	assert.Equal(t, 409, recorder.Code)
+
+	recorder = web.postControlApiResponse("loadMatch", "secret", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, model.Test, web.arena.CurrentMatch.Type)
+
+	recorder = web.postControlApiResponse("setAudienceDisplay", "secret", `{"mode": "bogus"}`)
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid display mode")
+	recorder = web.postControlApiResponse("setAllianceStationDisplay", "secret", `{"mode": "score"}`)
+	assert.Equal(t, 400, recorder.Code)
+
+	recorder = web.postControlApiResponse("startTimeout", "secret", `{"durationSec": 0}`)
+	assert.Equal(t, 400, recorder.Code)
+	recorder = web.postControlApiResponse("startTimeout", "secret", `{"durationSec": 300, "nextMatchName": "Q2"}`)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, "timeout", status.MatchState)
+	assert.Equal(t, field.TimeoutActive, web.arena.MatchState)
+
+	recorder = web.postControlApiResponse("selfDestruct", "secret", "")
+	assert.Equal(t, 404, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid command")
+}
@@ -7,10 +7,12 @@ import (
 	"encoding/json"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/playoff"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 	"time"
 )
@@ -94,8 +96,8 @@ func TestRankingsApi(t *testing.T) {
 	assert.Equal(t, 0, len(rankingsData.Rankings))
 	assert.Equal(t, "", rankingsData.HighestPlayedMatch)
 
-	ranking1 := RankingWithNickname{*game.TestRanking2(), "Simbots"}
-	ranking2 := RankingWithNickname{*game.TestRanking1(), "ChezyPof"}
+	ranking1 := RankingWithNickname{Ranking: *game.TestRanking2(), Nickname: "Simbots"}
+	ranking2 := RankingWithNickname{Ranking: *game.TestRanking1(), Nickname: "ChezyPof"}
 	web.arena.Database.CreateRanking(&ranking1.Ranking)
 	web.arena.Database.CreateRanking(&ranking2.Ranking)
 	web.arena.Database.CreateMatch(&model.Match{Type: model.Qualification, ShortName: "Q29", Status: game.RedWonMatch})
@@ -200,7 +202,91 @@ func TestBracketSvgApiFourAllianceDoubleElimination(t *testing.T) {
 	recorder := web.getHttpResponse("/api/bracket/svg")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "image/svg+xml", recorder.Header()["Content-Type"][0])
-	assert.Contains(t, recorder.Body.String(), "bracket_double4")
+	assert.Contains(t, recorder.Body.String(), "Lower Bracket")
 	assert.Contains(t, recorder.Body.String(), "match_M5")
 	assert.Contains(t, recorder.Body.String(), "Finals")
 }
+
+func TestBracketSvgApiSixAllianceDoubleElimination(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.PlayoffType = model.DoubleEliminationPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 6
+	tournament.CreateTestAlliances(web.arena.Database, 6)
+	web.arena.CreatePlayoffTournament()
+
+	recorder := web.getHttpResponse("/api/bracket/svg")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Lower Bracket")
+	assert.Contains(t, recorder.Body.String(), "match_M9")
+	assert.Contains(t, recorder.Body.String(), "W M2")
+}
+
+func TestBracketSvgApiLayout(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.PlayoffType = model.SingleEliminationPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 4
+	tournament.CreateTestAlliances(web.arena.Database, 4)
+	web.arena.CreatePlayoffTournament()
+
+	// Match groups and connectors are drawn at the positions computed by the layout.
+	recorder := web.getHttpResponse("/api/bracket/svg")
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Regexp(t, `<g transform="translate\(0 190\)">\s*<g id="match_SF2"`, body)
+	assert.Regexp(t, `<g transform="translate\(297 95\)">\s*<g id="match_F"`, body)
+	assert.Contains(t, body, "points=\"205,88 235,88 235,151 297,151 \"")
+	assert.Contains(t, body, ">Semifinals</text>")
+	assert.NotContains(t, body, "Lower Bracket")
+
+	// A small bracket is drawn at full size.
+	assert.Contains(t, body, "scale(1)")
+	web.arena.EventSettings.NumPlayoffAlliances = 16
+	tournament.CreateTestAlliances(web.arena.Database, 16)
+	web.arena.CreatePlayoffTournament()
+	recorder = web.getHttpResponse("/api/bracket/svg")
+	assert.NotContains(t, recorder.Body.String(), "scale(1)")
+}
+
+func TestBracketLayoutApi(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.PlayoffType = model.DoubleEliminationPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 4
+	tournament.CreateTestAlliances(web.arena.Database, 4)
+	web.arena.CreatePlayoffTournament()
+
+	recorder := web.getHttpResponse("/api/bracket/layout")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	var bracket struct {
+		Layout    playoff.BracketLayout
+		Matchups  map[string]allianceMatchup
+		Standings []allianceStanding
+	}
+	err := json.Unmarshal([]byte(recorder.Body.String()), &bracket)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(bracket.Layout.Nodes))
+	assert.Equal(t, 8, len(bracket.Layout.Connectors))
+	assert.Equal(t, 402, bracket.Layout.SeparatorY)
+	if assert.Contains(t, bracket.Matchups, "M1") {
+		assert.Equal(t, 1, bracket.Matchups["M1"].RedAlliance.Id)
+		assert.Equal(t, "W M3", bracket.Matchups["F"].RedAllianceSource)
+	}
+	assert.Empty(t, bracket.Standings)
+}
+
+func TestBracketSvgApiRoundRobin(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.PlayoffType = model.RoundRobinPlayoff
+	web.arena.EventSettings.NumPlayoffAlliances = 5
+	tournament.CreateTestAlliances(web.arena.Database, 5)
+	web.arena.CreatePlayoffTournament()
+
+	recorder := web.getHttpResponse("/api/bracket/svg")
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "Round Robin")
+	assert.Contains(t, body, "RR #1")
+	assert.Contains(t, body, "RR #2")
+	assert.Equal(t, 5, strings.Count(body, "class=\"standing"))
+	assert.NotContains(t, body, "standing advancing")
+}
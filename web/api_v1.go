@@ -0,0 +1,632 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Version 1 of the public, read-only web API. Responses are built from the stable types defined here rather than from
+// the internal model structs, so that changes to the latter don't break clients such as stream overlays and scouting
+// apps.
+
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiV1DefaultPerPage = 100
+	apiV1MaxPerPage     = 500
+)
+
+type apiV1Error struct {
+	Error string `json:"error"`
+}
+
+type apiV1Pagination struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"perPage"`
+	TotalItems int `json:"totalItems"`
+	TotalPages int `json:"totalPages"`
+}
+
+type apiV1Page[T any] struct {
+	Data       []T             `json:"data"`
+	Pagination apiV1Pagination `json:"pagination"`
+}
+
+type apiV1Event struct {
+	Name                string `json:"name"`
+	Code                string `json:"code"`
+	PlayoffType         string `json:"playoffType"`
+	NumPlayoffAlliances int    `json:"numPlayoffAlliances"`
+	NumFields           int    `json:"numFields"`
+}
+
+type apiV1Team struct {
+	Number     int    `json:"number"`
+	Name       string `json:"name"`
+	Nickname   string `json:"nickname"`
+	City       string `json:"city"`
+	StateProv  string `json:"stateProv"`
+	Country    string `json:"country"`
+	SchoolName string `json:"schoolName"`
+	RookieYear int    `json:"rookieYear"`
+	RobotName  string `json:"robotName"`
+}
+
+type apiV1Match struct {
+	Id                  int                `json:"id"`
+	Type                string             `json:"type"`
+	Order               int                `json:"order"`
+	ShortName           string             `json:"shortName"`
+	LongName            string             `json:"longName"`
+	NameDetail          string             `json:"nameDetail"`
+	ScheduledTime       time.Time          `json:"scheduledTime"`
+	StartedAt           *time.Time         `json:"startedAt"`
+	FieldNumber         int                `json:"fieldNumber"`
+	PlayoffMatchGroupId string             `json:"playoffMatchGroupId,omitempty"`
+	Status              string             `json:"status"`
+	Red                 apiV1MatchAlliance `json:"red"`
+	Blue                apiV1MatchAlliance `json:"blue"`
+	Result              *apiV1MatchResult  `json:"result"`
+}
+
+type apiV1MatchAlliance struct {
+	Teams             []int `json:"teams"`
+	SurrogateTeams    []int `json:"surrogateTeams"`
+	PlayoffAllianceId int   `json:"playoffAllianceId,omitempty"`
+}
+
+type apiV1MatchResult struct {
+	PlayNumber  int                 `json:"playNumber"`
+	CommittedAt time.Time           `json:"committedAt"`
+	Red         apiV1ScoreBreakdown `json:"red"`
+	Blue        apiV1ScoreBreakdown `json:"blue"`
+}
+
+type apiV1ScoreBreakdown struct {
+	Score                         int               `json:"score"`
+	MatchPoints                   int               `json:"matchPoints"`
+	AutoFuelPoints                int               `json:"autoFuelPoints"`
+	AutoTowerPoints               int               `json:"autoTowerPoints"`
+	TeleopFuelPoints              int               `json:"teleopFuelPoints"`
+	TeleopTowerPoints             int               `json:"teleopTowerPoints"`
+	FoulPoints                    int               `json:"foulPoints"`
+	NumFuel                       int               `json:"numFuel"`
+	EnergizedBonusRankingPoint    bool              `json:"energizedBonusRankingPoint"`
+	SuperchargedBonusRankingPoint bool              `json:"superchargedBonusRankingPoint"`
+	TraversalBonusRankingPoint    bool              `json:"traversalBonusRankingPoint"`
+	BonusRankingPoints            int               `json:"bonusRankingPoints"`
+	PlayoffDq                     bool              `json:"playoffDq"`
+	Cards                         map[string]string `json:"cards"`
+}
+
+type apiV1Ranking struct {
+	Rank              int    `json:"rank"`
+	PreviousRank      int    `json:"previousRank"`
+	TeamNumber        int    `json:"teamNumber"`
+	Nickname          string `json:"nickname"`
+	RankingPoints     int    `json:"rankingPoints"`
+	MatchPoints       int    `json:"matchPoints"`
+	AutoFuelPoints    int    `json:"autoFuelPoints"`
+	TowerPoints       int    `json:"towerPoints"`
+	Wins              int    `json:"wins"`
+	Losses            int    `json:"losses"`
+	Ties              int    `json:"ties"`
+	Disqualifications int    `json:"disqualifications"`
+	Played            int    `json:"played"`
+}
+
+type apiV1Alliance struct {
+	Id               int   `json:"id"`
+	Teams            []int `json:"teams"`
+	Lineup           []int `json:"lineup"`
+	BackupTeam       int   `json:"backupTeam,omitempty"`
+	ReplacedByBackup int   `json:"replacedByBackup,omitempty"`
+}
+
+type apiV1Award struct {
+	Id         int    `json:"id"`
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	TeamNumber int    `json:"teamNumber,omitempty"`
+	PersonName string `json:"personName,omitempty"`
+}
+
+type apiV1Bracket struct {
+	PlayoffType string                 `json:"playoffType"`
+	Matchups    []apiV1BracketMatchup  `json:"matchups"`
+	Standings   []apiV1BracketStanding `json:"standings"`
+}
+
+type apiV1BracketMatchup struct {
+	Id                 string `json:"id"`
+	RedAllianceSource  string `json:"redAllianceSource"`
+	BlueAllianceSource string `json:"blueAllianceSource"`
+	RedAllianceId      int    `json:"redAllianceId,omitempty"`
+	BlueAllianceId     int    `json:"blueAllianceId,omitempty"`
+	SeriesLeader       string `json:"seriesLeader"`
+	SeriesStatus       string `json:"seriesStatus"`
+	IsComplete         bool   `json:"isComplete"`
+}
+
+type apiV1BracketStanding struct {
+	Rank        int  `json:"rank"`
+	AllianceId  int  `json:"allianceId"`
+	Wins        int  `json:"wins"`
+	Losses      int  `json:"losses"`
+	Ties        int  `json:"ties"`
+	Played      int  `json:"played"`
+	IsAdvancing bool `json:"isAdvancing"`
+}
+
+// Returns the general information about the event.
+func (web *Web) eventApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	eventSettings := web.arena.EventSettings
+	event := apiV1Event{
+		Name:                eventSettings.Name,
+		Code:                eventSettings.TbaEventCode,
+		PlayoffType:         apiV1PlayoffType(eventSettings.PlayoffType),
+		NumPlayoffAlliances: eventSettings.NumPlayoffAlliances,
+		NumFields:           eventSettings.NumFields,
+	}
+	writeApiV1Response(w, r, event)
+}
+
+// Returns a page of the teams at the event, ordered by team number.
+func (web *Web) teamsApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+
+	apiTeams := make([]apiV1Team, len(teams))
+	for i, team := range teams {
+		apiTeams[i] = newApiV1Team(&team)
+	}
+	writeApiV1Page(w, r, apiTeams)
+}
+
+// Returns a single team.
+func (web *Web) teamApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	teamId, err := strconv.Atoi(r.PathValue("teamNumber"))
+	if err != nil {
+		writeApiV1Error(w, http.StatusBadRequest, "Invalid team number.")
+		return
+	}
+	team, err := web.arena.Database.GetTeamById(teamId)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	if team == nil {
+		writeApiV1Error(w, http.StatusNotFound, fmt.Sprintf("Team %d does not exist.", teamId))
+		return
+	}
+
+	writeApiV1Response(w, r, newApiV1Team(team))
+}
+
+// Returns a page of the scheduled matches along with their results, optionally filtered by match type.
+func (web *Web) matchesApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	matchTypes := []model.MatchType{model.Practice, model.Qualification, model.Playoff}
+	if matchTypeValue := r.URL.Query().Get("type"); matchTypeValue != "" {
+		matchType, err := model.MatchTypeFromString(matchTypeValue)
+		if err != nil || matchType == model.Test {
+			writeApiV1Error(w, http.StatusBadRequest, fmt.Sprintf("Invalid match type %q.", matchTypeValue))
+			return
+		}
+		matchTypes = []model.MatchType{matchType}
+	}
+
+	var matches []model.Match
+	for _, matchType := range matchTypes {
+		matchesOfType, err := web.arena.Database.GetMatchesByType(matchType, false)
+		if err != nil {
+			handleApiV1Err(w, err)
+			return
+		}
+		matches = append(matches, matchesOfType...)
+	}
+
+	apiMatches := make([]apiV1Match, len(matches))
+	for i, match := range matches {
+		apiMatch, err := web.newApiV1Match(&match)
+		if err != nil {
+			handleApiV1Err(w, err)
+			return
+		}
+		apiMatches[i] = *apiMatch
+	}
+	writeApiV1Page(w, r, apiMatches)
+}
+
+// Returns a single match along with its result.
+func (web *Web) matchApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	matchId, err := strconv.Atoi(r.PathValue("matchId"))
+	if err != nil {
+		writeApiV1Error(w, http.StatusBadRequest, "Invalid match ID.")
+		return
+	}
+	match, err := web.arena.Database.GetMatchById(matchId)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	if match == nil || match.Type == model.Test || match.Status == game.MatchHidden {
+		writeApiV1Error(w, http.StatusNotFound, fmt.Sprintf("Match %d does not exist.", matchId))
+		return
+	}
+
+	apiMatch, err := web.newApiV1Match(match)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	writeApiV1Response(w, r, apiMatch)
+}
+
+// Returns a page of the qualification rankings, in rank order.
+func (web *Web) rankingsApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	rankings, err := web.arena.Database.GetAllRankings()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	teamNicknames := make(map[int]string)
+	for _, team := range teams {
+		teamNicknames[team.Id] = team.Nickname
+	}
+
+	apiRankings := make([]apiV1Ranking, len(rankings))
+	for i, ranking := range rankings {
+		apiRankings[i] = apiV1Ranking{
+			Rank:              ranking.Rank,
+			PreviousRank:      ranking.PreviousRank,
+			TeamNumber:        ranking.TeamId,
+			Nickname:          teamNicknames[ranking.TeamId],
+			RankingPoints:     ranking.RankingPoints,
+			MatchPoints:       ranking.MatchPoints,
+			AutoFuelPoints:    ranking.AutoFuelPoints,
+			TowerPoints:       ranking.TowerPoints,
+			Wins:              ranking.Wins,
+			Losses:            ranking.Losses,
+			Ties:              ranking.Ties,
+			Disqualifications: ranking.Disqualifications,
+			Played:            ranking.Played,
+		}
+	}
+	writeApiV1Page(w, r, apiRankings)
+}
+
+// Returns the playoff alliances.
+func (web *Web) alliancesApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	alliances, err := web.arena.Database.GetAllAlliances()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+
+	apiAlliances := make([]apiV1Alliance, len(alliances))
+	for i, alliance := range alliances {
+		apiAlliances[i] = apiV1Alliance{
+			Id:               alliance.Id,
+			Teams:            append([]int{}, alliance.TeamIds...),
+			Lineup:           alliance.Lineup[:],
+			BackupTeam:       alliance.BackupTeamId,
+			ReplacedByBackup: alliance.ReplacedTeamId,
+		}
+	}
+	writeApiV1Response(w, r, apiAlliances)
+}
+
+// Returns the awards that have been given out, leaving out any that haven't been revealed yet.
+func (web *Web) awardsApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	awards, err := web.arena.Database.GetAllAwards()
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+
+	apiAwards := []apiV1Award{}
+	for _, award := range awards {
+		if !award.Revealed {
+			continue
+		}
+		apiAwards = append(
+			apiAwards,
+			apiV1Award{
+				Id:         award.Id,
+				Type:       apiV1AwardType(award.Type),
+				Name:       award.AwardName,
+				TeamNumber: award.TeamId,
+				PersonName: award.PersonName,
+			},
+		)
+	}
+	writeApiV1Response(w, r, apiAwards)
+}
+
+// Returns the state of each series in the playoff bracket, along with the standings of any round-robin stage.
+func (web *Web) bracketApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	bracket, err := web.getBracket(nil)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+
+	apiBracket := apiV1Bracket{
+		PlayoffType: apiV1PlayoffType(web.arena.EventSettings.PlayoffType),
+		Matchups:    []apiV1BracketMatchup{},
+		Standings:   []apiV1BracketStanding{},
+	}
+	for _, matchup := range bracket.Matchups {
+		apiMatchup := apiV1BracketMatchup{
+			Id:                 matchup.Id,
+			RedAllianceSource:  matchup.RedAllianceSource,
+			BlueAllianceSource: matchup.BlueAllianceSource,
+			SeriesLeader:       matchup.SeriesLeader,
+			SeriesStatus:       matchup.SeriesStatus,
+			IsComplete:         matchup.IsComplete,
+		}
+		if matchup.RedAlliance != nil {
+			apiMatchup.RedAllianceId = matchup.RedAlliance.Id
+		}
+		if matchup.BlueAlliance != nil {
+			apiMatchup.BlueAllianceId = matchup.BlueAlliance.Id
+		}
+		apiBracket.Matchups = append(apiBracket.Matchups, apiMatchup)
+	}
+	sort.Slice(apiBracket.Matchups, func(i, j int) bool {
+		return apiBracket.Matchups[i].Id < apiBracket.Matchups[j].Id
+	})
+	for _, standing := range bracket.Standings {
+		apiBracket.Standings = append(
+			apiBracket.Standings,
+			apiV1BracketStanding{
+				Rank:        standing.Rank,
+				AllianceId:  standing.AllianceId,
+				Wins:        standing.Wins,
+				Losses:      standing.Losses,
+				Ties:        standing.Ties,
+				Played:      standing.Played,
+				IsAdvancing: standing.IsAdvancing,
+			},
+		)
+	}
+	writeApiV1Response(w, r, apiBracket)
+}
+
+func newApiV1Team(team *model.Team) apiV1Team {
+	return apiV1Team{
+		Number:     team.Id,
+		Name:       team.Name,
+		Nickname:   team.Nickname,
+		City:       team.City,
+		StateProv:  team.StateProv,
+		Country:    team.Country,
+		SchoolName: team.SchoolName,
+		RookieYear: team.RookieYear,
+		RobotName:  team.RobotName,
+	}
+}
+
+// Converts the given match and its latest result, if any, into their API representation.
+func (web *Web) newApiV1Match(match *model.Match) (*apiV1Match, error) {
+	apiMatch := apiV1Match{
+		Id:                  match.Id,
+		Type:                strings.ToLower(match.Type.String()),
+		Order:               match.TypeOrder,
+		ShortName:           match.ShortName,
+		LongName:            match.LongName,
+		NameDetail:          match.NameDetail,
+		ScheduledTime:       match.Time,
+		FieldNumber:         match.FieldNumber,
+		PlayoffMatchGroupId: match.PlayoffMatchGroupId,
+		Status:              apiV1MatchStatus(match.Status),
+		Red: newApiV1MatchAlliance(
+			[3]int{match.Red1, match.Red2, match.Red3},
+			[3]bool{match.Red1IsSurrogate, match.Red2IsSurrogate, match.Red3IsSurrogate},
+			match.PlayoffRedAlliance,
+		),
+		Blue: newApiV1MatchAlliance(
+			[3]int{match.Blue1, match.Blue2, match.Blue3},
+			[3]bool{match.Blue1IsSurrogate, match.Blue2IsSurrogate, match.Blue3IsSurrogate},
+			match.PlayoffBlueAlliance,
+		),
+	}
+	if !match.StartedAt.IsZero() {
+		startedAt := match.StartedAt
+		apiMatch.StartedAt = &startedAt
+	}
+
+	matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
+	if err != nil {
+		return nil, err
+	}
+	if matchResult != nil {
+		apiMatch.Result = &apiV1MatchResult{
+			PlayNumber:  matchResult.PlayNumber,
+			CommittedAt: matchResult.CommittedAt,
+			Red:         newApiV1ScoreBreakdown(matchResult.RedScoreSummary(), matchResult.RedCards),
+			Blue:        newApiV1ScoreBreakdown(matchResult.BlueScoreSummary(), matchResult.BlueCards),
+		}
+	}
+	return &apiMatch, nil
+}
+
+func newApiV1MatchAlliance(teamIds [3]int, isSurrogate [3]bool, playoffAllianceId int) apiV1MatchAlliance {
+	alliance := apiV1MatchAlliance{
+		Teams:             []int{},
+		SurrogateTeams:    []int{},
+		PlayoffAllianceId: playoffAllianceId,
+	}
+	for i, teamId := range teamIds {
+		if teamId == 0 {
+			continue
+		}
+		alliance.Teams = append(alliance.Teams, teamId)
+		if isSurrogate[i] {
+			alliance.SurrogateTeams = append(alliance.SurrogateTeams, teamId)
+		}
+	}
+	return alliance
+}
+
+func newApiV1ScoreBreakdown(summary *game.ScoreSummary, cards map[string]string) apiV1ScoreBreakdown {
+	if cards == nil {
+		cards = map[string]string{}
+	}
+	return apiV1ScoreBreakdown{
+		Score:                         summary.Score,
+		MatchPoints:                   summary.MatchPoints,
+		AutoFuelPoints:                summary.AutoFuelPoints,
+		AutoTowerPoints:               summary.AutoTowerPoints,
+		TeleopFuelPoints:              summary.TeleopFuelPoints,
+		TeleopTowerPoints:             summary.TeleopTowerPoints,
+		FoulPoints:                    summary.FoulPoints,
+		NumFuel:                       summary.NumFuel,
+		EnergizedBonusRankingPoint:    summary.EnergizedBonusRankingPoint,
+		SuperchargedBonusRankingPoint: summary.SuperchargedBonusRankingPoint,
+		TraversalBonusRankingPoint:    summary.TraversalBonusRankingPoint,
+		BonusRankingPoints:            summary.BonusRankingPoints,
+		PlayoffDq:                     summary.PlayoffDq,
+		Cards:                         cards,
+	}
+}
+
+func apiV1MatchStatus(status game.MatchStatus) string {
+	switch status {
+	case game.RedWonMatch:
+		return "red_won"
+	case game.BlueWonMatch:
+		return "blue_won"
+	case game.TieMatch:
+		return "tie"
+	default:
+		return "scheduled"
+	}
+}
+
+func apiV1PlayoffType(playoffType model.PlayoffType) string {
+	switch playoffType {
+	case model.SingleEliminationPlayoff:
+		return "single_elimination"
+	case model.RoundRobinPlayoff:
+		return "round_robin"
+	case model.CustomPlayoff:
+		return "custom"
+	default:
+		return "double_elimination"
+	}
+}
+
+func apiV1AwardType(awardType model.AwardType) string {
+	switch awardType {
+	case model.FinalistAward:
+		return "finalist"
+	case model.WinnerAward:
+		return "winner"
+	default:
+		return "judged"
+	}
+}
+
+// Writes out the page of the given items requested using the "page" and "perPage" query parameters.
+func writeApiV1Page[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	pagination := apiV1Pagination{Page: 1, PerPage: apiV1DefaultPerPage, TotalItems: len(items)}
+	if pageValue := r.URL.Query().Get("page"); pageValue != "" {
+		page, err := strconv.Atoi(pageValue)
+		if err != nil || page < 1 {
+			writeApiV1Error(w, http.StatusBadRequest, "Page must be a positive integer.")
+			return
+		}
+		pagination.Page = page
+	}
+	if perPageValue := r.URL.Query().Get("perPage"); perPageValue != "" {
+		perPage, err := strconv.Atoi(perPageValue)
+		if err != nil || perPage < 1 || perPage > apiV1MaxPerPage {
+			writeApiV1Error(
+				w, http.StatusBadRequest, fmt.Sprintf("Items per page must be between 1 and %d.", apiV1MaxPerPage),
+			)
+			return
+		}
+		pagination.PerPage = perPage
+	}
+	pagination.TotalPages = (pagination.TotalItems + pagination.PerPage - 1) / pagination.PerPage
+
+	// Clamp the page to just past the end before multiplying so that a huge page number can't overflow the offset.
+	start := min((min(pagination.Page, pagination.TotalPages+1)-1)*pagination.PerPage, len(items))
+	end := min(start+pagination.PerPage, len(items))
+	page := apiV1Page[T]{Data: items[start:end], Pagination: pagination}
+	if page.Data == nil {
+		// Go marshals an empty slice to null, so explicitly create it so that it appears as an empty JSON array.
+		page.Data = []T{}
+	}
+	writeApiV1Response(w, r, page)
+}
+
+// Writes out the given data as JSON along with an entity tag derived from it, or just a 304 Not Modified response if
+// the tag matches one the client already has.
+func writeApiV1Response(w http.ResponseWriter, r *http.Request, data any) {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+	hash := sha256.Sum256(jsonData)
+	etag := fmt.Sprintf("\"%s\"", hex.EncodeToString(hash[:16]))
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Expose-Headers", "ETag")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleApiV1Err(w, err)
+		return
+	}
+}
+
+// Returns true if the given If-None-Match header value includes the given entity tag.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeApiV1Error(w http.ResponseWriter, statusCode int, message string) {
+	jsonData, _ := json.Marshal(apiV1Error{Error: message})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(jsonData)
+}
+
+// Writes out the given unexpected error as a JSON error response with a status code of 500.
+func handleApiV1Err(w http.ResponseWriter, err error) {
+	writeApiV1Error(w, http.StatusInternalServerError, err.Error())
+}
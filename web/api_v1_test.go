@@ -0,0 +1,296 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEventApiV1(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.Name = "Chezy Champs"
+	web.arena.EventSettings.TbaEventCode = "2026cc"
+
+	recorder := web.getHttpResponse("/api/v1/event")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var event apiV1Event
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &event))
+	assert.Equal(
+		t,
+		apiV1Event{
+			Name:                "Chezy Champs",
+			Code:                "2026cc",
+			PlayoffType:         "double_elimination",
+			NumPlayoffAlliances: 8,
+			NumFields:           1,
+		},
+		event,
+	)
+}
+
+func TestTeamsApiV1(t *testing.T) {
+	web := setupTestWeb(t)
+	for i := 1; i <= 5; i++ {
+		web.arena.Database.CreateTeam(
+			&model.Team{Id: 100 * i, Nickname: fmt.Sprintf("Team %d", i), WpaKey: "secretkey", FtaNotes: "notes"},
+		)
+	}
+
+	recorder := web.getHttpResponse("/api/v1/teams?page=2&perPage=2")
+	assert.Equal(t, 200, recorder.Code)
+	var page apiV1Page[apiV1Team]
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	assert.Equal(t, apiV1Pagination{Page: 2, PerPage: 2, TotalItems: 5, TotalPages: 3}, page.Pagination)
+	if assert.Equal(t, 2, len(page.Data)) {
+		assert.Equal(t, 300, page.Data[0].Number)
+		assert.Equal(t, "Team 4", page.Data[1].Nickname)
+	}
+	assert.NotContains(t, recorder.Body.String(), "secretkey")
+	assert.NotContains(t, recorder.Body.String(), "notes")
+
+	// Check a page past the end.
+	recorder = web.getHttpResponse("/api/v1/teams?page=4&perPage=2")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "\"data\": []")
+	recorder = web.getHttpResponse("/api/v1/teams?page=4611686018427387905&perPage=2")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	assert.Equal(t, 4611686018427387905, page.Pagination.Page)
+	assert.Empty(t, page.Data)
+
+	recorder = web.getHttpResponse("/api/v1/teams?perPage=501")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Items per page must be between 1 and 500.")
+	recorder = web.getHttpResponse("/api/v1/teams?page=0")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Page must be a positive integer.")
+
+	recorder = web.getHttpResponse("/api/v1/teams/300")
+	assert.Equal(t, 200, recorder.Code)
+	var team apiV1Team
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &team))
+	assert.Equal(t, "Team 3", team.Nickname)
+	recorder = web.getHttpResponse("/api/v1/teams/254")
+	assert.Equal(t, 404, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 254 does not exist.")
+}
+
+func TestMatchesApiV1(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match1 := model.Match{
+		Type:             model.Qualification,
+		TypeOrder:        1,
+		ShortName:        "Q1",
+		Time:             time.Unix(1000, 0).UTC(),
+		Red1:             1,
+		Red2:             2,
+		Red3:             3,
+		Blue1:            4,
+		Blue2:            5,
+		Blue3:            6,
+		Blue2IsSurrogate: true,
+		Status:           game.RedWonMatch,
+		FieldNumber:      2,
+	}
+	match2 := model.Match{Type: model.Qualification, TypeOrder: 2, ShortName: "Q2", Red1: 7, Blue1: 8}
+	match3 := model.Match{Type: model.Practice, TypeOrder: 1, ShortName: "P1"}
+	match4 := model.Match{Type: model.Qualification, TypeOrder: 3, ShortName: "Q3", Status: game.MatchHidden}
+	match5 := model.Match{Type: model.Test, ShortName: "T"}
+	for _, match := range []*model.Match{&match1, &match2, &match3, &match4, &match5} {
+		web.arena.Database.CreateMatch(match)
+	}
+	matchResult := model.BuildTestMatchResult(match1.Id, 1)
+	web.arena.Database.CreateMatchResult(matchResult)
+
+	recorder := web.getHttpResponse("/api/v1/matches")
+	assert.Equal(t, 200, recorder.Code)
+	var page apiV1Page[apiV1Match]
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	if assert.Equal(t, 3, len(page.Data)) {
+		assert.Equal(t, "P1", page.Data[0].ShortName)
+		assert.Equal(t, "practice", page.Data[0].Type)
+		assert.Equal(t, "Q1", page.Data[1].ShortName)
+		assert.Equal(t, "Q2", page.Data[2].ShortName)
+	}
+
+	recorder = web.getHttpResponse("/api/v1/matches?type=qualification")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	if assert.Equal(t, 2, len(page.Data)) {
+		match := page.Data[0]
+		assert.Equal(t, match1.Id, match.Id)
+		assert.Equal(t, "qualification", match.Type)
+		assert.Equal(t, "red_won", match.Status)
+		assert.Equal(t, 2, match.FieldNumber)
+		assert.Equal(t, time.Unix(1000, 0).UTC(), match.ScheduledTime.UTC())
+		assert.Nil(t, match.StartedAt)
+		assert.Equal(t, []int{1, 2, 3}, match.Red.Teams)
+		assert.Equal(t, []int{}, match.Red.SurrogateTeams)
+		assert.Equal(t, []int{4, 5, 6}, match.Blue.Teams)
+		assert.Equal(t, []int{5}, match.Blue.SurrogateTeams)
+		if assert.NotNil(t, match.Result) {
+			assert.Equal(t, 1, match.Result.PlayNumber)
+			assert.Equal(t, matchResult.RedScoreSummary().Score, match.Result.Red.Score)
+			assert.Equal(t, matchResult.BlueScoreSummary().Score, match.Result.Blue.Score)
+			assert.Equal(t, map[string]string{"1868": "yellow"}, match.Result.Red.Cards)
+		}
+		assert.Equal(t, "scheduled", page.Data[1].Status)
+		assert.Equal(t, []int{7}, page.Data[1].Red.Teams)
+		assert.Nil(t, page.Data[1].Result)
+	}
+
+	recorder = web.getHttpResponse("/api/v1/matches?type=test")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid match type")
+
+	recorder = web.getHttpResponse(fmt.Sprintf("/api/v1/matches/%d", match1.Id))
+	assert.Equal(t, 200, recorder.Code)
+	var match apiV1Match
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &match))
+	assert.Equal(t, "Q1", match.ShortName)
+	assert.NotNil(t, match.Result)
+	for _, matchId := range []int{match4.Id, match5.Id, 1000} {
+		recorder = web.getHttpResponse(fmt.Sprintf("/api/v1/matches/%d", matchId))
+		assert.Equal(t, 404, recorder.Code)
+	}
+}
+
+func TestRankingsApiV1(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/api/v1/rankings")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "\"data\": []")
+
+	web.arena.Database.CreateRanking(game.TestRanking1())
+	web.arena.Database.CreateRanking(game.TestRanking2())
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "ChezyPof"})
+	recorder = web.getHttpResponse("/api/v1/rankings")
+	assert.Equal(t, 200, recorder.Code)
+	var page apiV1Page[apiV1Ranking]
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	if assert.Equal(t, 2, len(page.Data)) {
+		assert.Equal(
+			t,
+			apiV1Ranking{
+				Rank:           1,
+				TeamNumber:     254,
+				Nickname:       "ChezyPof",
+				RankingPoints:  20,
+				MatchPoints:    625,
+				AutoFuelPoints: 90,
+				TowerPoints:    554,
+				Wins:           3,
+				Losses:         2,
+				Ties:           1,
+				Played:         10,
+			},
+			page.Data[0],
+		)
+		assert.Equal(t, 1114, page.Data[1].TeamNumber)
+		assert.Equal(t, 1, page.Data[1].PreviousRank)
+	}
+}
+
+func TestAlliancesAndAwardsApiV1(t *testing.T) {
+	web := setupTestWeb(t)
+	tournament.CreateTestAlliances(web.arena.Database, 2)
+	web.arena.Database.CreateAward(
+		&model.Award{Type: model.WinnerAward, AwardName: "Winner", TeamId: 254, Revealed: true},
+	)
+	web.arena.Database.CreateAward(
+		&model.Award{Type: model.JudgedAward, AwardName: "Volunteer", PersonName: "Pat", Revealed: true},
+	)
+	web.arena.Database.CreateAward(&model.Award{Type: model.JudgedAward, AwardName: "Spirit Award", TeamId: 1114})
+
+	recorder := web.getHttpResponse("/api/v1/alliances")
+	assert.Equal(t, 200, recorder.Code)
+	var alliances []apiV1Alliance
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &alliances))
+	if assert.Equal(t, 2, len(alliances)) {
+		assert.Equal(t, 2, alliances[1].Id)
+		assert.Equal(t, []int{201, 202, 203, 204}, alliances[1].Teams)
+		assert.Equal(t, []int{202, 201, 203}, alliances[1].Lineup)
+	}
+
+	recorder = web.getHttpResponse("/api/v1/awards")
+	assert.Equal(t, 200, recorder.Code)
+	var awards []apiV1Award
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &awards))
+	if assert.Equal(t, 2, len(awards)) {
+		assert.Equal(t, apiV1Award{Id: 1, Type: "winner", Name: "Winner", TeamNumber: 254}, awards[0])
+		assert.Equal(t, apiV1Award{Id: 2, Type: "judged", Name: "Volunteer", PersonName: "Pat"}, awards[1])
+	}
+
+	// Check that an award stays hidden until it has been revealed.
+	assert.NotContains(t, recorder.Body.String(), "Spirit Award")
+	award, _ := web.arena.Database.GetAwardById(3)
+	award.Revealed = true
+	assert.Nil(t, web.arena.Database.UpdateAward(award))
+	recorder = web.getHttpResponse("/api/v1/awards")
+	assert.Contains(t, recorder.Body.String(), "Spirit Award")
+}
+
+func TestBracketApiV1(t *testing.T) {
+	web := setupTestWeb(t)
+	tournament.CreateTestAlliances(web.arena.Database, 8)
+	web.arena.CreatePlayoffTournament()
+	web.arena.CreatePlayoffMatches(time.Unix(0, 0))
+
+	recorder := web.getHttpResponse("/api/v1/bracket")
+	assert.Equal(t, 200, recorder.Code)
+	var bracket apiV1Bracket
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &bracket))
+	assert.Equal(t, "double_elimination", bracket.PlayoffType)
+	assert.Equal(t, len(web.arena.PlayoffTournament.MatchGroups()), len(bracket.Matchups))
+	assert.Empty(t, bracket.Standings)
+	if assert.NotEmpty(t, bracket.Matchups) {
+		matchup := bracket.Matchups[0]
+		assert.Equal(t, "F", matchup.Id)
+		assert.Equal(t, "W M11", matchup.RedAllianceSource)
+		assert.Equal(t, 0, matchup.RedAllianceId)
+		assert.False(t, matchup.IsComplete)
+	}
+}
+
+func TestApiV1ETag(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+
+	recorder := web.getHttpResponse("/api/v1/teams")
+	assert.Equal(t, 200, recorder.Code)
+	etag := recorder.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+
+	getWithETag := func(etag string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest("GET", "/api/v1/teams", nil)
+		request.Header.Set("If-None-Match", etag)
+		web.newHandler().ServeHTTP(recorder, request)
+		return recorder
+	}
+	recorder = getWithETag(etag)
+	assert.Equal(t, 304, recorder.Code)
+	assert.Empty(t, recorder.Body.String())
+	recorder = getWithETag("\"other\", W/" + etag)
+	assert.Equal(t, 304, recorder.Code)
+
+	// Check that the tag changes along with the data.
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114})
+	recorder = getWithETag(etag)
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotEqual(t, etag, recorder.Header().Get("ETag"))
+}
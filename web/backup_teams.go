@@ -0,0 +1,110 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for calling up backup teams to replace members of playoff alliances.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// Shows the backup team call-up page.
+func (web *Web) backupTeamsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderBackupTeams(w, r, "")
+}
+
+// Calls up the selected backup team to replace a member of the selected alliance.
+func (web *Web) backupTeamsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	allianceId, _ := strconv.Atoi(r.PostFormValue("allianceId"))
+	backupTeamId, _ := strconv.Atoi(r.PostFormValue("backupTeamId"))
+	replacedTeamId, _ := strconv.Atoi(r.PostFormValue("replacedTeamId"))
+
+	backupTeams, pickedBackups, err := web.findAvailableBackupTeams()
+	if err != nil {
+		web.renderBackupTeams(w, r, err.Error())
+		return
+	}
+	available := false
+	for _, backupTeam := range backupTeams {
+		if backupTeam.TeamId == backupTeamId && !pickedBackups[backupTeamId] {
+			available = true
+			break
+		}
+	}
+	if !available {
+		web.renderBackupTeams(w, r, fmt.Sprintf("Team %d is not an available backup.", backupTeamId))
+		return
+	}
+
+	if err = web.arena.CallUpBackup(allianceId, backupTeamId, replacedTeamId); err != nil {
+		web.renderBackupTeams(w, r, err.Error())
+		return
+	}
+
+	if web.arena.ShouldPublishToTba() {
+		if err = web.arena.TbaClient.PublishAlliances(web.arena.Database); err != nil {
+			web.renderBackupTeams(w, r, fmt.Sprintf("Failed to publish alliances: %s", err.Error()))
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/backup_teams", 303)
+}
+
+func (web *Web) renderBackupTeams(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	alliances, err := web.arena.Database.GetAllAlliances()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var backupTeams game.Rankings
+	var pickedBackups map[int]bool
+	if len(alliances) > 0 {
+		backupTeams, pickedBackups, err = web.findAvailableBackupTeams()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	template, err := web.parseFiles("templates/backup_teams.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Alliances     []model.Alliance
+		BackupTeams   game.Rankings
+		PickedBackups map[int]bool
+		ErrorMessage  string
+	}{web.arena.EventSettings, alliances, backupTeams, pickedBackups, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns the ranked list of teams that aren't members of any alliance, along with the set of those that have already
+// been called up.
+func (web *Web) findAvailableBackupTeams() (game.Rankings, map[int]bool, error) {
+	rankings, err := web.arena.Database.GetAllRankings()
+	if err != nil {
+		return nil, nil, err
+	}
+	return web.findBackupTeams(rankings)
+}
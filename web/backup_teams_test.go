@@ -0,0 +1,83 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBackupTeams(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/backup_teams")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Backup teams can't be called up until alliance selection is complete.")
+
+	tournament.CreateTestAlliances(web.arena.Database, 8)
+	assert.Nil(t, web.arena.CreatePlayoffTournament())
+	assert.Nil(t, web.arena.CreatePlayoffMatches(time.Unix(0, 0)))
+	for i := 1; i <= 7; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: 100 + i, Withdrawn: i == 7})
+		web.arena.Database.CreateRanking(&game.Ranking{TeamId: 100 + i, Rank: i})
+	}
+	recorder = web.getHttpResponse("/backup_teams")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "105 (Rank 5)")
+	assert.Contains(t, recorder.Body.String(), "106 (Rank 6)")
+	assert.NotContains(t, recorder.Body.String(), "104 (Rank 4)")
+	assert.NotContains(t, recorder.Body.String(), "107 (Rank 7)")
+
+	// Teams that have withdrawn from the event can't be called up either.
+	recorder = web.postHttpResponse("/backup_teams", "allianceId=2&backupTeamId=107&replacedTeamId=202")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 107 is not an available backup.")
+
+	// Teams that are already on an alliance can't be called up as backups.
+	recorder = web.postHttpResponse("/backup_teams", "allianceId=2&backupTeamId=104&replacedTeamId=202")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 104 is not an available backup.")
+
+	recorder = web.postHttpResponse("/backup_teams", "allianceId=2&backupTeamId=105&replacedTeamId=101")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 101 is not a member of alliance 2.")
+
+	recorder = web.postHttpResponse("/backup_teams", "allianceId=2&backupTeamId=105&replacedTeamId=202")
+	assert.Equal(t, 303, recorder.Code)
+	alliance, _ := web.arena.Database.GetAllianceById(2)
+	assert.Equal(t, 105, alliance.BackupTeamId)
+	assert.Equal(t, 202, alliance.ReplacedTeamId)
+	assert.Equal(t, [3]int{105, 201, 203}, alliance.Lineup)
+
+	// The backup is badged on the displays for the alliance's matches.
+	matches, _ := web.arena.Database.GetMatchesByType(model.Playoff, false)
+	for _, match := range matches {
+		if match.PlayoffRedAlliance == 2 || match.PlayoffBlueAlliance == 2 {
+			assert.Nil(t, web.arena.LoadMatch(&match))
+			break
+		}
+	}
+	recorder = web.getHttpResponse("/displays/queueing/match_load")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "105<span class=\"badge bg-warning text-dark backup-badge\">B</span>")
+	recorder = web.getHttpResponse("/displays/announcer/match_load")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Backup</span>")
+	recorder = web.getHttpResponse("/api/bracket/svg?activeMatch=current")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Regexp(t, `class="teamnum [rb] backup">105<`, recorder.Body.String())
+
+	// A backup can only be called up once.
+	recorder = web.getHttpResponse("/backup_teams")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "replaced 202")
+	assert.NotContains(t, recorder.Body.String(), "105 (Rank 5)")
+	recorder = web.postHttpResponse("/backup_teams", "allianceId=1&backupTeamId=105&replacedTeamId=102")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 105 is not an available backup.")
+}
@@ -16,7 +16,7 @@ import (
 
 // Renders the field monitor display.
 func (web *Web) fieldMonitorDisplayHandler(w http.ResponseWriter, r *http.Request) {
-	if r.URL.Query().Get("fta") == "true" && !web.userIsAdmin(w, r) {
+	if r.URL.Query().Get("fta") == "true" && !web.userHasRole(w, r, model.FtaRole) {
 		return
 	}
 
@@ -42,7 +42,7 @@ func (web *Web) fieldMonitorDisplayHandler(w http.ResponseWriter, r *http.Reques
 // The websocket endpoint for the field monitor display client to receive status updates.
 func (web *Web) fieldMonitorDisplayWebsocketHandler(w http.ResponseWriter, r *http.Request) {
 	isFta := r.URL.Query().Get("fta") == "true"
-	if isFta && !web.userIsAdmin(w, r) {
+	if isFta && !web.userHasRole(w, r, model.FtaRole) {
 		return
 	}
 
@@ -85,7 +85,9 @@ func (web *Web) fieldMonitorDisplayWebsocketHandler(w http.ResponseWriter, r *ht
 		}
 
 		if command == "updateTeamNotes" {
-			if isFta {
+			if web.arena.IsPassive() {
+				writeWebsocketError(ws, "Cannot update team notes while this server is a standby")
+			} else if isFta {
 				args := struct {
 					Station string
 					Notes   string
@@ -10,8 +10,10 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/google/uuid"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 )
 
@@ -28,13 +30,30 @@ func (web *Web) loginPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := model.UserSession{Token: uuid.New().String(), Username: username, CreatedAt: time.Now()}
+	session := model.UserSession{
+		Token:     uuid.New().String(),
+		Username:  username,
+		CreatedAt: time.Now(),
+		IpAddress: getRemoteIp(r),
+		UserAgent: r.UserAgent(),
+	}
 	if err := web.arena.Database.CreateUserSession(&session); err != nil {
 		handleWebErr(w, err)
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{Name: sessionTokenCookie, Value: session.Token})
+	cookie := http.Cookie{
+		Name:     sessionTokenCookie,
+		Value:    session.Token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   isSecureRequest(r),
+		SameSite: http.SameSiteLaxMode,
+	}
+	if lifetimeHours := web.arena.EventSettings.SessionLifetimeHours; lifetimeHours > 0 {
+		cookie.MaxAge = lifetimeHours * 3600
+	}
+	http.SetCookie(w, &cookie)
 	redirectUrl := r.URL.Query().Get("redirect")
 	if redirectUrl == "" {
 		redirectUrl = "/"
@@ -42,6 +61,30 @@ func (web *Web) loginPostHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, redirectUrl, 303)
 }
 
+// Deletes the current user session, if there is one, and clears the session cookie.
+func (web *Web) logoutHandler(w http.ResponseWriter, r *http.Request) {
+	if session := web.getUserSessionFromCookie(r); session != nil {
+		if err := web.arena.Database.DeleteUserSession(session.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.SetCookie(
+		w,
+		&http.Cookie{
+			Name:     sessionTokenCookie,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   isSecureRequest(r),
+			SameSite: http.SameSiteLaxMode,
+		},
+	)
+	http.Redirect(w, r, "/login", 303)
+}
+
 func (web *Web) renderLogin(w http.ResponseWriter, r *http.Request, errorMessage string) {
 	template, err := web.parseFiles("templates/login.html", "templates/base.html")
 	if err != nil {
@@ -61,14 +104,17 @@ func (web *Web) renderLogin(w http.ResponseWriter, r *http.Request, errorMessage
 
 // Returns true if the given user is authorized for admin operations. Used for HTTP cookie authentication.
 func (web *Web) userIsAdmin(w http.ResponseWriter, r *http.Request) bool {
+	return web.userHasRole(w, r)
+}
+
+// Returns true if the given user holds any of the given roles or is an admin. Used for HTTP cookie authentication.
+func (web *Web) userHasRole(w http.ResponseWriter, r *http.Request, roles ...model.Role) bool {
 	if web.arena.EventSettings.AdminPassword == "" {
 		// Disable auth if there is no password configured.
 		return true
 	}
 	session := web.getUserSessionFromCookie(r)
-	if session != nil && session.Username == adminUser {
-		return true
-	} else {
+	if session == nil {
 		redirect := r.URL.Path
 		if r.URL.RawQuery != "" {
 			redirect += "?" + r.URL.RawQuery
@@ -76,6 +122,42 @@ func (web *Web) userIsAdmin(w http.ResponseWriter, r *http.Request) bool {
 		http.Redirect(w, r, "/login?redirect="+url.QueryEscape(redirect), 307)
 		return false
 	}
+
+	userRoles, err := web.getUserRoles(session.Username)
+	if err != nil {
+		handleWebErr(w, err)
+		return false
+	}
+	if slices.Contains(userRoles, model.AdminRole) {
+		return true
+	}
+	for _, role := range roles {
+		if slices.Contains(userRoles, role) {
+			return true
+		}
+	}
+	http.Error(w, "You do not have permission to access this page.", http.StatusForbidden)
+	return false
+}
+
+// Returns the username of the logged-in user making the given request, or the empty string if there is none.
+func (web *Web) getUsername(r *http.Request) string {
+	if session := web.getUserSessionFromCookie(r); session != nil {
+		return session.Username
+	}
+	return ""
+}
+
+// Returns the roles held by the user having the given username.
+func (web *Web) getUserRoles(username string) ([]model.Role, error) {
+	if username == adminUser {
+		return []model.Role{model.AdminRole}, nil
+	}
+	user, err := web.arena.Database.GetUserByUsername(username)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return user.Roles, nil
 }
 
 func (web *Web) getUserSessionFromCookie(r *http.Request) *model.UserSession {
@@ -87,13 +169,42 @@ func (web *Web) getUserSessionFromCookie(r *http.Request) *model.UserSession {
 	if err != nil {
 		log.Printf("Failed to get user session by token: %v", err)
 	}
+	if session != nil && session.IsExpired(web.arena.EventSettings.SessionLifetimeHours) {
+		if err = web.arena.Database.DeleteUserSession(session.Id); err != nil {
+			log.Printf("Failed to delete expired user session: %v", err)
+		}
+		return nil
+	}
 	return session
 }
 
+// Returns the IP address of the client that made the given request, without the port.
+func getRemoteIp(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Returns true if the given request arrived over HTTPS, either directly or via a TLS-terminating reverse proxy.
+func isSecureRequest(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
+
 func (web *Web) checkAuthPassword(user, password string) error {
-	if user == adminUser && password == web.arena.EventSettings.AdminPassword {
-		return nil
-	} else {
-		return fmt.Errorf("Invalid login credentials.")
+	if user == adminUser {
+		if password == web.arena.EventSettings.AdminPassword {
+			return nil
+		}
+	} else if password != "" {
+		userAccount, err := web.arena.Database.GetUserByUsername(user)
+		if err != nil {
+			return err
+		}
+		if userAccount != nil && userAccount.CheckPassword(password) {
+			return nil
+		}
 	}
+	return fmt.Errorf("Invalid login credentials.")
 }
@@ -4,8 +4,13 @@
 package web
 
 import (
+	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoginDisplay(t *testing.T) {
@@ -48,3 +53,111 @@ func TestLoginDisplay(t *testing.T) {
 	recorder = web.getHttpResponseWithHeaders("/match_play?p1=v1&p2=v2", map[string]string{"Cookie": cookie})
 	assert.Equal(t, 200, recorder.Code)
 }
+
+func TestLoginWithRoles(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.AdminPassword = "admin"
+	user := model.User{Username: "redscorer", Roles: []model.Role{model.ScorerRole}}
+	assert.Nil(t, user.SetPassword("fuel"))
+	assert.Nil(t, web.arena.Database.CreateUser(&user))
+
+	// Check logging in with the wrong password.
+	recorder := web.postHttpResponse("/login", "username=redscorer&password=admin")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid login credentials.")
+
+	// Check logging in with the right password.
+	recorder = web.postHttpResponse("/login?redirect=%2Fpanels%2Fscoring%2Fred", "username=redscorer&password=fuel")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "/panels/scoring/red", recorder.Header().Get("Location"))
+	cookie := recorder.Header().Get("Set-Cookie")
+	assert.Contains(t, cookie, "session_token=")
+
+	// Check that the user can reach pages permitted by their role but not others.
+	headers := map[string]string{"Cookie": cookie}
+	recorder = web.getHttpResponseWithHeaders("/panels/scoring/red", headers)
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/panels/referee", headers)
+	assert.Equal(t, 403, recorder.Code)
+	recorder = web.getHttpResponseWithHeaders("/setup/settings", headers)
+	assert.Equal(t, 403, recorder.Code)
+
+	// Check that a change in roles takes effect immediately.
+	user.Roles = []model.Role{model.HeadRefereeRole}
+	assert.Nil(t, web.arena.Database.UpdateUser(&user))
+	recorder = web.getHttpResponseWithHeaders("/panels/referee", headers)
+	assert.Equal(t, 200, recorder.Code)
+	user.Roles = []model.Role{model.AdminRole}
+	assert.Nil(t, web.arena.Database.UpdateUser(&user))
+	recorder = web.getHttpResponseWithHeaders("/setup/settings", headers)
+	assert.Equal(t, 200, recorder.Code)
+
+	// Check that deleting the user revokes their access.
+	assert.Nil(t, web.arena.Database.DeleteUser(user.Id))
+	recorder = web.getHttpResponseWithHeaders("/panels/scoring/red", headers)
+	assert.Equal(t, 403, recorder.Code)
+}
+
+func TestLoginCookieAndLogout(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.AdminPassword = "admin"
+	web.arena.EventSettings.SessionLifetimeHours = 2
+
+	recorder := web.postHttpResponse("/login", "username=admin&password=admin")
+	assert.Equal(t, 303, recorder.Code)
+	cookie := recorder.Header().Get("Set-Cookie")
+	assert.Contains(t, cookie, "Path=/")
+	assert.Contains(t, cookie, "Max-Age=7200")
+	assert.Contains(t, cookie, "HttpOnly")
+	assert.Contains(t, cookie, "SameSite=Lax")
+	assert.NotContains(t, cookie, "Secure")
+	sessions, _ := web.arena.Database.GetAllUserSessions()
+	if assert.Equal(t, 1, len(sessions)) {
+		assert.Equal(t, "admin", sessions[0].Username)
+	}
+	headers := map[string]string{"Cookie": cookie}
+	recorder = web.getHttpResponseWithHeaders("/match_play", headers)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "/logout")
+
+	// Check that logging out deletes the session and clears the cookie.
+	// Check that a GET, such as a prefetched link, doesn't log the user out.
+	recorder = web.getHttpResponseWithHeaders("/logout", headers)
+	assert.Empty(t, recorder.Header().Get("Set-Cookie"))
+	sessions, _ = web.arena.Database.GetAllUserSessions()
+	assert.Equal(t, 1, len(sessions))
+	recorder = web.postHttpResponseWithHeaders("/logout", "", headers)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "/login", recorder.Header().Get("Location"))
+	assert.Contains(t, recorder.Header().Get("Set-Cookie"), "Max-Age=0")
+	sessions, _ = web.arena.Database.GetAllUserSessions()
+	assert.Empty(t, sessions)
+	recorder = web.getHttpResponseWithHeaders("/match_play", headers)
+	assert.Equal(t, 307, recorder.Code)
+}
+
+func TestLoginSecureCookie(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.AdminPassword = "admin"
+
+	recorder := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/login", strings.NewReader("username=admin&password=admin"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Forwarded-Proto", "https")
+	web.newHandler().ServeHTTP(recorder, req)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Set-Cookie"), "Secure")
+}
+
+func TestExpiredSession(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.AdminPassword = "admin"
+	web.arena.EventSettings.SessionLifetimeHours = 1
+
+	session := model.UserSession{Token: "token1", Username: "admin", CreatedAt: time.Now().Add(-2 * time.Hour)}
+	assert.Nil(t, web.arena.Database.CreateUserSession(&session))
+	recorder := web.getHttpResponseWithHeaders("/match_play", map[string]string{"Cookie": "session_token=token1"})
+	assert.Equal(t, 307, recorder.Code)
+	session2, _ := web.arena.Database.GetUserSessionByToken("token1")
+	assert.Nil(t, session2)
+}
@@ -11,7 +11,9 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
@@ -52,12 +54,44 @@ type MatchLogRow struct {
 	DsReportedTeleop      bool
 	DsReportedDisabled    bool
 	DsReportedEnabled     bool
+	MissedPacketDelta     int
 }
 
 type MatchLog struct {
-	Filename  string
-	StartTime string
-	Rows      []MatchLogRow
+	Filename       string
+	StartTime      string
+	MatchType      string
+	MatchShortName string
+	Rows           []MatchLogRow
+	Events         []MatchLogEvent
+	Summary        MatchLogSummary
+}
+
+// MatchLogPeriod represents a span of match time, such as autonomous or a teleop shift, for overlaying on graphs.
+type MatchLogPeriod struct {
+	Name     string
+	StartSec float64
+	EndSec   float64
+}
+
+// MatchLogEvent represents a span of match time during which a team was in an abnormal state.
+type MatchLogEvent struct {
+	Type     string
+	StartSec float64
+	EndSec   float64
+}
+
+// MatchLogSummary contains the aggregate statistics for a single match log, for comparing a team across matches.
+type MatchLogSummary struct {
+	MinBatteryVoltage  float64
+	BrownoutCount      int
+	MaxTripTimeMs      int
+	AverageTripTimeMs  float64
+	MissedPacketCount  int
+	RobotLinkDropCount int
+	RobotLinkedPercent float64
+	EmergencyStopped   bool
+	AutonomousStopped  bool
 }
 
 type MatchLogs struct {
@@ -66,6 +100,16 @@ type MatchLogs struct {
 	Logs            []MatchLog
 }
 
+const (
+	matchLogEventEmergencyStop  = "E-Stop"
+	matchLogEventAutonomousStop = "A-Stop"
+	matchLogEventRobotLinkLost  = "Robot Link Lost"
+	matchLogEventBrownout       = "Brownout"
+
+	// Battery voltage below which a linked robot is considered to be browning out.
+	matchLogBrownoutVoltage = 7.0
+)
+
 // Shows the match Log interface.
 func (web *Web) matchLogsHandler(w http.ResponseWriter, r *http.Request) {
 	practiceMatches, err := web.buildMatchLogsList(model.Practice)
@@ -132,7 +176,8 @@ func (web *Web) matchLogsViewGetHandler(w http.ResponseWriter, r *http.Request)
 		Match      *model.Match
 		MatchLogs  *MatchLogs
 		FirstMatch string
-	}{web.arena.EventSettings, match, matchLogs, firstMatch}
+		Periods    []MatchLogPeriod
+	}{web.arena.EventSettings, match, matchLogs, firstMatch, buildMatchLogPeriods()}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -173,8 +218,6 @@ func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLog
 	case "B3":
 		logs.TeamId = match.Blue3
 	}
-	// rows []MatchLogRow
-	// Load a csv file.
 	if logs.TeamId == 0 {
 		return nil, nil, false, nil
 	}
@@ -190,80 +233,272 @@ func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLog
 	}
 
 	for _, filename := range files {
-		err := func() (err error) {
-			f, err := os.Open(filename)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				if closeErr := f.Close(); err == nil && closeErr != nil {
-					err = closeErr
-				}
-			}()
+		matchLog, err := readMatchLogFile(filename)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		logs.Logs = append(logs.Logs, *matchLog)
+	}
+	return match, &logs, false, nil
+}
 
-			// Create a new reader.
-			reader := csv.NewReader(f)
+// Shows the page comparing the logs of a single team across all of their matches.
+func (web *Web) teamMatchLogsGetHandler(w http.ResponseWriter, r *http.Request) {
+	teamId, err := strconv.Atoi(r.PathValue("teamId"))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	matchLogs, err := getTeamMatchLogs(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 
-			// Read row
-			header, err := reader.Read()
-			if err != nil {
-				return err
-			}
+	template, err := web.parseFiles("templates/team_match_logs.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		MatchLogs *MatchLogs
+		Periods   []MatchLogPeriod
+	}{web.arena.EventSettings, matchLogs, buildMatchLogPeriods()}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
 
-			// Add mapping: Column/property name --> record index
-			headerMap := make(map[string]int)
-			for i, v := range header {
-				headerMap[v] = i
-			}
-			records, err := reader.ReadAll()
-			if err != nil {
-				return err
-			}
+// Loads all of the match logs for the given team, in chronological order.
+func getTeamMatchLogs(teamId int) (*MatchLogs, error) {
+	files, err := filepath.Glob(
+		filepath.Join(".", "static", "logs", "*_*_Match_*_"+strconv.Itoa(teamId)+".csv"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
 
-			var curlog = MatchLog{
-				Filename:  filename,
-				StartTime: filename[12:26],
-				Rows:      make([]MatchLogRow, len(records)),
-			}
-			for i, record := range records {
-				var curRow MatchLogRow
-				curRow.MatchTimeSec = parseOptionalFloat(record, headerMap, "matchTimeSec", 0)
-				curRow.PacketType = parseOptionalInt(record, headerMap, "packetType", 0)
-				curRow.TeamId = parseOptionalInt(record, headerMap, "teamId", 0)
-				curRow.AllianceStation = parseOptionalString(record, headerMap, "allianceStation", "")
-				curRow.DsLinked = parseOptionalBool(record, headerMap, "dsLinked", false)
-				curRow.RadioLinked = parseOptionalBool(record, headerMap, "radioLinked", false)
-				curRow.RioLinked = parseOptionalBool(record, headerMap, "rioLinked", false)
-				curRow.RobotLinked = parseOptionalBool(record, headerMap, "robotLinked", false)
-				curRow.Auto = parseOptionalBool(record, headerMap, "auto", false)
-				curRow.Enabled = parseOptionalBool(record, headerMap, "enabled", false)
-				curRow.EmergencyStop = parseOptionalBool(record, headerMap, "emergencyStop", false)
-				curRow.AutonomousStop = parseOptionalBool(record, headerMap, "autonomousStop", false)
-				curRow.BatteryVoltage = parseOptionalFloat(record, headerMap, "batteryVoltage", 0)
-				curRow.MissedPacketCount = parseOptionalInt(record, headerMap, "missedPacketCount", 0)
-				curRow.DsRobotTripTimeMs = parseOptionalInt(record, headerMap, "dsRobotTripTimeMs", 0)
-				curRow.TxRate = parseOptionalFloat(record, headerMap, "txRate", -1)
-				curRow.RxRate = parseOptionalFloat(record, headerMap, "rxRate", -1)
-				curRow.SignalNoiseRatio = parseOptionalInt(record, headerMap, "signalNoiseRatio", -1)
-				curRow.EthernetConnected = parseOptionalBool(record, headerMap, "ethernetConnected", false)
-				curRow.DsReportedStatusValid = parseOptionalBool(record, headerMap, "dsReportedStatusValid", false)
-				curRow.DsReportedAuto = parseOptionalBool(record, headerMap, "dsReportedAuto", false)
-				curRow.DsReportedTeleop = parseOptionalBool(record, headerMap, "dsReportedTeleop", false)
-				curRow.DsReportedDisabled = parseOptionalBool(record, headerMap, "dsReportedDisabled", false)
-				curRow.DsReportedEnabled = parseOptionalBool(record, headerMap, "dsReportedEnabled", false)
-
-				// Store the parsed row in the same position as the CSV record.
-				curlog.Rows[i] = curRow
+	logs := MatchLogs{TeamId: teamId}
+	for _, filename := range files {
+		matchLog, err := readMatchLogFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		logs.Logs = append(logs.Logs, *matchLog)
+	}
+	return &logs, nil
+}
+
+// Parses the given team match log CSV file and computes the events and summary statistics for it.
+func readMatchLogFile(filename string) (matchLog *MatchLog, err error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}()
+
+	reader := csv.NewReader(f)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	// Add mapping: Column/property name --> record index
+	headerMap := make(map[string]int)
+	for i, v := range header {
+		headerMap[v] = i
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	matchLog = &MatchLog{Filename: filename, Rows: make([]MatchLogRow, len(records))}
+
+	// Filenames are of the form <timestamp>_<match type>_Match_<match short name>_<team ID>.csv.
+	nameParts := strings.Split(strings.TrimSuffix(filepath.Base(filename), ".csv"), "_")
+	if len(nameParts) >= 5 {
+		matchLog.StartTime = nameParts[0]
+		matchLog.MatchType = nameParts[1]
+		matchLog.MatchShortName = strings.Join(nameParts[3:len(nameParts)-1], "_")
+	}
+
+	for i, record := range records {
+		var curRow MatchLogRow
+		curRow.MatchTimeSec = parseOptionalFloat(record, headerMap, "matchTimeSec", 0)
+		curRow.PacketType = parseOptionalInt(record, headerMap, "packetType", 0)
+		curRow.TeamId = parseOptionalInt(record, headerMap, "teamId", 0)
+		curRow.AllianceStation = parseOptionalString(record, headerMap, "allianceStation", "")
+		curRow.DsLinked = parseOptionalBool(record, headerMap, "dsLinked", false)
+		curRow.RadioLinked = parseOptionalBool(record, headerMap, "radioLinked", false)
+		curRow.RioLinked = parseOptionalBool(record, headerMap, "rioLinked", false)
+		curRow.RobotLinked = parseOptionalBool(record, headerMap, "robotLinked", false)
+		curRow.Auto = parseOptionalBool(record, headerMap, "auto", false)
+		curRow.Enabled = parseOptionalBool(record, headerMap, "enabled", false)
+		curRow.EmergencyStop = parseOptionalBool(record, headerMap, "emergencyStop", false)
+		curRow.AutonomousStop = parseOptionalBool(record, headerMap, "autonomousStop", false)
+		curRow.BatteryVoltage = parseOptionalFloat(record, headerMap, "batteryVoltage", 0)
+		curRow.MissedPacketCount = parseOptionalInt(record, headerMap, "missedPacketCount", 0)
+		curRow.DsRobotTripTimeMs = parseOptionalInt(record, headerMap, "dsRobotTripTimeMs", 0)
+		curRow.TxRate = parseOptionalFloat(record, headerMap, "txRate", -1)
+		curRow.RxRate = parseOptionalFloat(record, headerMap, "rxRate", -1)
+		curRow.SignalNoiseRatio = parseOptionalInt(record, headerMap, "signalNoiseRatio", -1)
+		curRow.EthernetConnected = parseOptionalBool(record, headerMap, "ethernetConnected", false)
+		curRow.DsReportedStatusValid = parseOptionalBool(record, headerMap, "dsReportedStatusValid", false)
+		curRow.DsReportedAuto = parseOptionalBool(record, headerMap, "dsReportedAuto", false)
+		curRow.DsReportedTeleop = parseOptionalBool(record, headerMap, "dsReportedTeleop", false)
+		curRow.DsReportedDisabled = parseOptionalBool(record, headerMap, "dsReportedDisabled", false)
+		curRow.DsReportedEnabled = parseOptionalBool(record, headerMap, "dsReportedEnabled", false)
+
+		// The missed packet count is cumulative over the match, so also track how many were missed since the last row.
+		if i > 0 {
+			curRow.MissedPacketDelta = max(curRow.MissedPacketCount-matchLog.Rows[i-1].MissedPacketCount, 0)
+		}
+
+		// Store the parsed row in the same position as the CSV record.
+		matchLog.Rows[i] = curRow
+	}
+
+	matchLog.Events = findMatchLogEvents(matchLog.Rows)
+	matchLog.Summary = summarizeMatchLog(matchLog.Rows, matchLog.Events)
+	return matchLog, nil
+}
+
+// Returns the spans of match time for each period of the match, based on the current match timing settings.
+func buildMatchLogPeriods() []MatchLogPeriod {
+	timing := game.MatchTiming
+	type periodDuration struct {
+		name        string
+		durationSec int
+	}
+	durations := []periodDuration{
+		{"Auto", timing.AutoDurationSec},
+		{"Pause", timing.PauseDurationSec},
+	}
+	shiftedTeleopDurationSec := timing.TransitionShiftDurationSec + 4*timing.ShiftDurationSec +
+		timing.EndgameDurationSec
+	if timing.ShiftDurationSec > 0 && shiftedTeleopDurationSec == game.GetTeleopDurationSec() {
+		durations = append(durations, periodDuration{"Transition", timing.TransitionShiftDurationSec})
+		for i := 1; i <= 4; i++ {
+			durations = append(durations, periodDuration{fmt.Sprintf("Shift %d", i), timing.ShiftDurationSec})
+		}
+		durations = append(durations, periodDuration{"Endgame", timing.EndgameDurationSec})
+	} else {
+		durations = append(durations, periodDuration{"Teleop", game.GetTeleopDurationSec()})
+	}
+
+	var periods []MatchLogPeriod
+	startSec := 0
+	for _, duration := range durations {
+		if duration.durationSec <= 0 {
+			continue
+		}
+		periods = append(
+			periods,
+			MatchLogPeriod{
+				Name: duration.name, StartSec: float64(startSec), EndSec: float64(startSec + duration.durationSec),
+			},
+		)
+		startSec += duration.durationSec
+	}
+	return periods
+}
+
+// Returns the spans of match time during which the team was stopped, lost its robot link, or was browning out.
+func findMatchLogEvents(rows []MatchLogRow) []MatchLogEvent {
+	everLinked := false
+	conditions := []struct {
+		eventType string
+		isActive  func(row *MatchLogRow) bool
+	}{
+		{matchLogEventEmergencyStop, func(row *MatchLogRow) bool { return row.EmergencyStop }},
+		{matchLogEventAutonomousStop, func(row *MatchLogRow) bool { return row.AutonomousStop }},
+		{
+			matchLogEventRobotLinkLost,
+			func(row *MatchLogRow) bool {
+				// Only count a lost link once the robot has connected, so that a no-show isn't flagged all match.
+				everLinked = everLinked || row.RobotLinked
+				return everLinked && !row.RobotLinked
+			},
+		},
+		{
+			matchLogEventBrownout,
+			func(row *MatchLogRow) bool {
+				return row.RobotLinked && row.BatteryVoltage > 0 && row.BatteryVoltage < matchLogBrownoutVoltage
+			},
+		},
+	}
+
+	var events []MatchLogEvent
+	for _, condition := range conditions {
+		var currentEvent *MatchLogEvent
+		for i := range rows {
+			row := &rows[i]
+			if condition.isActive(row) {
+				if currentEvent == nil {
+					currentEvent = &MatchLogEvent{Type: condition.eventType, StartSec: row.MatchTimeSec}
+				}
+				currentEvent.EndSec = row.MatchTimeSec
+			} else if currentEvent != nil {
+				currentEvent.EndSec = row.MatchTimeSec
+				events = append(events, *currentEvent)
+				currentEvent = nil
 			}
+		}
+		if currentEvent != nil {
+			events = append(events, *currentEvent)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartSec < events[j].StartSec
+	})
+	return events
+}
 
-			logs.Logs = append(logs.Logs, curlog)
-			return nil
-		}()
-		if err != nil {
-			return nil, nil, false, err
+// Computes the aggregate statistics for the given match log rows and the events derived from them.
+func summarizeMatchLog(rows []MatchLogRow, events []MatchLogEvent) MatchLogSummary {
+	var summary MatchLogSummary
+	linkedRows := 0
+	totalTripTimeMs := 0
+	for _, row := range rows {
+		summary.MissedPacketCount = max(summary.MissedPacketCount, row.MissedPacketCount)
+		if !row.RobotLinked {
+			continue
+		}
+		linkedRows++
+		totalTripTimeMs += row.DsRobotTripTimeMs
+		summary.MaxTripTimeMs = max(summary.MaxTripTimeMs, row.DsRobotTripTimeMs)
+		if row.BatteryVoltage > 0 &&
+			(summary.MinBatteryVoltage == 0 || row.BatteryVoltage < summary.MinBatteryVoltage) {
+			summary.MinBatteryVoltage = row.BatteryVoltage
 		}
 	}
-	return match, &logs, false, nil
+	if linkedRows > 0 {
+		summary.AverageTripTimeMs = float64(totalTripTimeMs) / float64(linkedRows)
+		summary.RobotLinkedPercent = 100 * float64(linkedRows) / float64(len(rows))
+	}
+
+	for _, event := range events {
+		switch event.Type {
+		case matchLogEventEmergencyStop:
+			summary.EmergencyStopped = true
+		case matchLogEventAutonomousStop:
+			summary.AutonomousStopped = true
+		case matchLogEventRobotLinkLost:
+			summary.RobotLinkDropCount++
+		case matchLogEventBrownout:
+			summary.BrownoutCount++
+		}
+	}
+	return summary
 }
 
 // parseOptionalString returns a CSV value by column name, or a default for legacy files that lack the column.
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
 )
@@ -83,4 +84,107 @@ func TestGetMatchLogFromRequestParsesLegacyAndNewColumns(t *testing.T) {
 			assert.False(t, newRow.DsReportedEnabled)
 		}
 	}
+
+	recorder := web.getHttpResponse("/match_logs/" + strconv.Itoa(match.Id) + "/R1/log")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "renderMatchLog")
+	assert.Contains(t, recorder.Body.String(), "/match_logs/team/9998")
+}
+
+func TestReadMatchLogFileComputesEventsAndSummary(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "20260607120000_Qualification_Match_Q12_254.csv")
+	csvData := "matchTimeSec,robotLinked,emergencyStop,autonomousStop,batteryVoltage,missedPacketCount," +
+		"dsRobotTripTimeMs\n" +
+		"0.0,false,false,false,0,0,0\n" +
+		"0.5,true,false,false,12.5,0,4\n" +
+		"1.0,true,false,true,6.5,3,8\n" +
+		"1.5,false,false,true,0,10,0\n" +
+		"2.0,true,false,false,12.0,12,6\n" +
+		"2.5,true,true,false,11.5,12,6\n"
+	assert.Nil(t, os.WriteFile(filename, []byte(csvData), 0644))
+
+	matchLog, err := readMatchLogFile(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, "20260607120000", matchLog.StartTime)
+	assert.Equal(t, "Qualification", matchLog.MatchType)
+	assert.Equal(t, "Q12", matchLog.MatchShortName)
+	if assert.Len(t, matchLog.Rows, 6) {
+		assert.Equal(t, 3, matchLog.Rows[2].MissedPacketDelta)
+		assert.Equal(t, 7, matchLog.Rows[3].MissedPacketDelta)
+		assert.Equal(t, 0, matchLog.Rows[5].MissedPacketDelta)
+	}
+
+	assert.Equal(
+		t,
+		[]MatchLogEvent{
+			{Type: matchLogEventAutonomousStop, StartSec: 1.0, EndSec: 2.0},
+			{Type: matchLogEventBrownout, StartSec: 1.0, EndSec: 1.5},
+			{Type: matchLogEventRobotLinkLost, StartSec: 1.5, EndSec: 2.0},
+			{Type: matchLogEventEmergencyStop, StartSec: 2.5, EndSec: 2.5},
+		},
+		matchLog.Events,
+	)
+	summary := matchLog.Summary
+	assert.Equal(t, 6.5, summary.MinBatteryVoltage)
+	assert.Equal(t, 1, summary.BrownoutCount)
+	assert.Equal(t, 8, summary.MaxTripTimeMs)
+	assert.Equal(t, 6.0, summary.AverageTripTimeMs)
+	assert.Equal(t, 12, summary.MissedPacketCount)
+	assert.Equal(t, 1, summary.RobotLinkDropCount)
+	assert.InDelta(t, 66.7, summary.RobotLinkedPercent, 0.1)
+	assert.True(t, summary.EmergencyStopped)
+	assert.True(t, summary.AutonomousStopped)
+}
+
+func TestBuildMatchLogPeriods(t *testing.T) {
+	periods := buildMatchLogPeriods()
+	timing := game.MatchTiming
+	if assert.Len(t, periods, 8) {
+		assert.Equal(t, MatchLogPeriod{"Auto", 0, float64(timing.AutoDurationSec)}, periods[0])
+		assert.Equal(t, "Pause", periods[1].Name)
+		assert.Equal(t, "Transition", periods[2].Name)
+		assert.Equal(t, "Shift 1", periods[3].Name)
+		assert.Equal(t, "Endgame", periods[7].Name)
+		assert.Equal(t, game.GetDurationToTeleopEnd().Seconds(), periods[7].EndSec)
+		for i := 1; i < len(periods); i++ {
+			assert.Equal(t, periods[i-1].EndSec, periods[i].StartSec)
+		}
+	}
+}
+
+func TestTeamMatchLogs(t *testing.T) {
+	web := setupTestWeb(t)
+
+	logsDir := filepath.Join(".", "static", "logs")
+	assert.Nil(t, os.MkdirAll(logsDir, 0755))
+	filenames := []string{
+		filepath.Join(logsDir, "20260607130000_Qualification_Match_Q20_9997.csv"),
+		filepath.Join(logsDir, "20260607120000_Practice_Match_P3_9997.csv"),
+		filepath.Join(logsDir, "20260607120000_Practice_Match_P3_19997.csv"),
+	}
+	for _, filename := range filenames {
+		assert.Nil(t, os.WriteFile(filename, []byte("matchTimeSec,robotLinked,batteryVoltage\n1.0,true,12.5\n"), 0644))
+	}
+	t.Cleanup(func() {
+		for _, filename := range filenames {
+			os.Remove(filename)
+		}
+	})
+
+	matchLogs, err := getTeamMatchLogs(9997)
+	assert.Nil(t, err)
+	if assert.Len(t, matchLogs.Logs, 2) {
+		assert.Equal(t, "P3", matchLogs.Logs[0].MatchShortName)
+		assert.Equal(t, "Q20", matchLogs.Logs[1].MatchShortName)
+	}
+
+	recorder := web.getHttpResponse("/match_logs/team/9997")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Match Logs: Team 9997")
+	assert.Contains(t, recorder.Body.String(), "Q20")
+	assert.Contains(t, recorder.Body.String(), "renderTeamMatchLogs")
+
+	recorder = web.getHttpResponse("/match_logs/team/9996")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No match logs found for team 9996.")
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/mitchellh/mapstructure"
@@ -47,10 +48,12 @@ func (web *Web) matchPlayHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	data := struct {
 		*model.EventSettings
+		FieldNumber           int
 		PlcIsEnabled          bool
 		PlcArmorBlockStatuses map[string]bool
 	}{
 		web.arena.EventSettings,
+		web.arena.FieldNumber,
 		web.arena.Plc.IsEnabled(),
 		web.arena.Plc.GetArmorBlockStatuses(),
 	}
@@ -161,25 +164,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				writeWebsocketError(ws, err.Error())
 				continue
 			}
-			err = web.arena.ResetMatch()
-			if err != nil {
-				writeWebsocketError(ws, err.Error())
-				continue
-			}
-			if args.MatchId == 0 {
-				err = web.arena.LoadTestMatch()
-			} else {
-				match, err := web.arena.Database.GetMatchById(args.MatchId)
-				if err != nil {
-					writeWebsocketError(ws, err.Error())
-					continue
-				}
-				if match == nil {
-					writeWebsocketError(ws, fmt.Sprintf("invalid match ID %d", args.MatchId))
-					continue
-				}
-				err = web.arena.LoadMatch(match)
-			}
+			err = web.loadMatchById(args.MatchId)
 			if err != nil {
 				writeWebsocketError(ws, err.Error())
 				continue
@@ -292,12 +277,7 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				continue
 			}
 		case "discardResults":
-			err = web.arena.ResetMatch()
-			if err != nil {
-				writeWebsocketError(ws, err.Error())
-				continue
-			}
-			err = web.arena.LoadNextMatch(false)
+			err = web.discardResults()
 			if err != nil {
 				writeWebsocketError(ws, err.Error())
 				continue
@@ -375,6 +355,32 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// Resets the arena and loads the match with the given ID, or a test match if the ID is zero.
+func (web *Web) loadMatchById(matchId int) error {
+	if err := web.arena.ResetMatch(); err != nil {
+		return err
+	}
+	if matchId == 0 {
+		return web.arena.LoadTestMatch()
+	}
+	match, err := web.arena.Database.GetMatchById(matchId)
+	if err != nil {
+		return err
+	}
+	if match == nil {
+		return fmt.Errorf("invalid match ID %d", matchId)
+	}
+	return web.arena.LoadMatch(match)
+}
+
+// Throws away the results of the match just played, without committing them, and loads the next match.
+func (web *Web) discardResults() error {
+	if err := web.arena.ResetMatch(); err != nil {
+		return err
+	}
+	return web.arena.LoadNextMatch(false)
+}
+
 func (web *Web) commitPostAndLoadNextMatch() error {
 	if web.arena.MatchState != field.PostMatch {
 		return fmt.Errorf("cannot commit match while it is in progress")
@@ -400,6 +406,9 @@ func (web *Web) commitPostAndLoadNextMatch() error {
 
 // Saves the given match and result to the database, supplanting any previous result for the match.
 func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchResult, isMatchReviewEdit bool) error {
+	if web.arena.IsPassive() {
+		return fmt.Errorf("cannot commit a match score while this server is a standby for the primary server")
+	}
 	var updatedRankings game.Rankings
 
 	if match.Type == model.Playoff {
@@ -427,6 +436,7 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 			}
 
 			// Save the match result record to the database.
+			matchResult.CommittedAt = match.ScoreCommittedAt
 			err = web.arena.Database.CreateMatchResult(matchResult)
 			if err != nil {
 				return err
@@ -452,12 +462,25 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 		}
 
 		if match.ShouldUpdateRankings() {
+			oldRankings, err := web.arena.Database.GetAllRankings()
+			if err != nil {
+				return err
+			}
+
 			// Recalculate all the rankings.
 			rankings, err := tournament.CalculateRankings(web.arena.Database, isMatchReviewEdit)
 			if err != nil {
 				return err
 			}
 			updatedRankings = rankings
+
+			if isMatchReviewEdit {
+				// Record the effect of the edit on the rankings alongside the edited result.
+				matchResult.RankingChanges = buildRankingChanges(oldRankings, rankings)
+				if err = web.arena.Database.UpdateMatchResult(matchResult); err != nil {
+					return err
+				}
+			}
 		}
 
 		if match.ShouldUpdatePlayoffMatches() {
@@ -489,7 +512,7 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 			}
 		}
 
-		if web.arena.EventSettings.TbaPublishingEnabled && match.Type != model.Practice {
+		if web.arena.ShouldPublishToTba() && match.Type != model.Practice {
 			// Publish asynchronously to The Blue Alliance.
 			go func() {
 				if err = web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
@@ -517,6 +540,14 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 		if err != nil {
 			log.Println(err)
 		}
+
+		web.arena.SendWebhookEvent(
+			partner.WebhookScorePosted,
+			partner.NewWebhookScore(match, redScoreSummary.Score, blueScoreSummary.Score, isMatchReviewEdit),
+		)
+		if updatedRankings != nil {
+			web.arena.SendWebhookEvent(partner.WebhookRankingsUpdated, partner.NewWebhookRankings(updatedRankings))
+		}
 	}
 
 	if !isMatchReviewEdit {
@@ -568,8 +599,16 @@ func (web *Web) buildMatchPlayList(matchType model.MatchType) (MatchPlayList, er
 		return MatchPlayList{}, err
 	}
 
-	matchPlayList := make(MatchPlayList, len(matches))
-	for i, match := range matches {
+	// Only show the matches that are to be played on this field.
+	var fieldMatches []model.Match
+	for _, match := range matches {
+		if web.arena.IsMatchOnField(&match) {
+			fieldMatches = append(fieldMatches, match)
+		}
+	}
+
+	matchPlayList := make(MatchPlayList, len(fieldMatches))
+	for i, match := range fieldMatches {
 		matchPlayList[i].Id = match.Id
 		matchPlayList[i].ShortName = match.ShortName
 		matchPlayList[i].Time = match.Time.Local().Format("3:04 PM")
@@ -12,6 +12,7 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 type MatchReviewListItem struct {
@@ -86,7 +87,7 @@ func (web *Web) matchReviewHandler(w http.ResponseWriter, r *http.Request) {
 
 // Shows the page to edit the results for a match.
 func (web *Web) matchReviewEditGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.HeadRefereeRole) {
 		return
 	}
 
@@ -135,9 +136,43 @@ func (web *Web) matchReviewEditGetHandler(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// Shows the audit log of scoring commands entered from the scoring and referee panels during a match.
+func (web *Web) matchReviewScoreLogHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userHasRole(w, r, model.HeadRefereeRole) {
+		return
+	}
+
+	match, _, _, err := web.getMatchResultFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	scoreEvents, err := web.arena.Database.GetScoreEventsForMatch(match.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/match_review_score_log.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Match       *model.Match
+		ScoreEvents []model.ScoreEvent
+	}{web.arena.EventSettings, match, scoreEvents}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Calculates score summaries for an in-progress match result without saving it.
 func (web *Web) matchReviewSummaryPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.HeadRefereeRole) {
 		return
 	}
 
@@ -178,11 +213,11 @@ func (web *Web) matchReviewSummaryPostHandler(w http.ResponseWriter, r *http.Req
 
 // Updates the results for a match.
 func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.HeadRefereeRole) {
 		return
 	}
 
-	match, _, isCurrent, err := web.getMatchResultFromRequest(r)
+	match, previousMatchResult, isCurrent, err := web.getMatchResultFromRequest(r)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -210,6 +245,18 @@ func (web *Web) matchReviewEditPostHandler(w http.ResponseWriter, r *http.Reques
 
 		http.Redirect(w, r, "/match_play", 303)
 	} else {
+		editReason := strings.TrimSpace(r.PostFormValue("editReason"))
+		if previousMatchResult.PlayNumber > 0 && editReason == "" {
+			handleWebErr(w, fmt.Errorf("A reason must be given when editing a committed match result."))
+			return
+		}
+
+		// Save the edit as a new play so that the previous result is preserved for comparison.
+		matchResult.Id = 0
+		matchResult.PlayNumber = 0
+		matchResult.EditedBy = web.getUsername(r)
+		matchResult.EditReason = editReason
+		matchResult.RankingChanges = nil
 		err = web.commitMatchScore(match, &matchResult, true)
 		if err != nil {
 			handleWebErr(w, err)
@@ -311,3 +358,29 @@ func (web *Web) buildMatchReviewList(matchType model.MatchType) ([]MatchReviewLi
 
 	return matchReviewList, nil
 }
+
+// Returns the teams whose rank or ranking points differ between the given sets of rankings.
+func buildRankingChanges(oldRankings, newRankings game.Rankings) []model.RankingChange {
+	oldRankingsMap := make(map[int]game.Ranking, len(oldRankings))
+	for _, ranking := range oldRankings {
+		oldRankingsMap[ranking.TeamId] = ranking
+	}
+
+	var rankingChanges []model.RankingChange
+	for _, ranking := range newRankings {
+		oldRanking := oldRankingsMap[ranking.TeamId]
+		if oldRanking.Rank != ranking.Rank || oldRanking.RankingPoints != ranking.RankingPoints {
+			rankingChanges = append(
+				rankingChanges,
+				model.RankingChange{
+					TeamId:                ranking.TeamId,
+					PreviousRank:          oldRanking.Rank,
+					Rank:                  ranking.Rank,
+					PreviousRankingPoints: oldRanking.RankingPoints,
+					RankingPoints:         ranking.RankingPoints,
+				},
+			)
+		}
+	}
+	return rankingChanges
+}
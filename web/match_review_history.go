@@ -0,0 +1,171 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web route for comparing all stored plays of a match side by side.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+type MatchReviewHistoryCell struct {
+	Value     string
+	IsChanged bool
+}
+
+type MatchReviewHistoryRow struct {
+	Label      string
+	Cells      []MatchReviewHistoryCell
+	HasChanges bool
+}
+
+type MatchReviewHistorySection struct {
+	Title string
+	Rows  []MatchReviewHistoryRow
+}
+
+type matchReviewHistoryField struct {
+	label string
+	value string
+}
+
+// Shows every stored play of a match side by side, with the fields that changed from one play to the next
+// highlighted.
+func (web *Web) matchReviewHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userHasRole(w, r, model.HeadRefereeRole) {
+		return
+	}
+
+	match, _, _, err := web.getMatchResultFromRequest(r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	matchResults, err := web.arena.Database.GetMatchResultsForMatch(match.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	template, err := web.parseFiles("templates/match_review_history.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Match        *model.Match
+		MatchResults []model.MatchResult
+		Sections     []MatchReviewHistorySection
+	}{web.arena.EventSettings, match, matchResults, buildMatchReviewHistorySections(matchResults)}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Flattens the scores, cards and score summaries of each play into rows of comparable values.
+func buildMatchReviewHistorySections(matchResults []model.MatchResult) []MatchReviewHistorySection {
+	sectionTitles := []string{"Red Score", "Blue Score", "Red Cards", "Blue Cards", "Red Summary", "Blue Summary"}
+	playFields := make([][][]matchReviewHistoryField, len(sectionTitles))
+	for _, matchResult := range matchResults {
+		values := []any{
+			matchResult.RedScore,
+			matchResult.BlueScore,
+			matchResult.RedCards,
+			matchResult.BlueCards,
+			matchResult.RedScoreSummary(),
+			matchResult.BlueScoreSummary(),
+		}
+		for i, value := range values {
+			var fields []matchReviewHistoryField
+			flattenMatchReviewHistoryFields("", reflect.ValueOf(value), &fields)
+			playFields[i] = append(playFields[i], fields)
+		}
+	}
+
+	sections := make([]MatchReviewHistorySection, len(sectionTitles))
+	for i, title := range sectionTitles {
+		sections[i] = MatchReviewHistorySection{Title: title, Rows: buildMatchReviewHistoryRows(playFields[i])}
+	}
+	return sections
+}
+
+// Merges the flattened fields of each play into rows keyed by field label, in order of first appearance.
+func buildMatchReviewHistoryRows(playFields [][]matchReviewHistoryField) []MatchReviewHistoryRow {
+	var labels []string
+	playValues := make([]map[string]string, len(playFields))
+	for i, fields := range playFields {
+		playValues[i] = make(map[string]string, len(fields))
+		for _, field := range fields {
+			if !slices.Contains(labels, field.label) {
+				labels = append(labels, field.label)
+			}
+			playValues[i][field.label] = field.value
+		}
+	}
+
+	rows := make([]MatchReviewHistoryRow, len(labels))
+	for i, label := range labels {
+		rows[i].Label = label
+		for j := range playValues {
+			cell := MatchReviewHistoryCell{Value: playValues[j][label]}
+			if j > 0 && cell.Value != playValues[j-1][label] {
+				cell.IsChanged = true
+				rows[i].HasChanges = true
+			}
+			rows[i].Cells = append(rows[i].Cells, cell)
+		}
+	}
+	return rows
+}
+
+// Recursively walks the given value and appends a labeled string representation of each of its scalar fields.
+func flattenMatchReviewHistoryFields(prefix string, value reflect.Value, fields *[]matchReviewHistoryField) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			flattenMatchReviewHistoryFields(prefix, value.Elem(), fields)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			structField := value.Type().Field(i)
+			if !structField.IsExported() {
+				continue
+			}
+			label := structField.Name
+			if prefix != "" {
+				label = prefix + "." + label
+			}
+			flattenMatchReviewHistoryFields(label, value.Field(i), fields)
+		}
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			flattenMatchReviewHistoryFields(fmt.Sprintf("%s[%d]", prefix, i+1), value.Index(i), fields)
+		}
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(
+			keys,
+			func(i, j int) bool {
+				return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+			},
+		)
+		for _, key := range keys {
+			label := fmt.Sprint(key.Interface())
+			if prefix != "" {
+				label = fmt.Sprintf("%s[%s]", prefix, label)
+			}
+			flattenMatchReviewHistoryFields(label, value.MapIndex(key), fields)
+		}
+	default:
+		*fields = append(*fields, matchReviewHistoryField{label: prefix, value: fmt.Sprint(value.Interface())})
+	}
+}
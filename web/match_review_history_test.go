@@ -0,0 +1,86 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMatchReviewHistory(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", LongName: "Qualification 1"}
+	web.arena.Database.CreateMatch(&match)
+	recorder := web.getHttpResponse(fmt.Sprintf("/match_review/%d/history", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No results have been stored for this match.")
+
+	matchResult := model.BuildTestMatchResult(match.Id, 1)
+	web.arena.Database.CreateMatchResult(matchResult)
+	matchResult2 := model.BuildTestMatchResult(match.Id, 2)
+	matchResult2.RedScore.EndgameTowerStatuses[2] = game.TowerLevel3
+	matchResult2.BlueCards = map[string]string{"1678": "yellow"}
+	matchResult2.EditedBy = "headref"
+	matchResult2.EditReason = "Red 3 climbed to level 3"
+	matchResult2.RankingChanges = []model.RankingChange{{TeamId: 254, PreviousRank: 4, Rank: 2}}
+	web.arena.Database.CreateMatchResult(matchResult2)
+
+	recorder = web.getHttpResponse(fmt.Sprintf("/match_review/%d/history", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "Qualification 1 History")
+	assert.Contains(t, body, "Play 1")
+	assert.Contains(t, body, "Play 2")
+	assert.Contains(t, body, "headref")
+	assert.Contains(t, body, "Red 3 climbed to level 3")
+	assert.Contains(t, body, "Team 254: rank 4 &rarr; 2")
+	assert.Contains(t, body, "table-warning")
+
+	recorder = web.getHttpResponse("/match_review/254/history")
+	assert.Equal(t, 500, recorder.Code)
+}
+
+func TestBuildMatchReviewHistorySections(t *testing.T) {
+	matchResult := model.BuildTestMatchResult(1, 1)
+	matchResult2 := model.BuildTestMatchResult(1, 2)
+	matchResult2.RedScore.AutoTowerStatuses[0] = game.TowerLevel1
+	matchResult2.RedCards = map[string]string{"1868": "yellow", "254": "red"}
+
+	sections := buildMatchReviewHistorySections([]model.MatchResult{*matchResult, *matchResult2})
+	if !assert.Equal(t, 6, len(sections)) {
+		return
+	}
+	assert.Equal(t, "Red Score", sections[0].Title)
+	for _, row := range sections[0].Rows {
+		if row.Label == "AutoTowerStatuses[1]" {
+			assert.True(t, row.HasChanges)
+			assert.Equal(t, []MatchReviewHistoryCell{{"0", false}, {"1", true}}, row.Cells)
+		} else {
+			assert.False(t, row.HasChanges, row.Label)
+		}
+	}
+
+	// Check that a field present in only one play shows up as changed.
+	assert.Equal(t, "Red Cards", sections[2].Title)
+	if assert.Equal(t, 2, len(sections[2].Rows)) {
+		assert.Equal(t, "1868", sections[2].Rows[0].Label)
+		assert.False(t, sections[2].Rows[0].HasChanges)
+		assert.Equal(t, "254", sections[2].Rows[1].Label)
+		assert.Equal(t, []MatchReviewHistoryCell{{"", false}, {"red", true}}, sections[2].Rows[1].Cells)
+	}
+
+	// Check that the summary reflects the changed score.
+	assert.Equal(t, "Red Summary", sections[4].Title)
+	hasChangedSummary := false
+	for _, row := range sections[4].Rows {
+		if row.Label == "Score" {
+			hasChangedSummary = row.HasChanges
+		}
+	}
+	assert.True(t, hasChangedSummary)
+}
@@ -96,8 +96,22 @@ func TestMatchReviewEditExistingResult(t *testing.T) {
 		match.Id,
 	)
 	recorder = web.postHttpResponse(fmt.Sprintf("/match_review/%d/edit", match.Id), postBody)
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "A reason must be given when editing a committed match result.")
+	recorder = web.postHttpResponse(
+		fmt.Sprintf("/match_review/%d/edit", match.Id), postBody+"&editReason=Missed a foul on red",
+	)
 	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
 
+	// Check that the edit was stored as a new play alongside the original one.
+	matchResults, _ := web.arena.Database.GetMatchResultsForMatch(match.Id)
+	if assert.Equal(t, 2, len(matchResults)) {
+		assert.Equal(t, 1, matchResults[0].PlayNumber)
+		assert.Equal(t, "", matchResults[0].EditReason)
+		assert.Equal(t, 2, matchResults[1].PlayNumber)
+		assert.Equal(t, "Missed a foul on red", matchResults[1].EditReason)
+	}
+
 	// Check for the updated scores back on the match list page.
 	recorder = web.getHttpResponse("/match_review")
 	assert.Equal(t, 200, recorder.Code)
@@ -280,3 +294,50 @@ func TestMatchReviewSummaryCurrentMatch(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, matchResult)
 }
+
+func TestMatchReviewScoreLog(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", LongName: "Qualification 1"}
+	web.arena.Database.CreateMatch(&match)
+	recorder := web.getHttpResponse(fmt.Sprintf("/match_review/%d/score_log", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Qualification 1 Scoring Log")
+	assert.Contains(t, recorder.Body.String(), "No scoring commands have been logged for this match.")
+
+	web.arena.Database.CreateScoreEvent(
+		&model.ScoreEvent{
+			MatchId: match.Id, Position: "blue", Username: "scorer7", Alliance: "blue",
+			Description: "Set endgame tower for team 254 to Level 2 (was None).",
+		},
+	)
+	recorder = web.getHttpResponse(fmt.Sprintf("/match_review/%d/score_log", match.Id))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "scorer7")
+	assert.Contains(t, recorder.Body.String(), "Set endgame tower for team 254 to Level 2 (was None).")
+
+	recorder = web.getHttpResponse("/match_review/254/score_log")
+	assert.Equal(t, 500, recorder.Code)
+}
+
+func TestBuildRankingChanges(t *testing.T) {
+	oldRankings := game.Rankings{
+		{TeamId: 254, Rank: 1, RankingFields: game.RankingFields{RankingPoints: 10}},
+		{TeamId: 1114, Rank: 2, RankingFields: game.RankingFields{RankingPoints: 9}},
+		{TeamId: 2056, Rank: 3, RankingFields: game.RankingFields{RankingPoints: 8}},
+	}
+	newRankings := game.Rankings{
+		{TeamId: 1114, Rank: 1, RankingFields: game.RankingFields{RankingPoints: 11}},
+		{TeamId: 254, Rank: 2, RankingFields: game.RankingFields{RankingPoints: 10}},
+		{TeamId: 2056, Rank: 3, RankingFields: game.RankingFields{RankingPoints: 8}},
+	}
+	assert.Equal(
+		t,
+		[]model.RankingChange{
+			{TeamId: 1114, PreviousRank: 2, Rank: 1, PreviousRankingPoints: 9, RankingPoints: 11},
+			{TeamId: 254, PreviousRank: 1, Rank: 2, PreviousRankingPoints: 10, RankingPoints: 10},
+		},
+		buildRankingChanges(oldRankings, newRankings),
+	)
+	assert.Nil(t, buildRankingChanges(oldRankings, oldRankings))
+}
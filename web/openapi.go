@@ -0,0 +1,273 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Generation of the OpenAPI document describing version 1 of the web API. The schemas are derived by reflection from
+// the response types so that the document can't fall out of step with what the API actually returns.
+
+package web
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const openApiSchemaPrefix = "#/components/schemas/"
+
+type openApiParameter struct {
+	Name        string
+	In          string
+	Description string
+	IsInteger   bool
+}
+
+type apiV1Endpoint struct {
+	Method       string
+	Path         string
+	Summary      string
+	Parameters   []openApiParameter
+	RequestType  reflect.Type
+	ResponseType reflect.Type
+	IsPaginated  bool
+}
+
+var apiV1PaginationParameters = []openApiParameter{
+	{Name: "page", In: "query", Description: "Page number, starting at 1.", IsInteger: true},
+	{Name: "perPage", In: "query", Description: "Number of items per page, up to 500.", IsInteger: true},
+}
+
+// The endpoints making up version 1 of the API, in the order they appear in the OpenAPI document.
+var apiV1Endpoints = []apiV1Endpoint{
+	{Path: "/api/v1/event", Summary: "Get the event details", ResponseType: reflect.TypeFor[apiV1Event]()},
+	{
+		Path:         "/api/v1/teams",
+		Summary:      "List the teams at the event",
+		Parameters:   apiV1PaginationParameters,
+		ResponseType: reflect.TypeFor[apiV1Team](),
+		IsPaginated:  true,
+	},
+	{
+		Path:         "/api/v1/teams/{teamNumber}",
+		Summary:      "Get a team",
+		Parameters:   []openApiParameter{{Name: "teamNumber", In: "path", IsInteger: true}},
+		ResponseType: reflect.TypeFor[apiV1Team](),
+	},
+	{
+		Path:    "/api/v1/matches",
+		Summary: "List the scheduled matches and their results",
+		Parameters: append(
+			[]openApiParameter{
+				{Name: "type", In: "query", Description: "One of practice, qualification or playoff."},
+			},
+			apiV1PaginationParameters...,
+		),
+		ResponseType: reflect.TypeFor[apiV1Match](),
+		IsPaginated:  true,
+	},
+	{
+		Path:         "/api/v1/matches/{matchId}",
+		Summary:      "Get a match and its result",
+		Parameters:   []openApiParameter{{Name: "matchId", In: "path", IsInteger: true}},
+		ResponseType: reflect.TypeFor[apiV1Match](),
+	},
+	{
+		Path:         "/api/v1/rankings",
+		Summary:      "List the qualification rankings",
+		Parameters:   apiV1PaginationParameters,
+		ResponseType: reflect.TypeFor[apiV1Ranking](),
+		IsPaginated:  true,
+	},
+	{
+		Path:         "/api/v1/alliances",
+		Summary:      "List the playoff alliances",
+		ResponseType: reflect.TypeFor[[]apiV1Alliance](),
+	},
+	{Path: "/api/v1/awards", Summary: "List the awards", ResponseType: reflect.TypeFor[[]apiV1Award]()},
+	{Path: "/api/v1/bracket", Summary: "Get the playoff bracket", ResponseType: reflect.TypeFor[apiV1Bracket]()},
+	newControlApiV1Endpoint("loadMatch", "Load a match, or a test match if the ID is 0", apiV1LoadMatchRequest{}),
+	newControlApiV1Endpoint("startMatch", "Start the loaded match", apiV1StartMatchRequest{}),
+	newControlApiV1Endpoint("abortMatch", "Abort the match in progress", nil),
+	newControlApiV1Endpoint("commitAndPost", "Commit the match results and load the next match", nil),
+	newControlApiV1Endpoint("discardResults", "Discard the match results and load the next match", nil),
+	newControlApiV1Endpoint("startTimeout", "Start a timeout", apiV1StartTimeoutRequest{}),
+	newControlApiV1Endpoint("setAudienceDisplay", "Set the audience display mode", apiV1DisplayModeRequest{}),
+	newControlApiV1Endpoint(
+		"setAllianceStationDisplay", "Set the alliance station display mode", apiV1DisplayModeRequest{},
+	),
+}
+
+// Returns the description of the match control command with the given name and request type, if it takes arguments.
+func newControlApiV1Endpoint(command, summary string, request any) apiV1Endpoint {
+	endpoint := apiV1Endpoint{
+		Method:       http.MethodPost,
+		Path:         "/api/v1/control/" + command,
+		Summary:      summary,
+		ResponseType: reflect.TypeFor[apiV1ControlStatus](),
+	}
+	if request != nil {
+		endpoint.RequestType = reflect.TypeOf(request)
+	}
+	return endpoint
+}
+
+// Returns the OpenAPI document describing version 1 of the API.
+func (web *Web) openApiV1Handler(w http.ResponseWriter, r *http.Request) {
+	writeApiV1Response(w, r, buildOpenApiDocument(web.arena.EventSettings.Name, apiV1Endpoints))
+}
+
+// Assembles an OpenAPI 3 document describing the given endpoints.
+func buildOpenApiDocument(eventName string, endpoints []apiV1Endpoint) map[string]any {
+	schemas := make(map[string]any)
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content":     jsonContent(openApiSchema(reflect.TypeFor[apiV1Error](), schemas)),
+	}
+
+	paths := make(map[string]any)
+	for _, endpoint := range endpoints {
+		responseSchema := openApiSchema(endpoint.ResponseType, schemas)
+		if endpoint.IsPaginated {
+			responseSchema = map[string]any{
+				"type":     "object",
+				"required": []string{"data", "pagination"},
+				"properties": map[string]any{
+					"data":       map[string]any{"type": "array", "items": responseSchema},
+					"pagination": openApiSchema(reflect.TypeFor[apiV1Pagination](), schemas),
+				},
+			}
+		}
+
+		parameters := []any{}
+		for _, parameter := range endpoint.Parameters {
+			parameterType := "string"
+			if parameter.IsInteger {
+				parameterType = "integer"
+			}
+			parameterDoc := map[string]any{
+				"name":     parameter.Name,
+				"in":       parameter.In,
+				"required": parameter.In == "path",
+				"schema":   map[string]any{"type": parameterType},
+			}
+			if parameter.Description != "" {
+				parameterDoc["description"] = parameter.Description
+			}
+			parameters = append(parameters, parameterDoc)
+		}
+
+		operation := map[string]any{"summary": endpoint.Summary, "parameters": parameters}
+		if endpoint.Method == http.MethodPost {
+			// Commands that change the state of the field require an API token.
+			operation["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+			if endpoint.RequestType != nil {
+				operation["requestBody"] = map[string]any{
+					"required": true,
+					"content":  jsonContent(openApiSchema(endpoint.RequestType, schemas)),
+				}
+			}
+			operation["responses"] = map[string]any{
+				"200":     map[string]any{"description": "OK", "content": jsonContent(responseSchema)},
+				"default": errorResponse,
+			}
+			paths[endpoint.Path] = map[string]any{"post": operation}
+			continue
+		}
+		operation["responses"] = map[string]any{
+			"200": map[string]any{
+				"description": "OK",
+				"headers": map[string]any{
+					"ETag": map[string]any{
+						"description": "Pass in If-None-Match to get a 304 if unchanged.",
+						"schema":      map[string]any{"type": "string"},
+					},
+				},
+				"content": jsonContent(responseSchema),
+			},
+			"304":     map[string]any{"description": "Not Modified"},
+			"default": errorResponse,
+		}
+		paths[endpoint.Path] = map[string]any{"get": operation}
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Cheesy Arena API - " + eventName,
+			"version": "1",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// Returns the OpenAPI schema for the given type. Struct types are added to the given map of named schemas and
+// referenced from it.
+func openApiSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	if t == reflect.TypeFor[time.Time]() {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := openApiSchema(t.Elem(), schemas)
+		if _, ok := schema["$ref"]; ok {
+			// OpenAPI 3.0 ignores siblings of a reference, so it has to be wrapped in order to be made nullable.
+			return map[string]any{"allOf": []any{schema}, "nullable": true}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": openApiSchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": openApiSchema(t.Elem(), schemas)}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Struct:
+		name := strings.TrimPrefix(t.Name(), "apiV1")
+		reference := map[string]any{"$ref": openApiSchemaPrefix + name}
+		if _, ok := schemas[name]; ok {
+			return reference
+		}
+
+		// Register the name before descending into the fields in case the type refers to itself.
+		schemas[name] = nil
+		properties := make(map[string]any)
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if !field.IsExported() || tag == "-" {
+				continue
+			}
+			tagParts := strings.Split(tag, ",")
+			fieldName := tagParts[0]
+			if fieldName == "" {
+				fieldName = field.Name
+			}
+			properties[fieldName] = openApiSchema(field.Type, schemas)
+			if !strings.Contains(tag, ",omitempty") {
+				required = append(required, fieldName)
+			}
+		}
+		schemas[name] = map[string]any{"type": "object", "properties": properties, "required": required}
+		return reference
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOpenApiV1(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/api/v1/openapi.json")
+	assert.Equal(t, 200, recorder.Code)
+	var document struct {
+		OpenApi string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			Parameters []struct {
+				Name     string
+				In       string
+				Required bool
+			}
+		}
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any
+				Required   []string
+			}
+		}
+	}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	assert.Equal(t, "3.0.3", document.OpenApi)
+	assert.Equal(t, len(apiV1Endpoints), len(document.Paths))
+	if assert.Contains(t, document.Paths, "/api/v1/control/startTimeout") {
+		assert.Contains(t, document.Paths["/api/v1/control/startTimeout"], "post")
+	}
+	assert.Contains(t, document.Components.Schemas, "StartTimeoutRequest")
+	assert.Contains(t, document.Components.Schemas, "ControlStatus")
+	if assert.Contains(t, document.Paths, "/api/v1/matches/{matchId}") {
+		parameters := document.Paths["/api/v1/matches/{matchId}"]["get"].Parameters
+		if assert.Equal(t, 1, len(parameters)) {
+			assert.Equal(t, "matchId", parameters[0].Name)
+			assert.Equal(t, "path", parameters[0].In)
+			assert.True(t, parameters[0].Required)
+		}
+	}
+	for _, name := range []string{"Event", "Team", "Match", "MatchResult", "ScoreBreakdown", "Ranking", "Bracket"} {
+		assert.Contains(t, document.Components.Schemas, name)
+	}
+	matchSchema := document.Components.Schemas["Match"]
+	assert.Equal(t, "date-time", matchSchema.Properties["scheduledTime"]["format"])
+	assert.Equal(t, true, matchSchema.Properties["startedAt"]["nullable"])
+	assert.Contains(t, matchSchema.Required, "shortName")
+	assert.NotContains(t, matchSchema.Required, "playoffMatchGroupId")
+
+	// Check that every documented endpoint is actually served.
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	web.arena.Database.CreateMatch(&model.Match{Type: model.Qualification, ShortName: "Q1"})
+	for _, endpoint := range apiV1Endpoints {
+		if endpoint.Method == http.MethodPost {
+			// The control endpoints are exercised separately.
+			continue
+		}
+		path := strings.NewReplacer("{teamNumber}", "254", "{matchId}", "1").Replace(endpoint.Path)
+		recorder = web.getHttpResponse(path)
+		assert.Equal(t, 200, recorder.Code, path)
+	}
+}
+
+func TestOpenApiSchema(t *testing.T) {
+	type apiV1Node struct {
+		Name     string             `json:"name"`
+		Parent   *apiV1Node         `json:"parent"`
+		Children []apiV1Node        `json:"children,omitempty"`
+		Weights  map[string]float64 `json:"weights"`
+		internal int
+		Ignored  string `json:"-"`
+	}
+	schemas := make(map[string]any)
+	assert.Equal(
+		t, map[string]any{"$ref": "#/components/schemas/Node"}, openApiSchema(reflect.TypeFor[apiV1Node](), schemas),
+	)
+	assert.Equal(
+		t,
+		map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"name": map[string]any{"type": "string"},
+				"parent": map[string]any{
+					"allOf": []any{map[string]any{"$ref": "#/components/schemas/Node"}}, "nullable": true,
+				},
+				"children": map[string]any{
+					"type": "array", "items": map[string]any{"$ref": "#/components/schemas/Node"},
+				},
+				"weights": map[string]any{
+					"type": "object", "additionalProperties": map[string]any{"type": "number"},
+				},
+			},
+			"required": []string{"name", "parent", "weights"},
+		},
+		schemas["Node"],
+	)
+}
@@ -65,6 +65,10 @@ func (web *Web) queueingDisplayMatchLoadHandler(w http.ResponseWriter, r *http.R
 		if match.IsComplete() || match.TypeOrder < web.arena.CurrentMatch.TypeOrder {
 			continue
 		}
+		if !web.arena.IsMatchOnField(&match) {
+			// Skip matches that are scheduled to be played on a different field.
+			continue
+		}
 		upcomingMatches = append(upcomingMatches, match)
 		redOffFieldTeams, blueOffFieldTeams, err := web.arena.Database.GetOffFieldTeamIds(&match)
 		if err != nil {
@@ -84,6 +88,18 @@ func (web *Web) queueingDisplayMatchLoadHandler(w http.ResponseWriter, r *http.R
 		}
 	}
 
+	alliances, err := web.arena.Database.GetAllAlliances()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	backupTeams := make(map[int]bool)
+	for _, alliance := range alliances {
+		if alliance.BackupTeamId != 0 {
+			backupTeams[alliance.BackupTeamId] = true
+		}
+	}
+
 	template, err := web.parseFiles("templates/queueing_display_match_load.html")
 	if err != nil {
 		handleWebErr(w, err)
@@ -94,10 +110,12 @@ func (web *Web) queueingDisplayMatchLoadHandler(w http.ResponseWriter, r *http.R
 		Matches           []model.Match
 		RedOffFieldTeams  [][]int
 		BlueOffFieldTeams [][]int
+		BackupTeams       map[int]bool
 	}{
 		upcomingMatches,
 		redOffFieldTeamsByMatch,
 		blueOffFieldTeamsByMatch,
+		backupTeams,
 	}
 	err = template.ExecuteTemplate(w, "queueing_display_match_load.html", data)
 	if err != nil {
@@ -0,0 +1,148 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for projecting the final qualification rankings and exploring "what if" scenarios.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// Projections backing the clinch/elimination indicators on the rankings display, recalculated only when the rankings
+// or the cutoff change since the display polls frequently.
+var rankingIndicatorsCache struct {
+	sync.Mutex
+	rankings    game.Rankings
+	cutoff      int
+	projections *tournament.RankingProjections
+}
+
+// Shows the projected final rankings, optionally with the outcomes of some of the remaining matches imposed.
+func (web *Web) rankingProjectionsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userHasRole(w, r, model.EmceeRole) {
+		return
+	}
+
+	cutoff := web.arena.EventSettings.NumPlayoffAlliances
+	if cutoffValue := r.URL.Query().Get("cutoff"); cutoffValue != "" {
+		var err error
+		cutoff, err = strconv.Atoi(cutoffValue)
+		if err != nil || cutoff < 1 {
+			http.Error(w, "Error: invalid cutoff "+cutoffValue, 400)
+			return
+		}
+	}
+	numSimulations := tournament.DefaultNumRankingSimulations
+	if simulationsValue := r.URL.Query().Get("simulations"); simulationsValue != "" {
+		var err error
+		numSimulations, err = strconv.Atoi(simulationsValue)
+		if err != nil {
+			http.Error(w, "Error: invalid number of simulations "+simulationsValue, 400)
+			return
+		}
+	}
+	highlightTeamId, _ := strconv.Atoi(r.URL.Query().Get("team"))
+
+	matches, err := web.arena.Database.GetMatchesByType(model.Qualification, false)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var remainingMatches []model.Match
+	forcedOutcomes := make(map[int]game.MatchStatus)
+	for _, match := range matches {
+		if match.IsComplete() {
+			continue
+		}
+		remainingMatches = append(remainingMatches, match)
+		switch outcome := r.URL.Query().Get(fmt.Sprintf("outcome_%d", match.Id)); outcome {
+		case "":
+		case "red":
+			forcedOutcomes[match.Id] = game.RedWonMatch
+		case "blue":
+			forcedOutcomes[match.Id] = game.BlueWonMatch
+		case "tie":
+			forcedOutcomes[match.Id] = game.TieMatch
+		default:
+			http.Error(w, fmt.Sprintf("Error: invalid outcome '%s' for match %s", outcome, match.ShortName), 400)
+			return
+		}
+	}
+
+	projections, err := tournament.ProjectRankings(web.arena.Database, cutoff, numSimulations, forcedOutcomes)
+	if err != nil {
+		http.Error(w, "Error: "+err.Error(), 400)
+		return
+	}
+
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	teamNicknames := make(map[int]string)
+	for _, team := range teams {
+		teamNicknames[team.Id] = team.Nickname
+	}
+
+	template, err := web.parseFiles("templates/ranking_projections.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Projections      *tournament.RankingProjections
+		HighlightTeam    *tournament.TeamRankingProjection
+		HighlightTeamId  int
+		TeamNicknames    map[int]string
+		RemainingMatches []model.Match
+		ForcedOutcomes   map[int]game.MatchStatus
+	}{
+		web.arena.EventSettings,
+		projections,
+		projections.TeamProjection(highlightTeamId),
+		highlightTeamId,
+		teamNicknames,
+		remainingMatches,
+		forcedOutcomes,
+	}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns the projections for the current rankings against the playoff alliance cutoff, reusing the cached ones if
+// nothing has changed. Returns nil if the projections can't be calculated.
+func (web *Web) rankingIndicatorProjections(rankings game.Rankings) *tournament.RankingProjections {
+	rankingIndicatorsCache.Lock()
+	defer rankingIndicatorsCache.Unlock()
+
+	cutoff := web.arena.EventSettings.NumPlayoffAlliances
+	if rankingIndicatorsCache.projections != nil && rankingIndicatorsCache.cutoff == cutoff &&
+		reflect.DeepEqual(rankingIndicatorsCache.rankings, rankings) {
+		return rankingIndicatorsCache.projections
+	}
+	projections, err := tournament.ProjectRankings(
+		web.arena.Database, cutoff, tournament.DefaultNumRankingSimulations, nil,
+	)
+	if err != nil {
+		log.Printf("Failed to project rankings: %v", err)
+		return nil
+	}
+	rankingIndicatorsCache.rankings = rankings
+	rankingIndicatorsCache.cutoff = cutoff
+	rankingIndicatorsCache.projections = projections
+	return projections
+}
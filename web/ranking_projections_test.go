@@ -0,0 +1,129 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func setupTestProjectionMatches(t *testing.T, web *Web) (model.Match, model.Match) {
+	match1 := model.Match{
+		Type:      model.Qualification,
+		TypeOrder: 1,
+		ShortName: "Q1",
+		Red1:      1,
+		Red2:      2,
+		Red3:      3,
+		Blue1:     4,
+		Blue2:     5,
+		Blue3:     6,
+		Status:    game.BlueWonMatch,
+	}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match1))
+	assert.Nil(t, web.arena.Database.CreateMatchResult(model.BuildTestMatchResult(match1.Id, 1)))
+	match2 := model.Match{
+		Type:      model.Qualification,
+		TypeOrder: 2,
+		ShortName: "Q2",
+		Red1:      1,
+		Red2:      4,
+		Red3:      5,
+		Blue1:     2,
+		Blue2:     3,
+		Blue3:     6,
+		Status:    game.MatchScheduled,
+	}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match2))
+	_, err := tournament.CalculateRankings(web.arena.Database, false)
+	assert.Nil(t, err)
+	return match1, match2
+}
+
+func TestRankingProjections(t *testing.T) {
+	web := setupTestWeb(t)
+	_, match2 := setupTestProjectionMatches(t, web)
+	web.arena.Database.CreateTeam(&model.Team{Id: 6, Nickname: "Team Six"})
+
+	recorder := web.getHttpResponse("/ranking_projections?simulations=100")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Projected over 100 simulations")
+	assert.Contains(t, recorder.Body.String(), "Top 8")
+	assert.Contains(t, recorder.Body.String(), "Team Six")
+	assert.Contains(t, recorder.Body.String(), fmt.Sprintf("outcome_%d", match2.Id))
+
+	// Team 6 makes the top two in every simulation if its alliance wins the remaining match, but isn't guaranteed to
+	// since it could still be disqualified.
+	recorder = web.getHttpResponse(
+		fmt.Sprintf("/ranking_projections?cutoff=2&simulations=100&team=6&outcome_%d=blue", match2.Id),
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 6 finishes in the top 2 in 100% of")
+	assert.Contains(t, recorder.Body.String(), "<option value=\"blue\" selected>")
+	recorder = web.getHttpResponse(
+		fmt.Sprintf("/ranking_projections?cutoff=5&simulations=100&team=6&outcome_%d=blue", match2.Id),
+	)
+	assert.Contains(t, recorder.Body.String(), "Team 6 is guaranteed to finish in the top 5.")
+
+	recorder = web.getHttpResponse("/ranking_projections?cutoff=2&team=1&outcome_" + fmt.Sprint(match2.Id) + "=blue")
+	assert.Contains(t, recorder.Body.String(), "Team 1 can no longer make the top 2.")
+	recorder = web.getHttpResponse("/ranking_projections?team=254")
+	assert.Contains(t, recorder.Body.String(), "Team 254 is not in the qualification schedule.")
+
+	recorder = web.getHttpResponse(fmt.Sprintf("/ranking_projections?outcome_%d=purple", match2.Id))
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid outcome 'purple' for match Q2")
+	recorder = web.getHttpResponse("/ranking_projections?cutoff=0")
+	assert.Equal(t, 400, recorder.Code)
+	recorder = web.getHttpResponse("/ranking_projections?simulations=100000")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "number of simulations must be between 1 and 20000")
+}
+
+func TestRankingsApiIndicators(t *testing.T) {
+	web := setupTestWeb(t)
+	_, match2 := setupTestProjectionMatches(t, web)
+
+	// With only six teams, everyone fits within the default eight alliances.
+	recorder := web.getHttpResponse("/api/rankings")
+	assert.Equal(t, 200, recorder.Code)
+	var rankingsData struct {
+		Rankings []RankingWithNickname
+	}
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &rankingsData))
+	if assert.Equal(t, 6, len(rankingsData.Rankings)) {
+		for _, ranking := range rankingsData.Rankings {
+			assert.True(t, ranking.Clinched)
+			assert.False(t, ranking.Eliminated)
+		}
+	}
+
+	// Teams 4 and 5 score well enough to win their second match in every simulation, but nothing is certain while the
+	// match remains since any team could still be disqualified from it.
+	web.arena.EventSettings.NumPlayoffAlliances = 3
+	recorder = web.getHttpResponse("/api/rankings")
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &rankingsData))
+	for _, ranking := range rankingsData.Rankings {
+		assert.False(t, ranking.Clinched, "team %d", ranking.TeamId)
+		assert.False(t, ranking.Eliminated, "team %d", ranking.TeamId)
+	}
+
+	// Once the last match is played, the indicators follow the final rankings.
+	match2.Status = game.BlueWonMatch
+	assert.Nil(t, web.arena.Database.UpdateMatch(&match2))
+	assert.Nil(t, web.arena.Database.CreateMatchResult(model.BuildTestMatchResult(match2.Id, 1)))
+	_, err := tournament.CalculateRankings(web.arena.Database, false)
+	assert.Nil(t, err)
+	recorder = web.getHttpResponse("/api/rankings")
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &rankingsData))
+	for _, ranking := range rankingsData.Rankings {
+		assert.Equal(t, ranking.Rank <= 3, ranking.Clinched, "team %d", ranking.TeamId)
+		assert.Equal(t, ranking.Rank > 3, ranking.Eliminated, "team %d", ranking.TeamId)
+	}
+}
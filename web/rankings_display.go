@@ -6,6 +6,7 @@
 package web
 
 import (
+	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/websocket"
 	"net/http"
@@ -24,7 +25,8 @@ func (web *Web) rankingsDisplayHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	data := struct {
 		*model.EventSettings
-	}{web.arena.EventSettings}
+		RankingRules game.RankingRules
+	}{web.arena.EventSettings, game.CurrentRankingRules}
 	err = template.ExecuteTemplate(w, "rankings_display.html", data)
 	if err != nil {
 		handleWebErr(w, err)
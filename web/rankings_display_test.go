@@ -16,6 +16,7 @@ func TestRankingsDisplay(t *testing.T) {
 	recorder := web.getHttpResponse("/displays/rankings?displayId=1&scrollMsPerRow=700")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "Standings Display - Untitled Event - Cheesy Arena")
+	assert.Contains(t, recorder.Body.String(), "Ranked by Ranking Points, Match Points")
 }
 
 func TestRankingsDisplayWebsocket(t *testing.T) {
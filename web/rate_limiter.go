@@ -0,0 +1,77 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Token-bucket rate limiting of requests, tracked separately for each client.
+
+package web
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+type rateLimiter struct {
+	burst         int
+	ratePerSec    float64
+	now           func() time.Time
+	mutex         sync.Mutex
+	buckets       map[string]*rateLimiterBucket
+	lastPruneTime time.Time
+}
+
+type rateLimiterBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// Creates a rate limiter that allows bursts of up to the given number of requests per client, refilled at the given
+// steady rate.
+func newRateLimiter(burst int, ratePerSec float64) *rateLimiter {
+	return &rateLimiter{
+		burst:      burst,
+		ratePerSec: ratePerSec,
+		now:        time.Now,
+		buckets:    make(map[string]*rateLimiterBucket),
+	}
+}
+
+// Returns true if a request from the client with the given key may proceed, consuming one token from its bucket.
+// Otherwise returns false along with how long the client should wait before trying again.
+func (limiter *rateLimiter) allow(key string) (bool, time.Duration) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := limiter.now()
+	limiter.pruneBuckets(now)
+	bucket, ok := limiter.buckets[key]
+	if !ok {
+		bucket = &rateLimiterBucket{tokens: float64(limiter.burst), updatedAt: now}
+		limiter.buckets[key] = bucket
+	}
+	elapsedSec := now.Sub(bucket.updatedAt).Seconds()
+	bucket.tokens = math.Min(float64(limiter.burst), bucket.tokens+elapsedSec*limiter.ratePerSec)
+	bucket.updatedAt = now
+
+	if bucket.tokens < 1 {
+		waitSec := (1 - bucket.tokens) / limiter.ratePerSec
+		return false, time.Duration(waitSec * float64(time.Second))
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// Forgets the clients whose buckets have refilled completely, since they are no different from ones never seen before.
+// Runs at most once per refill period so that the cost is spread over many requests.
+func (limiter *rateLimiter) pruneBuckets(now time.Time) {
+	refillDuration := time.Duration(float64(limiter.burst) / limiter.ratePerSec * float64(time.Second))
+	if now.Sub(limiter.lastPruneTime) < refillDuration {
+		return
+	}
+	limiter.lastPruneTime = now
+	for key, bucket := range limiter.buckets {
+		if now.Sub(bucket.updatedAt) >= refillDuration {
+			delete(limiter.buckets, key)
+		}
+	}
+}
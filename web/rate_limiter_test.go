@@ -0,0 +1,71 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(3, 2)
+	now := time.Unix(1000, 0)
+	limiter.now = func() time.Time { return now }
+
+	// Check that a burst is allowed up to the limit.
+	for i := 0; i < 3; i++ {
+		allowed, _ := limiter.allow("client1")
+		assert.True(t, allowed)
+	}
+	allowed, retryAfter := limiter.allow("client1")
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// Check that clients are tracked separately.
+	allowed, _ = limiter.allow("client2")
+	assert.True(t, allowed)
+
+	// Check that tokens are refilled over time but not beyond the burst size.
+	now = now.Add(500 * time.Millisecond)
+	allowed, _ = limiter.allow("client1")
+	assert.True(t, allowed)
+	allowed, _ = limiter.allow("client1")
+	assert.False(t, allowed)
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		allowed, _ = limiter.allow("client1")
+		assert.True(t, allowed)
+	}
+	allowed, _ = limiter.allow("client1")
+	assert.False(t, allowed)
+}
+
+func TestRateLimiterPruning(t *testing.T) {
+	limiter := newRateLimiter(3, 2)
+	now := time.Unix(1000, 0)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 100; i++ {
+		limiter.allow(fmt.Sprintf("client%d", i))
+	}
+	assert.Equal(t, 100, len(limiter.buckets))
+
+	// Check that buckets which haven't fully refilled are kept.
+	now = now.Add(time.Second)
+	limiter.allow("client0")
+	assert.Equal(t, 100, len(limiter.buckets))
+
+	// Check that clients are forgotten once their buckets have refilled, without losing track of any still limited.
+	for i := 0; i < 3; i++ {
+		limiter.allow("client0")
+	}
+	now = now.Add(time.Second)
+	allowed, _ := limiter.allow("client100")
+	assert.True(t, allowed)
+	assert.Equal(t, 2, len(limiter.buckets))
+	allowed, _ = limiter.allow("client0")
+	assert.True(t, allowed)
+}
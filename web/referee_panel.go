@@ -21,7 +21,7 @@ import (
 
 // Renders the referee interface for assigning fouls.
 func (web *Web) refereePanelHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.HeadRefereeRole) {
 		return
 	}
 
@@ -69,7 +69,7 @@ func (web *Web) refereePanelFoulListHandler(w http.ResponseWriter, r *http.Reque
 
 // The websocket endpoint for the refereee interface client to send control commands and receive status updates.
 func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.HeadRefereeRole) {
 		return
 	}
 
@@ -79,6 +79,7 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	defer closeWebsocket(ws)
+	username := web.getUsername(r)
 
 	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
 	go ws.HandleNotifiers(
@@ -101,6 +102,7 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			log.Println(err)
 			return
 		}
+		var scoreEvent *model.ScoreEvent
 
 		switch messageType {
 		case "addFoul":
@@ -125,6 +127,12 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 					append(web.arena.BlueRealtimeScore.CurrentScore.Fouls, foul)
 			}
 			web.arena.RealtimeScoreNotifier.Notify()
+			scoreEvent = &model.ScoreEvent{
+				Command:     messageType,
+				Alliance:    args.Alliance,
+				Description: describeFoul("Added", args.Alliance, &foul),
+				FoulId:      foul.FoulId,
+			}
 		case "toggleFoulType", "updateFoulTeam", "updateFoulRule", "deleteFoul":
 			args := struct {
 				Alliance string
@@ -146,6 +154,7 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 				fouls = &web.arena.BlueRealtimeScore.CurrentScore.Fouls
 			}
 			if args.Index >= 0 && args.Index < len(*fouls) {
+				foul := (*fouls)[args.Index]
 				switch messageType {
 				case "toggleFoulType":
 					(*fouls)[args.Index].IsMajor = !(*fouls)[args.Index].IsMajor
@@ -161,7 +170,18 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 				case "updateFoulRule":
 					(*fouls)[args.Index].RuleId = args.RuleId
 				}
+				action := "Deleted"
+				if messageType != "deleteFoul" {
+					action = "Updated"
+					foul = (*fouls)[args.Index]
+				}
 				web.arena.RealtimeScoreNotifier.Notify()
+				scoreEvent = &model.ScoreEvent{
+					Command:     messageType,
+					Alliance:    args.Alliance,
+					Description: describeFoul(action, args.Alliance, &foul),
+					FoulId:      foul.FoulId,
+				}
 			}
 		case "card":
 			args := struct {
@@ -182,7 +202,14 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			} else {
 				cards = web.arena.BlueRealtimeScore.Cards
 			}
+			cardAction := fmt.Sprintf("Set %s card", args.Card)
+			if args.Card == "" {
+				cardAction = "Cleared card"
+			}
+			scoreEvent = &model.ScoreEvent{Command: messageType, Alliance: args.Alliance}
 			if web.arena.CurrentMatch.Type == model.Playoff {
+				scoreEvent.Description = fmt.Sprintf("%s for %s alliance.", cardAction, args.Alliance)
+
 				// Cards apply to the whole alliance in playoffs.
 				if args.Alliance == "red" {
 					cards[strconv.Itoa(web.arena.CurrentMatch.Red1)] = args.Card
@@ -194,6 +221,7 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 					cards[strconv.Itoa(web.arena.CurrentMatch.Blue3)] = args.Card
 				}
 			} else {
+				scoreEvent.Description = fmt.Sprintf("%s for team %d.", cardAction, args.TeamId)
 				cards[strconv.Itoa(args.TeamId)] = args.Card
 			}
 			web.arena.RealtimeScoreNotifier.Notify()
@@ -229,5 +257,13 @@ func (web *Web) refereePanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 		default:
 			writeWebsocketError(ws, fmt.Sprintf("Invalid message type '%s'.", messageType))
 		}
+
+		if scoreEvent != nil {
+			scoreEvent.Position = "referee"
+			scoreEvent.Username = username
+			if err = web.arena.LogScoreEvent(scoreEvent); err != nil {
+				log.Printf("Failed to log scoring event: %v", err)
+			}
+		}
 	}
 }
@@ -163,6 +163,17 @@ func TestRefereePanelWebsocket(t *testing.T) {
 		assert.Equal(t, "yellow", web.arena.BlueRealtimeScore.Cards["1681"])
 	}
 
+	// Check that all valid commands were recorded in the scoring log.
+	scoreEvents, _ := web.arena.Database.GetScoreEventsForMatch(web.arena.CurrentMatch.Id)
+	if assert.Equal(t, 12, len(scoreEvents)) {
+		assert.Equal(t, "referee", scoreEvents[0].Position)
+		assert.Equal(t, "Added major foul #1 against red.", scoreEvents[0].Description)
+		assert.Equal(t, "Updated major foul #1 against red team 256.", scoreEvents[4].Description)
+		assert.Equal(t, "Deleted minor foul #3 against blue for G301.", scoreEvents[6].Description)
+		assert.Equal(t, "Set red card for team 1680.", scoreEvents[9].Description)
+		assert.Equal(t, "Set red card for red alliance.", scoreEvents[11].Description)
+	}
+
 	// Test field reset and match committing.
 	web.arena.CurrentMatch.Type = model.Test
 	web.arena.MatchState = field.PostMatch
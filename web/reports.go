@@ -37,7 +37,11 @@ func (web *Web) rankingsCsvReportHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var buf bytes.Buffer
-	err = template.ExecuteTemplate(&buf, "rankings.csv", rankings)
+	data := struct {
+		Rankings     game.Rankings
+		RankingRules game.RankingRules
+	}{rankings, game.CurrentRankingRules}
+	err = template.ExecuteTemplate(&buf, "rankings.csv", data)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -149,10 +153,25 @@ func (web *Web) findBackupTeams(rankings game.Rankings) (game.Rankings, map[int]
 			}
 			pickedTeams[allianceTeamId] = true
 		}
+		if alliance.BackupTeamId != 0 {
+			pickedBackups[alliance.BackupTeamId] = true
+		}
+	}
+
+	// Teams that have withdrawn from the event can't be called up either.
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		return nil, nil, err
+	}
+	withdrawnTeams := make(map[int]bool)
+	for _, team := range teams {
+		if team.Withdrawn {
+			withdrawnTeams[team.Id] = true
+		}
 	}
 
 	for _, team := range rankings {
-		if !pickedTeams[team.TeamId] {
+		if !pickedTeams[team.TeamId] && !withdrawnTeams[team.TeamId] {
 			pruned = append(pruned, team)
 		}
 	}
@@ -659,7 +678,7 @@ func (web *Web) teamsPdfReportHandler(w http.ResponseWriter, r *http.Request) {
 
 // Generates a CSV-formatted report of the WPA keys, for import into the radio kiosk.
 func (web *Web) wpaKeysCsvReportHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.FtaRole) {
 		return
 	}
 
@@ -696,6 +715,19 @@ func (web *Web) alliancesPdfReportHandler(w http.ResponseWriter, r *http.Request
 	}
 	err = web.arena.PlayoffTournament.Traverse(
 		func(matchGroup playoff.MatchGroup) error {
+			if roundRobin, ok := matchGroup.(*playoff.RoundRobin); ok {
+				for _, standing := range roundRobin.Standings {
+					if _, ok := allianceStatuses[standing.AllianceId]; ok {
+						continue
+					}
+					if roundRobin.IsComplete() {
+						allianceStatuses[standing.AllianceId] = fmt.Sprintf("Eliminated in\n%s", roundRobin.Id())
+					} else {
+						allianceStatuses[standing.AllianceId] = fmt.Sprintf("Playing in\n%s", roundRobin.Id())
+					}
+				}
+				return nil
+			}
 			matchup, ok := matchGroup.(*playoff.Matchup)
 			if !ok {
 				return nil
@@ -24,7 +24,9 @@ func TestRankingsCsvReport(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
 	expectedBody := "Rank,TeamId,RankingPoints,MatchPoints,AutoFuelPoints,TowerPoints,Wins,Losses,Ties," +
-		"Disqualifications,Played\n1,254,20,625,90,554,3,2,1,0,10\n2,1114,18,700,625,90,1,3,2,0,10\n\n"
+		"Disqualifications,Played\n1,254,20,625,90,554,3,2,1,0,10\n2,1114,18,700,625,90,1,3,2,0,10\n\n" +
+		"\"Ranked by Ranking Points, Match Points, Auto Fuel Points, Tower Points. Win/Tie/Loss = 3/1/0 RP plus " +
+		"bonus RP.\"\n"
 	assert.Equal(t, expectedBody, recorder.Body.String())
 }
 
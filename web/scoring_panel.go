@@ -22,6 +22,13 @@ type ScoringPosition struct {
 	Alliance string
 }
 
+var towerStatusNames = map[game.TowerStatus]string{
+	game.TowerNone:   "None",
+	game.TowerLevel1: "Level 1",
+	game.TowerLevel2: "Level 2",
+	game.TowerLevel3: "Level 3",
+}
+
 var positionParameters = map[string]ScoringPosition{
 	"red": {
 		Title:    "Red",
@@ -35,7 +42,7 @@ var positionParameters = map[string]ScoringPosition{
 
 // Renders the scoring interface which enables input of scores in real-time.
 func (web *Web) scoringPanelHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.ScorerRole, model.HeadRefereeRole) {
 		return
 	}
 
@@ -65,7 +72,7 @@ func (web *Web) scoringPanelHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for the scoring interface client to send control commands and receive status updates.
 func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.ScorerRole, model.HeadRefereeRole) {
 		return
 	}
 
@@ -89,6 +96,7 @@ func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	defer closeWebsocket(ws)
+	username := web.getUsername(r)
 	web.arena.ScoringPanelRegistry.RegisterPanel(position, ws)
 	web.arena.ScoringStatusNotifier.Notify()
 	defer web.arena.ScoringStatusNotifier.Notify()
@@ -118,6 +126,7 @@ func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 		}
 		score := &(*realtimeScore).CurrentScore
 		scoreChanged := false
+		var scoreEvent *model.ScoreEvent
 
 		if command == "commitMatch" {
 			if web.arena.MatchState != field.PostMatch {
@@ -141,8 +150,22 @@ func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			if args.TeamPosition >= 1 && args.TeamPosition <= 3 && args.AutoTowerStatus >= 0 &&
 				args.AutoTowerStatus <= 3 {
 				autoTowerStatus := game.TowerStatus(args.AutoTowerStatus)
+				previousStatus := score.AutoTowerStatuses[args.TeamPosition-1]
 				score.AutoTowerStatuses[args.TeamPosition-1] = autoTowerStatus
 				scoreChanged = true
+				scoreEvent = &model.ScoreEvent{
+					Command:      command,
+					Alliance:     parameters.Alliance,
+					TeamPosition: args.TeamPosition,
+					Description: fmt.Sprintf(
+						"Set auto tower for %s to %s (was %s).",
+						web.describeTeamPosition(parameters.Alliance, args.TeamPosition),
+						towerStatusNames[autoTowerStatus],
+						towerStatusNames[previousStatus],
+					),
+					PreviousValue: int(previousStatus),
+					NewValue:      int(autoTowerStatus),
+				}
 			}
 		} else if command == "endgame" {
 			args := struct {
@@ -158,8 +181,22 @@ func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 			if args.TeamPosition >= 1 && args.TeamPosition <= 3 && args.EndgameTowerStatus >= 0 &&
 				args.EndgameTowerStatus <= 3 {
 				endgameStatus := game.TowerStatus(args.EndgameTowerStatus)
+				previousStatus := score.EndgameTowerStatuses[args.TeamPosition-1]
 				score.EndgameTowerStatuses[args.TeamPosition-1] = endgameStatus
 				scoreChanged = true
+				scoreEvent = &model.ScoreEvent{
+					Command:      command,
+					Alliance:     parameters.Alliance,
+					TeamPosition: args.TeamPosition,
+					Description: fmt.Sprintf(
+						"Set endgame tower for %s to %s (was %s).",
+						web.describeTeamPosition(parameters.Alliance, args.TeamPosition),
+						towerStatusNames[endgameStatus],
+						towerStatusNames[previousStatus],
+					),
+					PreviousValue: int(previousStatus),
+					NewValue:      int(endgameStatus),
+				}
 			}
 		} else if command == "addFoul" {
 			args := struct {
@@ -183,10 +220,57 @@ func (web *Web) scoringPanelWebsocketHandler(w http.ResponseWriter, r *http.Requ
 					append(web.arena.BlueRealtimeScore.CurrentScore.Fouls, foul)
 			}
 			web.arena.RealtimeScoreNotifier.Notify()
+			scoreEvent = &model.ScoreEvent{
+				Command:     command,
+				Alliance:    args.Alliance,
+				Description: describeFoul("Added", args.Alliance, &foul),
+				FoulId:      foul.FoulId,
+			}
+		} else if command == "undo" {
+			if _, err = web.arena.UndoLastScoreEvent(position, username); err != nil {
+				writeWebsocketError(ws, err.Error())
+				continue
+			}
 		}
 
 		if scoreChanged {
 			web.arena.RealtimeScoreNotifier.Notify()
 		}
+		if scoreEvent != nil {
+			scoreEvent.Position = position
+			scoreEvent.Username = username
+			if err = web.arena.LogScoreEvent(scoreEvent); err != nil {
+				log.Printf("Failed to log scoring event: %v", err)
+			}
+		}
+	}
+}
+
+// Returns a human-readable reference to the team in the given 1-indexed position of the given alliance.
+func (web *Web) describeTeamPosition(alliance string, teamPosition int) string {
+	match := web.arena.CurrentMatch
+	teams := []int{match.Blue1, match.Blue2, match.Blue3}
+	if alliance == "red" {
+		teams = []int{match.Red1, match.Red2, match.Red3}
+	}
+	if teamPosition >= 1 && teamPosition <= 3 && teams[teamPosition-1] > 0 {
+		return fmt.Sprintf("team %d", teams[teamPosition-1])
+	}
+	return fmt.Sprintf("%s position %d", alliance, teamPosition)
+}
+
+// Returns a human-readable description of the given action taken on a foul, for use in the scoring audit log.
+func describeFoul(action, alliance string, foul *game.Foul) string {
+	foulType := "minor"
+	if foul.IsMajor {
+		foulType = "major"
+	}
+	description := fmt.Sprintf("%s %s foul #%d against %s", action, foulType, foul.FoulId, alliance)
+	if foul.TeamId > 0 {
+		description += fmt.Sprintf(" team %d", foul.TeamId)
+	}
+	if rule := foul.Rule(); rule != nil {
+		description += fmt.Sprintf(" for %s", rule.RuleNumber)
 	}
+	return description + "."
 }
@@ -198,3 +198,50 @@ func TestScoringPanelWebsocket(t *testing.T) {
 	assert.Equal(t, 0, web.arena.ScoringPanelRegistry.GetNumScoreCommitted("red"))
 	assert.Equal(t, 0, web.arena.ScoringPanelRegistry.GetNumScoreCommitted("blue"))
 }
+
+func TestScoringPanelWebsocketScoreLogAndUndo(t *testing.T) {
+	web := setupTestWeb(t)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/panels/scoring/red/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketType(t, ws, "resetLocalState")
+	readWebsocketType(t, ws, "matchLoad")
+	readWebsocketType(t, ws, "matchTime")
+	readWebsocketType(t, ws, "realtimeScore")
+	web.arena.MatchState = field.TeleopPeriod
+
+	ws.Write("autoTower", map[string]int{"TeamPosition": 2, "AutoTowerStatus": 3})
+	readWebsocketType(t, ws, "realtimeScore")
+	ws.Write("addFoul", map[string]any{"Alliance": "blue", "IsMajor": true})
+	readWebsocketType(t, ws, "realtimeScore")
+	assert.Equal(t, game.TowerLevel3, web.arena.RedRealtimeScore.CurrentScore.AutoTowerStatuses[1])
+	assert.Equal(t, 1, len(web.arena.BlueRealtimeScore.CurrentScore.Fouls))
+
+	// Undo both actions in reverse order.
+	ws.Write("undo", nil)
+	readWebsocketType(t, ws, "realtimeScore")
+	assert.Empty(t, web.arena.BlueRealtimeScore.CurrentScore.Fouls)
+	ws.Write("undo", nil)
+	readWebsocketType(t, ws, "realtimeScore")
+	assert.Equal(t, game.TowerNone, web.arena.RedRealtimeScore.CurrentScore.AutoTowerStatuses[1])
+	ws.Write("undo", nil)
+	assert.Equal(t, "There is no action to undo.", readWebsocketError(t, ws))
+
+	scoreEvents, _ := web.arena.Database.GetScoreEventsForMatch(web.arena.CurrentMatch.Id)
+	if assert.Equal(t, 4, len(scoreEvents)) {
+		assert.Equal(t, "red", scoreEvents[0].Position)
+		assert.Equal(t, "Set auto tower for red position 2 to Level 3 (was None).", scoreEvents[0].Description)
+		assert.Equal(t, "Added major foul #1 against blue.", scoreEvents[1].Description)
+		assert.True(t, scoreEvents[0].IsUndone)
+		assert.True(t, scoreEvents[1].IsUndone)
+		assert.Equal(t, "undo", scoreEvents[2].Command)
+	}
+
+	recorder := web.getHttpResponse("/match_review/current/score_log")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Undid: Added major foul #1 against blue.")
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for managing the tokens that grant access to the match control API and for reviewing its use.
+
+package web
+
+import (
+	"crypto/rand"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The number of most recent audit entries shown on the API tokens page.
+const numApiAuditEntriesShown = 100
+
+// Shows the list of API tokens and the recent uses of them.
+func (web *Web) apiTokensGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderApiTokens(w, r, "", "")
+}
+
+// Creates a new API token and shows it to the user, since only its hash is kept.
+func (web *Web) apiTokensPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	name := strings.TrimSpace(r.PostFormValue("name"))
+	if name == "" {
+		web.renderApiTokens(w, r, "", "A name is required for the API token.")
+		return
+	}
+	token := rand.Text()
+	apiToken := model.ApiToken{Name: name, TokenHash: model.HashApiToken(token), CreatedAt: time.Now()}
+	if err := web.arena.Database.CreateApiToken(&apiToken); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	web.renderApiTokens(w, r, token, "")
+}
+
+// Deletes the given API token, immediately cutting off the tool using it.
+func (web *Web) apiTokenRevokePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	apiTokenId, _ := strconv.Atoi(r.PathValue("id"))
+	apiToken, err := web.arena.Database.GetApiTokenById(apiTokenId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if apiToken != nil {
+		if err = web.arena.Database.DeleteApiToken(apiToken.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/api_tokens", 303)
+}
+
+func (web *Web) renderApiTokens(w http.ResponseWriter, r *http.Request, newToken, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_api_tokens.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	apiTokens, err := web.arena.Database.GetAllApiTokens()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	auditEntries, err := web.arena.Database.GetRecentApiAuditEntries(numApiAuditEntriesShown)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	data := struct {
+		*model.EventSettings
+		ApiTokens    []model.ApiToken
+		AuditEntries []model.ApiAuditEntry
+		NewToken     string
+		ErrorMessage string
+	}{web.arena.EventSettings, apiTokens, auditEntries, newToken, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
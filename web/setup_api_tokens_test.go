@@ -0,0 +1,55 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestSetupApiTokens(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/api_tokens")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No API tokens have been created.")
+
+	recorder = web.postHttpResponse("/setup/api_tokens", "name=")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "A name is required for the API token.")
+
+	recorder = web.postHttpResponse("/setup/api_tokens", "name=Stream Deck")
+	assert.Equal(t, 200, recorder.Code)
+	matches := regexp.MustCompile(`<code id="newToken">(\w+)</code>`).FindStringSubmatch(recorder.Body.String())
+	if assert.Equal(t, 2, len(matches)) {
+		apiToken, err := web.arena.Database.GetApiTokenByToken(matches[1])
+		assert.Nil(t, err)
+		if assert.NotNil(t, apiToken) {
+			assert.Equal(t, "Stream Deck", apiToken.Name)
+			assert.NotEqual(t, matches[1], apiToken.TokenHash)
+		}
+
+		// Check that the token isn't shown again.
+		recorder = web.getHttpResponse("/setup/api_tokens")
+		assert.Contains(t, recorder.Body.String(), "Stream Deck")
+		assert.NotContains(t, recorder.Body.String(), matches[1])
+	}
+
+	web.postControlApiResponse("abortMatch", "wrong", "")
+	recorder = web.getHttpResponse("/setup/api_tokens")
+	assert.Contains(t, recorder.Body.String(), "abortMatch")
+	assert.Contains(t, recorder.Body.String(), "A valid API token is required.")
+
+	apiTokens, _ := web.arena.Database.GetAllApiTokens()
+	if assert.Equal(t, 1, len(apiTokens)) {
+		recorder = web.postHttpResponse(fmt.Sprintf("/setup/api_tokens/%d/revoke", apiTokens[0].Id), "")
+		assert.Equal(t, 303, recorder.Code)
+	}
+	apiTokens, _ = web.arena.Database.GetAllApiTokens()
+	assert.Empty(t, apiTokens)
+	assert.Nil(t, web.arena.Database.CreateApiToken(&model.ApiToken{Name: "Other"}))
+}
@@ -70,6 +70,17 @@ func (web *Web) awardsPostHandler(w http.ResponseWriter, r *http.Request) {
 			TeamId:     teamId,
 			PersonName: r.PostFormValue("personName"),
 		}
+		if awardId != 0 {
+			// An award that has already been revealed stays public when edited.
+			existingAward, err := web.arena.Database.GetAwardById(awardId)
+			if err != nil {
+				handleWebErr(w, err)
+				return
+			}
+			if existingAward != nil {
+				award.Revealed = existingAward.Revealed
+			}
+		}
 		if err := tournament.CreateOrUpdateAward(web.arena.Database, &award, true); err != nil {
 			handleWebErr(w, err)
 			return
@@ -12,8 +12,8 @@ import (
 func TestSetupAwards(t *testing.T) {
 	web := setupTestWeb(t)
 
-	web.arena.Database.CreateAward(&model.Award{0, model.JudgedAward, "Spirit Award", 0, ""})
-	web.arena.Database.CreateAward(&model.Award{0, model.JudgedAward, "Saftey Award", 0, ""})
+	web.arena.Database.CreateAward(&model.Award{0, model.JudgedAward, "Spirit Award", 0, "", false})
+	web.arena.Database.CreateAward(&model.Award{0, model.JudgedAward, "Saftey Award", 0, "", false})
 
 	recorder := web.getHttpResponse("/setup/awards")
 	assert.Equal(t, 200, recorder.Code)
@@ -32,4 +32,14 @@ func TestSetupAwards(t *testing.T) {
 	recorder = web.getHttpResponse("/setup/awards")
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "Englebert")
+
+	// Check that editing an award that has already been revealed keeps it public.
+	award, _ := web.arena.Database.GetAwardById(2)
+	award.Revealed = true
+	assert.Nil(t, web.arena.Database.UpdateAward(award))
+	recorder = web.postHttpResponse("/setup/awards", "id=2&awardName=Safety+Award")
+	assert.Equal(t, 303, recorder.Code)
+	award, _ = web.arena.Database.GetAwardById(2)
+	assert.Equal(t, "Safety Award", award.AwardName)
+	assert.True(t, award.Revealed)
 }
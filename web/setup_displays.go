@@ -18,7 +18,7 @@ import (
 
 // Shows the displays configuration page.
 func (web *Web) displaysGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.FtaRole, model.QueuerRole) {
 		return
 	}
 
@@ -40,7 +40,7 @@ func (web *Web) displaysGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for the display configuration page to send control commands and receive status updates.
 func (web *Web) displaysWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.FtaRole, model.QueuerRole) {
 		return
 	}
 
@@ -26,7 +26,7 @@ const (
 
 // Shows the Field Testing page.
 func (web *Web) fieldTestingGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.FtaRole) {
 		return
 	}
 
@@ -65,7 +65,7 @@ func (web *Web) fieldTestingGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for sending realtime updates to the Field Testing page.
 func (web *Web) fieldTestingWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.FtaRole) {
 		return
 	}
 
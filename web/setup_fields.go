@@ -0,0 +1,152 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for configuring the number of competition fields and the hardware of each additional field.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// The largest number of fields that can be run from a single server.
+const maxNumFields = 4
+
+// Shows the field configuration page.
+func (web *Web) fieldsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderFields(w, r, "")
+}
+
+// Saves the number of fields and the hardware settings of each additional field.
+func (web *Web) fieldsPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	numFields, _ := strconv.Atoi(r.PostFormValue("numFields"))
+	if numFields < 1 || numFields > maxNumFields {
+		web.renderFields(w, r, fmt.Sprintf("Number of fields must be between 1 and %d.", maxNumFields))
+		return
+	}
+	divisionsEnabled := r.PostFormValue("divisionsEnabled") == "on"
+	if divisionsEnabled && numFields < 3 {
+		web.renderFields(w, r, "Divisions need at least three fields: one for the finals and one for each division.")
+		return
+	}
+
+	allFieldSettings, err := web.getAdditionalFieldSettings(numFields)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	for i := range allFieldSettings {
+		fieldSettings := &allFieldSettings[i]
+		prefix := fmt.Sprintf("field%d", fieldSettings.Id)
+		fieldSettings.DriverStationAddress = r.PostFormValue(prefix + "DriverStationAddress")
+		fieldSettings.ApAddress = r.PostFormValue(prefix + "ApAddress")
+		fieldSettings.ApPassword = r.PostFormValue(prefix + "ApPassword")
+		fieldSettings.ApChannel, _ = strconv.Atoi(r.PostFormValue(prefix + "ApChannel"))
+		fieldSettings.SwitchAddress = r.PostFormValue(prefix + "SwitchAddress")
+		fieldSettings.SwitchPassword = r.PostFormValue(prefix + "SwitchPassword")
+		fieldSettings.RedSCCAddress = r.PostFormValue(prefix + "RedSCCAddress")
+		fieldSettings.BlueSCCAddress = r.PostFormValue(prefix + "BlueSCCAddress")
+		fieldSettings.PlcAddress = r.PostFormValue(prefix + "PlcAddress")
+		fieldSettings.LedControllerAddress = r.PostFormValue(prefix + "LedControllerAddress")
+		fieldSettings.TeamSignRed1Id, _ = strconv.Atoi(r.PostFormValue(prefix + "TeamSignRed1Id"))
+		fieldSettings.TeamSignRed2Id, _ = strconv.Atoi(r.PostFormValue(prefix + "TeamSignRed2Id"))
+		fieldSettings.TeamSignRed3Id, _ = strconv.Atoi(r.PostFormValue(prefix + "TeamSignRed3Id"))
+		fieldSettings.TeamSignRedTimerId, _ = strconv.Atoi(r.PostFormValue(prefix + "TeamSignRedTimerId"))
+		fieldSettings.TeamSignBlue1Id, _ = strconv.Atoi(r.PostFormValue(prefix + "TeamSignBlue1Id"))
+		fieldSettings.TeamSignBlue2Id, _ = strconv.Atoi(r.PostFormValue(prefix + "TeamSignBlue2Id"))
+		fieldSettings.TeamSignBlue3Id, _ = strconv.Atoi(r.PostFormValue(prefix + "TeamSignBlue3Id"))
+		fieldSettings.TeamSignBlueTimerId, _ = strconv.Atoi(r.PostFormValue(prefix + "TeamSignBlueTimerId"))
+		fieldSettings.BlackmagicAddresses = r.PostFormValue(prefix + "BlackmagicAddresses")
+		fieldSettings.CompanionAddress = r.PostFormValue(prefix + "CompanionAddress")
+		fieldSettings.CompanionPort, _ = strconv.Atoi(r.PostFormValue(prefix + "CompanionPort"))
+		fieldSettings.TbaEventCode = r.PostFormValue(prefix + "TbaEventCode")
+
+		existingFieldSettings, err := web.arena.Database.GetFieldSettingsById(fieldSettings.Id)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if existingFieldSettings == nil {
+			err = web.arena.Database.CreateFieldSettings(fieldSettings)
+		} else {
+			err = web.arena.Database.UpdateFieldSettings(fieldSettings)
+		}
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	web.arena.EventSettings.NumFields = numFields
+	web.arena.EventSettings.DivisionsEnabled = divisionsEnabled
+	if err = web.arena.Database.UpdateEventSettings(web.arena.EventSettings); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err = web.arena.LoadSettings(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/setup/fields", 303)
+}
+
+func (web *Web) renderFields(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_fields.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	allFieldSettings, err := web.getAdditionalFieldSettings(maxNumFields)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		MaxNumFields     int
+		RunningNumFields int
+		RunningDivisions bool
+		AllFieldSettings []model.FieldSettings
+		ErrorMessage     string
+	}{
+		web.arena.EventSettings,
+		maxNumFields,
+		1 + len(web.arena.FieldArenas()),
+		web.arena.HasDivisions(),
+		allFieldSettings,
+		errorMessage,
+	}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns the hardware settings for fields 2 through numFields, filling in empty settings for any not yet saved.
+func (web *Web) getAdditionalFieldSettings(numFields int) ([]model.FieldSettings, error) {
+	var allFieldSettings []model.FieldSettings
+	for fieldNumber := 2; fieldNumber <= numFields; fieldNumber++ {
+		fieldSettings, err := web.arena.Database.GetFieldSettingsById(fieldNumber)
+		if err != nil {
+			return nil, err
+		}
+		if fieldSettings == nil {
+			fieldSettings = &model.FieldSettings{Id: fieldNumber}
+		}
+		allFieldSettings = append(allFieldSettings, *fieldSettings)
+	}
+	return allFieldSettings, nil
+}
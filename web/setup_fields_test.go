@@ -0,0 +1,108 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetupFields(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/fields")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Number of fields")
+	assert.Contains(t, recorder.Body.String(), "field2ApAddress")
+
+	recorder = web.postHttpResponse(
+		"/setup/fields",
+		"numFields=2&field2DriverStationAddress=10.0.100.6&field2ApAddress=10.0.200.2&field2ApChannel=53&"+
+			"field2PlcAddress=10.0.200.10&field2TeamSignRed1Id=61&field2BlackmagicAddresses=10.0.200.50&"+
+			"field2CompanionAddress=10.0.200.60&field2CompanionPort=51235",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 2, web.arena.EventSettings.NumFields)
+	fieldSettings, err := web.arena.Database.GetFieldSettingsById(2)
+	assert.Nil(t, err)
+	if assert.NotNil(t, fieldSettings) {
+		assert.Equal(t, "10.0.100.6", fieldSettings.DriverStationAddress)
+		assert.Equal(t, "10.0.200.2", fieldSettings.ApAddress)
+		assert.Equal(t, 53, fieldSettings.ApChannel)
+		assert.Equal(t, "10.0.200.10", fieldSettings.PlcAddress)
+		assert.Equal(t, 61, fieldSettings.TeamSignRed1Id)
+		assert.Equal(t, "10.0.200.50", fieldSettings.BlackmagicAddresses)
+		assert.Equal(t, "10.0.200.60", fieldSettings.CompanionAddress)
+		assert.Equal(t, 51235, fieldSettings.CompanionPort)
+	}
+
+	// Check that the settings are updated in place on a subsequent save.
+	recorder = web.postHttpResponse("/setup/fields", "numFields=2&field2ApAddress=10.0.200.3")
+	assert.Equal(t, 303, recorder.Code)
+	fieldSettings, _ = web.arena.Database.GetFieldSettingsById(2)
+	assert.Equal(t, "10.0.200.3", fieldSettings.ApAddress)
+	recorder = web.getHttpResponse("/setup/fields")
+	assert.Contains(t, recorder.Body.String(), "10.0.200.3")
+}
+
+func TestSetupFieldsErrors(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/setup/fields", "numFields=0")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Number of fields must be between 1 and 4.")
+	recorder = web.postHttpResponse("/setup/fields", "numFields=5")
+	assert.Contains(t, recorder.Body.String(), "Number of fields must be between 1 and 4.")
+	assert.Equal(t, 1, web.arena.EventSettings.NumFields)
+	recorder = web.postHttpResponse("/setup/fields", "numFields=2&divisionsEnabled=on")
+	assert.Contains(t, recorder.Body.String(), "Divisions need at least three fields")
+	assert.False(t, web.arena.EventSettings.DivisionsEnabled)
+}
+
+func TestSetupFieldsDivisions(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse(
+		"/setup/fields", "numFields=3&divisionsEnabled=on&field2TbaEventCode=2026cur&field3TbaEventCode=2026dal",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.True(t, web.arena.EventSettings.DivisionsEnabled)
+	assert.False(t, web.arena.HasDivisions())
+	recorder = web.getHttpResponse("/setup/fields")
+	assert.Contains(t, recorder.Body.String(), "restart")
+
+	// The additional fields only start playing their divisions once Cheesy Arena is restarted.
+	assert.Nil(t, web.arena.CreateFieldArenas())
+	assert.True(t, web.arena.HasDivisions())
+	fieldSettings, _ := web.arena.Database.GetFieldSettingsById(3)
+	if assert.NotNil(t, fieldSettings) {
+		assert.Equal(t, "2026dal", fieldSettings.TbaEventCode)
+	}
+
+	// Check that the finals can't start before the divisions have finished.
+	recorder = web.postHttpResponse("/alliance_selection/start", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "the division on field 2 haven't finished yet")
+	assert.Empty(t, web.arena.AllianceSelectionAlliances)
+}
+
+func TestFieldRouting(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.NumFields = 2
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.CreateFieldArenas())
+
+	recorder := web.getHttpResponse("/match_play")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Match Play &ndash; Field 1")
+	recorder = web.getHttpResponse("/match_play?field=2")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Match Play &ndash; Field 2")
+
+	// Check that event-wide settings are saved through the first field, which propagates them to the others.
+	recorder = web.postHttpResponse("/setup/settings?field=2", "name=Two Field Event")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "Two Field Event", web.arena.EventSettings.Name)
+	assert.Equal(t, "Two Field Event", web.arena.GetFieldArena(2).EventSettings.Name)
+}
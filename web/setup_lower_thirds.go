@@ -8,6 +8,7 @@ package web
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/websocket"
 	"github.com/mitchellh/mapstructure"
 	"io"
@@ -17,7 +18,7 @@ import (
 
 // Shows the lower third configuration page.
 func (web *Web) lowerThirdsGetHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.EmceeRole) {
 		return
 	}
 
@@ -46,7 +47,7 @@ func (web *Web) lowerThirdsGetHandler(w http.ResponseWriter, r *http.Request) {
 
 // The websocket endpoint for the lower thirds client to send control commands.
 func (web *Web) lowerThirdsWebsocketHandler(w http.ResponseWriter, r *http.Request) {
-	if !web.userIsAdmin(w, r) {
+	if !web.userHasRole(w, r, model.EmceeRole) {
 		return
 	}
 
@@ -106,6 +107,22 @@ func (web *Web) lowerThirdsWebsocketHandler(w http.ResponseWriter, r *http.Reque
 			web.arena.LowerThird = &lowerThird
 			web.arena.ShowLowerThird = true
 			web.arena.LowerThirdNotifier.Notify()
+			if lowerThird.AwardId != 0 && lowerThird.BottomText != "" {
+				// Showing the lower third naming the winner is what reveals an award to the audience.
+				award, err := web.arena.Database.GetAwardById(lowerThird.AwardId)
+				if err != nil {
+					writeWebsocketError(ws, err.Error())
+					continue
+				}
+				if award != nil {
+					award.Revealed = true
+					if err = web.arena.Database.UpdateAward(award); err != nil {
+						writeWebsocketError(ws, err.Error())
+						continue
+					}
+					web.arena.SendWebhookEvent(partner.WebhookAwardRevealed, partner.NewWebhookAward(award))
+				}
+			}
 			continue
 		case "hideLowerThird":
 			var lowerThird model.LowerThird
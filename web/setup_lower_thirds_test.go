@@ -59,4 +59,15 @@ func TestSetupLowerThirds(t *testing.T) {
 	lowerThirds, _ := web.arena.Database.GetAllLowerThirds()
 	assert.Equal(t, 3, lowerThirds[0].Id)
 	assert.Equal(t, 2, lowerThirds[1].Id)
+
+	// Check that showing the lower third naming an award's winner reveals the award.
+	web.arena.Database.CreateAward(&model.Award{Type: model.JudgedAward, AwardName: "Spirit Award"})
+	ws.Write("showLowerThird", model.LowerThird{3, "Spirit Award", "", 2, 1})
+	time.Sleep(time.Millisecond * 10)
+	award, _ := web.arena.Database.GetAwardById(1)
+	assert.False(t, award.Revealed)
+	ws.Write("showLowerThird", model.LowerThird{3, "Spirit Award", "Team 254", 2, 1})
+	time.Sleep(time.Millisecond * 10)
+	award, _ = web.arena.Database.GetAwardById(1)
+	assert.True(t, award.Revealed)
 }
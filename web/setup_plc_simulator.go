@@ -0,0 +1,126 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for operating the simulated PLC.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/websocket"
+	"github.com/mitchellh/mapstructure"
+	"io"
+	"log"
+	"net/http"
+)
+
+// Names of the SimulatedPlcStatus fields representing PLC outputs, in the order they are shown.
+var plcSimulatorOutputNames = []string{
+	"StackLightRed",
+	"StackLightBlue",
+	"StackLightOrange",
+	"StackLightGreen",
+	"StackBuzzer",
+	"FieldResetLight",
+	"AwardsModeLight",
+	"RedHubMotor",
+	"BlueHubMotor",
+	"RedHubLight",
+	"BlueHubLight",
+}
+
+// Shows the PLC Simulator page.
+func (web *Web) plcSimulatorGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userHasRole(w, r, model.FtaRole) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_plc_simulator.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Stations  []string
+		Alliances []string
+		Outputs   []string
+	}{
+		web.arena.EventSettings,
+		[]string{"R1", "R2", "R3", "B1", "B2", "B3"},
+		[]string{"red", "blue"},
+		plcSimulatorOutputNames,
+	}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// The websocket endpoint for the PLC Simulator page to send input changes and receive I/O updates.
+func (web *Web) plcSimulatorWebsocketHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userHasRole(w, r, model.FtaRole) {
+		return
+	}
+
+	ws, err := websocket.NewWebsocket(w, r)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	defer closeWebsocket(ws)
+
+	// Subscribe the websocket to the notifiers whose messages will be passed on to the client, in a separate goroutine.
+	simulatedPlc := web.arena.SimulatedPlc
+	go ws.HandleNotifiers(simulatedPlc.IoChangeNotifier())
+
+	// Loop, waiting for commands and responding to them, until the client closes the connection.
+	for {
+		messageType, data, err := ws.Read()
+		if err != nil {
+			if err == io.EOF {
+				// Client has closed the connection; nothing to do here.
+				return
+			}
+			log.Println(err)
+			return
+		}
+
+		args := struct {
+			Station  string
+			Alliance string
+			Active   bool
+			Count    int
+		}{}
+		err = mapstructure.Decode(data, &args)
+		if err != nil {
+			ws.WriteError(err.Error())
+			continue
+		}
+
+		switch messageType {
+		case "setFieldEStop":
+			simulatedPlc.SetFieldEStop(args.Active)
+		case "setTeamEStop":
+			err = simulatedPlc.SetTeamEStop(args.Station, args.Active)
+		case "setTeamAStop":
+			err = simulatedPlc.SetTeamAStop(args.Station, args.Active)
+		case "setEthernetConnected":
+			err = simulatedPlc.SetEthernetConnected(args.Station, args.Active)
+		case "setFtaReady":
+			simulatedPlc.SetFtaReady(args.Active)
+		case "addHubCount":
+			err = simulatedPlc.AddHubCount(args.Alliance, args.Count)
+		default:
+			ws.WriteError(fmt.Sprintf("Invalid message type '%s'.", messageType))
+			continue
+		}
+		if err != nil {
+			ws.WriteError(err.Error())
+			continue
+		}
+		simulatedPlc.IoChangeNotifier().Notify()
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetupPlcSimulator(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/plc_simulator")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The simulated PLC is not in use")
+	assert.Contains(t, recorder.Body.String(), "eStopB3")
+	assert.Contains(t, recorder.Body.String(), "outputRedHubMotor")
+
+	web.arena.EventSettings.PlcSimulated = true
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.LoadSettings())
+	assert.Equal(t, web.arena.SimulatedPlc, web.arena.Plc)
+	recorder = web.getHttpResponse("/setup/plc_simulator")
+	assert.NotContains(t, recorder.Body.String(), "The simulated PLC is not in use")
+
+	web.arena.EventSettings.PlcSimulated = false
+	assert.Nil(t, web.arena.Database.UpdateEventSettings(web.arena.EventSettings))
+	assert.Nil(t, web.arena.LoadSettings())
+	assert.NotEqual(t, web.arena.SimulatedPlc, web.arena.Plc)
+}
+
+func TestSetupPlcSimulatorWebsocket(t *testing.T) {
+	web := setupTestWeb(t)
+	simulatedPlc := web.arena.SimulatedPlc
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/setup/plc_simulator/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+	readWebsocketType(t, ws, "plcIoChange")
+
+	ws.Write("setFieldEStop", map[string]any{"Active": true})
+	readWebsocketType(t, ws, "plcIoChange")
+	assert.True(t, simulatedPlc.GetFieldEStop())
+
+	ws.Write("setTeamAStop", map[string]any{"Station": "R3", "Active": true})
+	readWebsocketType(t, ws, "plcIoChange")
+	redAStops, _ := simulatedPlc.GetTeamAStops()
+	assert.Equal(t, [3]bool{false, false, true}, redAStops)
+
+	ws.Write("setEthernetConnected", map[string]any{"Station": "B2", "Active": false})
+	readWebsocketType(t, ws, "plcIoChange")
+	_, blueEthernets := simulatedPlc.GetEthernetConnected()
+	assert.Equal(t, [3]bool{true, false, true}, blueEthernets)
+
+	ws.Write("addHubCount", map[string]any{"Alliance": "blue", "Count": 5})
+	message := readWebsocketType(t, ws, "plcIoChange")
+	assert.Equal(t, 5.0, message.(map[string]any)["Simulated"].(map[string]any)["BlueHubCount"])
+
+	ws.Write("setTeamEStop", map[string]any{"Station": "X9", "Active": true})
+	assert.Contains(t, readWebsocketError(t, ws), "invalid alliance station 'X9'")
+	ws.Write("blorpy", nil)
+	assert.Contains(t, readWebsocketError(t, ws), "Invalid message type 'blorpy'.")
+}
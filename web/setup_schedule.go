@@ -11,12 +11,24 @@ import (
 	"github.com/Team254/cheesy-arena/tournament"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Global vars to hold schedules that are in the process of being generated.
 var cachedMatches = make(map[model.MatchType][]model.Match)
 var cachedTeamFirstMatches = make(map[model.MatchType]map[int]string)
+var cachedScheduleReports = make(map[model.MatchType]*tournament.ScheduleReport)
+var cachedScheduleConstraints = make(map[model.MatchType]scheduleConstraintsForm)
+
+// Holds the raw text of the schedule constraints entered by the user, one constraint per line.
+type scheduleConstraintsForm struct {
+	LateArrivals    string
+	EarlyDepartures string
+	SharedTeams     string
+}
+
+const scheduleConstraintTimeFormat = "2006-01-02 03:04 PM"
 
 // Shows the schedule editing page.
 func (web *Web) scheduleGetHandler(w http.ResponseWriter, r *http.Request) {
@@ -90,12 +102,29 @@ func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	matches, err := tournament.BuildRandomSchedule(teams, scheduleBlocks, matchType)
+	constraintsForm := scheduleConstraintsForm{
+		LateArrivals:    r.PostFormValue("lateArrivals"),
+		EarlyDepartures: r.PostFormValue("earlyDepartures"),
+		SharedTeams:     r.PostFormValue("sharedTeams"),
+	}
+	cachedScheduleConstraints[matchType] = constraintsForm
+	constraints, err := constraintsForm.parse()
+	if err != nil {
+		web.renderSchedule(w, r, fmt.Sprintf("Invalid schedule constraints: %s.", err.Error()))
+		return
+	}
+
+	matches, err := tournament.BuildConstrainedSchedule(teams, scheduleBlocks, matchType, constraints)
 	if err != nil {
 		web.renderSchedule(w, r, fmt.Sprintf("Error generating schedule: %s.", err.Error()))
 		return
 	}
+	if matchType == model.Qualification && !web.arena.IsDivision() && !web.arena.HasDivisions() {
+		// Alternate the matches between the fields, unless each field is playing its own division.
+		tournament.AssignFields(matches, web.arena.EventSettings.NumFields)
+	}
 	cachedMatches[matchType] = matches
+	cachedScheduleReports[matchType] = tournament.BuildScheduleReport(matches, constraints)
 
 	// Determine each team's first match.
 	teamFirstMatches := make(map[int]string)
@@ -199,6 +228,8 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 		NumTeams         int
 		Matches          []model.Match
 		TeamFirstMatches map[int]string
+		Report           *tournament.ScheduleReport
+		Constraints      scheduleConstraintsForm
 		ErrorMessage     string
 	}{
 		web.arena.EventSettings,
@@ -207,6 +238,8 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 		len(teams),
 		cachedMatches[matchType],
 		cachedTeamFirstMatches[matchType],
+		cachedScheduleReports[matchType],
+		cachedScheduleConstraints[matchType],
 		errorMessage,
 	}
 	err = template.ExecuteTemplate(w, "base", data)
@@ -244,6 +277,69 @@ func getScheduleBlocks(r *http.Request) ([]model.ScheduleBlock, error) {
 	return scheduleBlocks, returnErr
 }
 
+// Parses the constraints from the form text, in which each late arrival or early departure is given as
+// "<team>, <time>" and each pair of teams sharing a robot or pit crew is given as "<team>, <team>".
+func (form *scheduleConstraintsForm) parse() (tournament.ScheduleConstraints, error) {
+	constraints := tournament.ScheduleConstraints{
+		AvailableFrom:  make(map[int]time.Time),
+		AvailableUntil: make(map[int]time.Time),
+	}
+	location, _ := time.LoadLocation("Local")
+	parseTeamTimes := func(text, description string, teamTimes map[int]time.Time) error {
+		for _, line := range getNonEmptyLines(text) {
+			fields := strings.SplitN(line, ",", 2)
+			if len(fields) != 2 {
+				return fmt.Errorf("%s '%s' should be of the form '<team>, <YYYY-MM-DD hh:mm AM>'", description, line)
+			}
+			teamId, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+			if err != nil {
+				return fmt.Errorf("%s '%s' has an invalid team number", description, line)
+			}
+			teamTimes[teamId], err = time.ParseInLocation(
+				scheduleConstraintTimeFormat, strings.TrimSpace(fields[1]), location,
+			)
+			if err != nil {
+				return fmt.Errorf("%s '%s' has an invalid time", description, line)
+			}
+		}
+		return nil
+	}
+	if err := parseTeamTimes(form.LateArrivals, "late arrival", constraints.AvailableFrom); err != nil {
+		return constraints, err
+	}
+	if err := parseTeamTimes(form.EarlyDepartures, "early departure", constraints.AvailableUntil); err != nil {
+		return constraints, err
+	}
+
+	for _, line := range getNonEmptyLines(form.SharedTeams) {
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return constraints, fmt.Errorf("shared teams '%s' should be of the form '<team>, <team>'", line)
+		}
+		var pair [2]int
+		for i, field := range fields {
+			teamId, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return constraints, fmt.Errorf("shared teams '%s' has an invalid team number", line)
+			}
+			pair[i] = teamId
+		}
+		constraints.SharedTeams = append(constraints.SharedTeams, pair)
+	}
+	return constraints, nil
+}
+
+// Returns the trimmed, non-empty lines of the given text.
+func getNonEmptyLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 func getMatchType(r *http.Request) string {
 	if matchType, ok := r.URL.Query()["matchType"]; ok {
 		return matchType[0]
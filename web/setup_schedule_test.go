@@ -6,6 +6,7 @@ package web
 import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 	"time"
 )
@@ -33,6 +34,7 @@ func TestSetupSchedule(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "2014-01-01 09:48:00") // Last match of first block.
 	assert.Contains(t, recorder.Body.String(), "2014-01-02 11:48:00") // Last match of second block.
 	assert.Contains(t, recorder.Body.String(), "2014-01-03 16:54:00") // Last match of third block.
+	assert.Contains(t, recorder.Body.String(), "Minimum matches between plays")
 
 	// Save schedule and check that it was persisted.
 	recorder = web.postHttpResponse("/setup/schedule/save?matchType=qualification", "")
@@ -95,3 +97,71 @@ func TestSetupScheduleErrors(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "schedule of 2 Practice matches already exists")
 }
+
+func TestSetupScheduleConstraints(t *testing.T) {
+	web := setupTestWeb(t)
+
+	for i := 0; i < 24; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+	postData := "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=32&matchSpacingSec0=360&" +
+		"matchType=qualification&lateArrivals=101, 2014-01-01 10:00 AM&earlyDepartures=102, 2014-01-01 11:00 AM" +
+		"&sharedTeams=103, 104%0A105,106"
+	recorder := web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.Contains(t, recorder.Body.String(), "101, 2014-01-01 10:00 AM")
+	assert.Contains(t, recorder.Body.String(), "105,106")
+	assert.Contains(t, recorder.Body.String(), "Schedule Quality")
+	assert.NotContains(t, recorder.Body.String(), "alert-danger")
+
+	location, _ := time.LoadLocation("Local")
+	for _, match := range cachedMatches[model.Qualification] {
+		teams := []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3}
+		if match.Time.Before(time.Date(2014, 1, 1, 10, 0, 0, 0, location)) {
+			assert.NotContains(t, teams, 101)
+		}
+		if match.Time.After(time.Date(2014, 1, 1, 11, 0, 0, 0, location)) {
+			assert.NotContains(t, teams, 102)
+		}
+	}
+
+	// Check that malformed constraints are rejected.
+	recorder = web.postHttpResponse(
+		"/setup/schedule/generate", strings.Replace(postData, "101, 2014-01-01 10:00 AM", "blorpy", 1),
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(
+		t,
+		recorder.Body.String(),
+		"Invalid schedule constraints: late arrival 'blorpy' should be of the form",
+	)
+	recorder = web.postHttpResponse(
+		"/setup/schedule/generate", strings.Replace(postData, "103, 104", "103, 999", 1),
+	)
+	assert.Contains(t, recorder.Body.String(), "schedule constraint refers to team 999")
+}
+
+func TestSetupScheduleAlternatingFields(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.NumFields = 2
+
+	for i := 0; i < 18; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+	postData := "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=6&matchSpacingSec0=360&" +
+		"matchType=qualification"
+	recorder := web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/schedule?matchType=qualification")
+	assert.Contains(t, recorder.Body.String(), "<th>Field</th>")
+
+	recorder = web.postHttpResponse("/setup/schedule/save?matchType=qualification", "")
+	assert.Equal(t, 303, recorder.Code)
+	matches, err := web.arena.Database.GetMatchesByType(model.Qualification, true)
+	assert.Nil(t, err)
+	if assert.True(t, len(matches) >= 2) {
+		assert.Equal(t, 1, matches[0].FieldNumber)
+		assert.Equal(t, 2, matches[1].FieldNumber)
+	}
+}
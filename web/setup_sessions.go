@@ -0,0 +1,73 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for viewing and revoking active login sessions.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// Shows the list of active login sessions.
+func (web *Web) sessionsGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_sessions.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	sessions, err := web.arena.Database.GetAllUserSessions()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var activeSessions []model.UserSession
+	for _, session := range sessions {
+		if !session.IsExpired(web.arena.EventSettings.SessionLifetimeHours) {
+			activeSessions = append(activeSessions, session)
+		}
+	}
+	currentSessionId := 0
+	if currentSession := web.getUserSessionFromCookie(r); currentSession != nil {
+		currentSessionId = currentSession.Id
+	}
+
+	data := struct {
+		*model.EventSettings
+		Sessions         []model.UserSession
+		CurrentSessionId int
+	}{web.arena.EventSettings, activeSessions, currentSessionId}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Deletes the given login session, forcing its user to log in again.
+func (web *Web) sessionRevokePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	sessionId, _ := strconv.Atoi(r.PathValue("id"))
+	session, err := web.arena.Database.GetUserSessionById(sessionId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if session != nil {
+		if err = web.arena.Database.DeleteUserSession(session.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/sessions", 303)
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSetupSessions(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.SessionLifetimeHours = 24
+
+	web.arena.Database.CreateUserSession(
+		&model.UserSession{
+			Token:     "token1",
+			Username:  "redscorer",
+			CreatedAt: time.Now(),
+			IpAddress: "10.0.100.12",
+			UserAgent: "Mozilla/5.0 (iPad)",
+		},
+	)
+	web.arena.Database.CreateUserSession(
+		&model.UserSession{Token: "token2", Username: "oldscorer", CreatedAt: time.Now().Add(-48 * time.Hour)},
+	)
+
+	recorder := web.getHttpResponse("/setup/sessions")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "redscorer")
+	assert.Contains(t, recorder.Body.String(), "10.0.100.12")
+	assert.Contains(t, recorder.Body.String(), "Mozilla/5.0 (iPad)")
+	assert.NotContains(t, recorder.Body.String(), "oldscorer")
+
+	recorder = web.postHttpResponse("/setup/sessions/1/revoke", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "/setup/sessions", recorder.Header().Get("Location"))
+	session, _ := web.arena.Database.GetUserSessionByToken("token1")
+	assert.Nil(t, session)
+	recorder = web.getHttpResponse("/setup/sessions")
+	assert.Contains(t, recorder.Body.String(), "There are no active login sessions.")
+}
@@ -8,12 +8,16 @@ package web
 import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/playoff"
+	"github.com/Team254/cheesy-arena/tournament"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -59,6 +63,12 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	if playoffTypeValue == "" && eventSettings.PlayoffType == model.SingleEliminationPlayoff {
 		playoffTypeValue = "SingleEliminationPlayoff"
 	}
+	if playoffTypeValue == "" && eventSettings.PlayoffType == model.RoundRobinPlayoff {
+		playoffTypeValue = "RoundRobinPlayoff"
+	}
+	if playoffTypeValue == "" && eventSettings.PlayoffType == model.CustomPlayoff {
+		playoffTypeValue = "CustomPlayoff"
+	}
 	if playoffTypeValue == "SingleEliminationPlayoff" || playoffTypeValue == "single" {
 		playoffType = model.SingleEliminationPlayoff
 		if r.PostFormValue("numPlayoffAlliances") == "" {
@@ -74,6 +84,34 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 			web.renderSettingsWithStatus(w, r, "Number of alliances must be between 2 and 16.", activeSettingsTab, http.StatusOK)
 			return
 		}
+	} else if playoffTypeValue == "CustomPlayoff" || playoffTypeValue == "custom" {
+		playoffType = model.CustomPlayoff
+		definition, err := playoff.ParseBracketDefinition([]byte(eventSettings.CustomBracketDefinition))
+		if err != nil {
+			web.renderSettingsWithStatus(
+				w, r, "A valid bracket definition must be uploaded before selecting a custom playoff type.",
+				activeSettingsTab, http.StatusOK,
+			)
+			return
+		}
+		numAlliances = definition.NumAlliances
+	} else if playoffTypeValue == "RoundRobinPlayoff" || playoffTypeValue == "roundrobin" {
+		playoffType = model.RoundRobinPlayoff
+		if r.PostFormValue("numPlayoffAlliances") == "" {
+			if eventSettings.PlayoffType == model.RoundRobinPlayoff {
+				numAlliances = eventSettings.NumPlayoffAlliances
+			} else {
+				numAlliances = 6
+			}
+		} else {
+			numAlliances, _ = strconv.Atoi(r.PostFormValue("numPlayoffAlliances"))
+		}
+		if numAlliances < 3 || numAlliances > 8 {
+			web.renderSettingsWithStatus(
+				w, r, "Number of alliances for round robin must be between 3 and 8.", activeSettingsTab, http.StatusOK,
+			)
+			return
+		}
 	} else {
 		playoffType = model.DoubleEliminationPlayoff
 		if r.PostFormValue("numPlayoffAlliances") == "" {
@@ -85,9 +123,9 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		} else {
 			numAlliances, _ = strconv.Atoi(r.PostFormValue("numPlayoffAlliances"))
 		}
-		if numAlliances != 4 && numAlliances != 8 {
+		if numAlliances != 4 && numAlliances != 6 && numAlliances != 8 {
 			web.renderSettingsWithStatus(
-				w, r, "Number of alliances for double elimination must be 4 or 8.", activeSettingsTab, http.StatusOK,
+				w, r, "Number of alliances for double elimination must be 4, 6 or 8.", activeSettingsTab, http.StatusOK,
 			)
 			return
 		}
@@ -108,6 +146,43 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	eventSettings.PlayoffType = playoffType
 
+	previousGameKey := eventSettings.GameKey
+	if previousGameKey == "" {
+		previousGameKey = game.DefaultGameKey
+	}
+	gameKey := r.PostFormValue("gameKey")
+	if gameKey == "" {
+		gameKey = previousGameKey
+	}
+	selectedGame, err := game.GetGame(gameKey)
+	if err != nil {
+		web.renderSettingsWithStatus(w, r, err.Error(), activeSettingsTab, http.StatusOK)
+		return
+	}
+	if _, err = selectedGame.Summarize(&game.Score{}, &game.Score{}); err != nil {
+		// Realtime scoring and stored match results only support the 2026 score format so far.
+		web.renderSettingsWithStatus(
+			w, r, fmt.Sprintf("Cannot select %s since realtime scoring doesn't support it yet.", selectedGame.Name()),
+			activeSettingsTab, http.StatusOK,
+		)
+		return
+	}
+	if gameKey != previousGameKey {
+		rankings, err := web.arena.Database.GetAllRankings()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if len(rankings) > 0 {
+			web.renderSettingsWithStatus(
+				w, r, "Cannot change the game after qualification matches have been played.", activeSettingsTab,
+				http.StatusOK,
+			)
+			return
+		}
+	}
+	eventSettings.GameKey = gameKey
+
 	eventSettings.NumPlayoffAlliances = numAlliances
 	eventSettings.SelectionRound2Order = r.PostFormValue("selectionRound2Order")
 	eventSettings.SelectionRound3Order = r.PostFormValue("selectionRound3Order")
@@ -135,8 +210,19 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.SCCUpCommands = r.PostFormValue("sccUpCommands")
 	eventSettings.SCCDownCommands = r.PostFormValue("sccDownCommands")
 	eventSettings.PlcAddress = r.PostFormValue("plcAddress")
+	eventSettings.PlcSimulated = r.PostFormValue("plcSimulated") == "on"
 	eventSettings.LedControllerAddress = r.PostFormValue("ledControllerAddress")
 	eventSettings.AdminPassword = r.PostFormValue("adminPassword")
+	if sessionLifetimeHoursValue := r.PostFormValue("sessionLifetimeHours"); sessionLifetimeHoursValue != "" {
+		sessionLifetimeHours, err := strconv.Atoi(sessionLifetimeHoursValue)
+		if err != nil || sessionLifetimeHours < 1 {
+			web.renderSettingsWithStatus(
+				w, r, "Login session lifetime must be at least one hour.", activeSettingsTab, http.StatusOK,
+			)
+			return
+		}
+		eventSettings.SessionLifetimeHours = sessionLifetimeHours
+	}
 	eventSettings.TeamSignRed1Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed1Id"))
 	eventSettings.TeamSignRed2Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed2Id"))
 	eventSettings.TeamSignRed3Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed3Id"))
@@ -176,16 +262,69 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.CompanionMatchAbortPage, _ = strconv.Atoi(r.PostFormValue("companionMatchAbortPage"))
 	eventSettings.CompanionMatchAbortRow, _ = strconv.Atoi(r.PostFormValue("companionMatchAbortRow"))
 	eventSettings.CompanionMatchAbortColumn, _ = strconv.Atoi(r.PostFormValue("companionMatchAbortColumn"))
+	eventSettings.MqttAddress = r.PostFormValue("mqttAddress")
+	eventSettings.MqttPort, _ = strconv.Atoi(r.PostFormValue("mqttPort"))
+	eventSettings.MqttUsername = r.PostFormValue("mqttUsername")
+	eventSettings.MqttPassword = r.PostFormValue("mqttPassword")
+	eventSettings.MqttTopicPrefix = r.PostFormValue("mqttTopicPrefix")
 	eventSettings.AutoDurationSec, _ = strconv.Atoi(r.PostFormValue("autoDurationSec"))
 	eventSettings.PauseDurationSec, _ = strconv.Atoi(r.PostFormValue("pauseDurationSec"))
 	eventSettings.TransitionShiftDurationSec, _ = strconv.Atoi(r.PostFormValue("transitionShiftDurationSec"))
 	eventSettings.ShiftDurationSec, _ = strconv.Atoi(r.PostFormValue("shiftDurationSec"))
 	eventSettings.EndgameDurationSec, _ = strconv.Atoi(r.PostFormValue("endgameDurationSec"))
+	if gameKey != previousGameKey {
+		// Start the newly selected game off with its own period durations rather than those of the previous one.
+		matchTiming := selectedGame.DefaultMatchTiming()
+		eventSettings.AutoDurationSec = matchTiming.AutoDurationSec
+		eventSettings.PauseDurationSec = matchTiming.PauseDurationSec
+		eventSettings.TransitionShiftDurationSec = matchTiming.TransitionShiftDurationSec
+		eventSettings.ShiftDurationSec = matchTiming.ShiftDurationSec
+		eventSettings.EndgameDurationSec = matchTiming.EndgameDurationSec
+	}
 	eventSettings.EnergizedBonusThreshold, _ = strconv.Atoi(r.PostFormValue("energizedBonusThreshold"))
 	eventSettings.SuperchargedBonusThreshold, _ = strconv.Atoi(r.PostFormValue("superchargedBonusThreshold"))
 	eventSettings.TraversalBonusThreshold, _ = strconv.Atoi(r.PostFormValue("traversalBonusThreshold"))
 
-	err := web.arena.Database.UpdateEventSettings(eventSettings)
+	// Keep the existing ranking rules if the request doesn't include them, but otherwise take them entirely from the
+	// request so that a partially filled-in set of rules is rejected rather than silently replaced by the defaults.
+	previousRankingRules := eventSettings.RankingRules()
+	rankingRules := previousRankingRules
+	rankingRulesPosted := false
+	for key := range r.PostForm {
+		if strings.HasPrefix(key, "ranking") {
+			rankingRulesPosted = true
+		}
+	}
+	if rankingRulesPosted {
+		var rankingCriteria []string
+		for i := range game.AllRankingCriteria() {
+			if criterion := r.PostFormValue(fmt.Sprintf("rankingCriterion%d", i+1)); criterion != "" {
+				rankingCriteria = append(rankingCriteria, criterion)
+			}
+		}
+		rankingRules = game.RankingRules{}
+		rankingRules.WinPoints, _ = strconv.Atoi(r.PostFormValue("rankingWinPoints"))
+		rankingRules.TiePoints, _ = strconv.Atoi(r.PostFormValue("rankingTiePoints"))
+		rankingRules.LossPoints, _ = strconv.Atoi(r.PostFormValue("rankingLossPoints"))
+		rankingRules.BonusRankingPointsEnabled = r.PostFormValue("rankingBonusPointsEnabled") == "on"
+		rankingRules.DropLowestMatches, _ = strconv.Atoi(r.PostFormValue("rankingDropLowestMatches"))
+		var err error
+		if rankingRules.Criteria, err = game.ParseRankingCriteria(strings.Join(rankingCriteria, ",")); err == nil {
+			err = rankingRules.Validate()
+		}
+		if err != nil {
+			web.renderSettingsWithStatus(w, r, "Invalid ranking rules: "+err.Error(), activeSettingsTab, http.StatusOK)
+			return
+		}
+	}
+	eventSettings.RankingWinPoints = rankingRules.WinPoints
+	eventSettings.RankingTiePoints = rankingRules.TiePoints
+	eventSettings.RankingLossPoints = rankingRules.LossPoints
+	eventSettings.RankingBonusPointsEnabled = rankingRules.BonusRankingPointsEnabled
+	eventSettings.RankingDropLowestMatches = rankingRules.DropLowestMatches
+	eventSettings.RankingCriteria = rankingRules.CriteriaString()
+
+	err = web.arena.Database.UpdateEventSettings(eventSettings)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -198,6 +337,21 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Recalculate the rankings if the rules that determine them have changed.
+	if !reflect.DeepEqual(game.CurrentRankingRules, previousRankingRules) {
+		rankings, err := web.arena.Database.GetAllRankings()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if len(rankings) > 0 {
+			if _, err = tournament.CalculateRankings(web.arena.Database, false); err != nil {
+				handleWebErr(w, err)
+				return
+			}
+		}
+	}
+
 	if eventSettings.AdminPassword != previousAdminPassword {
 		// Delete any existing user sessions to force a logout.
 		if err := web.arena.Database.TruncateUserSessions(); err != nil {
@@ -295,24 +449,9 @@ func (web *Web) restoreDbHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Replace the current database with the new one.
-	if err = web.arena.Database.Close(); err != nil {
-		handleWebErr(w, err)
-		return
-	}
-	err = os.Remove(web.arena.Database.Path)
-	if err != nil {
-		handleWebErr(w, err)
-		return
-	}
-	err = os.Rename(tempFilePath, web.arena.Database.Path)
-	if err != nil {
-		handleWebErr(w, err)
-		return
-	}
-	tempFilePath = ""
-	web.arena.Database, err = model.OpenDatabase(web.arena.Database.Path)
-	if err != nil {
+	// Replace the contents of the current database with those of the new one in place, so that the arenas for any
+	// additional fields and divisions, which hold on to the same database, see the restored data too.
+	if err = web.arena.Database.ReplaceWithSnapshot(tempFilePath); err != nil {
 		handleWebErr(w, err)
 		return
 	}
@@ -382,7 +521,7 @@ func (web *Web) settingsPublishAlliancesHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	if web.arena.EventSettings.TbaPublishingEnabled {
+	if web.arena.ShouldPublishToTba() {
 		err := web.arena.TbaClient.PublishAlliances(web.arena.Database)
 		if err != nil {
 			web.renderSettingsWithStatus(
@@ -404,7 +543,7 @@ func (web *Web) settingsPublishAwardsHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if web.arena.EventSettings.TbaPublishingEnabled {
+	if web.arena.ShouldPublishToTba() {
 		err := web.arena.TbaClient.PublishAwards(web.arena.Database)
 		if err != nil {
 			web.renderSettingsWithStatus(
@@ -412,6 +551,20 @@ func (web *Web) settingsPublishAwardsHandler(w http.ResponseWriter, r *http.Requ
 			)
 			return
 		}
+
+		// The awards are public now that they're on TBA.
+		awards, err := web.arena.Database.GetAllAwards()
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		for _, award := range awards {
+			award.Revealed = true
+			if err = web.arena.Database.UpdateAward(&award); err != nil {
+				handleWebErr(w, err)
+				return
+			}
+		}
 	} else {
 		web.renderSettingsWithStatus(w, r, "TBA publishing is not enabled", "publishing", http.StatusInternalServerError)
 		return
@@ -426,7 +579,7 @@ func (web *Web) settingsPublishMatchesHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if web.arena.EventSettings.TbaPublishingEnabled {
+	if web.arena.ShouldPublishToTba() {
 		err := web.arena.TbaClient.DeletePublishedMatches()
 		if err != nil {
 			web.renderSettingsWithStatus(
@@ -455,7 +608,7 @@ func (web *Web) settingsPublishRankingsHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if web.arena.EventSettings.TbaPublishingEnabled {
+	if web.arena.ShouldPublishToTba() {
 		err := web.arena.TbaClient.PublishRankings(web.arena.Database)
 		if err != nil {
 			web.renderSettingsWithStatus(
@@ -477,7 +630,7 @@ func (web *Web) settingsPublishTeamsHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	if web.arena.EventSettings.TbaPublishingEnabled {
+	if web.arena.ShouldPublishToTba() {
 		err := web.arena.TbaClient.PublishTeams(web.arena.Database)
 		if err != nil {
 			web.renderSettingsWithStatus(
@@ -505,12 +658,47 @@ func (web *Web) renderSettingsWithStatus(
 		handleWebErr(w, err)
 		return
 	}
+
+	// Pad out the configured ranking criteria so that there is a selector for each possible one.
+	rankingRules := web.arena.EventSettings.RankingRules()
+	rankingCriterionSlots := make([]game.RankingCriterion, len(game.AllRankingCriteria()))
+	copy(rankingCriterionSlots, rankingRules.Criteria)
+
 	data := struct {
 		*model.EventSettings
-		ErrorMessage      string
-		ActiveSettingsTab string
-		NexusBaseUrl      string
-	}{web.arena.EventSettings, errorMessage, activeSettingsTab, web.arena.NexusClient.BaseUrl}
+		ErrorMessage          string
+		ActiveSettingsTab     string
+		NexusBaseUrl          string
+		Games                 []game.Game
+		CurrentRankingRules   game.RankingRules
+		RankingCriteria       []game.RankingCriterion
+		RankingCriterionSlots []game.RankingCriterion
+		CustomBracketName     string
+		DivisionFieldNumbers  []int
+	}{
+		web.arena.EventSettings,
+		errorMessage,
+		activeSettingsTab,
+		web.arena.NexusClient.BaseUrl,
+		game.GetAllGames(),
+		rankingRules,
+		game.AllRankingCriteria(),
+		rankingCriterionSlots,
+		"",
+		nil,
+	}
+	if web.arena.HasDivisions() {
+		for _, fieldArena := range web.arena.FieldArenas() {
+			data.DivisionFieldNumbers = append(data.DivisionFieldNumbers, fieldArena.FieldNumber)
+		}
+	}
+	if web.arena.EventSettings.CustomBracketDefinition != "" {
+		if definition, err := playoff.ParseBracketDefinition(
+			[]byte(web.arena.EventSettings.CustomBracketDefinition),
+		); err == nil {
+			data.CustomBracketName = definition.Name
+		}
+	}
 	if statusCode != http.StatusOK {
 		w.WriteHeader(statusCode)
 	}
@@ -521,6 +709,77 @@ func (web *Web) renderSettingsWithStatus(
 	}
 }
 
+// Saves an uploaded bracket definition and switches the playoff type to use it.
+func (web *Web) bracketDefinitionPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	file, _, err := r.FormFile("bracketFile")
+	if err != nil {
+		web.renderSettings(w, r, "No bracket definition file was specified.")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	definition, err := playoff.ParseBracketDefinition(data)
+	if err == nil {
+		// Also check the parts of the bracket that are only validated once the tournament is assembled.
+		_, err = playoff.NewCustomPlayoffTournament(definition)
+	}
+	if err != nil {
+		web.renderSettings(w, r, "Invalid bracket definition: "+err.Error())
+		return
+	}
+
+	alliances, err := web.arena.Database.GetAllAlliances()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if len(alliances) > 0 {
+		web.renderSettings(w, r, "Cannot change playoff type or size after alliance selection has been finalized.")
+		return
+	}
+
+	eventSettings := web.arena.EventSettings
+	eventSettings.PlayoffType = model.CustomPlayoff
+	eventSettings.NumPlayoffAlliances = definition.NumAlliances
+	eventSettings.CustomBracketDefinition = string(data)
+	if err = web.arena.Database.UpdateEventSettings(eventSettings); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if err = web.arena.LoadSettings(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	http.Redirect(w, r, "/setup/settings", 303)
+}
+
+// Serves the currently uploaded bracket definition as a file download.
+func (web *Web) bracketDefinitionGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if web.arena.EventSettings.CustomBracketDefinition == "" {
+		http.Error(w, "Error: no bracket definition has been uploaded", 404)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-yaml")
+	w.Header().Set("Content-Disposition", "attachment; filename=bracket.yaml")
+	_, err := w.Write([]byte(web.arena.EventSettings.CustomBracketDefinition))
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Deletes all match data (matches, results, and scheduled breaks) for the given match type.
 func (web *Web) deleteMatchDataForType(matchType model.MatchType) error {
 	matches, err := web.arena.Database.GetMatchesByType(matchType, true)
@@ -5,6 +5,7 @@ package web
 
 import (
 	"bytes"
+	"fmt"
 	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
@@ -14,6 +15,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 )
 
@@ -36,7 +38,8 @@ func TestSetupSettings(t *testing.T) {
 		"/setup/settings",
 		"name=Chezy Champs&code=CC&playoffType=single&numPlayoffAlliances=16&tbaPublishingEnabled=on&"+
 			"tbaEventCode=2014cc&tbaSecretId=secretId&tbaSecret=tbasec&transitionShiftDurationSec=12&"+
-			"shiftDurationSec=24&endgameDurationSec=32&ledControllerAddress=10.0.100.61",
+			"shiftDurationSec=24&endgameDurationSec=32&ledControllerAddress=10.0.100.61&sessionLifetimeHours=48&"+
+			"mqttAddress=10.0.100.70&mqttPort=1884&mqttTopicPrefix=chezy",
 	)
 	assert.Equal(t, 303, recorder.Code)
 	assert.Equal(t, "/setup/settings#event", recorder.Header().Get("Location"))
@@ -51,6 +54,10 @@ func TestSetupSettings(t *testing.T) {
 	assert.Equal(t, 24, web.arena.EventSettings.ShiftDurationSec)
 	assert.Equal(t, 32, web.arena.EventSettings.EndgameDurationSec)
 	assert.Equal(t, "10.0.100.61", web.arena.EventSettings.LedControllerAddress)
+	assert.Equal(t, 48, web.arena.EventSettings.SessionLifetimeHours)
+	assert.Equal(t, "10.0.100.70", web.arena.EventSettings.MqttAddress)
+	assert.Equal(t, 1884, web.arena.EventSettings.MqttPort)
+	assert.Equal(t, "chezy", web.arena.EventSettings.MqttTopicPrefix)
 	assert.Equal(t, 140, game.GetTeleopDurationSec())
 
 	recorder = web.postHttpResponse("/setup/settings", "name=Field Tab Event&activeSettingsTab=field")
@@ -115,8 +122,85 @@ func TestSetupSettingsDoubleElimination(t *testing.T) {
 
 	recorder = web.postHttpResponse("/setup/settings", "playoffType=DoubleEliminationPlayoff&numPlayoffAlliances=3")
 	assert.Equal(t, 200, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "Number of alliances for double elimination must be 4 or 8.")
+	assert.Contains(t, recorder.Body.String(), "Number of alliances for double elimination must be 4, 6 or 8.")
 	assert.Equal(t, 4, web.arena.EventSettings.NumPlayoffAlliances)
+
+	recorder = web.postHttpResponse("/setup/settings", "playoffType=DoubleEliminationPlayoff&numPlayoffAlliances=6")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 6, web.arena.EventSettings.NumPlayoffAlliances)
+	assert.Contains(t, web.arena.PlayoffTournament.MatchGroups(), "M9")
+}
+
+func TestSetupSettingsRoundRobin(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/setup/settings", "playoffType=RoundRobinPlayoff")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.RoundRobinPlayoff, web.arena.EventSettings.PlayoffType)
+	assert.Equal(t, 6, web.arena.EventSettings.NumPlayoffAlliances)
+	assert.Contains(t, web.arena.PlayoffTournament.MatchGroups(), "RR")
+
+	// The playoff type is retained when it isn't specified.
+	recorder = web.postHttpResponse("/setup/settings", "numPlayoffAlliances=5")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.RoundRobinPlayoff, web.arena.EventSettings.PlayoffType)
+	assert.Equal(t, 5, web.arena.EventSettings.NumPlayoffAlliances)
+
+	recorder = web.postHttpResponse("/setup/settings", "playoffType=roundrobin&numPlayoffAlliances=9")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Number of alliances for round robin must be between 3 and 8.")
+	assert.Equal(t, 5, web.arena.EventSettings.NumPlayoffAlliances)
+}
+
+func TestSetupSettingsCustomBracket(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// The custom type can't be selected before a definition has been uploaded.
+	recorder := web.postHttpResponse("/setup/settings", "playoffType=CustomPlayoff")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "A valid bracket definition must be uploaded")
+	assert.Equal(t, model.DoubleEliminationPlayoff, web.arena.EventSettings.PlayoffType)
+	recorder = web.getHttpResponse("/setup/settings/bracket")
+	assert.Equal(t, 404, recorder.Code)
+
+	definition, err := os.ReadFile("../brackets/single_elimination_4.json")
+	assert.Nil(t, err)
+	recorder = web.postFileHttpResponse("/setup/settings/bracket", "bracketFile", bytes.NewBuffer(definition))
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.Equal(t, model.CustomPlayoff, web.arena.EventSettings.PlayoffType)
+	assert.Equal(t, 4, web.arena.EventSettings.NumPlayoffAlliances)
+	assert.Equal(t, string(definition), web.arena.EventSettings.CustomBracketDefinition)
+	assert.Contains(t, web.arena.PlayoffTournament.MatchGroups(), "SF1")
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "Custom &ndash; Single-Elimination (4 Alliances)")
+	recorder = web.getHttpResponse("/setup/settings/bracket")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, string(definition), recorder.Body.String())
+
+	// The custom type and the alliance count from the definition are retained when saving other settings.
+	recorder = web.postHttpResponse("/setup/settings", "name=Custom+Event&numPlayoffAlliances=8")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.CustomPlayoff, web.arena.EventSettings.PlayoffType)
+	assert.Equal(t, 4, web.arena.EventSettings.NumPlayoffAlliances)
+
+	// An invalid definition is rejected and leaves the existing one in place.
+	recorder = web.postFileHttpResponse(
+		"/setup/settings/bracket", "bracketFile", bytes.NewBufferString("numAlliances: 1\nmatchups: []"),
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid bracket definition: bracket definition must have at least 2")
+	assert.Equal(t, string(definition), web.arena.EventSettings.CustomBracketDefinition)
+
+	// Switching back to a built-in format.
+	recorder = web.postHttpResponse("/setup/settings", "playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.SingleEliminationPlayoff, web.arena.EventSettings.PlayoffType)
+
+	// The definition can't be changed once alliance selection has been finalized.
+	assert.Nil(t, web.arena.Database.CreateAlliance(&model.Alliance{Id: 1}))
+	recorder = web.postFileHttpResponse("/setup/settings/bracket", "bracketFile", bytes.NewBuffer(definition))
+	assert.Contains(t, recorder.Body.String(), "Cannot change playoff type or size after alliance selection")
+	assert.Equal(t, model.SingleEliminationPlayoff, web.arena.EventSettings.PlayoffType)
 }
 
 func TestSetupSettingsInvalidValues(t *testing.T) {
@@ -129,7 +213,7 @@ func TestSetupSettingsInvalidValues(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "must be between 2 and 16")
 
 	recorder = web.postHttpResponse("/setup/settings", "playoffType=DoubleEliminationPlayoff&numPlayoffAlliances=3")
-	assert.Contains(t, recorder.Body.String(), "must be 4 or 8")
+	assert.Contains(t, recorder.Body.String(), "must be 4, 6 or 8")
 
 	// Changing the playoff type after alliance selection is finalized.
 	assert.Nil(t, web.arena.Database.CreateAlliance(&model.Alliance{Id: 1}))
@@ -291,6 +375,13 @@ func TestSetupSettingsPublishToTba(t *testing.T) {
 	assert.Equal(t, 303, recorder.Code)
 	assert.Equal(t, "/setup/settings#publishing", recorder.Header().Get("Location"))
 
+	// Check that publishing the awards reveals them.
+	web.arena.Database.CreateAward(&model.Award{Type: model.JudgedAward, AwardName: "Spirit Award"})
+	recorder = web.getHttpResponse("/setup/settings/publish_awards")
+	assert.Equal(t, 303, recorder.Code)
+	award, _ := web.arena.Database.GetAwardById(1)
+	assert.True(t, award.Revealed)
+
 	web.arena.TbaClient.BaseUrl = "fakeurl"
 
 	recorder = web.getHttpResponse("/setup/settings/publish_alliances")
@@ -331,3 +422,161 @@ func (web *Web) postFileHttpResponse(path string, paramName string, file *bytes.
 	web.newHandler().ServeHTTP(recorder, req)
 	return recorder
 }
+
+func TestSetupSettingsSessionLifetime(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/setup/settings", "name=Chezy Champs&sessionLifetimeHours=48")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 48, web.arena.EventSettings.SessionLifetimeHours)
+
+	// Check that omitting the session lifetime keeps it, and that it can't be set to never expire.
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 48, web.arena.EventSettings.SessionLifetimeHours)
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs&sessionLifetimeHours=0")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Login session lifetime must be at least one hour.")
+	assert.Equal(t, 48, web.arena.EventSettings.SessionLifetimeHours)
+}
+
+func TestSetupSettingsGame(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "<option value=\"2026\" selected>2026 REBUILT</option>")
+
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs&gameKey=1992&activeSettingsTab=game")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid game '1992'.")
+
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs&gameKey=2026&activeSettingsTab=game")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "2026", web.arena.EventSettings.GameKey)
+	assert.Equal(t, "2026", game.CurrentGame.Key())
+}
+
+// Variant of the 2026 game with a shorter autonomous period, used to verify that its timing is applied when selected.
+type shortAutoGame struct {
+	game.Rebuilt2026
+}
+
+func (shortAutoGame) Key() string {
+	return "short_auto"
+}
+
+func (shortAutoGame) DefaultMatchTiming() game.MatchTimingSettings {
+	matchTiming := game.Rebuilt2026{}.DefaultMatchTiming()
+	matchTiming.AutoDurationSec = 15
+	return matchTiming
+}
+
+// Game with its own score type, which realtime scoring doesn't support yet.
+type ownScoreGame struct {
+	game.Rebuilt2026
+}
+
+func (ownScoreGame) Key() string {
+	return "own_score"
+}
+
+func (ownScoreGame) Name() string {
+	return "Own Score Game"
+}
+
+func (ownScoreGame) Summarize(score, opponentScore game.AllianceScore) (*game.ScoreSummary, error) {
+	return nil, fmt.Errorf("unsupported score type %T", score)
+}
+
+func TestSetupSettingsGameTiming(t *testing.T) {
+	web := setupTestWeb(t)
+	game.RegisterGame(shortAutoGame{})
+	game.RegisterGame(ownScoreGame{})
+	defer game.SetCurrentGame(game.DefaultGameKey)
+
+	recorder := web.postHttpResponse("/setup/settings", "name=Chezy Champs&gameKey=own_score&autoDurationSec=20")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Cannot select Own Score Game since realtime scoring")
+	assert.Equal(t, "2026", web.arena.EventSettings.GameKey)
+
+	// Check that the new game's default timing replaces the posted timing only when the game changes.
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs&gameKey=short_auto&autoDurationSec=20")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "short_auto", web.arena.EventSettings.GameKey)
+	assert.Equal(t, 15, web.arena.EventSettings.AutoDurationSec)
+	assert.Equal(t, 15, game.MatchTiming.AutoDurationSec)
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs&gameKey=short_auto&autoDurationSec=17")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, 17, web.arena.EventSettings.AutoDurationSec)
+}
+
+func TestSetupSettingsRankingRules(t *testing.T) {
+	web := setupTestWeb(t)
+	defer func() {
+		game.CurrentRankingRules = game.CurrentGame.DefaultRankingRules()
+	}()
+
+	recorder := web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "<option value=\"TowerPoints\" selected>Tower Points</option>")
+	assert.Contains(t, recorder.Body.String(), "name=\"rankingCriterion5\"")
+
+	// Save rules and check that the existing rankings are recalculated.
+	match := model.Match{
+		Type:      model.Qualification,
+		TypeOrder: 1,
+		Red1:      1,
+		Red2:      2,
+		Red3:      3,
+		Blue1:     4,
+		Blue2:     5,
+		Blue3:     6,
+		Status:    game.RedWonMatch,
+	}
+	web.arena.Database.CreateMatch(&match)
+	web.arena.Database.CreateMatchResult(model.BuildTestMatchResult(match.Id, 1))
+	_, err := tournament.CalculateRankings(web.arena.Database, false)
+	assert.Nil(t, err)
+	recorder = web.postHttpResponse(
+		"/setup/settings",
+		"name=Chezy Champs&rankingWinPoints=2&rankingTiePoints=1&rankingLossPoints=0&rankingDropLowestMatches=1&"+
+			"rankingCriterion1=MatchPoints&rankingCriterion2=&rankingCriterion3=Wins&activeSettingsTab=game",
+	)
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.Equal(t, "MatchPoints,Wins", web.arena.EventSettings.RankingCriteria)
+	assert.Equal(t, 1, web.arena.EventSettings.RankingDropLowestMatches)
+	assert.False(t, web.arena.EventSettings.RankingBonusPointsEnabled)
+	assert.Equal(t, 2, game.CurrentRankingRules.WinPoints)
+	assert.Equal(
+		t, []game.RankingCriterion{game.MatchPointsCriterion, game.WinsCriterion}, game.CurrentRankingRules.Criteria,
+	)
+	rankings, _ := web.arena.Database.GetAllRankings()
+	if assert.Equal(t, 6, len(rankings)) {
+		for _, ranking := range rankings {
+			assert.Equal(t, 2*ranking.Wins, ranking.RankingPoints)
+		}
+	}
+
+	recorder = web.postHttpResponse(
+		"/setup/settings", "name=Chezy Champs&rankingCriterion1=MatchPoints&rankingCriterion2=MatchPoints",
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(
+		t, recorder.Body.String(), "Invalid ranking rules: ranking criterion 'Match Points' is listed more than once",
+	)
+	assert.Equal(t, "MatchPoints,Wins", web.arena.EventSettings.RankingCriteria)
+
+	// Check that posted ranking point values without any criteria are rejected rather than discarded.
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs&rankingWinPoints=5&rankingCriterion1=")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid ranking rules: at least one ranking criterion is required")
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs&rankingWinPoints=-1&rankingCriterion1=Wins")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid ranking rules: ranking point values must not be negative")
+	assert.Equal(t, 2, game.CurrentRankingRules.WinPoints)
+
+	// Omitting the ranking rules entirely keeps the existing ones.
+	recorder = web.postHttpResponse("/setup/settings", "name=Chezy Champs")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "MatchPoints,Wins", web.arena.EventSettings.RankingCriteria)
+	assert.Equal(t, 2, game.CurrentRankingRules.WinPoints)
+}
@@ -8,10 +8,14 @@ package web
 import (
 	"bytes"
 	"fmt"
+	"github.com/Team254/cheesy-arena/field"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/dchest/uniuri"
+	"log"
 	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -227,6 +231,59 @@ func (web *Web) teamDeletePostHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/setup/teams", 303)
 }
 
+// Withdraws a team from the rest of the event, replacing it with surrogates in its remaining qualification matches.
+func (web *Web) teamWithdrawPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	teamId, _ := strconv.Atoi(r.PathValue("id"))
+	currentMatch := web.arena.CurrentMatch
+	if web.arena.MatchState != field.PreMatch && currentMatch.Type == model.Qualification &&
+		slices.Contains(
+			[]int{
+				currentMatch.Red1, currentMatch.Red2, currentMatch.Red3,
+				currentMatch.Blue1, currentMatch.Blue2, currentMatch.Blue3,
+			},
+			teamId,
+		) {
+		http.Error(w, fmt.Sprintf("Error: Can't withdraw team %d while it is playing a match", teamId), 400)
+		return
+	}
+
+	changedMatches, err := tournament.WithdrawTeam(web.arena.Database, teamId)
+	if err != nil {
+		http.Error(w, "Error: "+err.Error(), 400)
+		return
+	}
+
+	// Reload the current match if the withdrawal changed its lineup, and otherwise refresh the queueing display.
+	reloaded := false
+	for _, match := range changedMatches {
+		if match.Id == currentMatch.Id && web.arena.MatchState == field.PreMatch {
+			if err = web.arena.LoadMatch(&match); err != nil {
+				handleWebErr(w, err)
+				return
+			}
+			reloaded = true
+		}
+	}
+	if !reloaded {
+		web.arena.MatchLoadNotifier.Notify()
+	}
+
+	if web.arena.ShouldPublishToTba() && len(changedMatches) > 0 {
+		// Publish asynchronously to The Blue Alliance.
+		go func() {
+			if err := web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
+				log.Printf("Failed to publish matches: %s", err.Error())
+			}
+		}()
+	}
+
+	http.Redirect(w, r, "/setup/teams", 303)
+}
+
 // Generates random WPA keys and saves them to the team models.
 func (web *Web) teamsGenerateWpaKeysHandler(w http.ResponseWriter, r *http.Request) {
 	if !web.userIsAdmin(w, r) {
@@ -281,7 +338,8 @@ func (web *Web) renderTeams(w http.ResponseWriter, r *http.Request, showErrorMes
 		*model.EventSettings
 		Teams            []model.Team
 		ShowErrorMessage bool
-	}{web.arena.EventSettings, teams, showErrorMessage}
+		CanWithdraw      bool
+	}{web.arena.EventSettings, teams, showErrorMessage, !web.canModifyTeamList()}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -172,6 +172,49 @@ func TestSetupTeamsDisallowModification(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "Teh Chezy Pofs")
 }
 
+func TestSetupTeamsWithdraw(t *testing.T) {
+	web := setupTestWeb(t)
+
+	for _, teamId := range []int{1, 2, 3, 4, 5, 6, 254} {
+		web.arena.Database.CreateTeam(&model.Team{Id: teamId})
+	}
+	match := model.Match{
+		Type:      model.Qualification,
+		TypeOrder: 1,
+		ShortName: "Q1",
+		Red1:      254,
+		Red2:      1,
+		Red3:      2,
+		Blue1:     3,
+		Blue2:     4,
+		Blue3:     5,
+	}
+	web.arena.Database.CreateMatch(&match)
+	assert.Nil(t, web.arena.LoadMatch(&match))
+
+	recorder := web.getHttpResponse("/setup/teams")
+	assert.Contains(t, recorder.Body.String(), "/setup/teams/254/withdraw")
+	assert.NotContains(t, recorder.Body.String(), "Withdrawn</span>")
+
+	recorder = web.postHttpResponse("/setup/teams/254/withdraw", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	team, _ := web.arena.Database.GetTeamById(254)
+	assert.True(t, team.Withdrawn)
+	assert.Equal(t, 6, web.arena.CurrentMatch.Red1)
+	assert.True(t, web.arena.CurrentMatch.Red1IsSurrogate)
+	assert.Equal(t, 6, web.arena.AllianceStations["R1"].Team.Id)
+
+	recorder = web.getHttpResponse("/setup/teams")
+	assert.Contains(t, recorder.Body.String(), "Withdrawn</span>")
+	assert.NotContains(t, recorder.Body.String(), "/setup/teams/254/withdraw")
+	recorder = web.getHttpResponse("/displays/queueing/match_load")
+	assert.Contains(t, recorder.Body.String(), "6 replaces withdrawn team 254")
+
+	recorder = web.postHttpResponse("/setup/teams/254/withdraw", "")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "already been withdrawn")
+}
+
 func TestSetupTeamsBadReqest(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -0,0 +1,158 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for managing user accounts and their roles.
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// Shows the user accounts configuration page.
+func (web *Web) usersGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderUsers(w, r, "")
+}
+
+// Saves the new or modified user account to the database, or deletes it.
+func (web *Web) usersPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	userId, _ := strconv.Atoi(r.PostFormValue("id"))
+	var user *model.User
+	if userId > 0 {
+		var err error
+		user, err = web.arena.Database.GetUserById(userId)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if user == nil {
+			web.renderUsers(w, r, fmt.Sprintf("User with ID %d does not exist.", userId))
+			return
+		}
+	}
+
+	if r.PostFormValue("action") == "delete" {
+		if user == nil {
+			web.renderUsers(w, r, "Cannot delete a user that has not been created.")
+			return
+		}
+		if err := web.arena.Database.DeleteUser(user.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err := web.arena.Database.DeleteUserSessionsByUsername(user.Username); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		http.Redirect(w, r, "/setup/users", 303)
+		return
+	}
+
+	username := r.PostFormValue("username")
+	password := r.PostFormValue("password")
+	if username == "" {
+		web.renderUsers(w, r, "Username must not be blank.")
+		return
+	}
+	if username == adminUser {
+		web.renderUsers(w, r, fmt.Sprintf("Username '%s' is reserved.", adminUser))
+		return
+	}
+	existingUser, err := web.arena.Database.GetUserByUsername(username)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if existingUser != nil && (user == nil || existingUser.Id != user.Id) {
+		web.renderUsers(w, r, fmt.Sprintf("User '%s' already exists.", username))
+		return
+	}
+	if user == nil && password == "" {
+		web.renderUsers(w, r, "Password must not be blank for a new user.")
+		return
+	}
+
+	var roles []model.Role
+	for _, roleString := range r.PostForm["roles"] {
+		role, err := model.RoleFromString(roleString)
+		if err != nil {
+			web.renderUsers(w, r, err.Error())
+			return
+		}
+		roles = append(roles, role)
+	}
+
+	if user == nil {
+		user = &model.User{Username: username, Roles: roles}
+		if err = user.SetPassword(password); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if err = web.arena.Database.CreateUser(user); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	} else {
+		previousUsername := user.Username
+		user.Username = username
+		user.Roles = roles
+		if password != "" {
+			if err = user.SetPassword(password); err != nil {
+				handleWebErr(w, err)
+				return
+			}
+		}
+		if err = web.arena.Database.UpdateUser(user); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		if password != "" || username != previousUsername {
+			// Force the user to log in again with their new credentials.
+			if err = web.arena.Database.DeleteUserSessionsByUsername(previousUsername); err != nil {
+				handleWebErr(w, err)
+				return
+			}
+		}
+	}
+
+	http.Redirect(w, r, "/setup/users", 303)
+}
+
+func (web *Web) renderUsers(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_users.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	users, err := web.arena.Database.GetAllUsers()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Append a blank user to the end that can be used to add a new one.
+	users = append(users, model.User{})
+
+	data := struct {
+		*model.EventSettings
+		Users        []model.User
+		Roles        []model.Role
+		ErrorMessage string
+	}{web.arena.EventSettings, users, model.AllRoles, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetupUsers(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/users")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Head Referee")
+
+	// Check creating a user.
+	recorder = web.postHttpResponse("/setup/users", "id=0&username=redscorer&password=fuel&roles=scorer&roles=fta")
+	assert.Equal(t, 303, recorder.Code)
+	user, _ := web.arena.Database.GetUserByUsername("redscorer")
+	if assert.NotNil(t, user) {
+		assert.Equal(t, []model.Role{model.ScorerRole, model.FtaRole}, user.Roles)
+		assert.True(t, user.CheckPassword("fuel"))
+	}
+	recorder = web.getHttpResponse("/setup/users")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "redscorer")
+
+	// Check validation errors.
+	recorder = web.postHttpResponse("/setup/users", "id=0&username=admin&password=fuel")
+	assert.Contains(t, recorder.Body.String(), "Username 'admin' is reserved.")
+	recorder = web.postHttpResponse("/setup/users", "id=0&username=redscorer&password=fuel")
+	assert.Contains(t, recorder.Body.String(), "User 'redscorer' already exists.")
+	recorder = web.postHttpResponse("/setup/users", "id=0&username=bluescorer")
+	assert.Contains(t, recorder.Body.String(), "Password must not be blank for a new user.")
+	recorder = web.postHttpResponse("/setup/users", "id=0&username=bluescorer&password=fuel&roles=blorpy")
+	assert.Contains(t, recorder.Body.String(), "Invalid role 'blorpy'.")
+
+	// Check updating a user without changing their password.
+	web.arena.Database.CreateUserSession(&model.UserSession{Token: "token1", Username: "redscorer"})
+	recorder = web.postHttpResponse("/setup/users", "id=1&username=redscorer&roles=head_referee")
+	assert.Equal(t, 303, recorder.Code)
+	user, _ = web.arena.Database.GetUserById(1)
+	assert.Equal(t, []model.Role{model.HeadRefereeRole}, user.Roles)
+	assert.True(t, user.CheckPassword("fuel"))
+	session, _ := web.arena.Database.GetUserSessionByToken("token1")
+	assert.NotNil(t, session)
+
+	// Check that changing the password logs the user out.
+	recorder = web.postHttpResponse("/setup/users", "id=1&username=redscorer&password=tower&roles=head_referee")
+	assert.Equal(t, 303, recorder.Code)
+	user, _ = web.arena.Database.GetUserById(1)
+	assert.True(t, user.CheckPassword("tower"))
+	session, _ = web.arena.Database.GetUserSessionByToken("token1")
+	assert.Nil(t, session)
+
+	// Check deleting a user.
+	recorder = web.postHttpResponse("/setup/users", "id=1&action=delete")
+	assert.Equal(t, 303, recorder.Code)
+	user, _ = web.arena.Database.GetUserById(1)
+	assert.Nil(t, user)
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for managing the webhooks notified of event lifecycle changes and for reviewing their deliveries.
+
+package web
+
+import (
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The number of most recent delivery attempts shown in the webhook delivery log.
+const numWebhookDeliveriesShown = 200
+
+// Shows the list of webhooks.
+func (web *Web) webhooksGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderWebhooks(w, r, "")
+}
+
+// Adds a new webhook.
+func (web *Web) webhooksPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	webhookUrl := strings.TrimSpace(r.PostFormValue("url"))
+	parsedUrl, err := url.Parse(webhookUrl)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		web.renderWebhooks(w, r, "The webhook URL must be a valid http:// or https:// address.")
+		return
+	}
+	r.ParseForm()
+	events := []string{}
+	for _, event := range partner.WebhookEvents {
+		if slices.Contains(r.PostForm["events"], string(event)) {
+			events = append(events, string(event))
+		}
+	}
+	if len(events) == 0 {
+		web.renderWebhooks(w, r, "At least one event must be selected for the webhook.")
+		return
+	}
+	if len(events) == len(partner.WebhookEvents) {
+		// Subscribe the webhook to everything so that it also receives any events added in the future.
+		events = []string{}
+	}
+
+	webhook := model.Webhook{
+		Url:       webhookUrl,
+		Secret:    r.PostFormValue("secret"),
+		Events:    events,
+		Enabled:   true,
+		CreatedAt: time.Now(),
+	}
+	if err = web.arena.Database.CreateWebhook(&webhook); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/setup/webhooks", 303)
+}
+
+// Pauses or resumes delivery of events to the given webhook.
+func (web *Web) webhookEnablePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	webhookId, _ := strconv.Atoi(r.PathValue("id"))
+	webhook, err := web.arena.Database.GetWebhookById(webhookId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if webhook != nil {
+		webhook.Enabled = r.PostFormValue("enabled") == "true"
+		if err = web.arena.Database.UpdateWebhook(webhook); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/webhooks", 303)
+}
+
+// Deletes the given webhook.
+func (web *Web) webhookDeletePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	webhookId, _ := strconv.Atoi(r.PathValue("id"))
+	webhook, err := web.arena.Database.GetWebhookById(webhookId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if webhook != nil {
+		if err = web.arena.Database.DeleteWebhook(webhook.Id); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/webhooks", 303)
+}
+
+// Shows the most recent attempts to deliver events to the webhooks.
+func (web *Web) webhookDeliveriesGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_webhook_deliveries.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	deliveries, err := web.arena.Database.GetRecentWebhookDeliveries(numWebhookDeliveriesShown)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	data := struct {
+		*model.EventSettings
+		Deliveries []model.WebhookDelivery
+	}{web.arena.EventSettings, deliveries}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Clears the webhook delivery log.
+func (web *Web) webhookDeliveriesClearPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if err := web.arena.Database.TruncateWebhookDeliveries(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/setup/webhooks/deliveries", 303)
+}
+
+func (web *Web) renderWebhooks(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_webhooks.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	webhooks, err := web.arena.Database.GetAllWebhooks()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	data := struct {
+		*model.EventSettings
+		Webhooks     []model.Webhook
+		Events       []partner.WebhookEvent
+		ErrorMessage string
+	}{web.arena.EventSettings, webhooks, partner.WebhookEvents, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetupWebhooks(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/webhooks")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No webhooks have been added.")
+	assert.Contains(t, recorder.Body.String(), "alliance_selection_changed")
+
+	recorder = web.postHttpResponse(
+		"/setup/webhooks", "url=https://example.com/hook&secret=hunter2&events=match_started&events=award_revealed",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	webhooks, _ := web.arena.Database.GetAllWebhooks()
+	if assert.Equal(t, 1, len(webhooks)) {
+		assert.Equal(t, "https://example.com/hook", webhooks[0].Url)
+		assert.Equal(t, "hunter2", webhooks[0].Secret)
+		assert.Equal(t, []string{"match_started", "award_revealed"}, webhooks[0].Events)
+		assert.True(t, webhooks[0].Enabled)
+	}
+	recorder = web.getHttpResponse("/setup/webhooks")
+	assert.Contains(t, recorder.Body.String(), "https://example.com/hook")
+	assert.NotContains(t, recorder.Body.String(), "hunter2")
+
+	// Check that selecting every event subscribes the webhook to all of them.
+	body := "url=http://10.0.100.50:8080/"
+	for _, event := range []string{
+		"match_loaded",
+		"match_started",
+		"match_ended",
+		"score_posted",
+		"rankings_updated",
+		"alliance_selection_changed",
+		"award_revealed",
+	} {
+		body += "&events=" + event
+	}
+	recorder = web.postHttpResponse("/setup/webhooks", body)
+	assert.Equal(t, 303, recorder.Code)
+	webhooks, _ = web.arena.Database.GetAllWebhooks()
+	if assert.Equal(t, 2, len(webhooks)) {
+		assert.Empty(t, webhooks[1].Events)
+		assert.True(t, webhooks[1].IsSubscribedTo("score_posted"))
+	}
+
+	recorder = web.postHttpResponse(fmt.Sprintf("/setup/webhooks/%d/enable", webhooks[0].Id), "enabled=false")
+	assert.Equal(t, 303, recorder.Code)
+	webhook, _ := web.arena.Database.GetWebhookById(webhooks[0].Id)
+	assert.False(t, webhook.Enabled)
+	recorder = web.postHttpResponse(fmt.Sprintf("/setup/webhooks/%d/enable", webhooks[0].Id), "enabled=true")
+	assert.Equal(t, 303, recorder.Code)
+	webhook, _ = web.arena.Database.GetWebhookById(webhooks[0].Id)
+	assert.True(t, webhook.Enabled)
+
+	recorder = web.postHttpResponse(fmt.Sprintf("/setup/webhooks/%d/delete", webhooks[0].Id), "")
+	assert.Equal(t, 303, recorder.Code)
+	webhooks, _ = web.arena.Database.GetAllWebhooks()
+	assert.Equal(t, 1, len(webhooks))
+}
+
+func TestSetupWebhooksErrors(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/setup/webhooks", "url=&events=match_started")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The webhook URL must be a valid http:// or https:// address.")
+
+	recorder = web.postHttpResponse("/setup/webhooks", "url=ftp://example.com/hook&events=match_started")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The webhook URL must be a valid http:// or https:// address.")
+
+	recorder = web.postHttpResponse("/setup/webhooks", "url=https://example.com/hook")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "At least one event must be selected for the webhook.")
+
+	webhooks, _ := web.arena.Database.GetAllWebhooks()
+	assert.Empty(t, webhooks)
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/webhooks/deliveries")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No webhook deliveries have been attempted.")
+
+	delivery := model.WebhookDelivery{
+		WebhookId:  1,
+		Url:        "https://example.com/hook",
+		DeliveryId: "ABCDEFG",
+		Event:      "match_ended",
+		Attempt:    2,
+		Time:       time.Now(),
+		StatusCode: 500,
+		Error:      "got status 500 Internal Server Error: oops",
+	}
+	assert.Nil(t, web.arena.Database.CreateWebhookDelivery(&delivery))
+	recorder = web.getHttpResponse("/setup/webhooks/deliveries")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "ABCDEFG")
+	assert.Contains(t, recorder.Body.String(), "match_ended")
+	assert.Contains(t, recorder.Body.String(), "oops")
+
+	recorder = web.postHttpResponse("/setup/webhooks/deliveries/clear", "")
+	assert.Equal(t, 303, recorder.Code)
+	deliveries, _ := web.arena.Database.GetRecentWebhookDeliveries(10)
+	assert.Empty(t, deliveries)
+}
+
+func TestCommitMatchSendsWebhooks(t *testing.T) {
+	web := setupTestWeb(t)
+
+	events := make(chan string, 10)
+	webhookServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				events <- r.Header.Get("X-Cheesy-Arena-Event")
+			},
+		),
+	)
+	defer webhookServer.Close()
+	assert.Nil(
+		t,
+		web.arena.Database.CreateWebhook(
+			&model.Webhook{
+				Url:     webhookServer.URL,
+				Events:  []string{"score_posted", "rankings_updated"},
+				Enabled: true,
+			},
+		),
+	)
+
+	match := &model.Match{
+		Type: model.Qualification, Red1: 101, Red2: 102, Red3: 103, Blue1: 104, Blue2: 105, Blue3: 106,
+	}
+	assert.Nil(t, web.arena.Database.CreateMatch(match))
+	matchResult := model.NewMatchResult()
+	matchResult.MatchId = match.Id
+	matchResult.BlueScore = &game.Score{}
+	assert.Nil(t, web.commitMatchScore(match, matchResult, false))
+
+	receivedEvents := []string{}
+	for len(receivedEvents) < 2 {
+		select {
+		case event := <-events:
+			receivedEvents = append(receivedEvents, event)
+		case <-time.After(time.Second):
+			assert.Fail(t, "Timed out waiting for webhook request")
+			return
+		}
+	}
+	assert.ElementsMatch(t, []string{"score_posted", "rankings_updated"}, receivedEvents)
+}
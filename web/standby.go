@@ -0,0 +1,135 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for mirroring data to a hot-standby server and for monitoring and promoting the standby.
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"net/http"
+	"strconv"
+)
+
+// Returns the journal entries written since the sequence number given by the standby server.
+func (web *Web) standbyJournalApiHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.standbyIsAuthorized(w, r) {
+		return
+	}
+
+	if web.arena.IsPassive() {
+		// A standby that hasn't been promoted has nothing of its own worth mirroring.
+		http.Error(w, "This server is itself a standby.", http.StatusServiceUnavailable)
+		return
+	}
+	afterSequence, err := strconv.Atoi(r.URL.Query().Get("after"))
+	if err != nil {
+		http.Error(w, "Invalid journal sequence number.", 400)
+		return
+	}
+
+	entries, complete, err := web.arena.Database.GetJournalEntriesAfter(afterSequence)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	response := field.StandbyJournalResponse{Entries: entries, SnapshotRequired: !complete}
+	jsonData, err := json.Marshal(response)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Returns a full copy of the database, for a standby server that is too far behind to catch up using the journal.
+func (web *Web) standbySnapshotApiHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.standbyIsAuthorized(w, r) {
+		return
+	}
+
+	if err := web.arena.Database.WriteBackup(w); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Shows the hot-standby status of this server.
+func (web *Web) standbyGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	template, err := web.parseFiles("templates/setup_standby.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	journalSequence, err := web.arena.Database.GetJournalSequence()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Passive         bool
+		Standby         field.StandbyStatus
+		JournalSequence int
+	}{web.arena.EventSettings, web.arena.IsPassive(), web.arena.Standby, journalSequence}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Promotes this standby server to primary, giving it control of the field hardware.
+func (web *Web) standbyPromotePostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if err := web.arena.Promote(); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, "/setup/standby", 303)
+}
+
+// Returns true if the given request would change the event data or take control of the field, which a standby server
+// refuses until it is promoted so that it doesn't diverge from the primary server it mirrors.
+func isRefusedByStandby(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/login", "/logout", "/setup/standby/promote":
+		return false
+	case "/match_play/websocket", "/setup/lower_thirds/websocket", "/setup/teams/generate_wpa_keys",
+		"/setup/teams/refresh":
+		return true
+	}
+	return r.Method != http.MethodGet && r.Method != http.MethodHead
+}
+
+// Returns true if the request carries the admin credentials. Used for HTTP basic authentication by the standby server,
+// and refused outright if no admin password is configured since the snapshot contains the entire event database.
+func (web *Web) standbyIsAuthorized(w http.ResponseWriter, r *http.Request) bool {
+	if web.arena.EventSettings.AdminPassword == "" {
+		http.Error(w, "An admin password must be set before a standby server can mirror this one.", http.StatusForbidden)
+		return false
+	}
+	user, password, ok := r.BasicAuth()
+	if ok && user == adminUser && web.checkAuthPassword(user, password) == nil {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", "Basic realm=\"Cheesy Arena\"")
+	http.Error(w, "Invalid standby credentials.", http.StatusUnauthorized)
+	return false
+}
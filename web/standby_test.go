@@ -0,0 +1,145 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestStandbyJournalApi(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.AdminPassword = "secret"
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114})
+	sequence, _ := web.arena.Database.GetJournalSequence()
+
+	recorder := web.getStandbyApiResponse("/api/standby/journal?after=" + strconv.Itoa(sequence-1))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	var response field.StandbyJournalResponse
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.False(t, response.SnapshotRequired)
+	if assert.Equal(t, 1, len(response.Entries)) {
+		assert.Equal(t, 1114, response.Entries[0].RecordId)
+	}
+
+	recorder = web.getStandbyApiResponse("/api/standby/journal?after=" + strconv.Itoa(sequence+10))
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.True(t, response.SnapshotRequired)
+	assert.Empty(t, response.Entries)
+
+	recorder = web.getStandbyApiResponse("/api/standby/journal")
+	assert.Equal(t, 400, recorder.Code)
+
+	// Check that a standby refuses to serve as a primary.
+	web = NewWeb(field.SetupTestStandbyArena(t, "http://localhost:1", ""))
+	web.arena.EventSettings.AdminPassword = "secret"
+	recorder = web.getStandbyApiResponse("/api/standby/journal?after=0")
+	assert.Equal(t, 503, recorder.Code)
+}
+
+func TestStandbyApiAuthorization(t *testing.T) {
+	web := setupTestWeb(t)
+
+	// Check that the event data isn't served at all without an admin password.
+	recorder := web.getHttpResponse("/api/standby/snapshot")
+	assert.Equal(t, 403, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "An admin password must be set")
+	recorder = web.getHttpResponse("/api/standby/journal?after=0")
+	assert.Equal(t, 403, recorder.Code)
+
+	web.arena.EventSettings.AdminPassword = "secret"
+	recorder = web.getHttpResponse("/api/standby/snapshot")
+	assert.Equal(t, 401, recorder.Code)
+
+	request := httptest.NewRequest("GET", "/api/standby/snapshot", nil)
+	request.SetBasicAuth(adminUser, "wrong")
+	recorder = httptest.NewRecorder()
+	web.newHandler().ServeHTTP(recorder, request)
+	assert.Equal(t, 401, recorder.Code)
+
+	request = httptest.NewRequest("GET", "/api/standby/snapshot", nil)
+	request.SetBasicAuth(adminUser, "secret")
+	recorder = httptest.NewRecorder()
+	web.newHandler().ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotEmpty(t, recorder.Body.Bytes())
+}
+
+func TestStandbyRefusesChanges(t *testing.T) {
+	web := NewWeb(field.SetupTestStandbyArena(t, "http://localhost:1", ""))
+
+	recorder := web.postHttpResponse("/setup/settings", "name=Standby Event")
+	assert.Equal(t, 503, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "promote it to primary")
+	eventSettings, _ := web.arena.Database.GetEventSettings()
+	assert.NotEqual(t, "Standby Event", eventSettings.Name)
+	recorder = web.getHttpResponse("/setup/teams/refresh")
+	assert.Equal(t, 503, recorder.Code)
+	recorder = web.getHttpResponse("/match_play/websocket")
+	assert.Equal(t, 503, recorder.Code)
+
+	// Check that the standby can still be viewed and promoted.
+	recorder = web.getHttpResponse("/setup/teams")
+	assert.Equal(t, 200, recorder.Code)
+	recorder = web.postHttpResponse("/setup/standby/promote", "")
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.postHttpResponse("/setup/settings", "name=Standby Event")
+	assert.Equal(t, 303, recorder.Code)
+	eventSettings, _ = web.arena.Database.GetEventSettings()
+	assert.Equal(t, "Standby Event", eventSettings.Name)
+}
+
+func TestStandbyPage(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/standby")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Primary")
+	assert.NotContains(t, recorder.Body.String(), "Promote to Primary")
+
+	web = NewWeb(field.SetupTestStandbyArena(t, "http://10.0.100.5:8080", ""))
+	web.arena.Standby.LastError = "connection refused"
+	recorder = web.getHttpResponse("/setup/standby")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "http://10.0.100.5:8080")
+	assert.Contains(t, recorder.Body.String(), "connection refused")
+	assert.Contains(t, recorder.Body.String(), "Promote to Primary")
+
+	recorder = web.postHttpResponse("/setup/standby/promote", "")
+	assert.Equal(t, 303, recorder.Code, recorder.Body.String())
+	assert.False(t, web.arena.IsPassive())
+	recorder = web.getHttpResponse("/setup/standby")
+	assert.Contains(t, recorder.Body.String(), "It was promoted from standby")
+
+	recorder = web.postHttpResponse("/setup/standby/promote", "")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Arena is not in standby mode.")
+}
+
+func TestStandbyRefusesToCommit(t *testing.T) {
+	web := NewWeb(field.SetupTestStandbyArena(t, "http://localhost:1", ""))
+	match := model.Match{Type: model.Qualification, ShortName: "Q1"}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	err := web.commitMatchScore(&match, model.NewMatchResult(), false)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "standby")
+	}
+	matchResult, _ := web.arena.Database.GetMatchResultForMatch(match.Id)
+	assert.Nil(t, matchResult)
+}
+
+func (web *Web) getStandbyApiResponse(path string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest("GET", path, nil)
+	request.SetBasicAuth(adminUser, "secret")
+	recorder := httptest.NewRecorder()
+	web.newHandler().ServeHTTP(recorder, request)
+	return recorder
+}
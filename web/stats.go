@@ -0,0 +1,110 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for viewing team statistics calculated from match results.
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/stats"
+	"net/http"
+)
+
+type TeamStatsWithNickname struct {
+	stats.TeamStats
+	Nickname string
+}
+
+// Shows the team statistics in a table that can be sorted by any column.
+func (web *Web) statsGetHandler(w http.ResponseWriter, r *http.Request) {
+	matchType, teamStats, ok := web.getTeamStats(w, r)
+	if !ok {
+		return
+	}
+
+	template, err := web.parseFiles("templates/stats.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		MatchType model.MatchType
+		SortKey   string
+		Teams     []TeamStatsWithNickname
+	}{web.arena.EventSettings, matchType, r.URL.Query().Get("sort"), teamStats}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Generates a JSON dump of the team statistics.
+func (web *Web) statsApiHandler(w http.ResponseWriter, r *http.Request) {
+	matchType, teamStats, ok := web.getTeamStats(w, r)
+	if !ok {
+		return
+	}
+
+	data := struct {
+		MatchType string
+		Teams     []TeamStatsWithNickname
+	}{matchType.String(), teamStats}
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.Header().Add("Access-Control-Allow-Origin", "*")
+	_, err = w.Write(jsonData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Calculates the team statistics for the match type given in the request (defaulting to qualification) and sorts them
+// by the given key, if any. Writes an error response and returns false if unsuccessful.
+func (web *Web) getTeamStats(w http.ResponseWriter, r *http.Request) (model.MatchType, []TeamStatsWithNickname, bool) {
+	matchType := model.Qualification
+	if matchTypeString := r.URL.Query().Get("type"); matchTypeString != "" {
+		var err error
+		matchType, err = model.MatchTypeFromString(matchTypeString)
+		if err != nil {
+			http.Error(w, "Error: "+err.Error(), 400)
+			return 0, nil, false
+		}
+	}
+	teamStats, err := stats.CalculateTeamStats(web.arena.Database, matchType)
+	if err != nil {
+		handleWebErr(w, err)
+		return 0, nil, false
+	}
+	if sortKey := r.URL.Query().Get("sort"); sortKey != "" {
+		if err = stats.SortTeamStats(teamStats, sortKey); err != nil {
+			http.Error(w, "Error: "+err.Error(), 400)
+			return 0, nil, false
+		}
+	}
+
+	// Get team info so that nicknames can be displayed.
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return 0, nil, false
+	}
+	teamNicknames := make(map[int]string)
+	for _, team := range teams {
+		teamNicknames[team.Id] = team.Nickname
+	}
+	teamStatsWithNicknames := make([]TeamStatsWithNickname, len(teamStats))
+	for i, teamStat := range teamStats {
+		teamStatsWithNicknames[i] = TeamStatsWithNickname{teamStat, teamNicknames[teamStat.TeamId]}
+	}
+	return matchType, teamStatsWithNicknames, true
+}
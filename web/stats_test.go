@@ -0,0 +1,85 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func setupTestStatsMatch(t *testing.T, web *Web) *model.MatchResult {
+	match := model.Match{
+		Type:   model.Qualification,
+		Red1:   254,
+		Red2:   1114,
+		Red3:   33,
+		Blue1:  4,
+		Blue2:  5,
+		Blue3:  6,
+		Status: game.BlueWonMatch,
+	}
+	assert.Nil(t, web.arena.Database.CreateMatch(&match))
+	matchResult := model.BuildTestMatchResult(match.Id, 1)
+	assert.Nil(t, web.arena.Database.CreateMatchResult(matchResult))
+	assert.Nil(t, web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs"}))
+	return matchResult
+}
+
+func TestStats(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/stats")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No Qualification matches have been completed yet.")
+
+	setupTestStatsMatch(t, web)
+	recorder = web.getHttpResponse("/stats?sort=dpr")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The Cheesy Poofs")
+	assert.Contains(t, recorder.Body.String(), "CCWM")
+	assert.Contains(t, recorder.Body.String(), "DPR &#9660;")
+	assert.Contains(t, recorder.Body.String(), "/stats?type=Playoff&sort=dpr")
+
+	recorder = web.getHttpResponse("/stats?type=playoff")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No Playoff matches have been completed yet.")
+
+	recorder = web.getHttpResponse("/stats?sort=height")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid sort key 'height'")
+	recorder = web.getHttpResponse("/stats?type=scrimmage")
+	assert.Equal(t, 400, recorder.Code)
+}
+
+func TestStatsApi(t *testing.T) {
+	web := setupTestWeb(t)
+	matchResult := setupTestStatsMatch(t, web)
+
+	recorder := web.getHttpResponse("/api/stats?sort=team")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	var statsData struct {
+		MatchType string
+		Teams     []TeamStatsWithNickname
+	}
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &statsData))
+	assert.Equal(t, "Qualification", statsData.MatchType)
+	if assert.Equal(t, 6, len(statsData.Teams)) {
+		assert.Equal(t, 4, statsData.Teams[0].TeamId)
+		assert.Equal(t, 1, statsData.Teams[0].NumMatches)
+		assert.InDelta(t, float64(matchResult.BlueScoreSummary().Score)/3, statsData.Teams[0].Opr, 0.01)
+		assert.Equal(t, 254, statsData.Teams[4].TeamId)
+		assert.Equal(t, "The Cheesy Poofs", statsData.Teams[4].Nickname)
+		assert.InDelta(t, float64(matchResult.BlueScoreSummary().Score)/3, statsData.Teams[4].Dpr, 0.01)
+	}
+
+	recorder = web.getHttpResponse("/api/stats?type=practice")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Nil(t, json.Unmarshal([]byte(recorder.Body.String()), &statsData))
+	assert.Equal(t, "Practice", statsData.MatchType)
+	assert.Empty(t, statsData.Teams)
+}
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/Team254/cheesy-arena/field"
@@ -25,12 +26,16 @@ const (
 )
 
 type Web struct {
-	arena           *field.Arena
-	templateHelpers template.FuncMap
+	arena                 *field.Arena
+	templateHelpers       template.FuncMap
+	controlApiRateLimiter *rateLimiter
 }
 
 func NewWeb(arena *field.Arena) *Web {
-	web := &Web{arena: arena}
+	web := &Web{
+		arena:                 arena,
+		controlApiRateLimiter: newRateLimiter(controlApiRateLimitBurst, controlApiRateLimitPerSec),
+	}
 
 	// Helper functions that can be used inside templates.
 	web.templateHelpers = template.FuncMap{
@@ -52,12 +57,18 @@ func NewWeb(arena *field.Arena) *Web {
 		"add": func(a, b int) int {
 			return a + b
 		},
+		"divide": func(a, b int) int {
+			return a / b
+		},
 		"itoa": func(a int) string {
 			return strconv.Itoa(a)
 		},
 		"multiply": func(a, b int) int {
 			return a * b
 		},
+		"percent": func(fraction float64) string {
+			return fmt.Sprintf("%.0f%%", 100*fraction)
+		},
 		"seq": func(count int) []int {
 			seq := make([]int, count)
 			for i := 0; i < count; i++ {
@@ -135,13 +146,32 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("POST /alliance_selection/finalize", web.allianceSelectionFinalizeHandler)
 	mux.HandleFunc("POST /alliance_selection/reset", web.allianceSelectionResetHandler)
 	mux.HandleFunc("POST /alliance_selection/start", web.allianceSelectionStartHandler)
+	mux.HandleFunc("GET /backup_teams", web.backupTeamsGetHandler)
+	mux.HandleFunc("POST /backup_teams", web.backupTeamsPostHandler)
+	mux.HandleFunc("GET /ranking_projections", web.rankingProjectionsGetHandler)
+	mux.HandleFunc("GET /stats", web.statsGetHandler)
 	mux.HandleFunc("GET /api/alliances", web.alliancesApiHandler)
 	mux.HandleFunc("GET /api/arena/websocket", web.arenaWebsocketApiHandler)
+	mux.HandleFunc("GET /api/bracket/layout", web.bracketLayoutApiHandler)
 	mux.HandleFunc("GET /api/bracket/svg", web.bracketSvgApiHandler)
 	mux.HandleFunc("GET /api/matches/{type}", web.matchesApiHandler)
 	mux.HandleFunc("GET /api/rankings", web.rankingsApiHandler)
+	mux.HandleFunc("GET /api/stats", web.statsApiHandler)
 	mux.HandleFunc("GET /api/sponsor_slides", web.sponsorSlidesApiHandler)
+	mux.HandleFunc("GET /api/standby/journal", web.standbyJournalApiHandler)
+	mux.HandleFunc("GET /api/standby/snapshot", web.standbySnapshotApiHandler)
 	mux.HandleFunc("GET /api/teams/{teamId}/avatar", web.teamAvatarsApiHandler)
+	mux.HandleFunc("GET /api/v1/alliances", web.alliancesApiV1Handler)
+	mux.HandleFunc("GET /api/v1/awards", web.awardsApiV1Handler)
+	mux.HandleFunc("GET /api/v1/bracket", web.bracketApiV1Handler)
+	mux.HandleFunc("POST /api/v1/control/{command}", web.controlApiV1Handler)
+	mux.HandleFunc("GET /api/v1/event", web.eventApiV1Handler)
+	mux.HandleFunc("GET /api/v1/matches", web.matchesApiV1Handler)
+	mux.HandleFunc("GET /api/v1/matches/{matchId}", web.matchApiV1Handler)
+	mux.HandleFunc("GET /api/v1/openapi.json", web.openApiV1Handler)
+	mux.HandleFunc("GET /api/v1/rankings", web.rankingsApiV1Handler)
+	mux.HandleFunc("GET /api/v1/teams", web.teamsApiV1Handler)
+	mux.HandleFunc("GET /api/v1/teams/{teamNumber}", web.teamApiV1Handler)
 	mux.HandleFunc("GET /display", web.placeholderDisplayHandler)
 	mux.HandleFunc("GET /display/websocket", web.placeholderDisplayWebsocketHandler)
 	mux.HandleFunc("GET /displays/alliance_station", web.allianceStationDisplayHandler)
@@ -171,14 +201,18 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /displays/webpage/websocket", web.webpageDisplayWebsocketHandler)
 	mux.HandleFunc("GET /login", web.loginHandler)
 	mux.HandleFunc("POST /login", web.loginPostHandler)
+	mux.HandleFunc("POST /logout", web.logoutHandler)
 	mux.HandleFunc("GET /match_play", web.matchPlayHandler)
 	mux.HandleFunc("GET /match_play/match_load", web.matchPlayMatchLoadHandler)
 	mux.HandleFunc("GET /match_play/websocket", web.matchPlayWebsocketHandler)
 	mux.HandleFunc("GET /match_logs", web.matchLogsHandler)
 	mux.HandleFunc("GET /match_logs/{matchId}/{stationId}/log", web.matchLogsViewGetHandler)
+	mux.HandleFunc("GET /match_logs/team/{teamId}", web.teamMatchLogsGetHandler)
 	mux.HandleFunc("GET /match_review", web.matchReviewHandler)
 	mux.HandleFunc("GET /match_review/{matchId}/edit", web.matchReviewEditGetHandler)
 	mux.HandleFunc("POST /match_review/{matchId}/edit", web.matchReviewEditPostHandler)
+	mux.HandleFunc("GET /match_review/{matchId}/history", web.matchReviewHistoryHandler)
+	mux.HandleFunc("GET /match_review/{matchId}/score_log", web.matchReviewScoreLogHandler)
 	mux.HandleFunc("POST /match_review/{matchId}/summary", web.matchReviewSummaryPostHandler)
 	mux.HandleFunc("GET /panels/scoring/{position}", web.scoringPanelHandler)
 	mux.HandleFunc("GET /panels/scoring/{position}/websocket", web.scoringPanelWebsocketHandler)
@@ -200,6 +234,9 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /reports/pdf/rankings", web.rankingsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/schedule/{type}", web.schedulePdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/teams", web.teamsPdfReportHandler)
+	mux.HandleFunc("GET /setup/api_tokens", web.apiTokensGetHandler)
+	mux.HandleFunc("POST /setup/api_tokens", web.apiTokensPostHandler)
+	mux.HandleFunc("POST /setup/api_tokens/{id}/revoke", web.apiTokenRevokePostHandler)
 	mux.HandleFunc("GET /setup/awards", web.awardsGetHandler)
 	mux.HandleFunc("POST /setup/awards", web.awardsPostHandler)
 	mux.HandleFunc("GET /setup/breaks", web.breaksGetHandler)
@@ -209,6 +246,8 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /setup/db/save", web.saveDbHandler)
 	mux.HandleFunc("GET /setup/displays", web.displaysGetHandler)
 	mux.HandleFunc("GET /setup/displays/websocket", web.displaysWebsocketHandler)
+	mux.HandleFunc("GET /setup/fields", web.fieldsGetHandler)
+	mux.HandleFunc("POST /setup/fields", web.fieldsPostHandler)
 	mux.HandleFunc("GET /setup/field_testing", web.fieldTestingGetHandler)
 	mux.HandleFunc("GET /setup/field_testing/websocket", web.fieldTestingWebsocketHandler)
 	mux.HandleFunc("GET /setup/judging", web.judgingGetHandler)
@@ -216,11 +255,17 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("POST /setup/judging/generate", web.judgingGeneratePostHandler)
 	mux.HandleFunc("GET /setup/lower_thirds", web.lowerThirdsGetHandler)
 	mux.HandleFunc("GET /setup/lower_thirds/websocket", web.lowerThirdsWebsocketHandler)
+	mux.HandleFunc("GET /setup/plc_simulator", web.plcSimulatorGetHandler)
+	mux.HandleFunc("GET /setup/plc_simulator/websocket", web.plcSimulatorWebsocketHandler)
 	mux.HandleFunc("GET /setup/schedule", web.scheduleGetHandler)
 	mux.HandleFunc("POST /setup/schedule/generate", web.scheduleGeneratePostHandler)
 	mux.HandleFunc("POST /setup/schedule/save", web.scheduleSavePostHandler)
+	mux.HandleFunc("GET /setup/sessions", web.sessionsGetHandler)
+	mux.HandleFunc("POST /setup/sessions/{id}/revoke", web.sessionRevokePostHandler)
 	mux.HandleFunc("GET /setup/settings", web.settingsGetHandler)
 	mux.HandleFunc("POST /setup/settings", web.settingsPostHandler)
+	mux.HandleFunc("GET /setup/settings/bracket", web.bracketDefinitionGetHandler)
+	mux.HandleFunc("POST /setup/settings/bracket", web.bracketDefinitionPostHandler)
 	mux.HandleFunc("GET /setup/settings/publish_alliances", web.settingsPublishAlliancesHandler)
 	mux.HandleFunc("GET /setup/settings/publish_awards", web.settingsPublishAwardsHandler)
 	mux.HandleFunc("GET /setup/settings/publish_matches", web.settingsPublishMatchesHandler)
@@ -228,16 +273,79 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /setup/settings/publish_teams", web.settingsPublishTeamsHandler)
 	mux.HandleFunc("GET /setup/sponsor_slides", web.sponsorSlidesGetHandler)
 	mux.HandleFunc("POST /setup/sponsor_slides", web.sponsorSlidesPostHandler)
+	mux.HandleFunc("GET /setup/standby", web.standbyGetHandler)
+	mux.HandleFunc("POST /setup/standby/promote", web.standbyPromotePostHandler)
 	mux.HandleFunc("GET /setup/teams", web.teamsGetHandler)
 	mux.HandleFunc("POST /setup/teams", web.teamsPostHandler)
 	mux.HandleFunc("POST /setup/teams/{id}/delete", web.teamDeletePostHandler)
 	mux.HandleFunc("GET /setup/teams/{id}/edit", web.teamEditGetHandler)
 	mux.HandleFunc("POST /setup/teams/{id}/edit", web.teamEditPostHandler)
+	mux.HandleFunc("POST /setup/teams/{id}/withdraw", web.teamWithdrawPostHandler)
 	mux.HandleFunc("POST /setup/teams/clear", web.teamsClearHandler)
 	mux.HandleFunc("GET /setup/teams/generate_wpa_keys", web.teamsGenerateWpaKeysHandler)
 	mux.HandleFunc("GET /setup/teams/progress", web.teamsUpdateProgressBarHandler)
 	mux.HandleFunc("GET /setup/teams/refresh", web.teamsRefreshHandler)
-	return mux
+	mux.HandleFunc("GET /setup/users", web.usersGetHandler)
+	mux.HandleFunc("POST /setup/users", web.usersPostHandler)
+	mux.HandleFunc("GET /setup/webhooks", web.webhooksGetHandler)
+	mux.HandleFunc("POST /setup/webhooks", web.webhooksPostHandler)
+	mux.HandleFunc("POST /setup/webhooks/{id}/delete", web.webhookDeletePostHandler)
+	mux.HandleFunc("POST /setup/webhooks/{id}/enable", web.webhookEnablePostHandler)
+	mux.HandleFunc("GET /setup/webhooks/deliveries", web.webhookDeliveriesGetHandler)
+	mux.HandleFunc("POST /setup/webhooks/deliveries/clear", web.webhookDeliveriesClearPostHandler)
+
+	// Requests for any additional fields are addressed using the "field" query parameter, and are served by a copy of
+	// the web interface that is bound to that field's arena. The copies are built on first use since a standby server
+	// only starts its additional fields once it is promoted. Pages that configure the whole event are always served by
+	// the first field's arena, which propagates any changes to the others.
+	var fieldHandlersMutex sync.Mutex
+	fieldHandlers := make(map[*field.Arena]http.Handler)
+	return http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if web.arena.IsPassive() && isRefusedByStandby(r) {
+				http.Error(
+					w, "This server is a standby; promote it to primary before making changes.",
+					http.StatusServiceUnavailable,
+				)
+				return
+			}
+
+			fieldNumber, err := strconv.Atoi(r.URL.Query().Get("field"))
+			fieldArena := web.arena.GetFieldArena(fieldNumber)
+			if err == nil && fieldArena != nil && !isEventWidePath(r.URL.Path, fieldArena.IsDivision()) {
+				fieldHandlersMutex.Lock()
+				fieldHandler, ok := fieldHandlers[fieldArena]
+				if !ok {
+					fieldWeb := &Web{
+						arena:                 fieldArena,
+						templateHelpers:       web.templateHelpers,
+						controlApiRateLimiter: web.controlApiRateLimiter,
+					}
+					fieldHandler = fieldWeb.newHandler()
+					fieldHandlers[fieldArena] = fieldHandler
+				}
+				fieldHandlersMutex.Unlock()
+				fieldHandler.ServeHTTP(w, r)
+				return
+			}
+			mux.ServeHTTP(w, r)
+		},
+	)
+}
+
+// Returns true if the given path belongs to a page that configures the whole event rather than a single field. Clearing
+// and publishing a division's data are done for its own field.
+func isEventWidePath(path string, isDivision bool) bool {
+	if isDivision &&
+		(strings.HasPrefix(path, "/setup/db/clear/") || strings.HasPrefix(path, "/setup/settings/publish_")) {
+		return false
+	}
+	for _, prefix := range []string{"/setup/settings", "/setup/db/", "/setup/fields"} {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 // Writes the given error out as plain text with a status code of 500.